@@ -116,6 +116,8 @@ func main() {
 	router.AdminRouter(e.Group("/admin"))
 	router.AssetRouter(e.Group("/asset"))
 	router.ContractRouter(e.Group("/contract"))
+	router.ShiftRouter(e.Group("/shift"))
+	router.CareerRoute(e.Group("/careers"))
 
 	go func() {
 		if err := e.Start(":8080"); err != nil {
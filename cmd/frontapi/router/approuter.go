@@ -1,8 +1,11 @@
 package router
 
 import (
+	"time"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
 	ad "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/admin"
 	as "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/asset"
 	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/auth"
@@ -22,6 +25,7 @@ import (
 	proj "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/projects"
 	rc "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/recruitment"
 	rq "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/registrationrequest"
+	sft "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/shift"
 	tech "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/technology"
 	tk "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/timekeeping"
 	up "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/userpermission"
@@ -29,6 +33,7 @@ import (
 	u "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/users"
 	ut "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/domains/usertechnology"
 	gc "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/cloud"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/ratelimit"
 	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils"
 )
 
@@ -58,6 +63,7 @@ type AppRouter struct {
 	adminCtr          *ad.Controller
 	assetCtr          *as.Controller
 	contractCtr       *ct.Controller
+	shiftCtr          *sft.Controller
 
 	userMw *u.UserMiddleware
 	gcs    *gc.GcsStorage
@@ -89,6 +95,7 @@ func NewAppRouter(logger echo.Logger) (r *AppRouter) {
 	adminRepo := ad.NewPgAdminRepository(logger)
 	assetRepo := as.NewPgAssetRepository(logger)
 	contractRepo := ct.NewPgContractRepository(logger)
+	shiftRepo := sft.NewPgShiftRepository(logger)
 
 	gcsStorage := gc.NewGcsStorage(logger)
 
@@ -102,30 +109,31 @@ func NewAppRouter(logger echo.Logger) (r *AppRouter) {
 		requestCtr:  rq.NewRegistRequestController(logger, userRepo, regRepo, orgRepo, requestRepo),
 		projCtr:     proj.NewProjectController(logger, projRepo, userRepo, userProjectRepo, gcsStorage),
 		tgevalCtr:   tgeval.NewTargetEvaluationController(logger, tgevalRepo, userRepo, projRepo, userProjectRepo, branchRepo, gcsStorage),
-		tkCtr:       tk.NewTimekeepingController(logger, timekeepingRepo, userRepo, branchRepo),
-		leaveCtr:    leave.NewLeaveController(logger, leaveRepo, userRepo, branchRepo, orgRepo, holidayRepo, notificationRepo, userProjectRepo, fcmTokenRepo, gcsStorage),
+		tkCtr:       tk.NewTimekeepingController(logger, timekeepingRepo, userRepo, branchRepo, shiftRepo, fcmTokenRepo),
+		leaveCtr:    leave.NewLeaveController(logger, leaveRepo, userRepo, branchRepo, orgRepo, holidayRepo, notificationRepo, userProjectRepo, fcmTokenRepo, projRepo, gcsStorage),
 		uprjCtr:     uprj.NewUserProjectController(logger, userProjectRepo, userRepo, projRepo, branchRepo),
 		branchCtr:   br.NewBranchController(logger, branchRepo, userRepo, orgRepo),
 		jobTitleCtr: jt.NewJobTitleController(logger, jobTitleRepo, userRepo, orgRepo),
 		techCtr:     tech.NewTechnologyController(logger, techRepo, userTechnologyRepo, orgRepo),
 		overtimeCtr: ot.NewOvertimeController(logger, overtimeRepo, orgRepo, userRepo,
-			projRepo, branchRepo, leaveRepo, holidayRepo, notificationRepo, userProjectRepo, fcmTokenRepo),
+			projRepo, branchRepo, leaveRepo, holidayRepo, notificationRepo, userProjectRepo, fcmTokenRepo, shiftRepo),
 		utechCtr:        ut.NewUserTechnologyController(logger, userTechnologyRepo, techRepo, userRepo),
 		notificationCtr: n.NewNotificationController(logger, notificationRepo, fcmTokenRepo, userRepo, gcsStorage, orgRepo),
 		holidayCtr:      hld.NewHolidayController(logger, holidayRepo, orgRepo),
 		fcmTokenCtr:     fcm.NewFcmTokenController(logger, fcmTokenRepo),
-		recruitmentCtr:  rc.NewRecruitmentController(logger, gcsStorage, recruitmentRepo, projRepo, branchRepo, userRepo, notificationRepo, fcmTokenRepo),
+		recruitmentCtr:  rc.NewRecruitmentController(logger, gcsStorage, recruitmentRepo, projRepo, branchRepo, userRepo, notificationRepo, fcmTokenRepo, techRepo, orgRepo, timekeepingRepo),
 		kanbanBoardCtr:  kb.NewKanbanBoardController(logger, kanbanBoardRepo, projRepo, userProjectRepo),
 		kanbanListCtr:   kl.NewKanbanListController(logger, kanbanListRepo, kanbanBoardRepo, userProjectRepo),
 		kanbanTaskCtr: kt.NewKanbanTaskController(
 			logger, gcsStorage, kanbanTaskRepo,
 			kanbanListRepo, kanbanBoardRepo, userProjectRepo,
-			projRepo, notificationRepo, fcmTokenRepo, userRepo,
+			projRepo, notificationRepo, fcmTokenRepo, userRepo, leaveRepo,
 		),
 		userPermissionCtr: up.NewUserPermissionController(logger, userPermissionRepo, userRepo, orgRepo),
 		adminCtr:          ad.NewAdminController(logger, adminRepo, userRepo, userPermissionRepo),
-		assetCtr:          as.NewAssetController(logger, assetRepo, userRepo, branchRepo, notificationRepo, fcmTokenRepo),
+		assetCtr:          as.NewAssetController(logger, assetRepo, userRepo, branchRepo, notificationRepo, fcmTokenRepo, leaveRepo),
 		contractCtr:       ct.NewContractController(logger, contractRepo, userRepo, branchRepo, gcsStorage),
+		shiftCtr:          sft.NewShiftController(logger, shiftRepo, userRepo, notificationRepo, fcmTokenRepo),
 
 		userMw: u.NewUserMiddleware(logger, userRepo),
 	}
@@ -290,6 +298,12 @@ func (r *AppRouter) TimekeepingRoute(g *echo.Group) {
 	g.POST("/get-all-timekeeping-user", r.tkCtr.GetAllTimekeepingUser, isLoggedIn, r.userMw.InitUserProfile)
 	g.POST("/get-all-timekeeping", r.tkCtr.GetAllTimekeeping, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
 	g.GET("/export-excel", r.tkCtr.ExportExcel, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/create-timekeeping-setting", r.tkCtr.CreateTimekeepingSetting, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/edit-timekeeping-setting", r.tkCtr.EditTimekeepingSetting, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-timekeeping-setting", r.tkCtr.GetTimekeepingSetting, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/cron-auto-checkout", r.tkCtr.CronAutoCheckout, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/cron-auto-checkout-start", r.tkCtr.CronAutoCheckoutStart, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/cron-auto-checkout-stop", r.tkCtr.CronAutoCheckoutStop, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
 }
 
 // LeaveRoute : create route for group /leave
@@ -321,6 +335,38 @@ func (r *AppRouter) LeaveRoute(g *echo.Group) {
 	g.POST("/remove-leave-bonus", r.leaveCtr.RemoveLeaveBonus, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckGeneralManager)
 	g.POST("/update-hour-remaining-leave", r.leaveCtr.UpdateHourRemainingLeave, isLoggedIn, r.userMw.InitUserProfile)
 	g.POST("/add-expire-leave", r.leaveCtr.AddExpireLeave, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/create-leave-policy", r.leaveCtr.CreateLeavePolicy, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/edit-leave-policy", r.leaveCtr.EditLeavePolicy, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/remove-leave-policy", r.leaveCtr.RemoveLeavePolicy, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-leave-policies", r.leaveCtr.GetLeavePolicies, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/run-leave-accrual", r.leaveCtr.RunLeaveAccrual, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-leave-ledger", r.leaveCtr.GetLeaveLedger, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-leave-balance", r.leaveCtr.GetLeaveBalance, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/recompute-leave-balances", r.leaveCtr.RecomputeLeaveBalances, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckGeneralManager)
+	g.POST("/cancel-leave", r.leaveCtr.CancelLeaveRequest, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/amend-leave", r.leaveCtr.AmendLeaveRequest, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-leave-changes", r.leaveCtr.GetLeaveChanges, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/update-leave-change-status", r.leaveCtr.UpdateLeaveChangeStatus, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/save-project-staffing-rule", r.leaveCtr.SaveProjectStaffingRule, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/remove-project-staffing-rule", r.leaveCtr.RemoveProjectStaffingRule, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-project-staffing-rules", r.leaveCtr.GetProjectStaffingRules, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-team-leave-calendar", r.leaveCtr.GetTeamLeaveCalendar, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/check-leave-conflicts", r.leaveCtr.CheckLeaveConflicts, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/forecast-leave-balance", r.leaveCtr.ForecastLeaveBalance, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/save-statutory-leave-rule", r.leaveCtr.SaveStatutoryLeaveRule, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/remove-statutory-leave-rule", r.leaveCtr.RemoveStatutoryLeaveRule, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-statutory-leave-rules", r.leaveCtr.GetStatutoryLeaveRules, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/create-statutory-leave", r.leaveCtr.CreateStatutoryLeave, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-statutory-leaves", r.leaveCtr.GetStatutoryLeaves, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/download-statutory-leave-document", r.leaveCtr.DownloadStatutoryLeaveDocument, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-leave-delegations", r.leaveCtr.GetLeaveDelegations, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/create-bulk-leave", r.leaveCtr.CreateBulkLeave, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckGeneralManager)
+	g.POST("/undo-bulk-leave", r.leaveCtr.UndoBulkLeave, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckGeneralManager)
+	g.POST("/get-bulk-leaves", r.leaveCtr.GetBulkLeaves, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-leave-settlement", r.leaveCtr.GetLeaveSettlement, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/sign-off-leave-settlement", r.leaveCtr.SignOffLeaveSettlement, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckGeneralManager)
+	g.POST("/export-leave-settlement", r.leaveCtr.ExportLeaveSettlement, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/settle-severance-leave", r.leaveCtr.SettleSeveranceLeave, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckGeneralManager)
 }
 
 // LeaveRoute : create route for group /leave
@@ -390,7 +436,6 @@ func (r *AppRouter) OvertimeRoute(g *echo.Group) {
 		r.overtimeCtr.UpdateOvertimeRequestStatus,
 		isLoggedIn,
 		r.userMw.InitUserProfile,
-		r.userMw.CheckGeneralManager,
 	)
 	g.POST(
 		"/get-overtime-requests",
@@ -423,6 +468,59 @@ func (r *AppRouter) OvertimeRoute(g *echo.Group) {
 		r.userMw.InitUserProfile,
 		r.userMw.CheckGeneralManager,
 	)
+	g.POST(
+		"/create-overtime-cap",
+		r.overtimeCtr.CreateOvertimeCap,
+		isLoggedIn,
+		r.userMw.InitUserProfile,
+		r.userMw.CheckGeneralManager,
+	)
+	g.POST(
+		"/edit-overtime-cap",
+		r.overtimeCtr.EditOvertimeCap,
+		isLoggedIn,
+		r.userMw.InitUserProfile,
+		r.userMw.CheckGeneralManager,
+	)
+	g.POST("/get-overtime-cap", r.overtimeCtr.GetOvertimeCap, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-overtime-allowance", r.overtimeCtr.GetOvertimeAllowance, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST(
+		"/get-overtime-request-histories",
+		r.overtimeCtr.GetOvertimeRequestHistories,
+		isLoggedIn,
+		r.userMw.InitUserProfile,
+	)
+	g.POST(
+		"/create-approval-chain",
+		r.overtimeCtr.CreateApprovalChain,
+		isLoggedIn,
+		r.userMw.InitUserProfile,
+		r.userMw.CheckGeneralManager,
+	)
+	g.POST(
+		"/edit-approval-chain",
+		r.overtimeCtr.EditApprovalChain,
+		isLoggedIn,
+		r.userMw.InitUserProfile,
+		r.userMw.CheckGeneralManager,
+	)
+	g.POST(
+		"/remove-approval-chain",
+		r.overtimeCtr.RemoveApprovalChain,
+		isLoggedIn,
+		r.userMw.InitUserProfile,
+		r.userMw.CheckGeneralManager,
+	)
+	g.POST(
+		"/get-approval-chains",
+		r.overtimeCtr.GetApprovalChains,
+		isLoggedIn,
+		r.userMw.InitUserProfile,
+		r.userMw.CheckGeneralManager,
+	)
+	g.POST("/create-approval-delegation", r.overtimeCtr.CreateApprovalDelegation, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/remove-approval-delegation", r.overtimeCtr.RemoveApprovalDelegation, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-approval-delegations", r.overtimeCtr.GetApprovalDelegations, isLoggedIn, r.userMw.InitUserProfile)
 }
 
 func (r *AppRouter) StatisticRoute(g *echo.Group) {
@@ -498,6 +596,39 @@ func (r *AppRouter) RecruitmentRoute(g *echo.Group) {
 	g.POST("/get-detail-job-file", r.recruitmentCtr.GetDetailJobFile, isLoggedIn, r.userMw.InitUserProfile)
 	g.POST("/remove-detail-job-file", r.recruitmentCtr.RemoveDetailJobFile, isLoggedIn, r.userMw.InitUserProfile)
 	g.POST("/create-log-cv-status", r.recruitmentCtr.CreateLogCvStatus, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/save-recruitment-stages", r.recruitmentCtr.SaveRecruitmentStages, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-recruitment-stages", r.recruitmentCtr.GetRecruitmentStages, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-cv-pipeline", r.recruitmentCtr.GetCvPipeline, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/create-interview", r.recruitmentCtr.CreateInterview, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/reschedule-interview", r.recruitmentCtr.RescheduleInterview, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/cancel-interview", r.recruitmentCtr.CancelInterview, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/update-interview-outcome", r.recruitmentCtr.UpdateInterviewOutcome, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-interviews", r.recruitmentCtr.GetInterviews, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/check-interview-availability", r.recruitmentCtr.CheckInterviewAvailability, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/save-scorecard-template", r.recruitmentCtr.SaveScorecardTemplate, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-scorecard-template", r.recruitmentCtr.GetScorecardTemplate, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/save-scorecard", r.recruitmentCtr.SaveScorecard, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-scorecards", r.recruitmentCtr.GetScorecards, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/parse-cv", r.recruitmentCtr.ParseCv, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-candidate-history", r.recruitmentCtr.GetCandidateHistory, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-candidate-duplicates", r.recruitmentCtr.GetCandidateDuplicates, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/merge-candidates", r.recruitmentCtr.MergeCandidates, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-recruitment-analytics", r.recruitmentCtr.GetRecruitmentAnalytics, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/export-recruitment-analytics", r.recruitmentCtr.ExportRecruitmentAnalytics, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/save-offer-template", r.recruitmentCtr.SaveOfferTemplate, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-offer-template", r.recruitmentCtr.GetOfferTemplate, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/create-offer", r.recruitmentCtr.CreateOffer, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/send-offer", r.recruitmentCtr.SendOffer, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-offers", r.recruitmentCtr.GetOffers, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/withdraw-offer", r.recruitmentCtr.WithdrawOffer, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/respond-offer", r.recruitmentCtr.RespondOffer, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-onboardings", r.recruitmentCtr.GetOnboardings, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/update-onboarding-task", r.recruitmentCtr.UpdateOnboardingTask, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-candidate-email-templates", r.recruitmentCtr.GetCandidateEmailTemplates, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/save-candidate-email-template", r.recruitmentCtr.SaveCandidateEmailTemplate, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/send-candidate-email", r.recruitmentCtr.SendCandidateEmail, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-cv-communications", r.recruitmentCtr.GetCvCommunications, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/send-rejection-emails", r.recruitmentCtr.SendRejectionEmails, isLoggedIn, r.userMw.InitUserProfile)
 }
 
 func (r *AppRouter) UserPermissionRoute(g *echo.Group) {
@@ -561,3 +692,35 @@ func (r *AppRouter) ContractRouter(g *echo.Group) {
 	g.POST("/preview-contract", r.contractCtr.PreviewContract, isLoggedIn, r.userMw.InitUserProfile)
 	g.POST("/delete-preview-contract", r.contractCtr.DeletePreviewContract, isLoggedIn, r.userMw.InitUserProfile)
 }
+
+func (r *AppRouter) ShiftRouter(g *echo.Group) {
+	keyTokenAuth := utils.GetKeyToken()
+	isLoggedIn := middleware.JWTWithConfig(middleware.JWTConfig{
+		SigningKey: []byte(keyTokenAuth),
+	})
+
+	g.POST("/create-shift", r.shiftCtr.CreateShift, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/edit-shift", r.shiftCtr.EditShift, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/remove-shift", r.shiftCtr.RemoveShift, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-shifts", r.shiftCtr.GetShifts, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/create-roster", r.shiftCtr.CreateRoster, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/remove-roster", r.shiftCtr.RemoveRoster, isLoggedIn, r.userMw.InitUserProfile, r.userMw.CheckAllManager)
+	g.POST("/get-roster", r.shiftCtr.GetRoster, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/create-swap-request", r.shiftCtr.CreateShiftSwapRequest, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/update-swap-request-status", r.shiftCtr.UpdateShiftSwapRequestStatus, isLoggedIn, r.userMw.InitUserProfile)
+	g.POST("/get-swap-requests", r.shiftCtr.GetShiftSwapRequests, isLoggedIn, r.userMw.InitUserProfile)
+}
+
+// CareerRoute : public routes of careers page, no login is required
+func (r *AppRouter) CareerRoute(g *echo.Group) {
+	jobsLimit := ratelimit.NewLimiter(cf.CareerJobsRateLimit, time.Minute).Middleware()
+	applyLimit := ratelimit.NewLimiter(cf.CareerApplyRateLimit, time.Hour).Middleware()
+
+	g.POST("/get-jobs", r.recruitmentCtr.GetCareerJobs, jobsLimit)
+	g.POST("/get-job", r.recruitmentCtr.GetCareerJob, jobsLimit)
+	g.POST("/apply-job", r.recruitmentCtr.ApplyCareerJob, middleware.BodyLimit(cf.CareerApplyBodyLimit), applyLimit)
+	g.GET("/feeds/indeed.xml", r.recruitmentCtr.GetIndeedJobFeed, jobsLimit)
+	g.GET("/feeds/job-postings.json", r.recruitmentCtr.GetJobPostingFeed, jobsLimit)
+	g.POST("/get-offer", r.recruitmentCtr.GetCareerOffer, jobsLimit)
+	g.POST("/respond-offer", r.recruitmentCtr.RespondCareerOffer, applyLimit)
+}
@@ -12,6 +12,8 @@ const (
 	OvertimeRequestTemplate     = "internal/platform/email/template/overtimeRequest.html"
 	SendTestMailTemplate        = "internal/platform/email/template/sendTestMail.html"
 	Recruitment                 = "internal/platform/email/template/recruitment.html"
+	InterviewTemplate           = "internal/platform/email/template/interview.html"
+	CandidateEmailTemplate      = "internal/platform/email/template/candidateEmail.html"
 	DirectoryAvatarImage        = "internal/platform/cloud/images/"
 	ExpiredHours                = 2                     // expired time for registration code , for now 2 hours
 	FormatDate                  = "2006-01-02 15:04:05" // must be set this format for parse date
@@ -25,6 +27,7 @@ const (
 	ContractTypeFolderGCS       = "contract_types/"
 	CVFOLDERGCS                 = "cvs/"
 	CONTRACTFOLDERGCS           = "contracts/"
+	StatutoryLeaveFolderGCS     = "statutory_leaves/"
 	FormatDisplayTimekeeping    = "2006/01/02 15:04 PM"
 	FormatDisplayTimekeeping2   = "2006/01/02 15:04:05"
 	EnLanguageId                = 1
@@ -119,6 +122,10 @@ var RankList = map[int]string{
 	62: "6-2",
 	63: "6-3",
 }
+
+// SeveranceStatus : working status of user who has left the company
+const SeveranceStatus = 3
+
 var WorkingStatus = map[int]string{
 	1: "Working",
 	2: "Onsite",
@@ -29,6 +29,53 @@ const (
 	Event     = 3
 	Other     = 4
 
+	// Leave accrual frequency
+	YearlyAccrual  = 1
+	MonthlyAccrual = 2
+	ProRataAccrual = 3
+
+	// Leave ledger entry type
+	GrantLedgerEntry      = 1
+	UsageLedgerEntry      = 2
+	ExpiryLedgerEntry     = 3
+	AdjustmentLedgerEntry = 4
+	OvertimeLedgerEntry   = 5
+	ReversalLedgerEntry   = 6
+	PayoutLedgerEntry     = 7
+	CarryOverLedgerEntry  = 8
+
+	// Leave ledger source type
+	LeaveBonusLedgerSource      = 1
+	LeaveRequestLedgerSource    = 2
+	OvertimeRequestLedgerSource = 3
+	AccrualRunLedgerSource      = 4
+	RecomputeLedgerSource       = 5
+	SettlementLedgerSource      = 6
+	SeveranceLedgerSource       = 7
+
+	// Leave request change type
+	CancelLeaveChange = 1
+	AmendLeaveChange  = 2
+
+	// Change by user of leave starting within notice hours must be approved by manager
+	LeaveChangeNoticeHour = 48
+
+	// Action when leave conflicts with staffing rule or task deadline of project
+	WarnLeaveConflict  = 1
+	BlockLeaveConflict = 2
+
+	// Leave conflict type
+	StaffingLeaveConflict = 1
+	DeadlineLeaveConflict = 2
+
+	// Max days of team leave calendar
+	MaxTeamLeaveCalendarDay = 62
+
+	// Default policy of organization which has not set leave policy
+	DefaultAnnualLeaveHour = 96
+	LeaveAccrualCronName   = "Leave accrual cron"
+	LeaveDelegateCronName  = "Leave delegate notification cron"
+
 	BreakLunchStart = "12:00"
 	BreakLunchEnd   = "13:30"
 
@@ -74,6 +121,64 @@ var LeaveRequestJpTypes = map[int]string{
 	OtherLeave:   "その他の",
 }
 
+var LeaveAccrualFrequencies = map[int]string{
+	YearlyAccrual:  "Yearly",
+	MonthlyAccrual: "Monthly",
+	ProRataAccrual: "Yearly pro-rata by join date",
+}
+
+var LeaveLedgerEntryTypes = map[int]string{
+	GrantLedgerEntry:      "Grant",
+	UsageLedgerEntry:      "Usage",
+	ExpiryLedgerEntry:     "Expiry",
+	AdjustmentLedgerEntry: "Adjustment",
+	OvertimeLedgerEntry:   "Overtime conversion",
+	ReversalLedgerEntry:   "Reversal",
+	PayoutLedgerEntry:     "Payout",
+	CarryOverLedgerEntry:  "Carry-over",
+}
+
+var LeaveLedgerSourceTypes = map[int]string{
+	LeaveBonusLedgerSource:      "Leave bonus",
+	LeaveRequestLedgerSource:    "Leave request",
+	OvertimeRequestLedgerSource: "Overtime request",
+	AccrualRunLedgerSource:      "Accrual run",
+	RecomputeLedgerSource:       "Recompute",
+	SettlementLedgerSource:      "Year-end settlement",
+	SeveranceLedgerSource:       "Severance settlement",
+}
+
+var LeaveChangeTypes = map[int]string{
+	CancelLeaveChange: "Cancel",
+	AmendLeaveChange:  "Amend",
+}
+
+var LeaveConflictTypes = map[int]string{
+	StaffingLeaveConflict: "Below minimum staffing",
+	DeadlineLeaveConflict: "Task deadline",
+}
+
+// StatutoryLeaveTypes : leave bonus types which are taken by rule of statutory leave instead of bonus buckets
+var StatutoryLeaveTypes = map[int]string{
+	SickLeave:        "Sick leave",
+	MarryLeave:       "Marry leave",
+	MaternityLeave:   "Maternity leave",
+	BereavementLeave: "Bereavement leave",
+}
+
+// AbsentLeaveTypes : leave types which make user absent from project on the day
+var AbsentLeaveTypes = []int{FullDayOff, MorningOff, AfternoonOff, BusinessTrip, OtherLeave}
+
+// DefaultSeniorityTiers : months of seniority and hours granted per year
+var DefaultSeniorityTiers = map[int]float64{
+	12: 4,
+	24: 8,
+	36: 12,
+	48: 16,
+	60: 20,
+	72: 24,
+}
+
 var SubtractDayOffTypes = map[int]string{
 	Subtract:  "Subtract",
 	ExtraWork: "Extra Work",
@@ -89,6 +194,11 @@ var LeaveCategoriesEn = map[string]string{
 	"Date To":            "Date To",
 	"Time":               "Time",
 	"Note":               "Note",
+	"Unused Hour":        "Unused Hour",
+	"Carry-over Hour":    "Carry-over Hour",
+	"Payout Hour":        "Payout Hour",
+	"Payout Amount":      "Payout Amount",
+	"Forfeit Hour":       "Forfeit Hour",
 }
 
 var LeaveCategoriesJp = map[string]string{
@@ -99,6 +209,11 @@ var LeaveCategoriesJp = map[string]string{
 	"Date To":            "時間まで",
 	"Time":               "タイム",
 	"Note":               "ノート",
+	"Unused Hour":        "未使用時間",
+	"Carry-over Hour":    "繰越時間",
+	"Payout Hour":        "買取時間",
+	"Payout Amount":      "買取金額",
+	"Forfeit Hour":       "失効時間",
 }
 
 var LeaveCategoriesVn = map[string]string{
@@ -109,4 +224,9 @@ var LeaveCategoriesVn = map[string]string{
 	"Date To":            "Ngày kết thúc",
 	"Time":               "Thời gian",
 	"Note":               "Ghi chú",
+	"Unused Hour":        "Số giờ chưa dùng",
+	"Carry-over Hour":    "Số giờ chuyển năm sau",
+	"Payout Hour":        "Số giờ thanh toán",
+	"Payout Amount":      "Số tiền thanh toán",
+	"Forfeit Hour":       "Số giờ hủy bỏ",
 }
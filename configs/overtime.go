@@ -6,8 +6,75 @@ const (
 
 	WorkAtNoon    = 1
 	NotWorkAtNoon = 2
+
+	ProjectManagerApprover = 1
+	GeneralManagerApprover = 2
+	SpecificUserApprover   = 3
+
+	SubmitOvertimeAction  = 1
+	ApproveOvertimeAction = 2
+	DenyOvertimeAction    = 3
+	EditOvertimeAction    = 4
+
+	BlockExceedOvertimeCap = 1
+	FlagExceedOvertimeCap  = 2
+
+	// Statutory limits of labour law, used when organization has not set overtime cap
+	DefaultOvertimeDailyCap   = 4
+	DefaultOvertimeMonthlyCap = 40
+	DefaultOvertimeYearlyCap  = 200
+
+	NormalDayOvertimeBand    = 1
+	NormalNightOvertimeBand  = 2
+	WeekendDayOvertimeBand   = 3
+	WeekendNightOvertimeBand = 4
+	HolidayDayOvertimeBand   = 5
+	HolidayNightOvertimeBand = 6
+
+	DefaultOvertimeNightStart = "22:00"
+	DefaultOvertimeNightEnd   = "06:00"
 )
 
+var MapOvertimeBand = map[int]string{
+	NormalDayOvertimeBand:    "Normal day",
+	NormalNightOvertimeBand:  "Normal night",
+	WeekendDayOvertimeBand:   "Weekend day",
+	WeekendNightOvertimeBand: "Weekend night",
+	HolidayDayOvertimeBand:   "Holiday day",
+	HolidayNightOvertimeBand: "Holiday night",
+}
+
+var VnMapOvertimeBand = map[int]string{
+	NormalDayOvertimeBand:    "Ngày thường",
+	NormalNightOvertimeBand:  "Đêm ngày thường",
+	WeekendDayOvertimeBand:   "Ngày cuối tuần",
+	WeekendNightOvertimeBand: "Đêm cuối tuần",
+	HolidayDayOvertimeBand:   "Ngày lễ",
+	HolidayNightOvertimeBand: "Đêm ngày lễ",
+}
+
+var JpMapOvertimeBand = map[int]string{
+	NormalDayOvertimeBand:    "平日",
+	NormalNightOvertimeBand:  "平日深夜",
+	WeekendDayOvertimeBand:   "週末",
+	WeekendNightOvertimeBand: "週末深夜",
+	HolidayDayOvertimeBand:   "祝日",
+	HolidayNightOvertimeBand: "祝日深夜",
+}
+
+var MapOvertimeApproverType = map[int]string{
+	ProjectManagerApprover: "Project Manager",
+	GeneralManagerApprover: "General Manager",
+	SpecificUserApprover:   "Specific User",
+}
+
+var MapOvertimeAction = map[int]string{
+	SubmitOvertimeAction:  "Submit",
+	ApproveOvertimeAction: "Approve",
+	DenyOvertimeAction:    "Deny",
+	EditOvertimeAction:    "Edit",
+}
+
 var MapOvertimeType = map[int]string{
 	DayOffTypeOvertime: "Take Day Off",
 	MoneyTypeOvertime:  "Take Money",
@@ -85,6 +152,7 @@ var EnOvertimeCategories = map[string]string{
 	"Type":               "Type",
 	"Status":             "Status",
 	"Note":               "Note",
+	"Breakdown":          "Breakdown",
 }
 
 var JpOvertimeCategories = map[string]string{
@@ -101,6 +169,7 @@ var JpOvertimeCategories = map[string]string{
 	"Type":               "残業タイプ",
 	"Status":             "承認状況",
 	"Note":               "備考",
+	"Breakdown":          "内訳",
 }
 
 var VnOvertimeCategories = map[string]string{
@@ -117,4 +186,5 @@ var VnOvertimeCategories = map[string]string{
 	"Type":               "Loại",
 	"Status":             "Trạng thái",
 	"Note":               "Ghi chú",
+	"Breakdown":          "Chi tiết",
 }
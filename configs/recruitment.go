@@ -8,6 +8,7 @@ const (
 	ITVIEC = 5
 	JOBNOW = 6
 	CAREERBUILDER = 7
+	CAREERSPAGE = 8
 
 	CVPENDING = 1
 	CVPASSROUNDONE = 2
@@ -17,6 +18,85 @@ const (
 	CVINTERVIEW = 6
 )
 
+// Recruitment stage type
+const (
+	ScreeningStage = 1
+	TestStage      = 2
+	InterviewStage = 3
+	OfferStage     = 4
+	HiredStage     = 5
+	RejectedStage  = 6
+)
+
+// Interview status
+const (
+	InterviewScheduled = 1
+	InterviewCompleted = 2
+	InterviewCancelled = 3
+
+	// Interview outcome
+	InterviewPass   = 1
+	InterviewFail   = 2
+	InterviewNoShow = 3
+
+	InterviewTimezone = "Asia/Ho_Chi_Minh"
+
+	// Scorecard status
+	ScorecardDraft     = 1
+	ScorecardSubmitted = 2
+
+	// Scorecard recommendation
+	StrongNoRecommendation  = 1
+	NoRecommendation        = 2
+	YesRecommendation       = 3
+	StrongYesRecommendation = 4
+
+	MinCompetencyScore = 1
+	MaxCompetencyScore = 5
+
+	// CandidateNameSimilarity : Candidates whose normalized names are at least this similar are possible duplicates
+	CandidateNameSimilarity = 0.9
+)
+
+// Public careers page
+const (
+	CareerJobsRateLimit  = 60
+	CareerApplyRateLimit = 5
+	CareerApplyBodyLimit = "8M"
+	CareerCvMaxSize      = 5 << 20
+
+	// Job posting feeds for job boards
+	JobFeedCountry  = "VN"
+	JobFeedCurrency = "VND"
+)
+
+// Offer status
+const (
+	OfferDraft     = 1
+	OfferSent      = 2
+	OfferAccepted  = 3
+	OfferDeclined  = 4
+	OfferWithdrawn = 5
+
+	// OfferInviteExpiredHours : Hours which new employee has to set password from invite after offer was accepted
+	OfferInviteExpiredHours = 72
+)
+
+// DefaultOfferTemplate : Offer letter of organization which has not saved offer template
+const DefaultOfferTemplate = `<p>Dear {{candidate_name}},</p>
+<p>We are pleased to offer you the position of <b>{{job_title}}</b> at {{organization_name}}.</p>
+<p>Your salary will be {{salary}} per month and your start date will be {{start_date}}.</p>
+<p>Please respond to this offer by {{expired_date}}.</p>
+<p>We look forward to working with you.</p>
+<p>{{organization_name}}</p>`
+
+// CareerCvSignatures : Extensions of cv which is accepted from careers page and first bytes of their files
+var CareerCvSignatures = map[string]string{
+	".pdf":  "%PDF",
+	".docx": "PK\x03\x04",
+	".doc":  "\xd0\xcf\x11\xe0",
+}
+
 var MediasRecruitment = map[int]string{
 	TOPCV: "TopCV",
 	VIETNAMWORKS: "Vietnamworks",
@@ -25,6 +105,7 @@ var MediasRecruitment = map[int]string{
 	ITVIEC: "ITviec",
 	JOBNOW: "JobNow",
 	CAREERBUILDER: "CareerBuilder",
+	CAREERSPAGE: "Careers page",
 }
 
 var CvStatuses = map[int]string{
@@ -35,3 +116,179 @@ var CvStatuses = map[int]string{
 	CVNOTPASS: "Not pass",
 	CVINTERVIEW: "Interview appointment",
 }
+
+var RecruitmentStageTypes = map[int]string{
+	ScreeningStage: "Screening",
+	TestStage:      "Test",
+	InterviewStage: "Interview",
+	OfferStage:     "Offer",
+	HiredStage:     "Hired",
+	RejectedStage:  "Rejected",
+}
+
+// StageTypeCvStatuses : cv status which is set when cv enters stage of type, so that status of cv keeps working
+var StageTypeCvStatuses = map[int]int{
+	ScreeningStage: CVPENDING,
+	TestStage:      CVPENDING,
+	InterviewStage: CVINTERVIEW,
+	OfferStage:     CVPASSROUNDONE,
+	HiredStage:     CVPASSFINAL,
+	RejectedStage:  CVREJECT,
+}
+
+var InterviewStatuses = map[int]string{
+	InterviewScheduled: "Scheduled",
+	InterviewCompleted: "Completed",
+	InterviewCancelled: "Cancelled",
+}
+
+var InterviewOutcomes = map[int]string{
+	InterviewPass:   "Pass",
+	InterviewFail:   "Fail",
+	InterviewNoShow: "No show",
+}
+
+var ScorecardRecommendations = map[int]string{
+	StrongNoRecommendation:  "Strong no",
+	NoRecommendation:        "No",
+	YesRecommendation:       "Yes",
+	StrongYesRecommendation: "Strong yes",
+}
+
+var OfferStatuses = map[int]string{
+	OfferDraft:     "Draft",
+	OfferSent:      "Sent",
+	OfferAccepted:  "Accepted",
+	OfferDeclined:  "Declined",
+	OfferWithdrawn: "Withdrawn",
+}
+
+// OfferPlaceholders : Placeholders of offer template and what they are replaced by
+var OfferPlaceholders = map[string]string{
+	"{{candidate_name}}":    "Full name of candidate",
+	"{{candidate_email}}":   "Email of candidate",
+	"{{candidate_phone}}":   "Phone number of candidate",
+	"{{job_name}}":          "Name of recruitment",
+	"{{job_title}}":         "Job title which is offered",
+	"{{salary}}":            "Salary which is offered",
+	"{{start_date}}":        "Start date",
+	"{{expired_date}}":      "Last date to respond",
+	"{{organization_name}}": "Name of organization",
+}
+
+// DefaultOnboardingChecklist : Tasks of onboarding which is started when offer is accepted
+var DefaultOnboardingChecklist = []string{
+	"Sign labor contract",
+	"Collect personal documents",
+	"Register social insurance",
+	"Prepare laptop and accounts",
+	"Orientation on first day",
+	"Assign mentor",
+}
+
+// Candidate email type, other is email which recruiter writes without template
+const (
+	InterviewInviteEmail = 1
+	RejectionEmail       = 2
+	OfferEmail           = 3
+	OtherCandidateEmail  = 4
+
+	// Communication status
+	CommunicationSent   = 1
+	CommunicationFailed = 2
+)
+
+var CandidateEmailTypes = map[int]string{
+	InterviewInviteEmail: "Interview invite",
+	RejectionEmail:       "Rejection",
+	OfferEmail:           "Offer",
+	OtherCandidateEmail:  "Other",
+}
+
+var CandidateEmailLanguages = map[int]string{
+	EnLanguageId: "English",
+	JpLanguageId: "Japanese",
+	VnLanguageId: "Vietnamese",
+}
+
+// CandidateEmailMergeFields : Merge fields of candidate email and what they are replaced by
+var CandidateEmailMergeFields = map[string]string{
+	"{{candidate_name}}":     "Full name of candidate",
+	"{{job_name}}":           "Name of recruitment",
+	"{{organization_name}}":  "Name of organization",
+	"{{sender_name}}":        "Full name of recruiter who sends email",
+	"{{interview_time}}":     "Start and end time of interview",
+	"{{interview_location}}": "Location of interview",
+	"{{meeting_link}}":       "Meeting link of online interview",
+	"{{job_title}}":          "Job title which is offered",
+	"{{start_date}}":         "Start date of offer",
+	"{{expired_date}}":       "Last date to respond to offer",
+}
+
+// DefaultCandidateEmailSubjects : Subject of candidate email per type and language, when organization has not saved template
+var DefaultCandidateEmailSubjects = map[int]map[int]string{
+	InterviewInviteEmail: {
+		EnLanguageId: "Interview invitation for {{job_name}} at {{organization_name}}",
+		JpLanguageId: "【{{organization_name}}】{{job_name}} 面接のご案内",
+		VnLanguageId: "Thư mời phỏng vấn vị trí {{job_name}} tại {{organization_name}}",
+	},
+	RejectionEmail: {
+		EnLanguageId: "Your application for {{job_name}} at {{organization_name}}",
+		JpLanguageId: "【{{organization_name}}】{{job_name}} 選考結果のご連絡",
+		VnLanguageId: "Kết quả ứng tuyển vị trí {{job_name}} tại {{organization_name}}",
+	},
+	OfferEmail: {
+		EnLanguageId: "Offer letter for {{job_title}} from {{organization_name}}",
+		JpLanguageId: "【{{organization_name}}】内定のご連絡",
+		VnLanguageId: "Thư mời nhận việc vị trí {{job_title}} tại {{organization_name}}",
+	},
+}
+
+// DefaultCandidateEmailBodies : Body of candidate email per type and language, when organization has not saved template
+var DefaultCandidateEmailBodies = map[int]map[int]string{
+	InterviewInviteEmail: {
+		EnLanguageId: "Dear {{candidate_name}},\n\n" +
+			"Thank you for applying for {{job_name}}. We would like to invite you to an interview.\n\n" +
+			"Time: {{interview_time}}\nLocation: {{interview_location}}\nMeeting link: {{meeting_link}}\n\n" +
+			"The calendar invite is attached.\n\nBest regards,\n{{sender_name}}\n{{organization_name}}",
+		JpLanguageId: "{{candidate_name}} 様\n\n" +
+			"この度は{{job_name}}にご応募いただき、誠にありがとうございます。下記の通り面接をご案内いたします。\n\n" +
+			"日時：{{interview_time}}\n場所：{{interview_location}}\nオンライン会議：{{meeting_link}}\n\n" +
+			"カレンダーの招待状を添付しております。\n\nよろしくお願いいたします。\n{{organization_name}}\n{{sender_name}}",
+		VnLanguageId: "Chào {{candidate_name}},\n\n" +
+			"Cảm ơn bạn đã ứng tuyển vị trí {{job_name}}. Chúng tôi trân trọng mời bạn tham gia phỏng vấn.\n\n" +
+			"Thời gian: {{interview_time}}\nĐịa điểm: {{interview_location}}\nLink họp trực tuyến: {{meeting_link}}\n\n" +
+			"Lịch hẹn được đính kèm trong email.\n\nTrân trọng,\n{{sender_name}}\n{{organization_name}}",
+	},
+	RejectionEmail: {
+		EnLanguageId: "Dear {{candidate_name}},\n\n" +
+			"Thank you for your interest in {{job_name}} at {{organization_name}}. " +
+			"After careful consideration, we have decided not to move forward with your application.\n\n" +
+			"We will keep your profile for future opportunities and wish you every success.\n\n" +
+			"Best regards,\n{{organization_name}}",
+		JpLanguageId: "{{candidate_name}} 様\n\n" +
+			"この度は{{organization_name}}の{{job_name}}にご応募いただき、誠にありがとうございました。\n" +
+			"慎重に選考を進めました結果、誠に残念ながら今回はご期待に添えない結果となりました。\n\n" +
+			"{{candidate_name}}様の今後のご活躍を心よりお祈り申し上げます。\n\n{{organization_name}}",
+		VnLanguageId: "Chào {{candidate_name}},\n\n" +
+			"Cảm ơn bạn đã quan tâm đến vị trí {{job_name}} tại {{organization_name}}. " +
+			"Sau khi cân nhắc kỹ, chúng tôi rất tiếc phải thông báo hồ sơ của bạn chưa phù hợp với vị trí này.\n\n" +
+			"Chúng tôi sẽ lưu hồ sơ của bạn cho các cơ hội sau và chúc bạn thành công.\n\n" +
+			"Trân trọng,\n{{organization_name}}",
+	},
+	OfferEmail: {
+		EnLanguageId: "Dear {{candidate_name}},\n\n" +
+			"{{organization_name}} is pleased to offer you the position of {{job_title}}, starting on {{start_date}}.\n\n" +
+			"Please find the offer letter attached and click the button below to respond by {{expired_date}}.\n\n" +
+			"Best regards,\n{{sender_name}}\n{{organization_name}}",
+		JpLanguageId: "{{candidate_name}} 様\n\n" +
+			"この度は{{organization_name}}の選考にご参加いただき、ありがとうございました。" +
+			"{{job_title}}として、{{start_date}}より入社いただきたく、内定をご連絡いたします。\n\n" +
+			"添付の内定通知書をご確認のうえ、{{expired_date}}までに下記のボタンよりご回答ください。\n\n" +
+			"よろしくお願いいたします。\n{{organization_name}}\n{{sender_name}}",
+		VnLanguageId: "Chào {{candidate_name}},\n\n" +
+			"{{organization_name}} trân trọng mời bạn nhận vị trí {{job_title}}, bắt đầu làm việc từ ngày {{start_date}}.\n\n" +
+			"Vui lòng xem thư mời nhận việc đính kèm và bấm nút bên dưới để phản hồi trước ngày {{expired_date}}.\n\n" +
+			"Trân trọng,\n{{sender_name}}\n{{organization_name}}",
+	},
+}
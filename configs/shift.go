@@ -0,0 +1,11 @@
+package configs
+
+const (
+	// Check in earlier than shift start by this margin still belongs to the shift
+	ShiftCheckInMarginMinute = 120
+
+	FormatShiftTime = "15:04"
+)
+
+// MaxRosterDays : max number of days can be assigned in one roster request
+const MaxRosterDays = 62
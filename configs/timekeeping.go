@@ -0,0 +1,9 @@
+package configs
+
+const (
+	DefaultTimekeepingTimezone = "Asia/Ho_Chi_Minh"
+	DefaultAutoCheckoutTime    = "23:00"
+
+	AutoCheckoutCronName         = "Auto checkout cron"
+	AutoCheckoutReminderCronName = "Auto checkout reminder cron"
+)
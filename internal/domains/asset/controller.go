@@ -19,6 +19,7 @@ import (
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
 	afb "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/appfirebase"
 	ex "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/excel"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils"
 )
 
 type Controller struct {
@@ -30,12 +31,14 @@ type Controller struct {
 	BranchRepo       rp.BranchRepository
 	NotificationRepo rp.NotificationRepository
 	FcmTokenRepo     rp.FcmTokenRepository
+	LeaveRepo        rp.LeaveRepository
 }
 
 func NewAssetController(logger echo.Logger, assetRepository rp.AssetRepository, userRepo rp.UserRepository,
-	branchRepo rp.BranchRepository, notificationRepo rp.NotificationRepository, fcmTokenRepo rp.FcmTokenRepository) (ctr *Controller) {
+	branchRepo rp.BranchRepository, notificationRepo rp.NotificationRepository, fcmTokenRepo rp.FcmTokenRepository,
+	leaveRepo rp.LeaveRepository) (ctr *Controller) {
 	ctr = &Controller{cm.BaseController{}, afb.FirebaseCloudMessage{}, assetRepository,
-		userRepo, branchRepo, notificationRepo, fcmTokenRepo}
+		userRepo, branchRepo, notificationRepo, fcmTokenRepo, leaveRepo}
 	ctr.Init(logger)
 	return
 }
@@ -599,6 +602,21 @@ func (ctr *Controller) CreateRequestAsset(c echo.Context) error {
 		})
 	}
 
+	// Delegates of general managers who are on leave handle asset requests too
+	if len(usersIdGm) > 0 {
+		leaveDelegations, err := ctr.LeaveRepo.SelectLeaveDelegates(userProfile.OrganizationID, usersIdGm, time.Now().Format(cf.FormatDateDatabase))
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System error",
+			})
+		}
+
+		for _, leaveDelegation := range leaveDelegations {
+			usersIdGm = utils.AppendUniqueSlice(usersIdGm, []int{leaveDelegation.DelegateUserId})
+		}
+	}
+
 	_, _, err = ctr.assetRepository.InsertAssetRequest(
 		userProfile.OrganizationID,
 		createRequestAssetParams,
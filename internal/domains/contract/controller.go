@@ -157,6 +157,7 @@ func (ctr *Controller) GetContractByUser(c echo.Context) error {
 			"total_salary":        contract.TotalSalary,
 			"currency_unit":       contract.CurrencyUnit,
 			"file_path":           filePath,
+			"is_draft":            contract.IsDraft,
 		}
 		contractList = append(contractList, res)
 	}
@@ -34,6 +34,7 @@ func (repo *PgContractRepository) SelectContractCurrentList(organizationId int,
 		Join("JOIN contract_types as ctt on contract.contract_type_id = ctt.id").
 		Join("FULL OUTER JOIN user_profiles as up on up.user_id = contract.user_id").
 		Where("contract.organization_id = ?", organizationId).
+		Where("contract.is_draft = FALSE").
 		Where("up.status != 3")
 
 	if getContractCurrentListParams.ContractTypeID != 0 {
@@ -71,7 +72,7 @@ func (repo *PgContractRepository) SelectContractByUser(getContractByUserParams *
 	var records []param.ContractByUserRecord
 	q := repo.DB.Model(&m.Contract{})
 	q.Column("contract.id", "contract.insurance_salary", "contract.total_salary", "contract.contract_start_date", "contract.contract_end_date", "contract.currency_unit",
-		"contract.contract_type_id", "contract.file_name", "ctt.file_template_name").
+		"contract.contract_type_id", "contract.file_name", "contract.is_draft", "ctt.file_template_name").
 		ColumnExpr("ctt.name as contract_type_name").
 		ColumnExpr("ctt.file_template_name as file_template_name").
 		Join("JOIN contract_types as ctt on contract.contract_type_id = ctt.id").
@@ -134,7 +135,19 @@ func (repo *PgContractRepository) InsertContract(organizationId int,fileName str
 		ContractCreationDate: contractCreationDate,
 	}
 
-	err := repo.DB.Insert(&contract)
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		if err := tx.Insert(&contract); err != nil {
+			return err
+		}
+
+		// Signed contract replaces draft which was created when user accepted offer
+		_, err := tx.Model(&m.Contract{}).
+			Where("user_id = ?", contract.UserId).
+			Where("is_draft = TRUE").
+			Delete()
+
+		return err
+	})
 
 	return err
 }
@@ -29,6 +29,7 @@ type Controller struct {
 	NotificationRepo rp.NotificationRepository
 	FcmTokenRepo     rp.FcmTokenRepository
 	UserRepo         rp.UserRepository
+	LeaveRepo        rp.LeaveRepository
 }
 
 func NewKanbanTaskController(
@@ -42,10 +43,11 @@ func NewKanbanTaskController(
 	notificationRepo rp.NotificationRepository,
 	fcmTokenRepo rp.FcmTokenRepository,
 	userRepo rp.UserRepository,
+	leaveRepo rp.LeaveRepository,
 ) (ctr *Controller) {
 	ctr = &Controller{cm.BaseController{}, afb.FirebaseCloudMessage{}, cloud, kanbanTaskRepo,
 		kanbanListRepo, kanbanBoardRepo, userProjectRepo,
-		projectRepo, notificationRepo, fcmTokenRepo, userRepo,
+		projectRepo, notificationRepo, fcmTokenRepo, userRepo, leaveRepo,
 	}
 	ctr.Init(logger)
 	ctr.InitFcm()
@@ -304,6 +306,7 @@ func (ctr *Controller) GetKanbanTasks(c echo.Context) error {
 		})
 	}
 
+	delegates := ctr.SelectDelegatesOfAbsentUsers(userProfile.OrganizationID, usersId)
 	var kanbanListsResponse []map[string]interface{}
 	if len(kanbanLists) > 0 {
 		location, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
@@ -328,6 +331,7 @@ func (ctr *Controller) GetKanbanTasks(c echo.Context) error {
 						"total_check_list":      record.TotalCheckList,
 						"total_check_list_done": record.TotalCheckListDone,
 						"avatars":               ctr.SelectAssigneeAndAvatars(record.Assignees),
+						"delegates":             delegatesOfAssignees(record.Assignees, delegates),
 					}
 
 					if err != nil {
@@ -436,6 +440,7 @@ func (ctr *Controller) GetKanbanTask(c echo.Context) error {
 		"assignees":   record.Assignees,
 		"status":      record.Status,
 		"checklists":  record.Checklists,
+		"delegates":   delegatesOfAssignees(record.Assignees, ctr.SelectDelegatesOfAbsentUsers(userProfile.OrganizationID, record.Assignees)),
 	}
 
 	if record.DueDate.IsZero() {
@@ -559,3 +564,34 @@ func (ctr *Controller) SelectAssigneeAndAvatars(assignees []int) map[int][]byte
 
 	return records
 }
+
+// SelectDelegatesOfAbsentUsers : Delegates of users who are on leave today, keyed by absent user
+func (ctr *Controller) SelectDelegatesOfAbsentUsers(organizationId int, usersId []int) map[int]param.LeaveDelegateRecords {
+	records := make(map[int]param.LeaveDelegateRecords)
+	if len(usersId) == 0 {
+		return records
+	}
+
+	delegations, err := ctr.LeaveRepo.SelectLeaveDelegates(organizationId, usersId, time.Now().Format(cf.FormatDateDatabase))
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		ctr.Logger.Error(err)
+		return records
+	}
+
+	for _, delegation := range delegations {
+		records[delegation.UserId] = delegation
+	}
+
+	return records
+}
+
+func delegatesOfAssignees(assignees []int, delegates map[int]param.LeaveDelegateRecords) []param.LeaveDelegateRecords {
+	var records []param.LeaveDelegateRecords
+	for _, assignee := range assignees {
+		if delegate, ok := delegates[assignee]; ok {
+			records = append(records, delegate)
+		}
+	}
+
+	return records
+}
@@ -1,7 +1,10 @@
 package leave
 
 import (
+	"math"
 	"net/http"
+	"path/filepath"
+	"sort"
 	"strconv"
 
 	"strings"
@@ -42,6 +45,7 @@ type LvController struct {
 	NotificationRepo rp.NotificationRepository
 	UserProjectRepo  rp.UserProjectRepository
 	FcmTokenRepo     rp.FcmTokenRepository
+	ProjectRepo      rp.ProjectRepository
 	cloud            gc.StorageUtility
 }
 
@@ -56,12 +60,13 @@ func NewLeaveController(
 	notificationRepo rp.NotificationRepository,
 	userProjectRepo rp.UserProjectRepository,
 	fcmTokenRepo rp.FcmTokenRepository,
+	projectRepo rp.ProjectRepository,
 	cloud gc.StorageUtility,
 ) (ctr *LvController) {
 	ctr = &LvController{
 		cm.BaseController{}, email.SMTPGoMail{}, cr.EtrCron{}, afb.FirebaseCloudMessage{},
 		leaveRepo, userRepo, branchRepo, orgRepo,
-		holidayRepo, notificationRepo, userProjectRepo, fcmTokenRepo, cloud,
+		holidayRepo, notificationRepo, userProjectRepo, fcmTokenRepo, projectRepo, cloud,
 	}
 	ctr.Init(logger)
 	ctr.InitCron("Asia/Ho_Chi_Minh")
@@ -131,6 +136,7 @@ func (ctr *LvController) CreateLeaveRequest(c echo.Context) error {
 
 	uniqueUsersId := utils.AppendUniqueSlice(usersIdProject, usersIdGmAndManager)
 	var hourReq float64
+	var warnings []param.LeaveConflict
 	for i, createLeaveRequestParam := range createLeaveRequestParams.LeaveRequest {
 		createLeaveRequestParam.OrgID = userProfile.OrganizationID
 		createLeaveRequestParam.CreatedBy, createLeaveRequestParam.UpdatedBy = userProfile.UserProfile.UserID, userProfile.UserProfile.UserID
@@ -162,6 +168,25 @@ func (ctr *LvController) CreateLeaveRequest(c echo.Context) error {
 			})
 		}
 
+		if _, ok := users[createLeaveRequestParam.UserID]; !ok {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "User has left the company.",
+				Data:    i,
+			})
+		}
+
+		if createLeaveRequestParam.DelegateUserID != 0 {
+			if _, ok := users[createLeaveRequestParam.DelegateUserID]; !ok ||
+				createLeaveRequestParam.DelegateUserID == createLeaveRequestParam.UserID {
+				return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "Delegate must be another user of organization.",
+					Data:    i,
+				})
+			}
+		}
+
 		timestampFrom := calendar.ParseTime(cf.FormatDateNoSec, createLeaveRequestParam.DatetimeLeaveFrom)
 		duration, _ := time.ParseDuration("12h0m0s")
 		if calendar.ParseTime(cf.FormatDateNoSec, time.Now().Format(cf.FormatDateNoSec)).Sub(timestampFrom) > duration {
@@ -211,6 +236,33 @@ func (ctr *LvController) CreateLeaveRequest(c echo.Context) error {
 			})
 		}
 
+		conflicts, isBlocked, err := ctr.checkLeaveConflicts(
+			userProfile.OrganizationID,
+			createLeaveRequestParam.UserID,
+			createLeaveRequestParam.LeaveRequestTypeID,
+			timestampFrom,
+			timestampTo,
+		)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System error",
+				Data:    i,
+			})
+		}
+
+		if isBlocked {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Leave conflicts with project staffing or task deadline.",
+				Data: map[string]interface{}{
+					"index":     i,
+					"conflicts": conflicts,
+				},
+			})
+		}
+		warnings = append(warnings, conflicts...)
+
 		Id, body, link, hour, err := ctr.LeaveRepo.InsertLeaveRequest(&createLeaveRequestParam, ctr.HolidayRepo, ctr.UserRepo, ctr.NotificationRepo, uniqueUsersId)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
@@ -247,27 +299,11 @@ func (ctr *LvController) CreateLeaveRequest(c echo.Context) error {
 			ctr.Logger.Error(err)
 		}
 
-		startDate, endDate := strings.Split(createLeaveRequestParam.DatetimeLeaveFrom, " ")[0], strings.Split(createLeaveRequestParam.DatetimeLeaveTo, " ")[0]
-		startTime, endTime := strings.Split(createLeaveRequestParam.DatetimeLeaveFrom, " ")[1], strings.Split(createLeaveRequestParam.DatetimeLeaveTo, " ")[1]
-		var start, end string
-		switch createLeaveRequestParam.LeaveRequestTypeID {
-		case cf.FullDayOff, cf.MorningOff, cf.AfternoonOff:
-			start = startDate
-			end = calendar.ParseTime(cf.FormatDateDatabase, endDate).AddDate(0, 0, 1).Format(cf.FormatDateDatabase)
-		case cf.LateForWork, cf.LeaveEarly, cf.GoOutside:
-			start = startDate + "T" + startTime + ":00+07:00"
-			end = endDate + "T" + endTime + ":00+07:00"
-		default:
-			hourOfDay, _ := time.ParseDuration("8h0m0s")
-			if 0 < timestampTo.Sub(timestampFrom) && timestampTo.Sub(timestampFrom) < hourOfDay {
-				start = startDate + "T" + startTime + ":00+07:00"
-				end = endDate + "T" + endTime + ":00+07:00"
-			} else {
-				start = startDate
-				end = calendar.ParseTime(cf.FormatDateDatabase, endDate).AddDate(0, 0, 1).Format(cf.FormatDateDatabase)
-			}
-		}
-
+		start, end := leaveEventPeriod(
+			createLeaveRequestParam.LeaveRequestTypeID,
+			createLeaveRequestParam.DatetimeLeaveFrom,
+			createLeaveRequestParam.DatetimeLeaveTo,
+		)
 		event := calendar.AddLeaveEvent(
 			createLeaveRequestParam.LeaveRequestTypeID,
 			users[createLeaveRequestParam.UserID]+" - "+cf.LeaveRequestJpTypes[createLeaveRequestParam.LeaveRequestTypeID],
@@ -286,8 +322,9 @@ func (ctr *LvController) CreateLeaveRequest(c echo.Context) error {
 		}
 	}
 
-	returnObj := map[string]float64{
-		"hour": hourReq,
+	returnObj := map[string]interface{}{
+		"hour":     hourReq,
+		"warnings": warnings,
 	}
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
@@ -740,431 +777,532 @@ func (ctr *LvController) getLeaveHistoriesByUser(organizationId int, leaveReques
 	return leaveHistoryByUser
 }
 
-// RemoveLeaveRequest : Remove leave request of user
-func (ctr *LvController) RemoveLeaveRequest(c echo.Context) error {
-	removeLeaveParams := new(param.RemoveLeaveParams)
+// CancelLeaveRequest : Cancel future leave request. Cancellation by user of leave starting soon waits for approval.
+func (ctr *LvController) CancelLeaveRequest(c echo.Context) error {
+	params := new(param.CancelLeaveRequestParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
 
-	if err := c.Bind(removeLeaveParams); err != nil {
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	leaveRequest, errResponse := ctr.getChangeableLeaveRequest(c, userProfile, params.LeaveId)
+	if errResponse != nil || leaveRequest.ID == 0 {
+		return errResponse
+	}
+
+	leaveRequestChange := m.LeaveRequestChange{
+		OrganizationId: userProfile.OrganizationID,
+		LeaveRequestId: leaveRequest.ID,
+		UserId:         leaveRequest.UserID,
+		RequestedBy:    userProfile.UserProfile.UserID,
+		ChangeType:     cf.CancelLeaveChange,
+		Reason:         params.Reason,
+	}
+
+	return ctr.submitLeaveRequestChange(c, userProfile, leaveRequest, leaveRequestChange)
+}
+
+// AmendLeaveRequest : Shorten future leave request. Amendment by user of leave starting soon waits for approval.
+func (ctr *LvController) AmendLeaveRequest(c echo.Context) error {
+	params := new(param.AmendLeaveRequestParams)
+	if err := c.Bind(params); err != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid Params",
 		})
 	}
 
-	_, err := valid.ValidateStruct(removeLeaveParams)
-	if err != nil {
+	if _, err := valid.ValidateStruct(params); err != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid field value",
 		})
 	}
 
-	leaveRequest, err := ctr.LeaveRepo.SelectLeaveRequestById(removeLeaveParams.LeaveID)
+	userProfile := c.Get("user_profile").(m.User)
+	leaveRequest, errResponse := ctr.getChangeableLeaveRequest(c, userProfile, params.LeaveId)
+	if errResponse != nil || leaveRequest.ID == 0 {
+		return errResponse
+	}
+
+	datetimeLeaveFrom, errFrom := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveFrom)
+	datetimeLeaveTo, errTo := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveTo)
+	if errFrom != nil || errTo != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	datetimeLeaveFrom = calendar.ParseTime(cf.FormatDateNoSec, params.DatetimeLeaveFrom)
+	datetimeLeaveTo = calendar.ParseTime(cf.FormatDateNoSec, params.DatetimeLeaveTo)
+	if !datetimeLeaveTo.After(datetimeLeaveFrom) ||
+		datetimeLeaveFrom.Before(leaveRequest.DatetimeLeaveFrom) ||
+		datetimeLeaveTo.After(leaveRequest.DatetimeLeaveTo) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Leave can only be shortened within its period.",
+		})
+	}
+
+	hour := calendar.CalculateHour(
+		userProfile.OrganizationID,
+		ctr.HolidayRepo,
+		leaveRequest.LeaveRequestTypeID,
+		datetimeLeaveFrom,
+		datetimeLeaveTo,
+		leaveRequest.SubtractDayOffTypeID,
+		params.ExtraTime,
+	)
+	if hour <= 0 || hour > leaveRequest.Hour {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Amended leave must be shorter than leave request, cancel it instead.",
+		})
+	}
+
+	leaveRequestChange := m.LeaveRequestChange{
+		OrganizationId:    userProfile.OrganizationID,
+		LeaveRequestId:    leaveRequest.ID,
+		UserId:            leaveRequest.UserID,
+		RequestedBy:       userProfile.UserProfile.UserID,
+		ChangeType:        cf.AmendLeaveChange,
+		DatetimeLeaveFrom: datetimeLeaveFrom,
+		DatetimeLeaveTo:   datetimeLeaveTo,
+		Hour:              hour,
+		Reason:            params.Reason,
+	}
+
+	return ctr.submitLeaveRequestChange(c, userProfile, leaveRequest, leaveRequestChange)
+}
+
+// UpdateLeaveChangeStatus : Approve or deny cancellation or amendment of leave request
+func (ctr *LvController) UpdateLeaveChangeStatus(c echo.Context) error {
+	params := new(param.UpdateLeaveChangeStatusParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	leaveRequestChange, err := ctr.LeaveRepo.SelectLeaveRequestChangeById(params.Id)
 	if err != nil {
 		if err.Error() == pg.ErrNoRows.Error() {
-			return c.JSON(http.StatusOK, cf.JsonResponse{
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
 				Status:  cf.FailResponseCode,
-				Message: "Empty record.",
+				Message: "Leave change does not exist",
 			})
 		}
 
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
-			Data:    err,
 		})
 	}
 
-	validDateRecords, err := ctr.LeaveRepo.SelectValidDateOfUser(leaveRequest.OrganizationID, leaveRequest.UserID)
+	isApprover, err := ctr.isLeaveApprover(userProfile)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
-			Data:    err,
 		})
 	}
 
-	hourLeaveTemp := leaveRequest.Hour
-	if len(validDateRecords) > 0 {
-		for _, validDate := range validDateRecords {
-			var hourUsed float64 = validDate.Hour - validDate.HourRemaining
-			if hourLeaveTemp < hourUsed {
-				ctr.LeaveRepo.UpdateHourRemainingLeaveByID(validDate.Id, validDate.HourRemaining+hourLeaveTemp)
-			} else {
-				ctr.LeaveRepo.UpdateHourRemainingLeaveByID(validDate.Id, validDate.HourRemaining+hourUsed)
-			}
-			hourLeaveTemp = hourLeaveTemp - hourUsed
-			if hourLeaveTemp <= 0 {
-				break
-			}
+	if leaveRequestChange.OrganizationId != userProfile.OrganizationID || !isApprover {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to update this leave change",
+		})
+	}
+
+	if leaveRequestChange.RequestedBy == userProfile.UserProfile.UserID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You can not approve your own leave change",
+		})
+	}
+
+	if leaveRequestChange.Status != cf.PendingRequestStatus {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Leave change has already been processed",
+		})
+	}
+
+	if params.Status == cf.AcceptRequestStatus {
+		leaveRequest, err := ctr.LeaveRepo.SelectLeaveRequestById(leaveRequestChange.LeaveRequestId)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if err := ctr.applyLeaveRequestChange(userProfile, leaveRequest, leaveRequestChange); err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
 		}
 	}
 
-	err = ctr.LeaveRepo.RemoveLeave(removeLeaveParams.LeaveID)
-	if err != nil {
+	if err := ctr.LeaveRepo.UpdateLeaveRequestChangeStatus(params.Id, params.Status, userProfile.UserProfile.UserID); err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
-			Data:    err,
 		})
 	}
 
-	if leaveRequest.CalendarEventId != "" {
-		calendar.RemoveLeaveEvent(leaveRequest.CalendarEventId)
+	content := "has just denied " + strings.ToLower(cf.LeaveChangeTypes[leaveRequestChange.ChangeType]) + " of your leave request"
+	if params.Status == cf.AcceptRequestStatus {
+		content = "has just accepted " + strings.ToLower(cf.LeaveChangeTypes[leaveRequestChange.ChangeType]) + " of your leave request"
 	}
+	ctr.sendLeaveNotification(userProfile, []int{leaveRequestChange.UserId, leaveRequestChange.RequestedBy}, content,
+		"/hrm/leave/history-user-leave?id="+strconv.Itoa(leaveRequestChange.LeaveRequestId)+"&user_id="+strconv.Itoa(leaveRequestChange.UserId))
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Remove leave request successfully.",
+		Message: "Update leave change successfully.",
 	})
 }
 
-// GetLeaveInfoAllUser : Get leave info of all user
-func (ctr *LvController) GetLeaveInfoAllUser(c echo.Context) error {
-	allUserNameAndCountParams := new(param.AllUserNameAndCountParams)
-	allUserNameAndCountParams.RowPerPage = 10
-
-	if err := c.Bind(allUserNameAndCountParams); err != nil {
+// GetLeaveChanges : Get cancellations and amendments of leave requests, user only gets own changes
+func (ctr *LvController) GetLeaveChanges(c echo.Context) error {
+	params := new(param.GetLeaveChangesParams)
+	if err := c.Bind(params); err != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid Params",
 		})
 	}
 
-	userProfile := c.Get("user_profile").(m.User)
-	allUserNameAndCountParams.OrgID = userProfile.OrganizationID
-
-	_, err := valid.ValidateStruct(allUserNameAndCountParams)
-	if err != nil {
+	if _, err := valid.ValidateStruct(params); err != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid field value",
 		})
 	}
 
-	users, totalRow, err := ctr.UserRepo.GetAllUserNameAndCountByOrgID(allUserNameAndCountParams)
-
-	if err != nil {
-		if err.Error() == pg.ErrNoRows.Error() {
-			return c.JSON(http.StatusOK, cf.JsonResponse{
-				Status:  cf.FailResponseCode,
-				Message: "Get user list failed",
-			})
-		}
+	userProfile := c.Get("user_profile").(m.User)
+	if userProfile.RoleID == cf.UserRoleID {
+		params.UserId = userProfile.UserProfile.UserID
+	}
 
+	records, totalRow, err := ctr.LeaveRepo.SelectLeaveRequestChanges(userProfile.OrganizationID, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
-			Data:    err,
 		})
 	}
 
-	var userLeaveInfoResponse []map[string]interface{}
-	for _, user := range users {
-		var base64Img []byte = nil
-
-		if user.Avatar != "" {
-			base64Img, err = ctr.cloud.GetFileByFileName(user.Avatar, cf.AvatarFolderGCS)
-
-			if err != nil {
-				ctr.Logger.Error(err)
-				base64Img = nil
-			}
+	var changes []map[string]interface{}
+	for _, record := range records {
+		change := map[string]interface{}{
+			"id":               record.Id,
+			"leave_request_id": record.LeaveRequestId,
+			"user_id":          record.UserId,
+			"full_name":        record.FullName,
+			"change_type":      record.ChangeType,
+			"change_type_name": cf.LeaveChangeTypes[record.ChangeType],
+			"leave_from":       record.LeaveFrom.Format(cf.FormatDateNoSec),
+			"leave_to":         record.LeaveTo.Format(cf.FormatDateNoSec),
+			"leave_hour":       record.LeaveHour,
+			"reason":           record.Reason,
+			"status":           record.Status,
+			"created_at":       record.CreatedAt.Format(cf.FormatDate),
 		}
-		userLeaveInfo := map[string]interface{}{
-			"user_id":   user.UserID,
-			"full_name": user.FullName,
-			"branch":    user.Branch,
-			"email":     user.Email,
-			"avatar":    base64Img,
+
+		if record.ChangeType == cf.AmendLeaveChange {
+			change["datetime_leave_from"] = record.DatetimeLeaveFrom.Format(cf.FormatDateNoSec)
+			change["datetime_leave_to"] = record.DatetimeLeaveTo.Format(cf.FormatDateNoSec)
+			change["hour"] = record.Hour
 		}
 
-		dayUsed, _, dayRemaining, _ := ctr.LeaveRepo.GetLeaveDayStatus(allUserNameAndCountParams.OrgID, user.UserID, time.Now().Year())
-		userLeaveInfo["day_used"] = dayUsed
-		userLeaveInfo["day_remaining"] = dayRemaining
+		changes = append(changes, change)
+	}
 
-		userLeaveInfoResponse = append(userLeaveInfoResponse, userLeaveInfo)
+	pagination := map[string]interface{}{
+		"current_page": params.CurrentPage,
+		"total_row":    totalRow,
+		"row_per_page": params.RowPerPage,
 	}
 
-	allUsers, err := ctr.UserRepo.GetAllUserNameByOrgID(allUserNameAndCountParams.OrgID)
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"pagination": pagination,
+			"changes":    changes,
+		},
+	})
+}
 
+// getChangeableLeaveRequest : Leave request which user can cancel or amend, it must be own or user is manager,
+// not started yet and has no pending change. Leave request id is 0 when response was written
+func (ctr *LvController) getChangeableLeaveRequest(c echo.Context, userProfile m.User, leaveId int) (m.UserLeaveRequest, error) {
+	leaveRequest, err := ctr.LeaveRepo.SelectLeaveRequestById(leaveId)
 	if err != nil {
 		if err.Error() == pg.ErrNoRows.Error() {
-			return c.JSON(http.StatusOK, cf.JsonResponse{
+			return m.UserLeaveRequest{}, c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
 				Status:  cf.FailResponseCode,
-				Message: "Get user list failed",
+				Message: "Leave request does not exist",
 			})
 		}
 
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+		return m.UserLeaveRequest{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
-			Data:    err,
 		})
 	}
 
-	usersBox := make(map[int]string)
+	if leaveRequest.OrganizationID != userProfile.OrganizationID ||
+		(userProfile.RoleID == cf.UserRoleID && leaveRequest.UserID != userProfile.UserProfile.UserID) {
+		return m.UserLeaveRequest{}, c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to change this leave request",
+		})
+	}
 
-	for i := 0; i < len(allUsers); i++ {
-		usersBox[allUsers[i].UserID] = allUsers[i].FullName
+	if !leaveRequest.DatetimeLeaveFrom.After(calendar.ParseTime(cf.FormatDateNoSec, time.Now().Format(cf.FormatDateNoSec))) {
+		return m.UserLeaveRequest{}, c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Only future leave request can be changed.",
+		})
 	}
 
-	if allUserNameAndCountParams.RowPerPage == 0 {
-		allUserNameAndCountParams.CurrentPage = 1
-		allUserNameAndCountParams.RowPerPage = totalRow
+	count, err := ctr.LeaveRepo.CountPendingLeaveRequestChanges(leaveRequest.ID)
+	if err != nil {
+		return m.UserLeaveRequest{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
 	}
 
-	pagination := map[string]interface{}{
-		"current_page": allUserNameAndCountParams.CurrentPage,
-		"total_row":    totalRow,
-		"row_per_page": allUserNameAndCountParams.RowPerPage,
+	if count > 0 {
+		return m.UserLeaveRequest{}, c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Leave request has a pending change.",
+		})
 	}
 
-	branchRecords, err := ctr.BranchRepo.SelectBranches(userProfile.OrganizationID)
-	if err != nil {
-		if err.Error() == pg.ErrNoRows.Error() {
-			return c.JSON(http.StatusOK, cf.JsonResponse{
+	return leaveRequest, nil
+}
+
+// submitLeaveRequestChange : Change is applied at once unless user changes leave starting within notice hours,
+// approvers and watchers of leave are notified
+func (ctr *LvController) submitLeaveRequestChange(
+	c echo.Context,
+	userProfile m.User,
+	leaveRequest m.UserLeaveRequest,
+	leaveRequestChange m.LeaveRequestChange,
+) error {
+	noticeHour := time.Duration(cf.LeaveChangeNoticeHour) * time.Hour
+	isApprovalRequired := userProfile.RoleID == cf.UserRoleID &&
+		leaveRequest.DatetimeLeaveFrom.Sub(calendar.ParseTime(cf.FormatDateNoSec, time.Now().Format(cf.FormatDateNoSec))) < noticeHour
+
+	leaveRequestChange.Status = cf.PendingRequestStatus
+	if !isApprovalRequired {
+		if err := ctr.applyLeaveRequestChange(userProfile, leaveRequest, leaveRequestChange); err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 				Status:  cf.FailResponseCode,
-				Message: "Empty record.",
+				Message: "System Error",
 			})
 		}
 
+		leaveRequestChange.Status = cf.AcceptRequestStatus
+		leaveRequestChange.ApprovedBy = userProfile.UserProfile.UserID
+		leaveRequestChange.ApprovedAt = utils.TimeNowUTC()
+	}
+
+	if err := ctr.LeaveRepo.InsertLeaveRequestChange(&leaveRequestChange); err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
 		})
 	}
 
-	branches := make(map[int]string)
-	for _, record := range branchRecords {
-		branches[record.Id] = record.Name
+	usersIdProject, err := ctr.UserProjectRepo.SelectUserIdsJoinProjectsWithUserId(userProfile.OrganizationID, leaveRequest.UserID)
+	if err != nil {
+		ctr.Logger.Error(err)
 	}
 
-	return c.JSON(http.StatusOK, cf.JsonResponse{
-		Status:  cf.SuccessResponseCode,
-		Message: "Success",
-		Data: map[string]interface{}{
-			"pagination":        pagination,
-			"branch_select_box": branches,
-			"user_list":         usersBox,
-			"users_leave_info":  userLeaveInfoResponse,
-		},
-	})
-}
-
-// CronLeaveBonus : Cron annual leave bonus with run year-01-01 00:00:00 and clear old leave with run year-04-01 00:00:00
-func (ctr *LvController) CronLeaveBonus(c echo.Context) error {
-	userProfile := c.Get("user_profile").(m.User)
-	users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	usersIdGmAndManager, err := ctr.UserRepo.SelectIdsOfGMAndManager(userProfile.OrganizationID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "System Error",
-		})
+		ctr.Logger.Error(err)
 	}
 
-	_, err = ctr.AddFuncCron("* * 1 1 *", "Annual leave bonus cron", func() {
-		var leaveBonusParams []param.LeaveBonus
-		t := time.Now()
-		firstday := time.Date(t.Year()+1, time.Month(userProfile.Organization.ExpirationResetDayOff+1), 1, 0, 0, 0, 0, time.Local)
-		for _, user := range users {
-			leaveBonusParam := new(param.LeaveBonus)
-			leaveBonusParam.UserID = user.UserID
-			leaveBonusParam.LeaveBonusTypeID = cf.AnnualLeave
-			leaveBonusParam.YearBelong = time.Now().Year()
-			leaveBonusParam.Reason = "Annual Leave"
-			leaveBonusParam.Hour = 96
-			leaveBonusParam.ExpireBonusLeaveDate = firstday.Format("2006-01-02 00:04:05")
-			leaveBonusParam.HourRemaining = 96
+	var content string
+	switch {
+	case isApprovalRequired:
+		content = "has just requested to " + strings.ToLower(cf.LeaveChangeTypes[leaveRequestChange.ChangeType]) + " a leave request"
+	case leaveRequestChange.ChangeType == cf.CancelLeaveChange:
+		content = "has just cancelled a leave request"
+	default:
+		content = "has just amended a leave request"
+	}
 
-			leaveBonusParams = append(leaveBonusParams, *leaveBonusParam)
-		}
+	receivers := utils.AppendUniqueSlice(usersIdProject, usersIdGmAndManager)
+	if isApprovalRequired {
+		receivers = utils.AppendUniqueSlice(receivers, ctr.selectDelegateIds(userProfile.OrganizationID, usersIdGmAndManager))
+	}
+	if leaveRequest.UserID != userProfile.UserProfile.UserID {
+		receivers = utils.AppendUniqueSlice(receivers, []int{leaveRequest.UserID})
+	}
+	ctr.sendLeaveNotification(userProfile, receivers, content,
+		"/hrm/leave/history-user-leave?id="+strconv.Itoa(leaveRequest.ID)+"&user_id="+strconv.Itoa(leaveRequest.UserID))
 
-		if len(leaveBonusParams) > 0 {
-			if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
-				userProfile.OrganizationID,
-				userProfile.UserProfile.UserID,
-				&leaveBonusParams,
-			); err != nil {
-				ctr.Logger.Error(err)
-			}
-		}
+	message := "Leave request has been changed successfully."
+	if isApprovalRequired {
+		message = "Leave change has been sent for approval."
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: message,
+		Data: map[string]interface{}{
+			"id":     leaveRequestChange.ID,
+			"status": leaveRequestChange.Status,
+		},
 	})
+}
 
+// applyLeaveRequestChange : Refund hours to bonuses, change leave request and its calendar event
+func (ctr *LvController) applyLeaveRequestChange(
+	userProfile m.User,
+	leaveRequest m.UserLeaveRequest,
+	leaveRequestChange m.LeaveRequestChange,
+) error {
+	validDateRecords, err := ctr.LeaveRepo.SelectValidDateOfUser(leaveRequest.OrganizationID, leaveRequest.UserID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "System Error",
-		})
+		return err
 	}
 
-	_, err = ctr.AddFuncCron("* * 1 * *", "Seniority leave bonus cron", func() {
-		t := time.Now()
-		firstday := time.Date(t.Year()+1, time.Month(userProfile.Organization.ExpirationResetDayOff+1), 1, 0, 0, 0, 0, time.Local)
-
-		var seniorityLeaveBonus []param.LeaveBonus
-		for _, user := range users {
-			if !user.CompanyJoinedDate.IsZero() && user.CompanyJoinedDate.Month() == t.Month() {
-				leaveBonusParam := new(param.LeaveBonus)
-				leaveBonusParam.UserID = user.UserID
-				leaveBonusParam.LeaveBonusTypeID = cf.SeniorityLeave
-				leaveBonusParam.YearBelong = time.Now().Year()
-				leaveBonusParam.Reason = "Seniority Leave"
-				workedMonths := monthsCountSince(user.CompanyJoinedDate)
-				monthCompanyJoined := user.CompanyJoinedDate.Month()
-
-				switch {
-				case workedMonths < 12:
-					break
-				case workedMonths >= 12 && workedMonths < 24:
-					leaveBonusParam.Hour = float64(8*(12-monthCompanyJoined)/12)
-					leaveBonusParam.HourRemaining = float64(8*(12-monthCompanyJoined)/12)
-					break
-				case workedMonths >= 24 && workedMonths < 36:
-					leaveBonusParam.Hour = 8
-					leaveBonusParam.HourRemaining = 8
-					break
-				case workedMonths >= 36 && workedMonths < 48:
-					leaveBonusParam.Hour = float64(8+(8*(12-monthCompanyJoined)/12))
-					leaveBonusParam.HourRemaining = float64(8+(8*(12-monthCompanyJoined)/12))
-					break
-				case workedMonths >= 48 && workedMonths < 60:
-					leaveBonusParam.Hour = 16
-					leaveBonusParam.HourRemaining = 16
-					break
-				case workedMonths >= 60 && workedMonths < 72:
-					leaveBonusParam.Hour = float64(16+(8*(12-monthCompanyJoined)/12))
-					leaveBonusParam.HourRemaining = float64(16+(8*(12-monthCompanyJoined)/12))
-					break
-				case workedMonths >= 72:
-					leaveBonusParam.Hour = 24
-					leaveBonusParam.HourRemaining = 24
-					break
-				}
-
-				leaveBonusParam.ExpireBonusLeaveDate = firstday.Format("2006-01-02 00:04:05")
-				seniorityLeaveBonus = append(seniorityLeaveBonus, *leaveBonusParam)
-			}
+	if leaveRequestChange.ChangeType == cf.CancelLeaveChange {
+		refundLeaveHour(ctr.LeaveRepo, validDateRecords, leaveRequest.Hour)
+		if err := ctr.LeaveRepo.RemoveLeave(leaveRequest.ID); err != nil {
+			return err
 		}
 
-		if len(seniorityLeaveBonus) > 0 {
-			if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
-				userProfile.OrganizationID,
-				userProfile.UserProfile.UserID,
-				&seniorityLeaveBonus,
-			); err != nil {
-				ctr.Logger.Error(err)
-			}
+		if leaveRequest.CalendarEventId != "" {
+			calendar.RemoveLeaveEvent(leaveRequest.CalendarEventId)
 		}
-	})
 
+		return nil
+	}
+
+	refundLeaveHour(ctr.LeaveRepo, validDateRecords, leaveRequest.Hour-leaveRequestChange.Hour)
+	err = ctr.LeaveRepo.AmendLeaveRequest(
+		leaveRequest.ID,
+		leaveRequestChange.DatetimeLeaveFrom,
+		leaveRequestChange.DatetimeLeaveTo,
+		leaveRequestChange.Hour,
+		userProfile.UserProfile.UserID,
+	)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "System Error",
-		})
+		return err
 	}
 
-	var spec string
-	if userProfile.Organization.ExpirationResetDayOff < 12 {
-		spec = "5 9 2 " + strconv.Itoa(userProfile.Organization.ExpirationResetDayOff+1) + " *"
-	} else {
-		spec = "0 0 1 1 *"
+	if leaveRequest.CalendarEventId != "" {
+		calendar.RemoveLeaveEvent(leaveRequest.CalendarEventId)
 	}
-	_, err = ctr.AddFuncCron(spec, "Clear old leave cron", func() {
-		previousYear := time.Now().Year() - 1
-		var leaveBonusParams []param.LeaveBonus
 
-		for _, user := range users {
-			hourUsedOld, err := ctr.LeaveRepo.CountHourUsed(userProfile.OrganizationID, user.UserID, previousYear)
-			if err != nil {
-				return
-			}
+	fullName, err := ctr.UserRepo.SelectFullNameUser(leaveRequest.UserID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return err
+	}
 
-			hourBonusOld, err := ctr.LeaveRepo.CountHourBonus(userProfile.OrganizationID, user.UserID, previousYear)
-			if err != nil {
-				return
-			}
+	start, end := leaveEventPeriod(
+		leaveRequest.LeaveRequestTypeID,
+		leaveRequestChange.DatetimeLeaveFrom.Format(cf.FormatDateNoSec),
+		leaveRequestChange.DatetimeLeaveTo.Format(cf.FormatDateNoSec),
+	)
+	event := calendar.AddLeaveEvent(
+		leaveRequest.LeaveRequestTypeID,
+		fullName+" - "+cf.LeaveRequestJpTypes[leaveRequest.LeaveRequestTypeID],
+		leaveRequest.Reason,
+		start,
+		end,
+	)
 
-			t := time.Now()
-			first := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
-			hoursUpOld, err := ctr.LeaveRepo.CountHoursUpToExpirationDate(userProfile.OrganizationID, user.UserID, t.Format(cf.FormatDate), first.Format(cf.FormatDate))
-			if err != nil {
-				return
-			}
+	return ctr.LeaveRepo.UpdateLeaveRequest(leaveRequest.ID, event.Id)
+}
 
-			hourRemainingOld := hourBonusOld - hourUsedOld - hoursUpOld
-			if hourRemainingOld > 0 {
-				leaveBonusParam := new(param.LeaveBonus)
-				leaveBonusParam.UserID = user.UserID
-				leaveBonusParam.LeaveBonusTypeID = cf.AnnualLeave
-				leaveBonusParam.YearBelong = previousYear
-				leaveBonusParam.Reason = "Clear old leave"
-				leaveBonusParam.Hour = -hourRemainingOld
-				leaveBonusParam.HourRemaining = -hourRemainingOld
+// sendLeaveNotification : Insert notification and push it to devices of receivers
+func (ctr *LvController) sendLeaveNotification(userProfile m.User, receivers []int, content string, link string) {
+	notificationParams := param.InsertNotificationParam{
+		Content:     content,
+		RedirectUrl: link,
+	}
 
-				leaveBonusParams = append(leaveBonusParams, *leaveBonusParam)
-			}
-		}
+	err := ctr.LeaveRepo.InsertLeaveNotifications(
+		userProfile.OrganizationID,
+		userProfile.UserProfile.UserID,
+		ctr.NotificationRepo,
+		receivers,
+		notificationParams,
+	)
+	if err != nil {
+		return
+	}
 
-		if len(leaveBonusParams) > 0 {
-			if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
-				userProfile.OrganizationID,
-				userProfile.UserProfile.UserID,
-				&leaveBonusParams,
-			); err != nil {
-				ctr.Logger.Error(err)
-			}
+	registrationTokens, err := ctr.FcmTokenRepo.SelectMultiFcmTokens(receivers, userProfile.UserProfile.UserID)
+	if err != nil || len(registrationTokens) == 0 {
+		return
+	}
+
+	body := userProfile.UserProfile.FirstName + " " + userProfile.UserProfile.LastName + " " + content
+	for _, token := range registrationTokens {
+		err := ctr.SendMessageToSpecificUser(token, "Micro Erp New Notification", body, link)
+		if err != nil && err.Error() == "http error status: 400; reason: request contains an invalid argument; "+
+			"code: invalid-argument; details: The registration token is not a valid FCM registration token" {
+			_ = ctr.FcmTokenRepo.DeleteFcmToken(token)
 		}
-	})
+	}
+}
 
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+func (ctr *LvController) SaveProjectStaffingRule(c echo.Context) error {
+	params := new(param.SaveProjectStaffingRuleParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
-			Message: "System Error",
+			Message: "Invalid Params",
 		})
 	}
 
-	_, err = ctr.AddFuncCron("0 0 1 * *", "Add extra day at the beginning of the month when running out of day off", func() {
-		currentYear := time.Now().Year()
-		var leaveBonusParams []param.LeaveBonus
-
-		for _, user := range users {
-			hourUsedCurrent, err := ctr.LeaveRepo.CountHourUsed(userProfile.OrganizationID, user.UserID, currentYear)
-			if err != nil {
-				return
-			}
-
-			hourBonusCurrent, err := ctr.LeaveRepo.CountHourBonus(userProfile.OrganizationID, user.UserID, currentYear)
-			if err != nil {
-				return
-			}
-
-			hourRemainingOld := hourBonusCurrent - hourUsedCurrent
-			if hourRemainingOld < 0 {
-				leaveBonusParam := new(param.LeaveBonus)
-				leaveBonusParam.UserID = user.UserID
-				leaveBonusParam.LeaveBonusTypeID = cf.ClearLeave
-				leaveBonusParam.YearBelong = currentYear
-				leaveBonusParam.Reason = "Add extra day at the beginning of the month when running out of day off"
-				leaveBonusParam.Hour = -hourRemainingOld
-
-				leaveBonusParams = append(leaveBonusParams, *leaveBonusParam)
-			}
-		}
+	if _, err := valid.ValidateStruct(params); err != nil || params.MinStaff < 1 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
 
-		if len(leaveBonusParams) > 0 {
-			if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
-				userProfile.OrganizationID,
-				userProfile.UserProfile.UserID,
-				&leaveBonusParams,
-			); err != nil {
-				ctr.Logger.Error(err)
-			}
-		}
-	})
+	userProfile := c.Get("user_profile").(m.User)
+	project, errResponse := ctr.findProjectOfOrganization(c, userProfile.OrganizationID, params.ProjectId)
+	if errResponse != nil || project.ID == 0 {
+		return errResponse
+	}
 
-	if err != nil {
+	if err := ctr.LeaveRepo.SaveProjectStaffingRule(userProfile.OrganizationID, params); err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
@@ -1173,509 +1311,3830 @@ func (ctr *LvController) CronLeaveBonus(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Create cron leave successfully.",
+		Message: "Save staffing rule successfully.",
 	})
 }
 
-// CronLeaveStart : Start leave cron
-func (ctr *LvController) CronLeaveStart(c echo.Context) error {
-	ctr.StartCron()
+func (ctr *LvController) RemoveProjectStaffingRule(c echo.Context) error {
+	params := new(param.RemoveProjectStaffingRuleParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if err := ctr.LeaveRepo.DeleteProjectStaffingRule(userProfile.OrganizationID, params.ProjectId); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Start cron leave successfully.",
+		Message: "Remove staffing rule successfully.",
 	})
 }
 
-// CronLeaveStop : Stop leave cron
-func (ctr *LvController) CronLeaveStop(c echo.Context) error {
-	ctr.StopCron()
+func (ctr *LvController) GetProjectStaffingRules(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	records, err := ctr.LeaveRepo.SelectProjectStaffingRules(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Stop cron leave successfully.",
+		Message: "Success",
+		Data:    records,
 	})
 }
 
-// GetEntriesLeave : Get entries leave
-func (ctr *LvController) GetEntriesLeave(c echo.Context) error {
-	entries := ctr.GetEntries()
-	var entriesResponse []map[string]interface{}
+// GetTeamLeaveCalendar : Get who is off per day in project or branch, days when project is below minimum staffing are marked
+func (ctr *LvController) GetTeamLeaveCalendar(c echo.Context) error {
+	params := new(param.TeamLeaveCalendarParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
 
-	for _, entry := range entries {
-		itemDataResponse := map[string]interface{}{
-			"id":   entry.ID,
-			"name": entry.Name,
+	_, err := valid.ValidateStruct(params)
+	dateFrom, errFrom := time.Parse(cf.FormatDateDatabase, params.DateFrom)
+	dateTo, errTo := time.Parse(cf.FormatDateDatabase, params.DateTo)
+	if err != nil || errFrom != nil || errTo != nil || dateTo.Before(dateFrom) ||
+		dateTo.Sub(dateFrom).Hours()/24 >= cf.MaxTeamLeaveCalendarDay {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	var userIds []int
+	var minStaff int
+	if params.ProjectId != 0 {
+		project, errResponse := ctr.findProjectOfOrganization(c, userProfile.OrganizationID, params.ProjectId)
+		if errResponse != nil || project.ID == 0 {
+			return errResponse
+		}
+
+		members, err := ctr.UserProjectRepo.SelectMembersInProject(userProfile.OrganizationID, params.ProjectId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		for _, member := range members {
+			userIds = append(userIds, member.UserId)
 		}
 
-		entriesResponse = append(entriesResponse, itemDataResponse)
+		rules, err := ctr.LeaveRepo.SelectProjectStaffingRulesByProjectIds([]int{params.ProjectId})
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if len(rules) > 0 {
+			minStaff = rules[0].MinStaff
+		}
+	}
+
+	var leaves []param.TeamLeaveRecords
+	if params.ProjectId == 0 || len(userIds) > 0 {
+		leaves, err = ctr.LeaveRepo.SelectTeamLeaveRequests(userProfile.OrganizationID, userIds, params.Branch, dateFrom, dateTo)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+	}
+
+	holidays := ctr.getHolidayDates(userProfile.OrganizationID, dateFrom, dateTo)
+	var days []map[string]interface{}
+	for date := dateFrom; !date.After(dateTo); date = date.AddDate(0, 0, 1) {
+		day := date.Format(cf.FormatDateDatabase)
+		absentUsers := make(map[int]bool)
+		var leavesOfDay []map[string]interface{}
+		for _, leave := range leaves {
+			if !isLeaveOnDate(leave, day) {
+				continue
+			}
+
+			if isAbsentLeaveType(leave.LeaveRequestTypeId) {
+				absentUsers[leave.UserId] = true
+			}
+
+			leavesOfDay = append(leavesOfDay, map[string]interface{}{
+				"id":                  leave.Id,
+				"user_id":             leave.UserId,
+				"full_name":           leave.FullName,
+				"leave_request_type":  cf.LeaveRequestTypes[leave.LeaveRequestTypeId],
+				"datetime_leave_from": leave.DatetimeLeaveFrom.Format(cf.FormatDateNoSec),
+				"datetime_leave_to":   leave.DatetimeLeaveTo.Format(cf.FormatDateNoSec),
+				"hour":                leave.Hour,
+			})
+		}
+
+		isWorkingDay := !calendar.IsWeekend(date) && !holidays[day]
+		dayResponse := map[string]interface{}{
+			"date":           day,
+			"is_working_day": isWorkingDay,
+			"off_count":      len(absentUsers),
+			"leaves":         leavesOfDay,
+		}
+
+		if params.ProjectId != 0 {
+			available := len(userIds) - len(absentUsers)
+			dayResponse["available"] = available
+			dayResponse["is_understaffed"] = isWorkingDay && available < minStaff
+		}
+
+		days = append(days, dayResponse)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"project_id":   params.ProjectId,
+			"branch":       params.Branch,
+			"member_count": len(userIds),
+			"min_staff":    minStaff,
+			"days":         days,
+		},
+	})
+}
+
+// CheckLeaveConflicts : Check leave before it is created, blocking conflict makes creating leave fail
+func (ctr *LvController) CheckLeaveConflicts(c echo.Context) error {
+	params := new(param.CheckLeaveConflictsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	datetimeLeaveFrom, errFrom := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveFrom)
+	datetimeLeaveTo, errTo := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveTo)
+	if err != nil || errFrom != nil || errTo != nil || datetimeLeaveTo.Before(datetimeLeaveFrom) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	conflicts, isBlocked, err := ctr.checkLeaveConflicts(
+		userProfile.OrganizationID,
+		params.UserId,
+		params.LeaveRequestTypeId,
+		datetimeLeaveFrom,
+		datetimeLeaveTo,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"is_blocked": isBlocked,
+			"conflicts":  conflicts,
+		},
+	})
+}
+
+// checkLeaveConflicts : Find working days when leave drops a project of user below minimum staffing
+// and undone tasks of user which are due during leave
+func (ctr *LvController) checkLeaveConflicts(
+	organizationId int,
+	userId int,
+	leaveRequestTypeId int,
+	datetimeLeaveFrom time.Time,
+	datetimeLeaveTo time.Time,
+) ([]param.LeaveConflict, bool, error) {
+	var conflicts []param.LeaveConflict
+	if !isAbsentLeaveType(leaveRequestTypeId) {
+		return conflicts, false, nil
+	}
+
+	if datetimeLeaveTo.Before(datetimeLeaveFrom) {
+		datetimeLeaveTo = datetimeLeaveFrom
+	}
+
+	projects, err := ctr.UserProjectRepo.SelectProjectsByUserId(organizationId, userId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return conflicts, false, err
+	}
+
+	var projectIds []int
+	projectNames := make(map[int]string)
+	for _, project := range projects {
+		projectIds = append(projectIds, project.ID)
+		projectNames[project.ID] = project.Name
+	}
+
+	rules, err := ctr.LeaveRepo.SelectProjectStaffingRulesByProjectIds(projectIds)
+	if err != nil {
+		return conflicts, false, err
+	}
+
+	holidays := ctr.getHolidayDates(organizationId, datetimeLeaveFrom, datetimeLeaveTo)
+	projectRules := make(map[int]m.ProjectStaffingRule)
+	for _, rule := range rules {
+		projectRules[rule.ProjectId] = rule
+		members, err := ctr.UserProjectRepo.SelectMembersInProject(organizationId, rule.ProjectId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return conflicts, false, err
+		}
+
+		var memberIds []int
+		for _, member := range members {
+			memberIds = append(memberIds, member.UserId)
+		}
+
+		leaves, err := ctr.LeaveRepo.SelectTeamLeaveRequests(organizationId, memberIds, 0, datetimeLeaveFrom, datetimeLeaveTo)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return conflicts, false, err
+		}
+
+		for date := dateOf(datetimeLeaveFrom); !date.After(datetimeLeaveTo); date = date.AddDate(0, 0, 1) {
+			day := date.Format(cf.FormatDateDatabase)
+			if calendar.IsWeekend(date) || holidays[day] {
+				continue
+			}
+
+			absentUsers := map[int]bool{userId: true}
+			for _, leave := range leaves {
+				if isAbsentLeaveType(leave.LeaveRequestTypeId) && isLeaveOnDate(leave, day) {
+					absentUsers[leave.UserId] = true
+				}
+			}
+
+			available := len(memberIds) - len(absentUsers)
+			if available < rule.MinStaff {
+				conflicts = append(conflicts, param.LeaveConflict{
+					Type:        cf.StaffingLeaveConflict,
+					TypeName:    cf.LeaveConflictTypes[cf.StaffingLeaveConflict],
+					ProjectId:   rule.ProjectId,
+					ProjectName: projectNames[rule.ProjectId],
+					Date:        day,
+					Available:   available,
+					MinStaff:    rule.MinStaff,
+					IsBlocking:  rule.ConflictAction == cf.BlockLeaveConflict,
+				})
+			}
+		}
+	}
+
+	tasks, err := ctr.LeaveRepo.SelectDueKanbanTasks(projectIds, userId, datetimeLeaveFrom, datetimeLeaveTo)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return conflicts, false, err
+	}
+
+	for _, task := range tasks {
+		conflicts = append(conflicts, param.LeaveConflict{
+			Type:        cf.DeadlineLeaveConflict,
+			TypeName:    cf.LeaveConflictTypes[cf.DeadlineLeaveConflict],
+			ProjectId:   task.ProjectId,
+			ProjectName: projectNames[task.ProjectId],
+			Date:        task.DueDate.Format(cf.FormatDateDatabase),
+			TaskId:      task.Id,
+			TaskTitle:   task.Title,
+			IsBlocking:  projectRules[task.ProjectId].ConflictAction == cf.BlockLeaveConflict,
+		})
+	}
+
+	isBlocked := false
+	for _, conflict := range conflicts {
+		isBlocked = isBlocked || conflict.IsBlocking
+	}
+
+	return conflicts, isBlocked, nil
+}
+
+// findProjectOfOrganization : Project of organization, project id is 0 when response was written
+func (ctr *LvController) findProjectOfOrganization(c echo.Context, organizationId int, projectId int) (m.Project, error) {
+	project, err := ctr.ProjectRepo.GetProjectByID(projectId)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return m.Project{}, c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Project does not exist",
+			})
+		}
+
+		return m.Project{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if project.OrganizationID != organizationId {
+		return m.Project{}, c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to access this project",
+		})
+	}
+
+	return project, nil
+}
+
+// getHolidayDates : Holidays of organization between dates, keyed by date
+func (ctr *LvController) getHolidayDates(organizationId int, dateFrom time.Time, dateTo time.Time) map[string]bool {
+	holidays := make(map[string]bool)
+	for year := dateFrom.Year(); year <= dateTo.Year(); year++ {
+		for _, holiday := range ctr.getHolidaysOfYear(organizationId, year) {
+			holidays[holiday.Format(cf.FormatDateDatabase)] = true
+		}
+	}
+
+	return holidays
+}
+
+func isLeaveOnDate(leave param.TeamLeaveRecords, day string) bool {
+	leaveTo := leave.DatetimeLeaveTo
+	if leaveTo.IsZero() {
+		leaveTo = leave.DatetimeLeaveFrom
+	}
+
+	return leave.DatetimeLeaveFrom.Format(cf.FormatDateDatabase) <= day && day <= leaveTo.Format(cf.FormatDateDatabase)
+}
+
+func isAbsentLeaveType(leaveRequestTypeId int) bool {
+	for _, absentLeaveType := range cf.AbsentLeaveTypes {
+		if absentLeaveType == leaveRequestTypeId {
+			return true
+		}
+	}
+
+	return false
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// ForecastLeaveBalance : Project balance of user month by month with future accruals, expiries,
+// pending changes of leave requests and proposed leave
+func (ctr *LvController) ForecastLeaveBalance(c echo.Context) error {
+	params := new(param.ForecastLeaveBalanceParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if params.UserId == 0 {
+		params.UserId = userProfile.UserProfile.UserID
+	}
+
+	if userProfile.RoleID == cf.UserRoleID && params.UserId != userProfile.UserProfile.UserID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to view balance of other user",
+		})
+	}
+
+	if params.Months == 0 {
+		params.Months = 12
+	}
+
+	var events []leaveForecastEvent
+	var proposedResponse []map[string]interface{}
+	for i, proposed := range params.Proposed {
+		_, err := valid.ValidateStruct(proposed)
+		datetimeLeaveFrom, errFrom := time.Parse(cf.FormatDateNoSec, proposed.DatetimeLeaveFrom)
+		datetimeLeaveTo, errTo := time.Parse(cf.FormatDateNoSec, proposed.DatetimeLeaveTo)
+		if err != nil || errFrom != nil || errTo != nil || datetimeLeaveTo.Before(datetimeLeaveFrom) {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+				Data:    i,
+			})
+		}
+
+		if proposed.SubtractDayOffTypeId == 0 {
+			proposed.SubtractDayOffTypeId = cf.Subtract
+		}
+
+		hour := calendar.CalculateHour(
+			userProfile.OrganizationID,
+			ctr.HolidayRepo,
+			proposed.LeaveRequestTypeId,
+			datetimeLeaveFrom,
+			datetimeLeaveTo,
+			proposed.SubtractDayOffTypeId,
+			proposed.ExtraTime,
+		)
+		if proposed.SubtractDayOffTypeId != cf.Subtract {
+			hour = 0
+		}
+
+		events = append(events, leaveForecastEvent{date: dateOf(datetimeLeaveFrom), hour: -hour})
+		proposedResponse = append(proposedResponse, map[string]interface{}{
+			"leave_request_type":  cf.LeaveRequestTypes[proposed.LeaveRequestTypeId],
+			"datetime_leave_from": proposed.DatetimeLeaveFrom,
+			"datetime_leave_to":   proposed.DatetimeLeaveTo,
+			"hour":                hour,
+		})
+	}
+
+	records, err := ctr.LeaveRepo.SelectLeaveBonusBuckets(userProfile.OrganizationID, params.UserId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var buckets []*leaveForecastBucket
+	for _, record := range records {
+		buckets = append(buckets, newLeaveForecastBucket(record.LeaveBonusTypeId, record.Hour, record.HourRemaining, record.ExpireBonusLeaveDate))
+	}
+
+	today := dateOf(time.Now())
+	if params.IncludePending {
+		changes, _, err := ctr.LeaveRepo.SelectLeaveRequestChanges(userProfile.OrganizationID, &param.GetLeaveChangesParams{
+			Status:      cf.PendingRequestStatus,
+			UserId:      params.UserId,
+			CurrentPage: 1,
+			RowPerPage:  100,
+		})
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		for _, change := range changes {
+			refundHour := change.LeaveHour
+			if change.ChangeType == cf.AmendLeaveChange {
+				refundHour -= change.Hour
+			}
+
+			events = append(events, leaveForecastEvent{date: today, hour: refundHour, isRefund: true})
+		}
+	}
+
+	// Accrual cron runs at the beginning of every month
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+	grantedPeriods := make(map[string]bool)
+	for i := 1; i <= params.Months; i++ {
+		accrualDate := monthStart.AddDate(0, i, 0)
+		grants, err := ctr.computeLeaveAccrualGrants(userProfile.OrganizationID, params.UserId, accrualDate)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		for _, grant := range grants {
+			key := leaveAccrualKeyOfGrant(grant)
+			if grantedPeriods[key] {
+				continue
+			}
+
+			grantedPeriods[key] = true
+			events = append(events, leaveForecastEvent{
+				date:       accrualDate,
+				hour:       grant.Hour,
+				bonusType:  grant.LeaveBonusTypeId,
+				expireDate: grant.ExpireBonusLeaveDate,
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].date.Before(events[j].date) })
+
+	var months []map[string]interface{}
+	eventIndex := 0
+	for i := 0; i <= params.Months; i++ {
+		monthEnd := monthStart.AddDate(0, i+1, 0)
+		var accrued, used, refunded, expired, shortfall float64
+		for ; eventIndex < len(events) && events[eventIndex].date.Before(monthEnd); eventIndex++ {
+			event := events[eventIndex]
+			expired += expireLeaveForecastBuckets(buckets, event.date)
+			switch {
+			case event.isRefund:
+				refundLeaveForecastBuckets(buckets, event.hour)
+				refunded += event.hour
+			case event.hour > 0:
+				buckets = append(buckets, newLeaveForecastBucket(event.bonusType, event.hour, event.hour, event.expireDate))
+				accrued += event.hour
+			default:
+				shortfall += consumeLeaveForecastBuckets(buckets, -event.hour, event.date)
+				used -= event.hour
+			}
+		}
+		expired += expireLeaveForecastBuckets(buckets, monthEnd)
+
+		balances := make(map[string]float64)
+		var total float64
+		for _, bucket := range buckets {
+			if bucket.hourRemaining > 0 {
+				balances[cf.LeaveBonusTypes[bucket.bonusType]] = roundLeaveHour(balances[cf.LeaveBonusTypes[bucket.bonusType]] + bucket.hourRemaining)
+				total += bucket.hourRemaining
+			}
+		}
+
+		months = append(months, map[string]interface{}{
+			"month":     monthStart.AddDate(0, i, 0).Format("2006-01"),
+			"accrued":   roundLeaveHour(accrued),
+			"used":      roundLeaveHour(used),
+			"refunded":  roundLeaveHour(refunded),
+			"expired":   roundLeaveHour(expired),
+			"shortfall": roundLeaveHour(shortfall),
+			"balances":  balances,
+			"total":     roundLeaveHour(total),
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"user_id":  params.UserId,
+			"proposed": proposedResponse,
+			"months":   months,
+		},
+	})
+}
+
+// leaveForecastEvent : Change of balance on date, positive hour is accrual or refund and negative hour is usage
+type leaveForecastEvent struct {
+	date       time.Time
+	hour       float64
+	bonusType  int
+	expireDate string
+	isRefund   bool
+}
+
+// leaveForecastBucket : Leave bonus in simulation, zero expire date never expires
+type leaveForecastBucket struct {
+	bonusType     int
+	hour          float64
+	hourRemaining float64
+	expireDate    time.Time
+}
+
+func newLeaveForecastBucket(bonusType int, hour float64, hourRemaining float64, expireBonusLeaveDate string) *leaveForecastBucket {
+	bucket := &leaveForecastBucket{bonusType: bonusType, hour: hour, hourRemaining: hourRemaining}
+	if len(expireBonusLeaveDate) >= len(cf.FormatDateDatabase) {
+		expireDate, err := time.ParseInLocation(cf.FormatDateDatabase, expireBonusLeaveDate[:len(cf.FormatDateDatabase)], time.Local)
+		if err == nil {
+			bucket.expireDate = expireDate
+		}
+	}
+
+	return bucket
+}
+
+func isLeaveForecastBucketValid(bucket *leaveForecastBucket, date time.Time) bool {
+	return bucket.expireDate.IsZero() || !bucket.expireDate.Before(date)
+}
+
+// expireLeaveForecastBuckets : Clear buckets whose expire date is before date, returns hours expired
+func expireLeaveForecastBuckets(buckets []*leaveForecastBucket, date time.Time) float64 {
+	var expired float64
+	for _, bucket := range buckets {
+		if bucket.hourRemaining > 0 && !isLeaveForecastBucketValid(bucket, date) {
+			expired += bucket.hourRemaining
+			bucket.hourRemaining = 0
+		}
+	}
+
+	return expired
+}
+
+// consumeLeaveForecastBuckets : Use hours from valid buckets expiring first, returns hours which can not be covered
+func consumeLeaveForecastBuckets(buckets []*leaveForecastBucket, hour float64, date time.Time) float64 {
+	sortLeaveForecastBuckets(buckets)
+	for _, bucket := range buckets {
+		if hour <= 0 {
+			break
+		}
+
+		if bucket.hourRemaining <= 0 || !isLeaveForecastBucketValid(bucket, date) {
+			continue
+		}
+
+		usedHour := math.Min(hour, bucket.hourRemaining)
+		bucket.hourRemaining -= usedHour
+		hour -= usedHour
+	}
+
+	return hour
+}
+
+// refundLeaveForecastBuckets : Give back hours to used buckets expiring first, same as refundLeaveHour
+func refundLeaveForecastBuckets(buckets []*leaveForecastBucket, hour float64) {
+	sortLeaveForecastBuckets(buckets)
+	for _, bucket := range buckets {
+		if hour <= 0 {
+			break
+		}
+
+		refundHour := math.Min(hour, bucket.hour-bucket.hourRemaining)
+		if refundHour <= 0 {
+			continue
+		}
+
+		bucket.hourRemaining += refundHour
+		hour -= refundHour
+	}
+}
+
+func sortLeaveForecastBuckets(buckets []*leaveForecastBucket) {
+	sort.SliceStable(buckets, func(i, j int) bool {
+		if buckets[i].expireDate.IsZero() || buckets[j].expireDate.IsZero() {
+			return !buckets[i].expireDate.IsZero()
+		}
+
+		return buckets[i].expireDate.Before(buckets[j].expireDate)
+	})
+}
+
+func leaveAccrualKeyOfGrant(grant param.LeaveAccrualGrant) string {
+	return strconv.Itoa(grant.PolicyId) + ":" + grant.Period + ":" + strconv.Itoa(grant.LeaveBonusTypeId)
+}
+
+// SaveStatutoryLeaveRule : Set entitlement, required documents and annual leave deduction of statutory leave type
+func (ctr *LvController) SaveStatutoryLeaveRule(c echo.Context) error {
+	params := new(param.SaveStatutoryLeaveRuleParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if _, ok := cf.StatutoryLeaveTypes[params.LeaveBonusTypeId]; err != nil || !ok ||
+		params.EntitlementDay < 0 || (params.EntitlementDay == 0 && params.MaxMonth == 0) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	var requiredDocuments []string
+	for _, document := range params.RequiredDocuments {
+		if strings.TrimSpace(document) != "" {
+			requiredDocuments = append(requiredDocuments, strings.TrimSpace(document))
+		}
+	}
+	params.RequiredDocuments = requiredDocuments
+
+	userProfile := c.Get("user_profile").(m.User)
+	if err := ctr.LeaveRepo.SaveStatutoryLeaveRule(userProfile.OrganizationID, params); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Save statutory leave rule successfully.",
+	})
+}
+
+func (ctr *LvController) RemoveStatutoryLeaveRule(c echo.Context) error {
+	params := new(param.RemoveStatutoryLeaveRuleParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if err := ctr.LeaveRepo.DeleteStatutoryLeaveRule(userProfile.OrganizationID, params.LeaveBonusTypeId); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove statutory leave rule successfully.",
+	})
+}
+
+func (ctr *LvController) GetStatutoryLeaveRules(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	statutoryLeaveRules, err := ctr.LeaveRepo.SelectStatutoryLeaveRules(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var rules []map[string]interface{}
+	for _, rule := range statutoryLeaveRules {
+		rules = append(rules, map[string]interface{}{
+			"leave_bonus_type_id":  rule.LeaveBonusTypeId,
+			"leave_bonus_type":     cf.StatutoryLeaveTypes[rule.LeaveBonusTypeId],
+			"entitlement_day":      rule.EntitlementDay,
+			"is_calendar_day":      rule.IsCalendarDay,
+			"max_month":            rule.MaxMonth,
+			"required_documents":   rule.RequiredDocuments,
+			"count_against_annual": rule.CountAgainstAnnual,
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"rules":                 rules,
+			"statutory_leave_types": cf.StatutoryLeaveTypes,
+		},
+	})
+}
+
+// CreateStatutoryLeave : Create leave by statutory leave rule with supporting documents. Leave which does not count
+// against annual leave is created with subtract type Other so balance is not touched.
+func (ctr *LvController) CreateStatutoryLeave(c echo.Context) error {
+	params := new(param.CreateStatutoryLeaveParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	eventDate, errEvent := time.Parse(cf.FormatDateDatabase, params.EventDate)
+	datetimeLeaveFrom, errFrom := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveFrom)
+	datetimeLeaveTo, errTo := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveTo)
+	if err != nil || errEvent != nil || errFrom != nil || errTo != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	for _, document := range params.Documents {
+		if _, err := valid.ValidateStruct(document); err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+			})
+		}
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if userProfile.RoleID == cf.UserRoleID && params.UserId != userProfile.UserProfile.UserID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You can't create leave request for another user.",
+		})
+	}
+
+	leaveUser, err := ctr.UserRepo.GetUserProfileExpand(params.UserId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || leaveUser.OrganizationID != userProfile.OrganizationID || leaveUser.Status == cf.SeveranceStatus {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "User has left the company.",
+		})
+	}
+
+	if datetimeLeaveTo.Before(datetimeLeaveFrom) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "From date must be less than To date.",
+		})
+	}
+
+	rule, err := ctr.LeaveRepo.SelectStatutoryLeaveRule(userProfile.OrganizationID, params.LeaveBonusTypeId)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Statutory leave rule of this leave type has not been set.",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	uploadedDocuments := make(map[string]bool)
+	for _, document := range params.Documents {
+		uploadedDocuments[document.DocumentName] = true
+	}
+
+	var missingDocuments []string
+	for _, requiredDocument := range rule.RequiredDocuments {
+		if !uploadedDocuments[requiredDocument] {
+			missingDocuments = append(missingDocuments, requiredDocument)
+		}
+	}
+
+	if len(missingDocuments) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Supporting documents are required.",
+			Data:    missingDocuments,
+		})
+	}
+
+	if rule.MaxMonth > 0 && !dateOf(datetimeLeaveTo).Before(dateOf(datetimeLeaveFrom).AddDate(0, rule.MaxMonth, 0)) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Leave can't be longer than " + strconv.Itoa(rule.MaxMonth) + " months.",
+		})
+	}
+
+	hour := calendar.CalculateHour(
+		userProfile.OrganizationID,
+		ctr.HolidayRepo,
+		cf.FullDayOff,
+		datetimeLeaveFrom,
+		datetimeLeaveTo,
+		cf.Subtract,
+		0,
+	)
+	day := hour / 8
+	if rule.IsCalendarDay {
+		day = dateOf(datetimeLeaveTo).Sub(dateOf(datetimeLeaveFrom)).Hours()/24 + 1
+	}
+
+	if rule.EntitlementDay > 0 {
+		takenDay, err := ctr.LeaveRepo.SumStatutoryLeaveDays(userProfile.OrganizationID, params.UserId, params.LeaveBonusTypeId, params.EventDate)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if takenDay+day > rule.EntitlementDay {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Leave is over entitlement of this event.",
+				Data: map[string]interface{}{
+					"entitlement_day": rule.EntitlementDay,
+					"taken_day":       takenDay,
+					"day":             day,
+				},
+			})
+		}
+	}
+
+	leaveRequest := m.UserLeaveRequest{
+		OrganizationID:       userProfile.OrganizationID,
+		UserID:               params.UserId,
+		LeaveRequestTypeID:   cf.FullDayOff,
+		DatetimeLeaveFrom:    datetimeLeaveFrom,
+		DatetimeLeaveTo:      datetimeLeaveTo,
+		CreatedBy:            userProfile.UserProfile.UserID,
+		UpdatedBy:            userProfile.UserProfile.UserID,
+		SubtractDayOffTypeID: cf.Other,
+		Reason:               cf.StatutoryLeaveTypes[params.LeaveBonusTypeId] + ": " + params.Reason,
+	}
+	if rule.CountAgainstAnnual {
+		leaveRequest.SubtractDayOffTypeID = cf.Subtract
+		leaveRequest.Hour = hour
+	}
+
+	statutoryLeave := m.StatutoryLeave{
+		OrganizationId:     userProfile.OrganizationID,
+		UserId:             params.UserId,
+		LeaveBonusTypeId:   params.LeaveBonusTypeId,
+		EventDate:          eventDate,
+		DatetimeLeaveFrom:  datetimeLeaveFrom,
+		DatetimeLeaveTo:    datetimeLeaveTo,
+		Day:                day,
+		Hour:               hour,
+		CountAgainstAnnual: rule.CountAgainstAnnual,
+		Reason:             params.Reason,
+		CreatedBy:          userProfile.UserProfile.UserID,
+	}
+
+	directoryCloud := cf.StatutoryLeaveFolderGCS + strconv.Itoa(userProfile.OrganizationID) + "/"
+	var documents []m.StatutoryLeaveDocument
+	for _, document := range params.Documents {
+		fileName := strconv.FormatInt(time.Now().UnixNano(), 10) + "_" + filepath.Base(document.FileName)
+		if err := ctr.cloud.UploadFileToCloud(document.FileContent, fileName, directoryCloud); err != nil {
+			ctr.removeStatutoryLeaveFiles(documents, directoryCloud)
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		documents = append(documents, m.StatutoryLeaveDocument{
+			DocumentName: document.DocumentName,
+			FileName:     fileName,
+			CreatedBy:    userProfile.UserProfile.UserID,
+		})
+	}
+
+	if err := ctr.LeaveRepo.InsertStatutoryLeave(&statutoryLeave, &leaveRequest, documents); err != nil {
+		ctr.removeStatutoryLeaveFiles(documents, directoryCloud)
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	fullName, _ := ctr.UserRepo.SelectFullNameUser(params.UserId)
+	start, end := leaveEventPeriod(cf.FullDayOff, params.DatetimeLeaveFrom, params.DatetimeLeaveTo)
+	event := calendar.AddLeaveEvent(
+		cf.FullDayOff,
+		fullName+" - "+cf.StatutoryLeaveTypes[params.LeaveBonusTypeId],
+		params.Reason,
+		start,
+		end,
+	)
+
+	if err := ctr.LeaveRepo.UpdateLeaveRequest(leaveRequest.ID, event.Id); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	usersIdGmAndManager, err := ctr.UserRepo.SelectIdsOfGMAndManager(userProfile.OrganizationID)
+	if err == nil {
+		link := "/hrm/leave/history-user-leave?id=" + strconv.Itoa(leaveRequest.ID) +
+			"&user_id=" + strconv.Itoa(params.UserId) +
+			"&date_from=" + datetimeLeaveFrom.Format(cf.FormatDateDatabase) +
+			"&date_to=" + datetimeLeaveTo.Format(cf.FormatDateDatabase)
+		ctr.sendLeaveNotification(userProfile, usersIdGmAndManager, "has just created a statutory leave", link)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create statutory leave successfully.",
+		Data: map[string]interface{}{
+			"id":                   statutoryLeave.ID,
+			"leave_request_id":     leaveRequest.ID,
+			"day":                  day,
+			"hour":                 hour,
+			"count_against_annual": rule.CountAgainstAnnual,
+		},
+	})
+}
+
+// GetStatutoryLeaves : Report of statutory leaves with documents, days of leave spanning months are split by month
+func (ctr *LvController) GetStatutoryLeaves(c echo.Context) error {
+	params := new(param.GetStatutoryLeavesParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if userProfile.RoleID == cf.UserRoleID {
+		params.UserId = userProfile.UserProfile.UserID
+	}
+
+	records, totalRow, err := ctr.LeaveRepo.SelectStatutoryLeaves(userProfile.OrganizationID, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	statutoryLeaveRules, err := ctr.LeaveRepo.SelectStatutoryLeaveRules(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	isCalendarDay := make(map[int]bool)
+	for _, rule := range statutoryLeaveRules {
+		isCalendarDay[rule.LeaveBonusTypeId] = rule.IsCalendarDay
+	}
+
+	var statutoryLeaveIds []int
+	for _, record := range records {
+		statutoryLeaveIds = append(statutoryLeaveIds, record.Id)
+	}
+
+	documents, err := ctr.LeaveRepo.SelectStatutoryLeaveDocuments(statutoryLeaveIds)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	documentsOfLeave := make(map[int][]map[string]interface{})
+	for _, document := range documents {
+		documentsOfLeave[document.StatutoryLeaveId] = append(documentsOfLeave[document.StatutoryLeaveId], map[string]interface{}{
+			"id":            document.ID,
+			"document_name": document.DocumentName,
+			"file_name":     document.FileName,
+		})
+	}
+
+	var statutoryLeaves []map[string]interface{}
+	for _, record := range records {
+		holidays := ctr.getHolidayDates(userProfile.OrganizationID, record.DatetimeLeaveFrom, record.DatetimeLeaveTo)
+		daysByMonth := make(map[string]float64)
+		for day := dateOf(record.DatetimeLeaveFrom); !day.After(record.DatetimeLeaveTo); day = day.AddDate(0, 0, 1) {
+			if isCalendarDay[record.LeaveBonusTypeId] || (!calendar.IsWeekend(day) && !holidays[day.Format(cf.FormatDateDatabase)]) {
+				daysByMonth[day.Format("2006-01")]++
+			}
+		}
+
+		statutoryLeaves = append(statutoryLeaves, map[string]interface{}{
+			"id":                   record.Id,
+			"user_id":              record.UserId,
+			"full_name":            record.FullName,
+			"leave_bonus_type_id":  record.LeaveBonusTypeId,
+			"leave_bonus_type":     cf.StatutoryLeaveTypes[record.LeaveBonusTypeId],
+			"leave_request_id":     record.LeaveRequestId,
+			"event_date":           record.EventDate.Format(cf.FormatDateDisplay),
+			"datetime_leave_from":  record.DatetimeLeaveFrom.Format(cf.FormatTimeDisplay),
+			"datetime_leave_to":    record.DatetimeLeaveTo.Format(cf.FormatTimeDisplay),
+			"day":                  record.Day,
+			"hour":                 record.Hour,
+			"count_against_annual": record.CountAgainstAnnual,
+			"reason":               record.Reason,
+			"days_by_month":        daysByMonth,
+			"documents":            documentsOfLeave[record.Id],
+		})
+	}
+
+	pagination := map[string]interface{}{
+		"current_page": params.CurrentPage,
+		"total_row":    totalRow,
+		"row_per_page": params.RowPerPage,
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"pagination":       pagination,
+			"statutory_leaves": statutoryLeaves,
+		},
+	})
+}
+
+// DownloadStatutoryLeaveDocument : Get content of supporting document, user can only get own documents
+func (ctr *LvController) DownloadStatutoryLeaveDocument(c echo.Context) error {
+	params := new(param.DownloadStatutoryLeaveDocumentParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	document, err := ctr.LeaveRepo.SelectStatutoryLeaveDocumentById(params.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Document does not exist.",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if document.OrganizationId != userProfile.OrganizationID ||
+		(userProfile.RoleID == cf.UserRoleID && document.UserId != userProfile.UserProfile.UserID) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to view this document",
+		})
+	}
+
+	content, err := ctr.cloud.GetFileByFileName(
+		document.FileName,
+		cf.StatutoryLeaveFolderGCS+strconv.Itoa(userProfile.OrganizationID)+"/",
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"document_name": document.DocumentName,
+			"file_name":     document.FileName,
+			"content":       content,
+		},
+	})
+}
+
+func (ctr *LvController) removeStatutoryLeaveFiles(documents []m.StatutoryLeaveDocument, directoryCloud string) {
+	for _, document := range documents {
+		if err := ctr.cloud.DeleteFileCloud(document.FileName, directoryCloud); err != nil {
+			ctr.Logger.Error(err)
+		}
+	}
+}
+
+// GetLeaveDelegations : Get who covers users on leave on date, and leaves which current user covers
+func (ctr *LvController) GetLeaveDelegations(c echo.Context) error {
+	params := new(param.GetLeaveDelegationsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if params.Date == "" {
+		params.Date = time.Now().Format(cf.FormatDateDatabase)
+	} else if _, err := time.Parse(cf.FormatDateDatabase, params.Date); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	delegations, err := ctr.LeaveRepo.SelectLeaveDelegates(userProfile.OrganizationID, nil, params.Date)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var covering []param.LeaveDelegateRecords
+	for _, delegation := range delegations {
+		if delegation.DelegateUserId == userProfile.UserProfile.UserID {
+			covering = append(covering, delegation)
+		}
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"date":        params.Date,
+			"delegations": delegations,
+			"covering":    covering,
+		},
+	})
+}
+
+// isLeaveApprover : Managers approve leave changes, delegate of manager who is on leave approves on behalf of manager
+func (ctr *LvController) isLeaveApprover(userProfile m.User) (bool, error) {
+	if userProfile.RoleID == cf.GeneralManagerRoleID || userProfile.RoleID == cf.ManagerRoleID {
+		return true, nil
+	}
+
+	delegations, err := ctr.LeaveRepo.SelectLeaveDelegatesByDelegate(
+		userProfile.OrganizationID,
+		userProfile.UserProfile.UserID,
+		time.Now().Format(cf.FormatDateDatabase),
+	)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return false, err
+	}
+
+	if len(delegations) == 0 {
+		return false, nil
+	}
+
+	usersIdGmAndManager, err := ctr.UserRepo.SelectIdsOfGMAndManager(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return false, err
+	}
+
+	for _, delegation := range delegations {
+		if utils.FindIntInSlice(usersIdGmAndManager, delegation.UserId) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// selectDelegateIds : Delegates of users who are on leave today
+func (ctr *LvController) selectDelegateIds(organizationId int, usersId []int) []int {
+	var delegateIds []int
+	if len(usersId) == 0 {
+		return delegateIds
+	}
+
+	delegations, err := ctr.LeaveRepo.SelectLeaveDelegates(organizationId, usersId, time.Now().Format(cf.FormatDateDatabase))
+	if err != nil {
+		return delegateIds
+	}
+
+	for _, delegation := range delegations {
+		delegateIds = utils.AppendUniqueSlice(delegateIds, []int{delegation.DelegateUserId})
+	}
+
+	return delegateIds
+}
+
+// notifyLeaveDelegates : Notify delegates of leaves which have started and mark them notified
+func (ctr *LvController) notifyLeaveDelegates(organizationId int) {
+	delegations, err := ctr.LeaveRepo.SelectUnnotifiedLeaveDelegates(organizationId, time.Now().Format(cf.FormatDateDatabase))
+	if err != nil || len(delegations) == 0 {
+		return
+	}
+
+	var leaveRequestIds []int
+	for _, delegation := range delegations {
+		content := "is on leave until " + delegation.DatetimeLeaveTo.Format(cf.FormatTimeDisplay) + " and you are the delegate"
+		if delegation.HandoverNote != "" {
+			content += ": " + delegation.HandoverNote
+		}
+
+		link := "/hrm/leave/history-user-leave?id=" + strconv.Itoa(delegation.LeaveRequestId) +
+			"&user_id=" + strconv.Itoa(delegation.UserId)
+		err := ctr.LeaveRepo.InsertLeaveNotifications(
+			organizationId,
+			delegation.UserId,
+			ctr.NotificationRepo,
+			[]int{delegation.DelegateUserId},
+			param.InsertNotificationParam{Content: content, RedirectUrl: link},
+		)
+		if err != nil {
+			continue
+		}
+		leaveRequestIds = append(leaveRequestIds, delegation.LeaveRequestId)
+
+		registrationTokens, err := ctr.FcmTokenRepo.SelectMultiFcmTokens([]int{delegation.DelegateUserId}, delegation.UserId)
+		if err != nil {
+			continue
+		}
+
+		for _, token := range registrationTokens {
+			err := ctr.SendMessageToSpecificUser(token, "Micro Erp New Notification", delegation.FullName+" "+content, link)
+			if err != nil && err.Error() == "http error status: 400; reason: request contains an invalid argument; "+
+				"code: invalid-argument; details: The registration token is not a valid FCM registration token" {
+				_ = ctr.FcmTokenRepo.DeleteFcmToken(token)
+			}
+		}
+	}
+
+	if err := ctr.LeaveRepo.UpdateLeaveDelegatesNotified(leaveRequestIds); err != nil {
+		ctr.Logger.Error(err)
+	}
+}
+
+// CreateBulkLeave : Create the same leave for every member of branch or organization in one transaction,
+// with one calendar event and one notification instead of per-request mails and events
+func (ctr *LvController) CreateBulkLeave(c echo.Context) error {
+	params := new(param.CreateBulkLeaveParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	datetimeLeaveFrom, errFrom := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveFrom)
+	datetimeLeaveTo, errTo := time.Parse(cf.FormatDateNoSec, params.DatetimeLeaveTo)
+	if _, ok := cf.SubtractDayOffTypes[params.SubtractDayOffTypeId]; err != nil || errFrom != nil || errTo != nil || !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if datetimeLeaveTo.Before(datetimeLeaveFrom) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "From date must be less than To date.",
+		})
+	}
+
+	if params.LeaveRequestTypeId != cf.FullDayOff && !utils.CompareEqualDate(datetimeLeaveTo, datetimeLeaveFrom) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "From date & To date not same.",
+		})
+	}
+
+	userIds, err := ctr.LeaveRepo.SelectBulkLeaveUserIds(userProfile.OrganizationID, params.BranchId, datetimeLeaveFrom, datetimeLeaveTo)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if len(userIds) == 0 {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "There is no user without leave on these dates.",
+		})
+	}
+
+	hour := calendar.CalculateHour(
+		userProfile.OrganizationID,
+		ctr.HolidayRepo,
+		params.LeaveRequestTypeId,
+		datetimeLeaveFrom,
+		datetimeLeaveTo,
+		params.SubtractDayOffTypeId,
+		0,
+	)
+	if params.SubtractDayOffTypeId != cf.Subtract {
+		hour = 0
+	}
+
+	leaveBatch := m.LeaveBatch{
+		OrganizationId:       userProfile.OrganizationID,
+		BranchId:             params.BranchId,
+		LeaveRequestTypeId:   params.LeaveRequestTypeId,
+		DatetimeLeaveFrom:    datetimeLeaveFrom,
+		DatetimeLeaveTo:      datetimeLeaveTo,
+		SubtractDayOffTypeId: params.SubtractDayOffTypeId,
+		Reason:               params.Reason,
+		Hour:                 hour,
+		CreatedBy:            userProfile.UserProfile.UserID,
+	}
+
+	content := "has registered " + strings.ToLower(cf.LeaveRequestTypes[params.LeaveRequestTypeId]) + ": " + params.Reason
+	link := "/hrm/leave/history-user-leave?date_from=" + datetimeLeaveFrom.Format(cf.FormatDateDatabase) +
+		"&date_to=" + datetimeLeaveTo.Format(cf.FormatDateDatabase)
+	err = ctr.LeaveRepo.InsertLeaveBatch(&leaveBatch, userIds, ctr.NotificationRepo, param.InsertNotificationParam{
+		Content:     content,
+		RedirectUrl: link,
+	})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	start, end := leaveEventPeriod(params.LeaveRequestTypeId, params.DatetimeLeaveFrom, params.DatetimeLeaveTo)
+	event := calendar.AddLeaveEvent(
+		params.LeaveRequestTypeId,
+		cf.LeaveRequestJpTypes[params.LeaveRequestTypeId]+" ("+strconv.Itoa(len(userIds))+")",
+		params.Reason,
+		start,
+		end,
+	)
+
+	if err := ctr.LeaveRepo.UpdateLeaveBatchCalendarEvent(leaveBatch.ID, event.Id); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	ctr.sendBulkLeavePush(userProfile, userIds, content, link)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create bulk leave successfully.",
+		Data: map[string]interface{}{
+			"id":         leaveBatch.ID,
+			"user_count": leaveBatch.UserCount,
+			"hour":       hour,
+		},
+	})
+}
+
+// UndoBulkLeave : Remove every leave request of batch and give back subtracted hours
+func (ctr *LvController) UndoBulkLeave(c echo.Context) error {
+	params := new(param.UndoBulkLeaveParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	leaveBatch, err := ctr.LeaveRepo.SelectLeaveBatchById(params.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Bulk leave does not exist.",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if leaveBatch.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to undo this bulk leave.",
+		})
+	}
+
+	if !leaveBatch.UndoneAt.IsZero() {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Bulk leave has already been undone.",
+		})
+	}
+
+	userIds, err := ctr.LeaveRepo.UndoLeaveBatch(leaveBatch.ID, userProfile.UserProfile.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if leaveBatch.CalendarEventId != "" {
+		calendar.RemoveLeaveEvent(leaveBatch.CalendarEventId)
+	}
+
+	content := "has cancelled " + strings.ToLower(cf.LeaveRequestTypes[leaveBatch.LeaveRequestTypeId]) + ": " + leaveBatch.Reason
+	link := "/hrm/leave/history-user-leave?date_from=" + leaveBatch.DatetimeLeaveFrom.Format(cf.FormatDateDatabase) +
+		"&date_to=" + leaveBatch.DatetimeLeaveTo.Format(cf.FormatDateDatabase)
+	ctr.sendLeaveNotification(userProfile, userIds, content, link)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Undo bulk leave successfully.",
+		Data: map[string]interface{}{
+			"user_count": len(userIds),
+		},
+	})
+}
+
+func (ctr *LvController) GetBulkLeaves(c echo.Context) error {
+	params := new(param.GetBulkLeavesParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	records, totalRow, err := ctr.LeaveRepo.SelectLeaveBatches(userProfile.OrganizationID, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	pagination := map[string]interface{}{
+		"current_page": params.CurrentPage,
+		"total_row":    totalRow,
+		"row_per_page": params.RowPerPage,
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"pagination":             pagination,
+			"bulk_leaves":            records,
+			"leave_request_types":    cf.LeaveRequestTypes,
+			"subtract_day_off_types": cf.SubtractDayOffTypes,
+		},
+	})
+}
+
+// sendBulkLeavePush : Send one push message to users of batch, notifications were inserted with batch
+func (ctr *LvController) sendBulkLeavePush(userProfile m.User, userIds []int, content string, link string) {
+	registrationTokens, err := ctr.FcmTokenRepo.SelectMultiFcmTokens(userIds, userProfile.UserProfile.UserID)
+	if err != nil || len(registrationTokens) == 0 {
+		return
+	}
+
+	body := userProfile.UserProfile.FirstName + " " + userProfile.UserProfile.LastName + " " + content
+	for _, token := range registrationTokens {
+		err := ctr.SendMessageToSpecificUser(token, "Micro Erp New Notification", body, link)
+		if err != nil && err.Error() == "http error status: 400; reason: request contains an invalid argument; "+
+			"code: invalid-argument; details: The registration token is not a valid FCM registration token" {
+			_ = ctr.FcmTokenRepo.DeleteFcmToken(token)
+		}
+	}
+}
+
+// GetLeaveSettlement : Get unused hours of year split into carry-over, payout and forfeit.
+// Signed off settlement is returned as saved, otherwise it is previewed with rate and cap of params
+func (ctr *LvController) GetLeaveSettlement(c echo.Context) error {
+	params := new(param.LeaveSettlementParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil || !isValidLeaveSettlement(params) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	leaveSettlement, records, err := ctr.leaveSettlementRecords(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var totalUnusedHour, totalCarryOverHour, totalPayoutHour, totalPayoutAmount, totalForfeitHour float64
+	for _, record := range records {
+		totalUnusedHour += record.UnusedHour
+		totalCarryOverHour += record.CarryOverHour
+		totalPayoutHour += record.PayoutHour
+		totalPayoutAmount += record.PayoutAmount
+		totalForfeitHour += record.ForfeitHour
+	}
+
+	settlement := map[string]interface{}{
+		"year":            params.Year,
+		"signed":          leaveSettlement.ID != 0,
+		"payout_rate":     params.PayoutRate,
+		"max_payout_hour": params.MaxPayoutHour,
+	}
+
+	if leaveSettlement.ID != 0 {
+		settlement["payout_rate"] = leaveSettlement.PayoutRate
+		settlement["max_payout_hour"] = leaveSettlement.MaxPayoutHour
+		settlement["signed_by"] = leaveSettlement.SignedBy
+		settlement["signed_at"] = leaveSettlement.SignedAt.Format(cf.FormatDate)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"settlement": settlement,
+			"total": map[string]interface{}{
+				"unused_hour":     roundLeaveHour(totalUnusedHour),
+				"carry_over_hour": roundLeaveHour(totalCarryOverHour),
+				"payout_hour":     roundLeaveHour(totalPayoutHour),
+				"payout_amount":   math.Round(totalPayoutAmount*100) / 100,
+				"forfeit_hour":    roundLeaveHour(totalForfeitHour),
+			},
+			"users": records,
+		},
+	})
+}
+
+// SignOffLeaveSettlement : Save settlement of year and append its bonuses and ledger entries,
+// clear old leave cron and carry-over cap of accrual skip the settled year
+func (ctr *LvController) SignOffLeaveSettlement(c echo.Context) error {
+	params := new(param.LeaveSettlementParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil || !isValidLeaveSettlement(params) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	// Leave taken after sign off could never be settled, so year must have ended
+	if params.Year >= time.Now().Year() {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Only leave settlement of past year can be signed off.",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	_, err := ctr.LeaveRepo.SelectLeaveSettlementByYear(userProfile.OrganizationID, params.Year)
+	if err == nil {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Leave settlement of this year has already been signed off.",
+		})
+	}
+
+	if err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	records, err := ctr.computeLeaveSettlement(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if len(records) == 0 {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "There is no unused leave to settle.",
+		})
+	}
+
+	leaveSettlement := m.LeaveSettlement{
+		OrganizationId: userProfile.OrganizationID,
+		Year:           params.Year,
+		PayoutRate:     params.PayoutRate,
+		MaxPayoutHour:  params.MaxPayoutHour,
+		UserCount:      len(records),
+		SignedBy:       userProfile.UserProfile.UserID,
+		SignedAt:       time.Now(),
+	}
+
+	var userIds []int
+	var leaveSettlementItems []m.LeaveSettlementItem
+	for _, record := range records {
+		userIds = append(userIds, record.UserId)
+		leaveSettlement.TotalPayoutAmount += record.PayoutAmount
+		leaveSettlementItems = append(leaveSettlementItems, m.LeaveSettlementItem{
+			UserId:        record.UserId,
+			UnusedHour:    record.UnusedHour,
+			CarryOverHour: record.CarryOverHour,
+			PayoutHour:    record.PayoutHour,
+			PayoutAmount:  record.PayoutAmount,
+			ForfeitHour:   record.ForfeitHour,
+		})
+	}
+	leaveSettlement.TotalPayoutAmount = math.Round(leaveSettlement.TotalPayoutAmount*100) / 100
+
+	expireDate := time.Date(params.Year+1, time.Month(userProfile.Organization.ExpirationResetDayOff+1), 1, 0, 0, 0, 0, time.Local)
+	err = ctr.LeaveRepo.InsertLeaveSettlement(&leaveSettlement, leaveSettlementItems, expireDate.Format(cf.FormatDate))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	ctr.sendLeaveNotification(
+		userProfile,
+		userIds,
+		"has signed off leave settlement of "+strconv.Itoa(params.Year),
+		"/hrm/leave/leave-settlement?year="+strconv.Itoa(params.Year),
+	)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Sign off leave settlement successfully.",
+		Data: map[string]interface{}{
+			"id":                  leaveSettlement.ID,
+			"user_count":          leaveSettlement.UserCount,
+			"total_payout_amount": leaveSettlement.TotalPayoutAmount,
+		},
+	})
+}
+
+// ExportLeaveSettlement : Export settlement of year to excel for finance
+func (ctr *LvController) ExportLeaveSettlement(c echo.Context) error {
+	params := new(param.LeaveSettlementParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil || !isValidLeaveSettlement(params) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	_, records, err := ctr.leaveSettlementRecords(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	f := excelize.NewFile()
+	_ = f.SetColWidth("Sheet1", "A", "A", 15)
+	_ = f.SetColWidth("Sheet1", "B", "B", 30)
+	_ = f.SetColWidth("Sheet1", "C", "G", 18)
+
+	titleStyle, _ := f.NewStyle(`{
+		"font":{"bold":true, "size":16},
+		"alignment":{"horizontal":"center", "vertical":"center"}
+	}`)
+
+	var categories map[string]string
+	if userProfile.LanguageId == cf.EnLanguageId {
+		categories = cf.LeaveCategoriesEn
+	} else if userProfile.LanguageId == cf.VnLanguageId {
+		categories = cf.LeaveCategoriesVn
+	} else {
+		categories = cf.LeaveCategoriesJp
+	}
+
+	settlementCategories := map[string]string{
+		"A1": categories["Employee Id"],
+		"B1": categories["Full Name"],
+		"C1": categories["Unused Hour"],
+		"D1": categories["Carry-over Hour"],
+		"E1": categories["Payout Hour"],
+		"F1": categories["Payout Amount"],
+		"G1": categories["Forfeit Hour"],
+	}
+	for k, v := range settlementCategories {
+		_ = f.SetCellValue("Sheet1", k, v)
+	}
+	_ = f.SetCellStyle("Sheet1", "A1", "G1", titleStyle)
+
+	for i, record := range records {
+		pos := strconv.Itoa(i + 2)
+		values := map[string]interface{}{
+			"A" + pos: record.EmployeeId,
+			"B" + pos: record.FullName,
+			"C" + pos: record.UnusedHour,
+			"D" + pos: record.CarryOverHour,
+			"E" + pos: record.PayoutHour,
+			"F" + pos: record.PayoutAmount,
+			"G" + pos: record.ForfeitHour,
+		}
+
+		for k, v := range values {
+			_ = f.SetCellValue("Sheet1", k, v)
+		}
+	}
+
+	buf, _ := f.WriteToBuffer()
+	return c.Blob(http.StatusOK, "application/octet-stream", buf.Bytes())
+}
+
+// leaveSettlementRecords : Saved records when year was signed off, otherwise computed records
+func (ctr *LvController) leaveSettlementRecords(
+	organizationId int,
+	params *param.LeaveSettlementParams,
+) (m.LeaveSettlement, []param.LeaveSettlementRecords, error) {
+	leaveSettlement, err := ctr.LeaveRepo.SelectLeaveSettlementByYear(organizationId, params.Year)
+	if err != nil {
+		if err.Error() != pg.ErrNoRows.Error() {
+			return leaveSettlement, nil, err
+		}
+
+		records, err := ctr.computeLeaveSettlement(organizationId, params)
+		return m.LeaveSettlement{}, records, err
+	}
+
+	records, err := ctr.LeaveRepo.SelectLeaveSettlementItems(leaveSettlement.ID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return leaveSettlement, records, err
+	}
+
+	return leaveSettlement, records, nil
+}
+
+// computeLeaveSettlement : Unused hours are carried over within cap of annual leave policy of user,
+// the rest is paid out up to max payout hour and what remains is forfeited
+func (ctr *LvController) computeLeaveSettlement(organizationId int, params *param.LeaveSettlementParams) ([]param.LeaveSettlementRecords, error) {
+	var records []param.LeaveSettlementRecords
+	leavePolicies, err := ctr.LeaveRepo.SelectLeavePolicies(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return records, err
+	}
+
+	contractTypeRecords, err := ctr.LeaveRepo.SelectLatestContractTypes(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return records, err
+	}
+
+	contractTypes := make(map[int]int)
+	for _, record := range contractTypeRecords {
+		contractTypes[record.UserId] = record.ContractTypeId
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(organizationId)
+	if err != nil {
+		return records, err
+	}
+
+	employeeIdRecords, err := ctr.UserRepo.SelectEmployeeIdByOrganizationId(organizationId)
+	if err != nil {
+		return records, err
+	}
+
+	employeeIds := make(map[int]string)
+	for _, record := range employeeIdRecords {
+		employeeIds[record.UserId] = record.EmployeeId
+	}
+
+	yearEnd := time.Date(params.Year, time.December, 31, 0, 0, 0, 0, time.Local)
+	for _, user := range users {
+		hourBonus, err := ctr.LeaveRepo.CountHourBonus(organizationId, user.UserID, params.Year)
+		if err != nil {
+			return records, err
+		}
+
+		hourUsed, err := ctr.LeaveRepo.CountHourUsed(organizationId, user.UserID, params.Year)
+		if err != nil {
+			return records, err
+		}
+
+		unusedHour := roundLeaveHour(hourBonus - hourUsed)
+		if unusedHour <= 0 {
+			continue
+		}
+
+		carryOverHour := unusedHour
+		for _, leavePolicy := range leavePolicies {
+			if leavePolicy.CarryOverHour == nil || leavePolicy.LeaveBonusTypeId != cf.AnnualLeave ||
+				!isEligibleForLeavePolicy(leavePolicy, user.CompanyJoinedDate, contractTypes[user.UserID], yearEnd) {
+				continue
+			}
+
+			carryOverHour = math.Min(unusedHour, *leavePolicy.CarryOverHour)
+			break
+		}
+
+		excessHour := roundLeaveHour(unusedHour - carryOverHour)
+		payoutHour := excessHour
+		if params.MaxPayoutHour != nil {
+			payoutHour = math.Min(excessHour, *params.MaxPayoutHour)
+		}
+
+		records = append(records, param.LeaveSettlementRecords{
+			UserId:        user.UserID,
+			EmployeeId:    employeeIds[user.UserID],
+			FullName:      user.FullName,
+			UnusedHour:    unusedHour,
+			CarryOverHour: carryOverHour,
+			PayoutHour:    payoutHour,
+			PayoutAmount:  math.Round(payoutHour*params.PayoutRate*100) / 100,
+			ForfeitHour:   roundLeaveHour(excessHour - payoutHour),
+		})
+	}
+
+	return records, nil
+}
+
+func isValidLeaveSettlement(params *param.LeaveSettlementParams) bool {
+	if params.Year > time.Now().Year() || params.PayoutRate < 0 {
+		return false
+	}
+
+	return params.MaxPayoutHour == nil || *params.MaxPayoutHour >= 0
+}
+
+// SettleSeveranceLeave : Compute final pro-rata leave balance of user who left the company for payroll.
+// Apply cancels leave and calendar events after date severance and saves settlement
+func (ctr *LvController) SettleSeveranceLeave(c echo.Context) error {
+	params := new(param.SettleSeveranceLeaveParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil || params.PayoutRate < 0 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	severedUser, err := ctr.UserRepo.GetUserProfileExpand(params.UserId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || severedUser.OrganizationID != userProfile.OrganizationID {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "User does not exist.",
+		})
+	}
+
+	if severedUser.Status != cf.SeveranceStatus {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "User has not been set to severance.",
+		})
+	}
+
+	severanceLeaveSettlement, err := ctr.LeaveRepo.SelectSeveranceLeaveSettlement(userProfile.OrganizationID, params.UserId)
+	if err == nil {
+		if params.Apply {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Leave of this user has already been settled.",
+			})
+		}
+
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.SuccessResponseCode,
+			Message: "Success",
+			Data: map[string]interface{}{
+				"settled":    true,
+				"settlement": severanceLeaveSettlementResponse(severanceLeaveSettlement),
+			},
+		})
+	}
+
+	if err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var dateSeverance time.Time
+	if params.DateSeverance != "" {
+		dateSeverance, err = time.Parse(cf.FormatDateDatabase, params.DateSeverance)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+			})
+		}
+	} else if severedUser.DateSeverance != nil {
+		dateSeverance = *severedUser.DateSeverance
+	} else {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Date severance is required.",
+		})
+	}
+
+	leaveRequests, err := ctr.LeaveRepo.SelectLeaveRequestsAfter(params.UserId, dateSeverance)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	balances, err := ctr.LeaveRepo.SelectLeaveLedgerBalances(userProfile.OrganizationID, params.UserId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	unearnedHour, err := ctr.unearnedLeaveHour(userProfile.OrganizationID, params.UserId, severedUser.CompanyJoinedDate, dateSeverance)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	// Balance is taken out of each year after cancelled leave is given back to its year
+	payoutHours := make(map[int]float64)
+	for _, balance := range balances {
+		payoutHours[balance.YearBelong] += balance.Hour
+	}
+
+	// Leave which starts after severance is cancelled, leave which overlaps it is shortened to end on date severance
+	var cancelledLeaveRequests, amendedLeaveRequests []m.UserLeaveRequest
+	var cancelledLeaves, amendedLeaves []map[string]interface{}
+	for _, leaveRequest := range leaveRequests {
+		if leaveRequest.DatetimeLeaveFrom.Format(cf.FormatDateDatabase) > dateSeverance.Format(cf.FormatDateDatabase) {
+			payoutHours[leaveRequest.DatetimeLeaveFrom.Year()] += leaveRequest.Hour
+			cancelledLeaveRequests = append(cancelledLeaveRequests, leaveRequest)
+			cancelledLeaves = append(cancelledLeaves, map[string]interface{}{
+				"id":                    leaveRequest.ID,
+				"leave_request_type_id": leaveRequest.LeaveRequestTypeID,
+				"datetime_leave_from":   leaveRequest.DatetimeLeaveFrom.Format(cf.FormatDateNoSec),
+				"hour":                  leaveRequest.Hour,
+			})
+			continue
+		}
+
+		to := leaveRequest.DatetimeLeaveTo
+		amendedLeaveRequest := leaveRequest
+		amendedLeaveRequest.DatetimeLeaveTo = time.Date(dateSeverance.Year(), dateSeverance.Month(), dateSeverance.Day(),
+			to.Hour(), to.Minute(), 0, 0, to.Location())
+		if amendedLeaveRequest.DatetimeLeaveTo.Before(leaveRequest.DatetimeLeaveFrom) {
+			amendedLeaveRequest.DatetimeLeaveTo = leaveRequest.DatetimeLeaveFrom
+		}
+
+		amendedLeaveRequest.Hour = calendar.CalculateHour(
+			userProfile.OrganizationID,
+			ctr.HolidayRepo,
+			leaveRequest.LeaveRequestTypeID,
+			leaveRequest.DatetimeLeaveFrom,
+			amendedLeaveRequest.DatetimeLeaveTo,
+			leaveRequest.SubtractDayOffTypeID,
+			0,
+		)
+		amendedLeaveRequest.Hour = math.Min(amendedLeaveRequest.Hour, leaveRequest.Hour)
+
+		payoutHours[leaveRequest.DatetimeLeaveFrom.Year()] += leaveRequest.Hour - amendedLeaveRequest.Hour
+		amendedLeaveRequests = append(amendedLeaveRequests, amendedLeaveRequest)
+		amendedLeaves = append(amendedLeaves, map[string]interface{}{
+			"id":                    leaveRequest.ID,
+			"leave_request_type_id": leaveRequest.LeaveRequestTypeID,
+			"datetime_leave_from":   leaveRequest.DatetimeLeaveFrom.Format(cf.FormatDateNoSec),
+			"datetime_leave_to":     amendedLeaveRequest.DatetimeLeaveTo.Format(cf.FormatDateNoSec),
+			"hour":                  amendedLeaveRequest.Hour,
+			"refunded_hour":         leaveRequest.Hour - amendedLeaveRequest.Hour,
+		})
+	}
+
+	var balanceHour float64
+	for _, hour := range payoutHours {
+		balanceHour += hour
+	}
+
+	payoutHours[dateSeverance.Year()] -= unearnedHour
+	for year, hour := range payoutHours {
+		payoutHours[year] = roundLeaveHour(hour)
+	}
+
+	finalHour := roundLeaveHour(balanceHour - unearnedHour)
+	severanceLeaveSettlement = m.SeveranceLeaveSettlement{
+		OrganizationId:      userProfile.OrganizationID,
+		UserId:              params.UserId,
+		DateSeverance:       dateSeverance,
+		BalanceHour:         roundLeaveHour(balanceHour),
+		UnearnedHour:        unearnedHour,
+		FinalHour:           finalHour,
+		PayoutRate:          params.PayoutRate,
+		PayoutAmount:        math.Round(finalHour*params.PayoutRate*100) / 100,
+		CancelledLeaveCount: len(cancelledLeaveRequests),
+		SettledBy:           userProfile.UserProfile.UserID,
+	}
+
+	if params.Apply {
+		err := ctr.LeaveRepo.InsertSeveranceLeaveSettlement(
+			&severanceLeaveSettlement,
+			cancelledLeaveRequests,
+			amendedLeaveRequests,
+			payoutHours,
+		)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		for _, leaveRequest := range leaveRequests {
+			if leaveRequest.CalendarEventId != "" {
+				calendar.RemoveLeaveEvent(leaveRequest.CalendarEventId)
+			}
+		}
+
+		ctr.addAmendedLeaveEvents(amendedLeaveRequests)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"settled":          params.Apply,
+			"settlement":       severanceLeaveSettlementResponse(severanceLeaveSettlement),
+			"cancelled_leaves": cancelledLeaves,
+			"amended_leaves":   amendedLeaves,
+		},
+	})
+}
+
+// addAmendedLeaveEvents : Calendar events of leave which was shortened, old events have been removed
+func (ctr *LvController) addAmendedLeaveEvents(amendedLeaveRequests []m.UserLeaveRequest) {
+	for _, leaveRequest := range amendedLeaveRequests {
+		fullName, err := ctr.UserRepo.SelectFullNameUser(leaveRequest.UserID)
+		if err != nil {
+			continue
+		}
+
+		start, end := leaveEventPeriod(
+			leaveRequest.LeaveRequestTypeID,
+			leaveRequest.DatetimeLeaveFrom.Format(cf.FormatDateNoSec),
+			leaveRequest.DatetimeLeaveTo.Format(cf.FormatDateNoSec),
+		)
+		event := calendar.AddLeaveEvent(
+			leaveRequest.LeaveRequestTypeID,
+			fullName+" - "+cf.LeaveRequestJpTypes[leaveRequest.LeaveRequestTypeID],
+			leaveRequest.Reason,
+			start,
+			end,
+		)
+
+		if err := ctr.LeaveRepo.UpdateLeaveRequest(leaveRequest.ID, event.Id); err != nil {
+			ctr.Logger.Error(err)
+		}
+	}
+}
+
+// unearnedLeaveHour : Part of yearly grants of severance year which covers months after severance
+func (ctr *LvController) unearnedLeaveHour(organizationId int, userId int, joinedDate time.Time, dateSeverance time.Time) (float64, error) {
+	runs, err := ctr.LeaveRepo.SelectLeaveAccrualRuns(organizationId, []string{strconv.Itoa(dateSeverance.Year())})
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return 0, err
+	}
+
+	var yearlyHour float64
+	for _, run := range runs {
+		if run.UserId == userId {
+			yearlyHour += run.Hour
+		}
+	}
+
+	coveredMonth := 12
+	if !joinedDate.IsZero() && joinedDate.Year() == dateSeverance.Year() {
+		coveredMonth = 13 - int(joinedDate.Month())
+	}
+
+	return roundLeaveHour(yearlyHour * float64(12-int(dateSeverance.Month())) / float64(coveredMonth)), nil
+}
+
+func severanceLeaveSettlementResponse(severanceLeaveSettlement m.SeveranceLeaveSettlement) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":               severanceLeaveSettlement.UserId,
+		"date_severance":        severanceLeaveSettlement.DateSeverance.Format(cf.FormatDateDatabase),
+		"balance_hour":          severanceLeaveSettlement.BalanceHour,
+		"unearned_hour":         severanceLeaveSettlement.UnearnedHour,
+		"final_hour":            severanceLeaveSettlement.FinalHour,
+		"final_day":             severanceLeaveSettlement.FinalHour / 8,
+		"payout_rate":           severanceLeaveSettlement.PayoutRate,
+		"payout_amount":         severanceLeaveSettlement.PayoutAmount,
+		"cancelled_leave_count": severanceLeaveSettlement.CancelledLeaveCount,
+	}
+}
+
+// refundLeaveHour : Give back used hours to bonuses which will expire first
+func refundLeaveHour(leaveRepo rp.LeaveRepository, validDateRecords []param.ValidLeaveBonusRecords, hour float64) {
+	hourLeaveTemp := hour
+	for _, validDate := range validDateRecords {
+		var hourUsed float64 = validDate.Hour - validDate.HourRemaining
+		if hourLeaveTemp < hourUsed {
+			leaveRepo.UpdateHourRemainingLeaveByID(validDate.Id, validDate.HourRemaining+hourLeaveTemp)
+		} else {
+			leaveRepo.UpdateHourRemainingLeaveByID(validDate.Id, validDate.HourRemaining+hourUsed)
+		}
+		hourLeaveTemp = hourLeaveTemp - hourUsed
+		if hourLeaveTemp <= 0 {
+			break
+		}
+	}
+}
+
+// leaveEventPeriod : Start and end of calendar event of leave, day off is all-day event
+func leaveEventPeriod(leaveRequestTypeId int, datetimeLeaveFrom string, datetimeLeaveTo string) (string, string) {
+	startDate, endDate := strings.Split(datetimeLeaveFrom, " ")[0], strings.Split(datetimeLeaveTo, " ")[0]
+	startTime, endTime := strings.Split(datetimeLeaveFrom, " ")[1], strings.Split(datetimeLeaveTo, " ")[1]
+	var start, end string
+	switch leaveRequestTypeId {
+	case cf.FullDayOff, cf.MorningOff, cf.AfternoonOff:
+		start = startDate
+		end = calendar.ParseTime(cf.FormatDateDatabase, endDate).AddDate(0, 0, 1).Format(cf.FormatDateDatabase)
+	case cf.LateForWork, cf.LeaveEarly, cf.GoOutside:
+		start = startDate + "T" + startTime + ":00+07:00"
+		end = endDate + "T" + endTime + ":00+07:00"
+	default:
+		timestampFrom := calendar.ParseTime(cf.FormatDateNoSec, datetimeLeaveFrom)
+		timestampTo := calendar.ParseTime(cf.FormatDateNoSec, datetimeLeaveTo)
+		hourOfDay, _ := time.ParseDuration("8h0m0s")
+		if 0 < timestampTo.Sub(timestampFrom) && timestampTo.Sub(timestampFrom) < hourOfDay {
+			start = startDate + "T" + startTime + ":00+07:00"
+			end = endDate + "T" + endTime + ":00+07:00"
+		} else {
+			start = startDate
+			end = calendar.ParseTime(cf.FormatDateDatabase, endDate).AddDate(0, 0, 1).Format(cf.FormatDateDatabase)
+		}
+	}
+
+	return start, end
+}
+
+// RemoveLeaveRequest : Remove leave request of user
+func (ctr *LvController) RemoveLeaveRequest(c echo.Context) error {
+	removeLeaveParams := new(param.RemoveLeaveParams)
+
+	if err := c.Bind(removeLeaveParams); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(removeLeaveParams)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	leaveRequest, err := ctr.LeaveRepo.SelectLeaveRequestById(removeLeaveParams.LeaveID)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusOK, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Empty record.",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	validDateRecords, err := ctr.LeaveRepo.SelectValidDateOfUser(leaveRequest.OrganizationID, leaveRequest.UserID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	refundLeaveHour(ctr.LeaveRepo, validDateRecords, leaveRequest.Hour)
+
+	err = ctr.LeaveRepo.RemoveLeave(removeLeaveParams.LeaveID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	if leaveRequest.CalendarEventId != "" {
+		calendar.RemoveLeaveEvent(leaveRequest.CalendarEventId)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove leave request successfully.",
+	})
+}
+
+// GetLeaveInfoAllUser : Get leave info of all user
+func (ctr *LvController) GetLeaveInfoAllUser(c echo.Context) error {
+	allUserNameAndCountParams := new(param.AllUserNameAndCountParams)
+	allUserNameAndCountParams.RowPerPage = 10
+
+	if err := c.Bind(allUserNameAndCountParams); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	allUserNameAndCountParams.OrgID = userProfile.OrganizationID
+
+	_, err := valid.ValidateStruct(allUserNameAndCountParams)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	users, totalRow, err := ctr.UserRepo.GetAllUserNameAndCountByOrgID(allUserNameAndCountParams)
+
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusOK, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Get user list failed",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	var userLeaveInfoResponse []map[string]interface{}
+	for _, user := range users {
+		var base64Img []byte = nil
+
+		if user.Avatar != "" {
+			base64Img, err = ctr.cloud.GetFileByFileName(user.Avatar, cf.AvatarFolderGCS)
+
+			if err != nil {
+				ctr.Logger.Error(err)
+				base64Img = nil
+			}
+		}
+		userLeaveInfo := map[string]interface{}{
+			"user_id":   user.UserID,
+			"full_name": user.FullName,
+			"branch":    user.Branch,
+			"email":     user.Email,
+			"avatar":    base64Img,
+		}
+
+		dayUsed, _, dayRemaining, _ := ctr.LeaveRepo.GetLeaveDayStatus(allUserNameAndCountParams.OrgID, user.UserID, time.Now().Year())
+		userLeaveInfo["day_used"] = dayUsed
+		userLeaveInfo["day_remaining"] = dayRemaining
+
+		userLeaveInfoResponse = append(userLeaveInfoResponse, userLeaveInfo)
+	}
+
+	allUsers, err := ctr.UserRepo.GetAllUserNameByOrgID(allUserNameAndCountParams.OrgID)
+
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusOK, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Get user list failed",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	usersBox := make(map[int]string)
+
+	for i := 0; i < len(allUsers); i++ {
+		usersBox[allUsers[i].UserID] = allUsers[i].FullName
+	}
+
+	if allUserNameAndCountParams.RowPerPage == 0 {
+		allUserNameAndCountParams.CurrentPage = 1
+		allUserNameAndCountParams.RowPerPage = totalRow
+	}
+
+	pagination := map[string]interface{}{
+		"current_page": allUserNameAndCountParams.CurrentPage,
+		"total_row":    totalRow,
+		"row_per_page": allUserNameAndCountParams.RowPerPage,
+	}
+
+	branchRecords, err := ctr.BranchRepo.SelectBranches(userProfile.OrganizationID)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusOK, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Empty record.",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	branches := make(map[int]string)
+	for _, record := range branchRecords {
+		branches[record.Id] = record.Name
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"pagination":        pagination,
+			"branch_select_box": branches,
+			"user_list":         usersBox,
+			"users_leave_info":  userLeaveInfoResponse,
+		},
+	})
+}
+
+// CronLeaveBonus : Cron leave accrual by policies at the beginning of every month and clear old leave with run year-04-01 00:00:00
+func (ctr *LvController) CronLeaveBonus(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	_, err := ctr.AddFuncCron("0 0 1 * *", cf.LeaveAccrualCronName, func() {
+		grants, err := ctr.computeLeaveAccrualGrants(userProfile.OrganizationID, 0, time.Now())
+		if err != nil {
+			ctr.Logger.Error(err)
+			return
+		}
+
+		if len(grants) > 0 {
+			if _, err := ctr.LeaveRepo.InsertLeaveAccrualGrants(
+				userProfile.OrganizationID,
+				userProfile.UserProfile.UserID,
+				grants,
+			); err != nil {
+				ctr.Logger.Error(err)
+			}
+		}
+	})
+
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	_, err = ctr.AddFuncCron("0 * * * *", cf.LeaveDelegateCronName, func() {
+		ctr.notifyLeaveDelegates(userProfile.OrganizationID)
+	})
+
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var spec string
+	if userProfile.Organization.ExpirationResetDayOff < 12 {
+		spec = "5 9 2 " + strconv.Itoa(userProfile.Organization.ExpirationResetDayOff+1) + " *"
+	} else {
+		spec = "0 0 1 1 *"
+	}
+	_, err = ctr.AddFuncCron(spec, "Clear old leave cron", func() {
+		previousYear := time.Now().Year() - 1
+		if _, err := ctr.LeaveRepo.SelectLeaveSettlementByYear(userProfile.OrganizationID, previousYear); err == nil {
+			return
+		}
+
+		var leaveBonusParams []param.LeaveBonus
+		users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+		if err != nil {
+			ctr.Logger.Error(err)
+			return
+		}
+
+		for _, user := range users {
+			hourUsedOld, err := ctr.LeaveRepo.CountHourUsed(userProfile.OrganizationID, user.UserID, previousYear)
+			if err != nil {
+				return
+			}
+
+			hourBonusOld, err := ctr.LeaveRepo.CountHourBonus(userProfile.OrganizationID, user.UserID, previousYear)
+			if err != nil {
+				return
+			}
+
+			t := time.Now()
+			first := time.Date(t.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+			hoursUpOld, err := ctr.LeaveRepo.CountHoursUpToExpirationDate(userProfile.OrganizationID, user.UserID, t.Format(cf.FormatDate), first.Format(cf.FormatDate))
+			if err != nil {
+				return
+			}
+
+			hourRemainingOld := hourBonusOld - hourUsedOld - hoursUpOld
+			if hourRemainingOld > 0 {
+				leaveBonusParam := new(param.LeaveBonus)
+				leaveBonusParam.UserID = user.UserID
+				leaveBonusParam.LeaveBonusTypeID = cf.AnnualLeave
+				leaveBonusParam.YearBelong = previousYear
+				leaveBonusParam.Reason = "Clear old leave"
+				leaveBonusParam.Hour = -hourRemainingOld
+				leaveBonusParam.HourRemaining = -hourRemainingOld
+
+				leaveBonusParams = append(leaveBonusParams, *leaveBonusParam)
+			}
+		}
+
+		if len(leaveBonusParams) > 0 {
+			if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
+				userProfile.OrganizationID,
+				userProfile.UserProfile.UserID,
+				&leaveBonusParams,
+			); err != nil {
+				ctr.Logger.Error(err)
+			}
+		}
+	})
+
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	_, err = ctr.AddFuncCron("0 0 1 * *", "Add extra day at the beginning of the month when running out of day off", func() {
+		currentYear := time.Now().Year()
+		var leaveBonusParams []param.LeaveBonus
+		users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+		if err != nil {
+			ctr.Logger.Error(err)
+			return
+		}
+
+		for _, user := range users {
+			hourUsedCurrent, err := ctr.LeaveRepo.CountHourUsed(userProfile.OrganizationID, user.UserID, currentYear)
+			if err != nil {
+				return
+			}
+
+			hourBonusCurrent, err := ctr.LeaveRepo.CountHourBonus(userProfile.OrganizationID, user.UserID, currentYear)
+			if err != nil {
+				return
+			}
+
+			hourRemainingOld := hourBonusCurrent - hourUsedCurrent
+			if hourRemainingOld < 0 {
+				leaveBonusParam := new(param.LeaveBonus)
+				leaveBonusParam.UserID = user.UserID
+				leaveBonusParam.LeaveBonusTypeID = cf.ClearLeave
+				leaveBonusParam.YearBelong = currentYear
+				leaveBonusParam.Reason = "Add extra day at the beginning of the month when running out of day off"
+				leaveBonusParam.Hour = -hourRemainingOld
+
+				leaveBonusParams = append(leaveBonusParams, *leaveBonusParam)
+			}
+		}
+
+		if len(leaveBonusParams) > 0 {
+			if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
+				userProfile.OrganizationID,
+				userProfile.UserProfile.UserID,
+				&leaveBonusParams,
+			); err != nil {
+				ctr.Logger.Error(err)
+			}
+		}
+	})
+
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create cron leave successfully.",
+	})
+}
+
+// CronLeaveStart : Start leave cron
+func (ctr *LvController) CronLeaveStart(c echo.Context) error {
+	ctr.StartCron()
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Start cron leave successfully.",
+	})
+}
+
+// CronLeaveStop : Stop leave cron
+func (ctr *LvController) CronLeaveStop(c echo.Context) error {
+	ctr.StopCron()
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Stop cron leave successfully.",
+	})
+}
+
+// GetEntriesLeave : Get entries leave
+func (ctr *LvController) GetEntriesLeave(c echo.Context) error {
+	entries := ctr.GetEntries()
+	var entriesResponse []map[string]interface{}
+
+	for _, entry := range entries {
+		itemDataResponse := map[string]interface{}{
+			"id":   entry.ID,
+			"name": entry.Name,
+		}
+
+		entriesResponse = append(entriesResponse, itemDataResponse)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get entries successfully.",
+		Data:    entriesResponse,
+	})
+}
+
+// RemoveCronLeave : Remove cron leave
+func (ctr *LvController) RemoveCronLeave(c echo.Context) error {
+	removeCronLeaveParam := new(param.RemoveCronLeaveParam)
+
+	if err := c.Bind(removeCronLeaveParam); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(removeCronLeaveParam)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	ctr.RemoveCron(removeCronLeaveParam.ID)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove cron successfully.",
+	})
+}
+
+func (ctr *LvController) ImportBonuses(c echo.Context) error {
+	file, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	rows, errEx := ex.ReadExcelFile(file)
+	if errEx != "" {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: errEx,
+		})
+	}
+
+	if len(rows) < 2 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "File is empty.",
+		})
+	}
+
+	var leaveBonusParams []param.LeaveBonus
+	userProfile := c.Get("user_profile").(m.User)
+	for i, row := range rows {
+		if i == 0 {
+			continue
+		}
+
+		if len(row) > 5 {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid Params",
+				Data:    i + 1,
+			})
+		}
+
+		record := [5]string{"", "", "", "", ""}
+		for i := 0; i < len(row); i++ {
+			record[i] = row[i]
+		}
+
+		for _, elm := range record {
+			if elm == "" {
+				return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "Invalid field value",
+					Data:    i + 1,
+				})
+			}
+		}
+
+		userId, err := ctr.UserRepo.SelectUserIdByEmployeeId(userProfile.OrganizationID, record[0])
+		if err != nil {
+			if err.Error() == pg.ErrNoRows.Error() {
+				return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "User is not exist",
+					Data:    i + 1,
+				})
+			}
+
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		leaveBonusParam := new(param.LeaveBonus)
+		leaveBonusParam.LeaveBonusTypeID, err = strconv.Atoi(record[1])
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+				Data:    i + 1,
+			})
+		}
+
+		leaveBonusParam.YearBelong, err = strconv.Atoi(record[2])
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+				Data:    i + 1,
+			})
+		}
+
+		leaveBonusParam.Hour, err = strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+				Data:    i + 1,
+			})
+		}
+
+		leaveBonusParam.HourRemaining, err = strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+				Data:    i + 1,
+			})
+		}
+
+		leaveBonusParam.UserID = userId
+		leaveBonusParam.Reason = record[3]
+
+		leaveBonusParams = append(leaveBonusParams, *leaveBonusParam)
+	}
+
+	if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
+		userProfile.OrganizationID,
+		userProfile.UserProfile.UserID,
+		&leaveBonusParams,
+	); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Import successfully.",
+	})
+}
+
+func (ctr *LvController) DownloadTemplate(c echo.Context) error {
+	downloadTemplateParam := new(param.DownloadTemplateParam)
+	if err := c.Bind(downloadTemplateParam); err != nil {
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(downloadTemplateParam)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if downloadTemplateParam.TypeFile == "xlsx" {
+		if userProfile.LanguageId == cf.EnLanguageId {
+			return c.Attachment("internal/platform/excel/template/import-leave.xlsx", "import-leave.xlsx")
+		} else if userProfile.LanguageId == cf.VnLanguageId {
+			return c.Attachment("internal/platform/excel/template/import-leave-vn.xlsx", "import-leave-vn.xlsx")
+		} else {
+			return c.Attachment("internal/platform/excel/template/import-leave-jp.xlsx", "import-leave-jp.xlsx")
+		}
+	}
+
+	if userProfile.LanguageId == cf.EnLanguageId {
+		return c.Attachment("internal/platform/excel/template/import-leave.csv", "import-leave.csv")
+	} else if userProfile.LanguageId == cf.VnLanguageId {
+		return c.Attachment("internal/platform/excel/template/import-leave-vn.csv", "import-leave-vn.csv")
+	} else {
+		return c.Attachment("internal/platform/excel/template/import-leave-jp.csv", "import-leave-jp.csv")
+	}
+}
+
+func (ctr *LvController) ExportExcel(c echo.Context) error {
+	leaveRequestTypeId, _ := strconv.Atoi(c.FormValue("leave_request_type_id"))
+	branch, _ := strconv.Atoi(c.FormValue("branch"))
+	exportExcelParams := &param.LeaveRequestListParams{
+		UserName:           c.FormValue("username"),
+		LeaveRequestTypeID: leaveRequestTypeId,
+		Branch:             branch,
+		DatetimeLeaveFrom:  c.FormValue("datetime_leave_from"),
+		DatetimeLeaveTo:    c.FormValue("datetime_leave_to"),
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	columns := []string{"ulr.user_id", "ulr.leave_request_type_id", "ulr.datetime_leave_from", "ulr.datetime_leave_to", "ulr.subtract_day_off_type_id"}
+	leaveRequestRecords, _, err := ctr.LeaveRepo.LeaveRequests(
+		userProfile.OrganizationID,
+		"ulr.user_id ASC, ulr.datetime_leave_from ASC",
+		exportExcelParams,
+		false,
+		columns...,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	f := excelize.NewFile()
+	_ = f.SetColWidth("Sheet1", "A", "A", 15)
+	_ = f.SetColWidth("Sheet1", "B", "C", 30)
+	_ = f.SetColWidth("Sheet1", "D", "G", 15)
+
+	titleStyle, _ := f.NewStyle(`{
+		"font":{"bold":true, "size":16},
+		"alignment":{"horizontal":"center", "vertical":"center"}
+	}`)
+
+	contentStyle, _ := f.NewStyle(`{"alignment":{"horizontal":"center", "vertical":"center"}}`)
+
+	var categories map[string]string
+	if userProfile.LanguageId == cf.EnLanguageId {
+		categories = cf.LeaveCategoriesEn
+	} else if userProfile.LanguageId == cf.VnLanguageId {
+		categories = cf.LeaveCategoriesVn
+	} else {
+		categories = cf.LeaveCategoriesJp
+	}
+
+	leaveCategories := map[string]string{
+		"A1": categories["Employee Id"],
+		"B1": categories["Full Name"],
+		"C1": categories["Leave Request Type"],
+		"D1": categories["Date From"],
+		"E1": categories["Date To"],
+		"F1": categories["Time"],
+		"G1": categories["Note"],
+	}
+	for k, v := range leaveCategories {
+		_ = f.SetCellValue("Sheet1", k, v)
+	}
+	_ = f.SetCellStyle("Sheet1", "A1", "G1", titleStyle)
+	_ = f.SetColStyle("Sheet1", "A", contentStyle)
+	_ = f.SetColStyle("Sheet1", "D", contentStyle)
+	_ = f.SetColStyle("Sheet1", "E", contentStyle)
+	_ = f.SetColStyle("Sheet1", "F", contentStyle)
+	_ = f.SetColStyle("Sheet1", "G", contentStyle)
+
+	userProfileRecords, err := ctr.UserRepo.SelectEmployeeIdByOrganizationId(userProfile.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	idx := 2
+	var userProfileRecord param.EmployeeIdAndFullName
+	for i, record := range leaveRequestRecords {
+		pos := idx + i
+		for _, upr := range userProfileRecords {
+			if record.UserID == upr.UserId {
+				userProfileRecord = upr
+			}
+		}
+
+		var datetimeLeaveFrom, datetimeLeaveTo, hourFrom, minuteFrom, hourTo, minuteTo, leaveTime string
+		datetimeLeaveFrom = record.DatetimeLeaveFrom.Format(cf.FormatDateDisplay)
+		datetimeLeaveTo = record.DatetimeLeaveTo.Format(cf.FormatDateDisplay)
+
+		if record.LeaveRequestTypeID == cf.LateForWork || record.LeaveRequestTypeID == cf.LeaveEarly || record.LeaveRequestTypeID == cf.GoOutside {
+			hourFrom = utils.ConvertTwoChar(record.HourFrom)
+			minuteFrom = utils.ConvertTwoChar(record.MinuteFrom)
+			hourTo = utils.ConvertTwoChar(record.HourTo)
+			minuteTo = utils.ConvertTwoChar(record.MinuteTo)
+
+			if hourFrom != "" && minuteFrom != "" && hourTo != "" && minuteTo != "" {
+				leaveTime = hourFrom + ":" + minuteFrom + " - " + hourTo + ":" + minuteTo
+			}
+		}
+
+		values := map[string]interface{}{
+			"A" + strconv.Itoa(pos): userProfileRecord.EmployeeId,
+			"B" + strconv.Itoa(pos): userProfileRecord.FullName,
+			"C" + strconv.Itoa(pos): cf.LeaveRequestTypes[record.LeaveRequestTypeID],
+			"D" + strconv.Itoa(pos): datetimeLeaveFrom,
+			"E" + strconv.Itoa(pos): datetimeLeaveTo,
+			"F" + strconv.Itoa(pos): leaveTime,
+		}
+
+		if record.SubtractDayOffTypeID != cf.Subtract {
+			values["G"+strconv.Itoa(pos)] = cf.SubtractDayOffTypes[record.SubtractDayOffTypeID]
+		}
+
+		for k, v := range values {
+			_ = f.SetCellValue("Sheet1", k, v)
+		}
+	}
+
+	buf, _ := f.WriteToBuffer()
+	return c.Blob(http.StatusOK, "application/octet-stream", buf.Bytes())
+}
+
+func (ctr *LvController) GetLeaveBonuses(c echo.Context) error {
+	params := new(param.GetLeaveBonusParam)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+	userProfile := c.Get("user_profile").(m.User)
+
+	records, totalRow, err := ctr.LeaveRepo.SelectLeaveBonuses(userProfile.OrganizationID, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	var leaveBonusesResponse []map[string]interface{}
+	pagination := map[string]interface{}{
+		"total_row": totalRow,
+	}
+
+	location, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+	for _, record := range records {
+		leaveBonus := map[string]interface{}{
+			"id":               record.Id,
+			"user_id":          record.UserId,
+			"created_by":       record.CreatedBy,
+			"reason":           record.Reason,
+			"year":             record.Year,
+			"hour":             record.Hour,
+			"leave_bonus_type": cf.LeaveBonusTypes[record.LeaveBonusTypeId],
+			"created_at":       record.CreatedAt.In(location).Format(cf.FormatDisplayTimekeeping2),
+		}
+
+		leaveBonusesResponse = append(leaveBonusesResponse, leaveBonus)
+	}
+
+	userRecords, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusOK, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Get user list failed",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+			Data:    err,
+		})
+	}
+
+	userList := make(map[int]string)
+	for i := 0; i < len(userRecords); i++ {
+		userList[userRecords[i].UserID] = userRecords[i].FullName
+	}
+
+	dataResponse := map[string]interface{}{
+		"user_leave_bonuses": leaveBonusesResponse,
+		"pagination":         pagination,
+		"user_list":          userList,
+		"leave_bonus_types":  cf.LeaveBonusTypes,
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get leave bonuses successful",
+		Data:    dataResponse,
+	})
+}
+
+func (ctr *LvController) GetLeaveBonus(c echo.Context) error {
+	params := new(param.GetLeaveBonusByIdParam)
+	if err := c.Bind(params); err != nil {
+		ctr.Logger.Error(err)
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		ctr.Logger.Error(err)
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	leaveBonus, err := ctr.LeaveRepo.SelectLeaveBonusById(params.Id)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	dataResponse := map[string]interface{}{
+		"leave_bonus_type_id": leaveBonus.LeaveBonusTypeID,
+		"hour":                leaveBonus.Hour,
+		"reason":              leaveBonus.Reason,
+		"year_belong":         leaveBonus.YearBelong,
+		"leave_bonus_types":   cf.LeaveBonusTypes,
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get leave bonus successful",
+		Data:    dataResponse,
+	})
+}
+
+func (ctr *LvController) EditLeaveBonuses(c echo.Context) error {
+	params := new(param.EditLeaveBonusParam)
+	if err := c.Bind(params); err != nil {
+		ctr.Logger.Error(err)
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		ctr.Logger.Error(err)
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if err := ctr.LeaveRepo.UpdateLeaveBonus(userProfile.UserProfile.UserID, params); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Update leave bonus successful",
+	})
+}
+
+func (ctr *LvController) RemoveLeaveBonus(c echo.Context) error {
+	params := new(param.RemoveLeaveBonusParam)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	if err := ctr.LeaveRepo.UpdateDeleted(params.Id, params.IsDeleted); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var message string
+	if params.IsDeleted {
+		message = "Remove leave bonuses successful"
+	} else {
+		message = "Restore leave bonuses successful"
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: message,
+	})
+}
+
+func (ctr *LvController) getHolidaysOfYear(organizationId int, year int) []time.Time {
+	records, err := ctr.HolidayRepo.SelectHolidays(organizationId, year, "holiday_date")
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		panic(err)
+	}
+
+	var holidayDates []time.Time
+	for _, record := range records {
+		holidayDates = append(holidayDates, record.HolidayDate)
+	}
+
+	return holidayDates
+}
+
+func (ctr *LvController) CreateLeavePolicy(c echo.Context) error {
+	params := new(param.CreateLeavePolicyParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if params.LeaveBonusTypeId == 0 {
+		params.LeaveBonusTypeId = cf.AnnualLeave
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil || !isValidLeavePolicy(
+		params.LeaveBonusTypeId, params.AccrualHour, params.ProbationMonth, params.CarryOverHour, params.Tiers,
+	) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if err := ctr.LeaveRepo.InsertLeavePolicy(userProfile.OrganizationID, params); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create leave policy successfully.",
+	})
+}
+
+func (ctr *LvController) EditLeavePolicy(c echo.Context) error {
+	params := new(param.EditLeavePolicyParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if params.LeaveBonusTypeId == 0 {
+		params.LeaveBonusTypeId = cf.AnnualLeave
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil || !isValidLeavePolicy(
+		params.LeaveBonusTypeId, params.AccrualHour, params.ProbationMonth, params.CarryOverHour, params.Tiers,
+	) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	leavePolicy, err := ctr.LeaveRepo.SelectLeavePolicyById(params.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Leave policy does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if leavePolicy.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to edit this leave policy",
+		})
+	}
+
+	if err := ctr.LeaveRepo.UpdateLeavePolicy(params); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
 	}
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Get entries successfully.",
-		Data:    entriesResponse,
+		Message: "Edit leave policy successfully.",
 	})
 }
 
-// RemoveCronLeave : Remove cron leave
-func (ctr *LvController) RemoveCronLeave(c echo.Context) error {
-	removeCronLeaveParam := new(param.RemoveCronLeaveParam)
-
-	if err := c.Bind(removeCronLeaveParam); err != nil {
+func (ctr *LvController) RemoveLeavePolicy(c echo.Context) error {
+	params := new(param.RemoveLeavePolicyParams)
+	if err := c.Bind(params); err != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid Params",
 		})
 	}
 
-	_, err := valid.ValidateStruct(removeCronLeaveParam)
-	if err != nil {
+	if _, err := valid.ValidateStruct(params); err != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid field value",
 		})
 	}
 
-	ctr.RemoveCron(removeCronLeaveParam.ID)
-
-	return c.JSON(http.StatusOK, cf.JsonResponse{
-		Status:  cf.SuccessResponseCode,
-		Message: "Remove cron successfully.",
-	})
-}
-
-func (ctr *LvController) ImportBonuses(c echo.Context) error {
-	file, err := c.FormFile("file")
+	userProfile := c.Get("user_profile").(m.User)
+	leavePolicy, err := ctr.LeaveRepo.SelectLeavePolicyById(params.Id)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Leave policy does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
-			Message: "Invalid Params",
+			Message: "System Error",
 		})
 	}
 
-	rows, errEx := ex.ReadExcelFile(file)
-	if errEx != "" {
-		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+	if leavePolicy.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
-			Message: errEx,
+			Message: "You do not have permission to remove this leave policy",
 		})
 	}
 
-	if len(rows) < 2 {
-		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+	if err := ctr.LeaveRepo.DeleteLeavePolicy(params.Id); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
-			Message: "File is empty.",
+			Message: "System Error",
 		})
 	}
 
-	var leaveBonusParams []param.LeaveBonus
-	userProfile := c.Get("user_profile").(m.User)
-	for i, row := range rows {
-		if i == 0 {
-			continue
-		}
-
-		if len(row) > 5 {
-			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
-				Status:  cf.FailResponseCode,
-				Message: "Invalid Params",
-				Data:    i + 1,
-			})
-		}
-
-		record := [5]string{"", "", "", "", ""}
-		for i := 0; i < len(row); i++ {
-			record[i] = row[i]
-		}
-
-		for _, elm := range record {
-			if elm == "" {
-				return c.JSON(http.StatusBadRequest, cf.JsonResponse{
-					Status:  cf.FailResponseCode,
-					Message: "Invalid field value",
-					Data:    i + 1,
-				})
-			}
-		}
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove leave policy successfully.",
+	})
+}
 
-		userId, err := ctr.UserRepo.SelectUserIdByEmployeeId(userProfile.OrganizationID, record[0])
-		if err != nil {
-			if err.Error() == pg.ErrNoRows.Error() {
-				return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
-					Status:  cf.FailResponseCode,
-					Message: "User is not exist",
-					Data:    i + 1,
-				})
-			}
+func (ctr *LvController) GetLeavePolicies(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	leavePolicies, err := ctr.LeaveRepo.SelectLeavePolicies(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
 
+	var policiesResponse []map[string]interface{}
+	for _, leavePolicy := range leavePolicies {
+		tiers, err := ctr.LeaveRepo.SelectLeavePolicyTiers(leavePolicy.ID)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
 			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 				Status:  cf.FailResponseCode,
 				Message: "System Error",
 			})
 		}
 
-		leaveBonusParam := new(param.LeaveBonus)
-		leaveBonusParam.LeaveBonusTypeID, err = strconv.Atoi(record[1])
-		if err != nil {
-			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
-				Status:  cf.FailResponseCode,
-				Message: "Invalid field value",
-				Data:    i + 1,
+		var tiersResponse []map[string]interface{}
+		for _, tier := range tiers {
+			tiersResponse = append(tiersResponse, map[string]interface{}{
+				"from_month": tier.FromMonth,
+				"hour":       tier.Hour,
 			})
 		}
 
-		leaveBonusParam.YearBelong, err = strconv.Atoi(record[2])
-		if err != nil {
-			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
-				Status:  cf.FailResponseCode,
-				Message: "Invalid field value",
-				Data:    i + 1,
-			})
-		}
+		policiesResponse = append(policiesResponse, map[string]interface{}{
+			"id":                     leavePolicy.ID,
+			"name":                   leavePolicy.Name,
+			"leave_bonus_type_id":    leavePolicy.LeaveBonusTypeId,
+			"leave_bonus_type":       cf.LeaveBonusTypes[leavePolicy.LeaveBonusTypeId],
+			"accrual_hour":           leavePolicy.AccrualHour,
+			"accrual_frequency":      leavePolicy.AccrualFrequency,
+			"accrual_frequency_name": cf.LeaveAccrualFrequencies[leavePolicy.AccrualFrequency],
+			"probation_month":        leavePolicy.ProbationMonth,
+			"carry_over_hour":        leavePolicy.CarryOverHour,
+			"expiry_month":           leavePolicy.ExpiryMonth,
+			"contract_type_ids":      leavePolicy.ContractTypeIds,
+			"tiers":                  tiersResponse,
+		})
+	}
 
-		leaveBonusParam.Hour, err = strconv.ParseFloat(record[4], 64)
-		if err != nil {
-			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
-				Status:  cf.FailResponseCode,
-				Message: "Invalid field value",
-				Data:    i + 1,
-			})
-		}
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data:    policiesResponse,
+	})
+}
 
-		leaveBonusParam.HourRemaining, err = strconv.ParseFloat(record[4], 64)
+// RunLeaveAccrual : Grant leave by policies at date, dry run only returns grants which would be inserted
+func (ctr *LvController) RunLeaveAccrual(c echo.Context) error {
+	params := new(param.RunLeaveAccrualParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	date := time.Now()
+	if params.Date != "" {
+		var err error
+		date, err = time.Parse(cf.FormatDateDatabase, params.Date)
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 				Status:  cf.FailResponseCode,
 				Message: "Invalid field value",
-				Data:    i + 1,
 			})
 		}
-
-		leaveBonusParam.UserID = userId
-		leaveBonusParam.Reason = record[3]
-
-		leaveBonusParams = append(leaveBonusParams, *leaveBonusParam)
 	}
 
-	if err := ctr.LeaveRepo.InsertLeaveBonusWithTx(
-		userProfile.OrganizationID,
-		userProfile.UserProfile.UserID,
-		&leaveBonusParams,
-	); err != nil {
+	userProfile := c.Get("user_profile").(m.User)
+	grants, err := ctr.computeLeaveAccrualGrants(userProfile.OrganizationID, 0, date)
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
 		})
 	}
 
+	insertedCount := 0
+	if !params.DryRun && len(grants) > 0 {
+		insertedCount, err = ctr.LeaveRepo.InsertLeaveAccrualGrants(userProfile.OrganizationID, userProfile.UserProfile.UserID, grants)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+	}
+
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Import successfully.",
+		Message: "Success",
+		Data: map[string]interface{}{
+			"dry_run":        params.DryRun,
+			"inserted_count": insertedCount,
+			"grants":         grants,
+		},
 	})
 }
 
-func (ctr *LvController) DownloadTemplate(c echo.Context) error {
-	downloadTemplateParam := new(param.DownloadTemplateParam)
-	if err := c.Bind(downloadTemplateParam); err != nil {
-		return c.JSON(http.StatusOK, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "Invalid Params",
-			Data:    err,
-		})
-	}
-
-	_, err := valid.ValidateStruct(downloadTemplateParam)
+// computeLeaveAccrualGrants : Build grants of policies of organization at date, userId 0 is all users. Organization without policy uses default policy.
+// Period which has already been granted is skipped.
+func (ctr *LvController) computeLeaveAccrualGrants(organizationId int, userId int, date time.Time) ([]param.LeaveAccrualGrant, error) {
+	var grants []param.LeaveAccrualGrant
+	organization, err := ctr.OrgRepo.GetOrganizationByID(organizationId)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "Invalid field value",
-		})
+		return grants, err
 	}
 
-	userProfile := c.Get("user_profile").(m.User)
-	if downloadTemplateParam.TypeFile == "xlsx" {
-		if userProfile.LanguageId == cf.EnLanguageId {
-			return c.Attachment("internal/platform/excel/template/import-leave.xlsx", "import-leave.xlsx")
-		} else if userProfile.LanguageId == cf.VnLanguageId {
-			return c.Attachment("internal/platform/excel/template/import-leave-vn.xlsx", "import-leave-vn.xlsx")
-		} else {
-			return c.Attachment("internal/platform/excel/template/import-leave-jp.xlsx", "import-leave-jp.xlsx")
-		}
+	leavePolicies, err := ctr.LeaveRepo.SelectLeavePolicies(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return grants, err
 	}
 
-	if userProfile.LanguageId == cf.EnLanguageId {
-		return c.Attachment("internal/platform/excel/template/import-leave.csv", "import-leave.csv")
-	} else if userProfile.LanguageId == cf.VnLanguageId {
-		return c.Attachment("internal/platform/excel/template/import-leave-vn.csv", "import-leave-vn.csv")
+	policyTiers := make(map[int][]m.LeavePolicyTier)
+	if len(leavePolicies) == 0 {
+		leavePolicies = []m.LeavePolicy{{
+			Name:             cf.LeaveBonusTypes[cf.AnnualLeave],
+			LeaveBonusTypeId: cf.AnnualLeave,
+			AccrualHour:      cf.DefaultAnnualLeaveHour,
+			AccrualFrequency: cf.YearlyAccrual,
+		}}
+
+		for fromMonth, hour := range cf.DefaultSeniorityTiers {
+			policyTiers[0] = append(policyTiers[0], m.LeavePolicyTier{FromMonth: fromMonth, Hour: hour})
+		}
 	} else {
-		return c.Attachment("internal/platform/excel/template/import-leave-jp.csv", "import-leave-jp.csv")
-	}
-}
+		for _, leavePolicy := range leavePolicies {
+			tiers, err := ctr.LeaveRepo.SelectLeavePolicyTiers(leavePolicy.ID)
+			if err != nil && err.Error() != pg.ErrNoRows.Error() {
+				return grants, err
+			}
 
-func (ctr *LvController) ExportExcel(c echo.Context) error {
-	leaveRequestTypeId, _ := strconv.Atoi(c.FormValue("leave_request_type_id"))
-	branch, _ := strconv.Atoi(c.FormValue("branch"))
-	exportExcelParams := &param.LeaveRequestListParams{
-		UserName:           c.FormValue("username"),
-		LeaveRequestTypeID: leaveRequestTypeId,
-		Branch:             branch,
-		DatetimeLeaveFrom:  c.FormValue("datetime_leave_from"),
-		DatetimeLeaveTo:    c.FormValue("datetime_leave_to"),
+			policyTiers[leavePolicy.ID] = tiers
+		}
 	}
 
-	userProfile := c.Get("user_profile").(m.User)
-	columns := []string{"ulr.user_id", "ulr.leave_request_type_id", "ulr.datetime_leave_from", "ulr.datetime_leave_to", "ulr.subtract_day_off_type_id"}
-	leaveRequestRecords, _, err := ctr.LeaveRepo.LeaveRequests(
-		userProfile.OrganizationID,
-		"ulr.user_id ASC, ulr.datetime_leave_from ASC",
-		exportExcelParams,
-		false,
-		columns...,
-	)
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(organizationId)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "System Error",
-			Data:    err,
-		})
+		return grants, err
 	}
 
-	f := excelize.NewFile()
-	_ = f.SetColWidth("Sheet1", "A", "A", 15)
-	_ = f.SetColWidth("Sheet1", "B", "C", 30)
-	_ = f.SetColWidth("Sheet1", "D", "G", 15)
-
-	titleStyle, _ := f.NewStyle(`{
-		"font":{"bold":true, "size":16},
-		"alignment":{"horizontal":"center", "vertical":"center"}
-	}`)
-
-	contentStyle, _ := f.NewStyle(`{"alignment":{"horizontal":"center", "vertical":"center"}}`)
-
-	var categories map[string]string
-	if userProfile.LanguageId == cf.EnLanguageId {
-		categories = cf.LeaveCategoriesEn
-	} else if userProfile.LanguageId == cf.VnLanguageId {
-		categories = cf.LeaveCategoriesVn
-	} else {
-		categories = cf.LeaveCategoriesJp
+	contractTypeRecords, err := ctr.LeaveRepo.SelectLatestContractTypes(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return grants, err
 	}
 
-	leaveCategories := map[string]string{
-		"A1": categories["Employee Id"],
-		"B1": categories["Full Name"],
-		"C1": categories["Leave Request Type"],
-		"D1": categories["Date From"],
-		"E1": categories["Date To"],
-		"F1": categories["Time"],
-		"G1": categories["Note"],
+	contractTypes := make(map[int]int)
+	for _, record := range contractTypeRecords {
+		contractTypes[record.UserId] = record.ContractTypeId
 	}
-	for k, v := range leaveCategories {
-		_ = f.SetCellValue("Sheet1", k, v)
+
+	yearPeriod := strconv.Itoa(date.Year())
+	monthPeriod := date.Format("2006-01")
+	carryPeriod := yearPeriod + "-carry"
+	runs, err := ctr.LeaveRepo.SelectLeaveAccrualRuns(organizationId, []string{yearPeriod, monthPeriod, carryPeriod})
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return grants, err
+	}
+
+	granted := make(map[string]bool)
+	for _, run := range runs {
+		granted[strconv.Itoa(run.PolicyId)+":"+strconv.Itoa(run.UserId)+":"+run.Period] = true
 	}
-	_ = f.SetCellStyle("Sheet1", "A1", "G1", titleStyle)
-	_ = f.SetColStyle("Sheet1", "A", contentStyle)
-	_ = f.SetColStyle("Sheet1", "D", contentStyle)
-	_ = f.SetColStyle("Sheet1", "E", contentStyle)
-	_ = f.SetColStyle("Sheet1", "F", contentStyle)
-	_ = f.SetColStyle("Sheet1", "G", contentStyle)
 
-	userProfileRecords, err := ctr.UserRepo.SelectEmployeeIdByOrganizationId(userProfile.OrganizationID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "System Error",
-			Data:    err,
-		})
+	_, err = ctr.LeaveRepo.SelectLeaveSettlementByYear(organizationId, date.Year()-1)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return grants, err
 	}
 
-	idx := 2
-	var userProfileRecord param.EmployeeIdAndFullName
-	for i, record := range leaveRequestRecords {
-		pos := idx + i
-		for _, upr := range userProfileRecords {
-			if record.UserID == upr.UserId {
-				userProfileRecord = upr
-			}
+	settled := err == nil
+	carried := make(map[int]bool)
+	for _, leavePolicy := range leavePolicies {
+		period := yearPeriod
+		if leavePolicy.AccrualFrequency == cf.MonthlyAccrual {
+			period = monthPeriod
 		}
 
-		var datetimeLeaveFrom, datetimeLeaveTo, hourFrom, minuteFrom, hourTo, minuteTo, leaveTime string
-		datetimeLeaveFrom = record.DatetimeLeaveFrom.Format(cf.FormatDateDisplay)
-		datetimeLeaveTo = record.DatetimeLeaveTo.Format(cf.FormatDateDisplay)
+		expiryMonth := leavePolicy.ExpiryMonth
+		if expiryMonth == 0 {
+			expiryMonth = organization.ExpirationResetDayOff + 1
+		}
+		expireDate := time.Date(date.Year()+1, time.Month(expiryMonth), 1, 0, 0, 0, 0, time.Local).Format(cf.FormatDate)
 
-		if record.LeaveRequestTypeID == cf.LateForWork || record.LeaveRequestTypeID == cf.LeaveEarly || record.LeaveRequestTypeID == cf.GoOutside {
-			hourFrom = utils.ConvertTwoChar(record.HourFrom)
-			minuteFrom = utils.ConvertTwoChar(record.MinuteFrom)
-			hourTo = utils.ConvertTwoChar(record.HourTo)
-			minuteTo = utils.ConvertTwoChar(record.MinuteTo)
+		for _, user := range users {
+			if userId != 0 && user.UserID != userId {
+				continue
+			}
 
-			if hourFrom != "" && minuteFrom != "" && hourTo != "" && minuteTo != "" {
-				leaveTime = hourFrom + ":" + minuteFrom + " - " + hourTo + ":" + minuteTo
+			if !isEligibleForLeavePolicy(leavePolicy, user.CompanyJoinedDate, contractTypes[user.UserID], date) {
+				continue
 			}
-		}
 
-		values := map[string]interface{}{
-			"A" + strconv.Itoa(pos): userProfileRecord.EmployeeId,
-			"B" + strconv.Itoa(pos): userProfileRecord.FullName,
-			"C" + strconv.Itoa(pos): cf.LeaveRequestTypes[record.LeaveRequestTypeID],
-			"D" + strconv.Itoa(pos): datetimeLeaveFrom,
-			"E" + strconv.Itoa(pos): datetimeLeaveTo,
-			"F" + strconv.Itoa(pos): leaveTime,
-		}
+			policyKey := strconv.Itoa(leavePolicy.ID) + ":" + strconv.Itoa(user.UserID) + ":"
+			if !granted[policyKey+period] {
+				ratio := leaveAccrualRatio(leavePolicy.AccrualFrequency, user.CompanyJoinedDate, date)
+				hour := roundLeaveHour(leavePolicy.AccrualHour * ratio)
+				seniorityHour := roundLeaveHour(seniorityTierHour(policyTiers[leavePolicy.ID], monthsBetween(user.CompanyJoinedDate, date)) * ratio)
+
+				if hour > 0 {
+					grants = append(grants, param.LeaveAccrualGrant{
+						PolicyId:             leavePolicy.ID,
+						PolicyName:           leavePolicy.Name,
+						UserId:               user.UserID,
+						FullName:             user.FullName,
+						Period:               period,
+						LeaveBonusTypeId:     leavePolicy.LeaveBonusTypeId,
+						YearBelong:           date.Year(),
+						Reason:               leavePolicy.Name + " " + period,
+						Hour:                 hour,
+						ExpireBonusLeaveDate: expireDate,
+					})
+				}
 
-		if record.SubtractDayOffTypeID != cf.Subtract {
-			values["G"+strconv.Itoa(pos)] = cf.SubtractDayOffTypes[record.SubtractDayOffTypeID]
-		}
+				if seniorityHour > 0 {
+					grants = append(grants, param.LeaveAccrualGrant{
+						PolicyId:             leavePolicy.ID,
+						PolicyName:           leavePolicy.Name,
+						UserId:               user.UserID,
+						FullName:             user.FullName,
+						Period:               period,
+						LeaveBonusTypeId:     cf.SeniorityLeave,
+						YearBelong:           date.Year(),
+						Reason:               "Seniority Leave " + period,
+						Hour:                 seniorityHour,
+						ExpireBonusLeaveDate: expireDate,
+					})
+				}
+			}
 
-		for k, v := range values {
-			_ = f.SetCellValue("Sheet1", k, v)
+			if settled || leavePolicy.CarryOverHour == nil || leavePolicy.LeaveBonusTypeId != cf.AnnualLeave ||
+				carried[user.UserID] || granted[policyKey+carryPeriod] {
+				continue
+			}
+
+			carried[user.UserID] = true
+			previousYear := date.Year() - 1
+			hourBonusOld, err := ctr.LeaveRepo.CountHourBonus(organizationId, user.UserID, previousYear)
+			if err != nil {
+				return grants, err
+			}
+
+			hourUsedOld, err := ctr.LeaveRepo.CountHourUsed(organizationId, user.UserID, previousYear)
+			if err != nil {
+				return grants, err
+			}
+
+			excessHour := roundLeaveHour(hourBonusOld - hourUsedOld - *leavePolicy.CarryOverHour)
+			if excessHour > 0 {
+				grants = append(grants, param.LeaveAccrualGrant{
+					PolicyId:         leavePolicy.ID,
+					PolicyName:       leavePolicy.Name,
+					UserId:           user.UserID,
+					FullName:         user.FullName,
+					Period:           carryPeriod,
+					LeaveBonusTypeId: cf.AnnualLeave,
+					YearBelong:       previousYear,
+					Reason:           "Clear leave over carry-over cap",
+					Hour:             -excessHour,
+				})
+			}
 		}
 	}
 
-	buf, _ := f.WriteToBuffer()
-	return c.Blob(http.StatusOK, "application/octet-stream", buf.Bytes())
+	return grants, nil
 }
 
-func (ctr *LvController) GetLeaveBonuses(c echo.Context) error {
-	params := new(param.GetLeaveBonusParam)
-	if err := c.Bind(params); err != nil {
-		return c.JSON(http.StatusOK, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "Invalid Params",
-			Data:    err,
-		})
+func isEligibleForLeavePolicy(leavePolicy m.LeavePolicy, joinedDate time.Time, contractTypeId int, date time.Time) bool {
+	if !joinedDate.IsZero() {
+		if joinedDate.After(date) || monthsBetween(joinedDate, date) < leavePolicy.ProbationMonth {
+			return false
+		}
 	}
 
-	_, err := valid.ValidateStruct(params)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "Invalid field value",
-		})
+	if len(leavePolicy.ContractTypeIds) == 0 {
+		return true
 	}
-	userProfile := c.Get("user_profile").(m.User)
 
-	records, totalRow, err := ctr.LeaveRepo.SelectLeaveBonuses(userProfile.OrganizationID, params)
-	if err != nil && err.Error() != pg.ErrNoRows.Error() {
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "System Error",
-			Data:    err,
-		})
+	for _, id := range leavePolicy.ContractTypeIds {
+		if id == contractTypeId {
+			return true
+		}
 	}
 
-	var leaveBonusesResponse []map[string]interface{}
-	pagination := map[string]interface{}{
-		"total_row": totalRow,
+	return false
+}
+
+// leaveAccrualRatio : Part of yearly hours granted in a run
+func leaveAccrualRatio(frequency int, joinedDate time.Time, date time.Time) float64 {
+	switch frequency {
+	case cf.MonthlyAccrual:
+		return 1.0 / 12
+	case cf.ProRataAccrual:
+		if !joinedDate.IsZero() && joinedDate.Year() == date.Year() {
+			return float64(13-int(joinedDate.Month())) / 12
+		}
 	}
 
-	location, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
-	for _, record := range records {
-		leaveBonus := map[string]interface{}{
-			"id":               record.Id,
-			"user_id":          record.UserId,
-			"created_by":       record.CreatedBy,
-			"reason":           record.Reason,
-			"year":             record.Year,
-			"hour":             record.Hour,
-			"leave_bonus_type": cf.LeaveBonusTypes[record.LeaveBonusTypeId],
-			"created_at":       record.CreatedAt.In(location).Format(cf.FormatDisplayTimekeeping2),
+	return 1
+}
+
+func seniorityTierHour(tiers []m.LeavePolicyTier, workedMonths int) float64 {
+	var hour float64
+	fromMonth := -1
+	for _, tier := range tiers {
+		if workedMonths >= tier.FromMonth && tier.FromMonth > fromMonth {
+			hour = tier.Hour
+			fromMonth = tier.FromMonth
 		}
+	}
 
-		leaveBonusesResponse = append(leaveBonusesResponse, leaveBonus)
+	return hour
+}
+
+func monthsBetween(from time.Time, to time.Time) int {
+	if from.IsZero() || to.Before(from) {
+		return 0
 	}
 
-	userRecords, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
-	if err != nil {
-		if err.Error() == pg.ErrNoRows.Error() {
-			return c.JSON(http.StatusOK, cf.JsonResponse{
-				Status:  cf.FailResponseCode,
-				Message: "Get user list failed",
-			})
-		}
+	months := (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+	if to.Day() < from.Day() {
+		months--
+	}
 
-		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
-			Status:  cf.FailResponseCode,
-			Message: "System Error",
-			Data:    err,
-		})
+	return months
+}
+
+func roundLeaveHour(hour float64) float64 {
+	return math.Round(hour*100) / 100
+}
+
+func isValidLeavePolicy(
+	leaveBonusTypeId int,
+	accrualHour float64,
+	probationMonth int,
+	carryOverHour *float64,
+	tiers []param.LeavePolicyTierParams,
+) bool {
+	if _, ok := cf.LeaveBonusTypes[leaveBonusTypeId]; !ok || accrualHour <= 0 || probationMonth < 0 {
+		return false
 	}
 
-	userList := make(map[int]string)
-	for i := 0; i < len(userRecords); i++ {
-		userList[userRecords[i].UserID] = userRecords[i].FullName
+	if carryOverHour != nil && *carryOverHour < 0 {
+		return false
 	}
 
-	dataResponse := map[string]interface{}{
-		"user_leave_bonuses": leaveBonusesResponse,
-		"pagination":         pagination,
-		"user_list":          userList,
-		"leave_bonus_types":  cf.LeaveBonusTypes,
+	for _, tier := range tiers {
+		if tier.FromMonth < 0 || tier.Hour < 0 {
+			return false
+		}
 	}
 
-	return c.JSON(http.StatusOK, cf.JsonResponse{
-		Status:  cf.SuccessResponseCode,
-		Message: "Get leave bonuses successful",
-		Data:    dataResponse,
-	})
+	return true
 }
 
-func (ctr *LvController) GetLeaveBonus(c echo.Context) error {
-	params := new(param.GetLeaveBonusByIdParam)
+// GetLeaveLedger : Get ledger entries of leave balance with their sources
+func (ctr *LvController) GetLeaveLedger(c echo.Context) error {
+	params := new(param.LeaveLedgerParams)
 	if err := c.Bind(params); err != nil {
-		ctr.Logger.Error(err)
-		return c.JSON(http.StatusOK, cf.JsonResponse{
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid Params",
-			Data:    err,
 		})
 	}
 
-	_, err := valid.ValidateStruct(params)
-	if err != nil {
-		ctr.Logger.Error(err)
+	if _, err := valid.ValidateStruct(params); err != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid field value",
 		})
 	}
 
-	leaveBonus, err := ctr.LeaveRepo.SelectLeaveBonusById(params.Id)
+	userProfile := c.Get("user_profile").(m.User)
+	if userProfile.RoleID == cf.UserRoleID {
+		params.UserId = userProfile.UserProfile.UserID
+	}
+
+	records, totalRow, err := ctr.LeaveRepo.SelectLeaveLedgerEntries(userProfile.OrganizationID, params)
 	if err != nil && err.Error() != pg.ErrNoRows.Error() {
-		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
-			Message: "Invalid field value",
+			Message: "System Error",
 		})
 	}
 
-	dataResponse := map[string]interface{}{
-		"leave_bonus_type_id": leaveBonus.LeaveBonusTypeID,
-		"hour":                leaveBonus.Hour,
-		"reason":              leaveBonus.Reason,
-		"year_belong":         leaveBonus.YearBelong,
-		"leave_bonus_types":   cf.LeaveBonusTypes,
+	var entries []map[string]interface{}
+	for _, record := range records {
+		entries = append(entries, map[string]interface{}{
+			"id":               record.Id,
+			"user_id":          record.UserId,
+			"full_name":        record.FullName,
+			"entry_type":       record.EntryType,
+			"entry_type_name":  cf.LeaveLedgerEntryTypes[record.EntryType],
+			"leave_bonus_type": cf.LeaveBonusTypes[record.LeaveBonusTypeId],
+			"year_belong":      record.YearBelong,
+			"hour":             record.Hour,
+			"effective_date":   record.EffectiveDate.Format(cf.FormatDateDatabase),
+			"source_type":      record.SourceType,
+			"source_type_name": cf.LeaveLedgerSourceTypes[record.SourceType],
+			"source_id":        record.SourceId,
+			"note":             record.Note,
+			"created_at":       record.CreatedAt.Format(cf.FormatDate),
+		})
+	}
+
+	pagination := map[string]interface{}{
+		"current_page": params.CurrentPage,
+		"total_row":    totalRow,
+		"row_per_page": params.RowPerPage,
 	}
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Get leave bonus successful",
-		Data:    dataResponse,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"pagination": pagination,
+			"entries":    entries,
+		},
 	})
 }
 
-func (ctr *LvController) EditLeaveBonuses(c echo.Context) error {
-	params := new(param.EditLeaveBonusParam)
+// GetLeaveBalance : Get balance of user from ledger as of date, default is today
+func (ctr *LvController) GetLeaveBalance(c echo.Context) error {
+	params := new(param.LeaveBalanceParams)
 	if err := c.Bind(params); err != nil {
-		ctr.Logger.Error(err)
-		return c.JSON(http.StatusOK, cf.JsonResponse{
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid Params",
-			Data:    err,
 		})
 	}
 
+	if params.Date == "" {
+		params.Date = time.Now().Format(cf.FormatDateDatabase)
+	}
+
 	_, err := valid.ValidateStruct(params)
-	if err != nil {
-		ctr.Logger.Error(err)
+	if _, errDate := time.Parse(cf.FormatDateDatabase, params.Date); err != nil || errDate != nil {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid field value",
@@ -1683,7 +5142,15 @@ func (ctr *LvController) EditLeaveBonuses(c echo.Context) error {
 	}
 
 	userProfile := c.Get("user_profile").(m.User)
-	if err := ctr.LeaveRepo.UpdateLeaveBonus(userProfile.UserProfile.UserID, params); err != nil {
+	if userProfile.RoleID == cf.UserRoleID && params.UserId != userProfile.UserProfile.UserID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to view balance of other user",
+		})
+	}
+
+	hour, err := ctr.LeaveRepo.SelectLeaveLedgerBalance(userProfile.OrganizationID, params.UserId, params.Date)
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
@@ -1692,79 +5159,114 @@ func (ctr *LvController) EditLeaveBonuses(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
 		Status:  cf.SuccessResponseCode,
-		Message: "Update leave bonus successful",
+		Message: "Success",
+		Data: map[string]interface{}{
+			"user_id": params.UserId,
+			"date":    params.Date,
+			"hour":    hour,
+			"day":     hour / 8,
+		},
 	})
 }
 
-func (ctr *LvController) RemoveLeaveBonus(c echo.Context) error {
-	params := new(param.RemoveLeaveBonusParam)
+// RecomputeLeaveBalances : Rebuild balances from leave bonuses and leave requests and compare with ledger.
+// Apply appends adjustment entries so that ledger matches history again.
+func (ctr *LvController) RecomputeLeaveBalances(c echo.Context) error {
+	params := new(param.RecomputeLeaveBalancesParams)
 	if err := c.Bind(params); err != nil {
-		return c.JSON(http.StatusOK, cf.JsonResponse{
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid Params",
-			Data:    err,
 		})
 	}
 
-	_, err := valid.ValidateStruct(params)
-	if err != nil {
-		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+	userProfile := c.Get("user_profile").(m.User)
+	historyBalances, err := ctr.LeaveRepo.SelectLeaveHistoryBalances(userProfile.OrganizationID, params.UserId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
-			Message: "Invalid field value",
+			Message: "System Error",
 		})
 	}
 
-	if err := ctr.LeaveRepo.UpdateDeleted(params.Id, params.IsDeleted); err != nil {
+	ledgerBalances, err := ctr.LeaveRepo.SelectLeaveLedgerBalances(userProfile.OrganizationID, params.UserId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "System Error",
 		})
 	}
 
-	var message string
-	if params.IsDeleted {
-		message = "Remove leave bonuses successful"
-	} else {
-		message = "Restore leave bonuses successful"
+	balances := make(map[[2]int][2]float64)
+	for _, balance := range historyBalances {
+		key := [2]int{balance.UserId, balance.YearBelong}
+		balances[key] = [2]float64{balance.Hour, balances[key][1]}
 	}
 
-	return c.JSON(http.StatusOK, cf.JsonResponse{
-		Status:  cf.SuccessResponseCode,
-		Message: message,
-	})
-}
-
-func (ctr *LvController) getHolidaysOfYear(organizationId int, year int) []time.Time {
-	records, err := ctr.HolidayRepo.SelectHolidays(organizationId, year, "holiday_date")
-	if err != nil && err.Error() != pg.ErrNoRows.Error() {
-		panic(err)
+	for _, balance := range ledgerBalances {
+		key := [2]int{balance.UserId, balance.YearBelong}
+		balances[key] = [2]float64{balances[key][0], balance.Hour}
 	}
 
-	var holidayDates []time.Time
-	for _, record := range records {
-		holidayDates = append(holidayDates, record.HolidayDate)
+	var keys [][2]int
+	for key := range balances {
+		keys = append(keys, key)
 	}
 
-	return holidayDates
-}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+
+		return keys[i][1] < keys[j][1]
+	})
+
+	var drifts []map[string]interface{}
+	var adjustments []m.LeaveLedgerEntry
+	for _, key := range keys {
+		balance := balances[key]
+		drift := roundLeaveHour(balance[0] - balance[1])
+		if drift == 0 {
+			continue
+		}
 
-func monthsCountSince(createdAtTime time.Time) int {
-	now := time.Now()
-	currentYear, _, _ := now.Date()
-	currentLocation := now.Location()
-	lastYear := time.Date(currentYear, 12, 31, 23, 0, 0, 0, currentLocation)
-	months := 0
-	month := createdAtTime.Month()
-	for createdAtTime.Before(lastYear) {
-		createdAtTime = createdAtTime.Add(time.Hour * 24)
-		nextMonth := createdAtTime.Month()
-		if nextMonth != month {
-			months++
+		drifts = append(drifts, map[string]interface{}{
+			"user_id":      key[0],
+			"year_belong":  key[1],
+			"history_hour": roundLeaveHour(balance[0]),
+			"ledger_hour":  roundLeaveHour(balance[1]),
+			"drift":        drift,
+		})
+
+		adjustments = append(adjustments, m.LeaveLedgerEntry{
+			OrganizationId: userProfile.OrganizationID,
+			UserId:         key[0],
+			EntryType:      cf.AdjustmentLedgerEntry,
+			YearBelong:     key[1],
+			Hour:           drift,
+			SourceType:     cf.RecomputeLedgerSource,
+			Note:           "Recompute drift",
+			CreatedBy:      userProfile.UserProfile.UserID,
+		})
+	}
+
+	if params.Apply && len(adjustments) > 0 {
+		if err := ctr.LeaveRepo.InsertLeaveLedgerEntries(adjustments); err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
 		}
-		month = nextMonth
 	}
 
-	return months
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data: map[string]interface{}{
+			"applied": params.Apply && len(adjustments) > 0,
+			"drifts":  drifts,
+		},
+	})
 }
 
 func (ctr *LvController) UpdateHourRemainingLeave(c echo.Context) error {
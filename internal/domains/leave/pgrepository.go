@@ -58,6 +58,8 @@ func (repo *PgLeaveRepository) InsertLeaveRequest(
 			EmailContent:         leaveRequestParams.EmailContent,
 			SubtractDayOffTypeID: leaveRequestParams.SubtractDayOffTypeID,
 			Reason:               leaveRequestParams.Reason,
+			DelegateUserId:       leaveRequestParams.DelegateUserID,
+			HandoverNote:         leaveRequestParams.HandoverNote,
 			Hour: calendar.CalculateHour(
 				leaveRequestParams.OrgID,
 				holidayRepo,
@@ -75,6 +77,22 @@ func (repo *PgLeaveRepository) InsertLeaveRequest(
 		id = leaveRequest.ID
 		hour = leaveRequest.Hour
 
+		transErr = repo.insertLeaveLedgerEntryWithTx(tx, m.LeaveLedgerEntry{
+			OrganizationId: leaveRequest.OrganizationID,
+			UserId:         leaveRequest.UserID,
+			EntryType:      cf.UsageLedgerEntry,
+			YearBelong:     datetimeLeaveFrom.Year(),
+			Hour:           -leaveRequest.Hour,
+			EffectiveDate:  datetimeLeaveFrom,
+			SourceType:     cf.LeaveRequestLedgerSource,
+			SourceId:       leaveRequest.ID,
+			Note:           leaveRequest.Reason,
+			CreatedBy:      leaveRequest.CreatedBy,
+		})
+		if transErr != nil {
+			return transErr
+		}
+
 		notificationParams := new(param.InsertNotificationParam)
 		notificationParams.Content = "has just created a leave request"
 		notificationParams.RedirectUrl = "/hrm/leave/history-user-leave?id=" + strconv.Itoa(id) +
@@ -125,7 +143,13 @@ func (repo *PgLeaveRepository) InsertLeaveBonus(leaveBonusParams *param.LeaveBon
 		Hour:             leaveBonusParams.Hour,
 	}
 
-	err := repo.DB.Insert(&leaveBonus)
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		if err := tx.Insert(&leaveBonus); err != nil {
+			return err
+		}
+
+		return repo.insertLeaveLedgerEntryWithTx(tx, leaveBonusLedgerEntry(leaveBonus))
+	})
 
 	if err != nil {
 		repo.Logger.Error(err)
@@ -152,9 +176,16 @@ func (repo *PgLeaveRepository) InsertLeaveBonusOvertimeWithTx(tx *pg.Tx, leaveBo
 	err := tx.Insert(&leaveBonus)
 	if err != nil {
 		repo.Logger.Error(err)
+		return err
 	}
 
-	return err
+	leaveLedgerEntry := leaveBonusLedgerEntry(leaveBonus)
+	if leaveBonusParams.OvertimeRequestId != 0 {
+		leaveLedgerEntry.SourceType = cf.OvertimeRequestLedgerSource
+		leaveLedgerEntry.SourceId = leaveBonusParams.OvertimeRequestId
+	}
+
+	return repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry)
 }
 
 func (repo *PgLeaveRepository) InsertLeaveBonusWithTx(organizationId int, createdBy int, leaveBonusParams *[]param.LeaveBonus) error {
@@ -179,6 +210,11 @@ func (repo *PgLeaveRepository) InsertLeaveBonusWithTx(organizationId int, create
 				repo.Logger.Error(errTx)
 				return errTx
 			}
+
+			errTx = repo.insertLeaveLedgerEntryWithTx(tx, leaveBonusLedgerEntry(leaveBonus))
+			if errTx != nil {
+				return errTx
+			}
 		}
 
 		return errTx
@@ -187,37 +223,39 @@ func (repo *PgLeaveRepository) InsertLeaveBonusWithTx(organizationId int, create
 	return err
 }
 
-// CountHourUsed : Sum hour used of user
+// CountHourUsed : Sum hour used of user from ledger
 func (repo *PgLeaveRepository) CountHourUsed(orgID int, userID int, year int) (float64, error) {
 	var hour float64
-	err := repo.DB.Model(&m.UserLeaveRequest{}).
-		ColumnExpr("SUM(hour)").
+	err := repo.DB.Model(&m.LeaveLedgerEntry{}).
+		ColumnExpr("COALESCE(-SUM(hour), 0)").
 		Where("organization_id = ?", orgID).
 		Where("user_id = ?", userID).
-		Where("date_part('year', datetime_leave_from) = ?", year).
+		Where("entry_type IN (?)", pg.In([]int{cf.UsageLedgerEntry, cf.ReversalLedgerEntry})).
+		Where("year_belong = ?", year).
 		Select(&hour)
 
 	return hour, err
 }
 
-// CountHourBonus : Sum hour remaining of user
+// CountHourBonus : Sum hour bonus of user from ledger
 func (repo *PgLeaveRepository) CountHourBonus(orgID int, userID int, year int) (float64, error) {
 	var hour float64
-	err := repo.DB.Model(&m.UserLeaveBonus{}).
-		ColumnExpr("SUM(hour)").
+	err := repo.DB.Model(&m.LeaveLedgerEntry{}).
+		ColumnExpr("COALESCE(SUM(hour), 0)").
 		Where("organization_id = ?", orgID).
 		Where("user_id = ?", userID).
+		Where("entry_type NOT IN (?)", pg.In([]int{cf.UsageLedgerEntry, cf.ReversalLedgerEntry})).
 		Where("year_belong = ?", year).
 		Select(&hour)
 
 	return hour, err
 }
 
-// CountHourRemaining : Sum hour remaining of user
+// CountHourRemaining : Sum hour remaining of user from ledger
 func (repo *PgLeaveRepository) CountHourRemaining(orgID int, userID int, year int) (float64, error) {
 	var hour float64
-	err := repo.DB.Model(&m.UserLeaveBonus{}).
-		ColumnExpr("SUM(hour_remaining)").
+	err := repo.DB.Model(&m.LeaveLedgerEntry{}).
+		ColumnExpr("COALESCE(SUM(hour), 0)").
 		Where("organization_id = ?", orgID).
 		Where("user_id = ?", userID).
 		Where("year_belong = ?", year).
@@ -226,15 +264,16 @@ func (repo *PgLeaveRepository) CountHourRemaining(orgID int, userID int, year in
 	return hour, err
 }
 
-// CountHoursUpToExpirationDate : Number of hours up to the expiration date
+// CountHoursUpToExpirationDate : Number of hours used up to the expiration date from ledger
 func (repo *PgLeaveRepository) CountHoursUpToExpirationDate(orgID int, userID int, date string, firstDate string) (float64, error) {
 	var hour float64
-	err := repo.DB.Model(&m.UserLeaveRequest{}).
-		ColumnExpr("SUM(hour)").
+	err := repo.DB.Model(&m.LeaveLedgerEntry{}).
+		ColumnExpr("COALESCE(-SUM(hour), 0)").
 		Where("organization_id = ?", orgID).
 		Where("user_id = ?", userID).
-		Where("date(ulr.datetime_leave_from) <= DATE(?)", date).
-		Where("date(ulr.datetime_leave_from) >= DATE(?)", firstDate).
+		Where("entry_type IN (?)", pg.In([]int{cf.UsageLedgerEntry, cf.ReversalLedgerEntry})).
+		Where("lle.effective_date <= DATE(?)", date).
+		Where("lle.effective_date >= DATE(?)", firstDate).
 		Select(&hour)
 
 	return hour, err
@@ -360,7 +399,8 @@ func (repo *PgLeaveRepository) UpdateLeaveRequest(Id int, calendarEventId string
 func (repo *PgLeaveRepository) SelectLeaveRequestById(Id int) (m.UserLeaveRequest, error) {
 	var leaveRequest m.UserLeaveRequest
 	err := repo.DB.Model(&leaveRequest).
-		Column("calendar_event_id", "organization_id", "user_id", "hour").
+		Column("id", "calendar_event_id", "organization_id", "user_id", "hour", "leave_request_type_id",
+			"datetime_leave_from", "datetime_leave_to", "subtract_day_off_type_id", "reason").
 		Where("id = ?", Id).
 		Select()
 
@@ -371,11 +411,38 @@ func (repo *PgLeaveRepository) SelectLeaveRequestById(Id int) (m.UserLeaveReques
 	return leaveRequest, err
 }
 
-// RemoveLeave : Remove leave request
+// RemoveLeave : Remove leave request and reverse its usage in ledger
 func (repo *PgLeaveRepository) RemoveLeave(leaveID int) error {
-	_, err := repo.DB.Model(&m.UserLeaveRequest{}).
-		Where("id = ?", leaveID).
-		Delete()
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var leaveRequest m.UserLeaveRequest
+		err := tx.Model(&leaveRequest).
+			Column("id", "organization_id", "user_id", "datetime_leave_from", "hour", "updated_by").
+			Where("id = ?", leaveID).
+			Select()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(&m.UserLeaveRequest{}).
+			Where("id = ?", leaveID).
+			Delete()
+		if err != nil {
+			return err
+		}
+
+		return repo.insertLeaveLedgerEntryWithTx(tx, m.LeaveLedgerEntry{
+			OrganizationId: leaveRequest.OrganizationID,
+			UserId:         leaveRequest.UserID,
+			EntryType:      cf.ReversalLedgerEntry,
+			YearBelong:     leaveRequest.DatetimeLeaveFrom.Year(),
+			Hour:           leaveRequest.Hour,
+			EffectiveDate:  leaveRequest.DatetimeLeaveFrom,
+			SourceType:     cf.LeaveRequestLedgerSource,
+			SourceId:       leaveRequest.ID,
+			Note:           "Remove leave request",
+			CreatedBy:      leaveRequest.UpdatedBy,
+		})
+	})
 
 	if err != nil {
 		repo.Logger.Error(err)
@@ -499,9 +566,54 @@ func (repo *PgLeaveRepository) UpdateLeaveBonus(userId int, params *param.EditLe
 		HourRemaining:    params.Hour,
 	}
 
-	_, err := repo.DB.Model(&leaveBonus).
-		Where("id = ?", params.Id).
-		UpdateNotZero()
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var oldLeaveBonus m.UserLeaveBonus
+		err := tx.Model(&oldLeaveBonus).
+			Where("id = ?", params.Id).
+			Select()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(&leaveBonus).
+			Where("id = ?", params.Id).
+			UpdateNotZero()
+		if err != nil {
+			return err
+		}
+
+		newLeaveBonus := oldLeaveBonus
+		if params.LeaveBonusTypeId != 0 {
+			newLeaveBonus.LeaveBonusTypeID = params.LeaveBonusTypeId
+		}
+		if params.YearBelong != 0 {
+			newLeaveBonus.YearBelong = params.YearBelong
+		}
+		if params.Hour != 0 {
+			newLeaveBonus.Hour = params.Hour
+		}
+
+		if newLeaveBonus.LeaveBonusTypeID == oldLeaveBonus.LeaveBonusTypeID &&
+			newLeaveBonus.YearBelong == oldLeaveBonus.YearBelong &&
+			newLeaveBonus.Hour == oldLeaveBonus.Hour {
+			return nil
+		}
+
+		// Edited bonus is reversed and granted again so that ledger keeps its history
+		oldLeaveBonus.Hour = -oldLeaveBonus.Hour
+		newLeaveBonus.CreatedBy = userId
+		oldLeaveBonus.CreatedBy = userId
+		for _, bonus := range []m.UserLeaveBonus{oldLeaveBonus, newLeaveBonus} {
+			leaveLedgerEntry := leaveBonusLedgerEntry(bonus)
+			leaveLedgerEntry.EntryType = cf.AdjustmentLedgerEntry
+			leaveLedgerEntry.Note = "Edit leave bonus"
+			if err := repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 
 	if err != nil {
 		repo.Logger.Error(err)
@@ -519,7 +631,36 @@ func (repo *PgLeaveRepository) UpdateDeleted(id int, isDeleted bool) error {
 	}
 
 	q += " WHERE id = " + strconv.Itoa(id)
-	_, err := repo.DB.Query(m.UserLeaveBonus{}, q)
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var leaveBonus m.UserLeaveBonus
+		err := tx.Model(&leaveBonus).
+			AllWithDeleted().
+			Where("id = ?", id).
+			Select()
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Query(m.UserLeaveBonus{}, q); err != nil {
+			return err
+		}
+
+		// Only change of state is recorded, deleting a deleted bonus again does not change balance
+		if isDeleted == !leaveBonus.DeletedAt.IsZero() {
+			return nil
+		}
+
+		leaveLedgerEntry := leaveBonusLedgerEntry(leaveBonus)
+		leaveLedgerEntry.EntryType = cf.AdjustmentLedgerEntry
+		leaveLedgerEntry.Note = "Restore leave bonus"
+		if isDeleted {
+			leaveLedgerEntry.Hour = -leaveLedgerEntry.Hour
+			leaveLedgerEntry.Note = "Remove leave bonus"
+		}
+
+		return repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry)
+	})
+
 	if err != nil {
 		repo.Logger.Error(err)
 	}
@@ -717,3 +858,1653 @@ func (repo *PgLeaveRepository) UpdateExpireLeaveDate(id int, hour float64, expir
 
 	return err
 }
+
+func (repo *PgLeaveRepository) InsertLeavePolicy(organizationId int, params *param.CreateLeavePolicyParams) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		leavePolicy := m.LeavePolicy{
+			OrganizationId:   organizationId,
+			Name:             params.Name,
+			LeaveBonusTypeId: params.LeaveBonusTypeId,
+			AccrualHour:      params.AccrualHour,
+			AccrualFrequency: params.AccrualFrequency,
+			ProbationMonth:   params.ProbationMonth,
+			CarryOverHour:    params.CarryOverHour,
+			ExpiryMonth:      params.ExpiryMonth,
+			ContractTypeIds:  params.ContractTypeIds,
+		}
+
+		errTx := tx.Insert(&leavePolicy)
+		if errTx != nil {
+			return errTx
+		}
+
+		return repo.insertLeavePolicyTiersWithTx(tx, leavePolicy.ID, params.Tiers)
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) UpdateLeavePolicy(params *param.EditLeavePolicyParams) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		leavePolicy := m.LeavePolicy{
+			Name:             params.Name,
+			LeaveBonusTypeId: params.LeaveBonusTypeId,
+			AccrualHour:      params.AccrualHour,
+			AccrualFrequency: params.AccrualFrequency,
+			ProbationMonth:   params.ProbationMonth,
+			CarryOverHour:    params.CarryOverHour,
+			ExpiryMonth:      params.ExpiryMonth,
+			ContractTypeIds:  params.ContractTypeIds,
+		}
+
+		_, errTx := tx.Model(&leavePolicy).
+			Column("name", "leave_bonus_type_id", "accrual_hour", "accrual_frequency", "probation_month",
+				"carry_over_hour", "expiry_month", "contract_type_ids", "updated_at").
+			Where("id = ?", params.Id).
+			Update()
+		if errTx != nil {
+			return errTx
+		}
+
+		_, errTx = tx.Model(&m.LeavePolicyTier{}).
+			Where("policy_id = ?", params.Id).
+			Delete()
+		if errTx != nil {
+			return errTx
+		}
+
+		return repo.insertLeavePolicyTiersWithTx(tx, params.Id, params.Tiers)
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) insertLeavePolicyTiersWithTx(tx *pg.Tx, policyId int, tiers []param.LeavePolicyTierParams) error {
+	for _, tier := range tiers {
+		leavePolicyTier := m.LeavePolicyTier{
+			PolicyId:  policyId,
+			FromMonth: tier.FromMonth,
+			Hour:      tier.Hour,
+		}
+
+		if err := tx.Insert(&leavePolicyTier); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *PgLeaveRepository) DeleteLeavePolicy(id int) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		_, errTx := tx.Model(&m.LeavePolicyTier{}).
+			Where("policy_id = ?", id).
+			Delete()
+		if errTx != nil {
+			return errTx
+		}
+
+		_, errTx = tx.Model(&m.LeavePolicy{}).
+			Where("id = ?", id).
+			Delete()
+
+		return errTx
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) SelectLeavePolicyById(id int) (m.LeavePolicy, error) {
+	var leavePolicy m.LeavePolicy
+	err := repo.DB.Model(&leavePolicy).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return leavePolicy, err
+}
+
+func (repo *PgLeaveRepository) SelectLeavePolicies(organizationId int) ([]m.LeavePolicy, error) {
+	var leavePolicies []m.LeavePolicy
+	err := repo.DB.Model(&leavePolicies).
+		Where("organization_id = ?", organizationId).
+		Order("id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return leavePolicies, err
+}
+
+func (repo *PgLeaveRepository) SelectLeavePolicyTiers(policyId int) ([]m.LeavePolicyTier, error) {
+	var tiers []m.LeavePolicyTier
+	err := repo.DB.Model(&tiers).
+		Where("policy_id = ?", policyId).
+		Order("from_month ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return tiers, err
+}
+
+// SelectLatestContractTypes : Get contract type of latest contract of each user
+func (repo *PgLeaveRepository) SelectLatestContractTypes(organizationId int) ([]param.UserContractTypeRecord, error) {
+	var records []param.UserContractTypeRecord
+	err := repo.DB.Model(&m.Contract{}).
+		ColumnExpr("DISTINCT ON (contract.user_id) contract.user_id, contract.contract_type_id").
+		Where("contract.organization_id = ?", organizationId).
+		OrderExpr("contract.user_id ASC, contract.contract_start_date DESC").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+func (repo *PgLeaveRepository) SelectLeaveAccrualRuns(organizationId int, periods []string) ([]m.LeaveAccrualRun, error) {
+	var runs []m.LeaveAccrualRun
+	err := repo.DB.Model(&runs).
+		Column("policy_id", "user_id", "period", "hour").
+		Where("organization_id = ?", organizationId).
+		Where("period IN (?)", pg.In(periods)).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return runs, err
+}
+
+// InsertLeaveAccrualGrants : Insert leave bonuses of grants. Period which has already been granted to user by policy is skipped,
+// so running accrual many times grants only once
+func (repo *PgLeaveRepository) InsertLeaveAccrualGrants(
+	organizationId int,
+	createdBy int,
+	grants []param.LeaveAccrualGrant,
+) (int, error) {
+	var insertedCount int
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		runIds := make(map[string]int)
+		totalHours := make(map[string]float64)
+		for _, grant := range grants {
+			totalHours[leaveAccrualKey(grant.PolicyId, grant.UserId, grant.Period)] += grant.Hour
+		}
+
+		inserted := make(map[string]bool)
+		for _, grant := range grants {
+			key := leaveAccrualKey(grant.PolicyId, grant.UserId, grant.Period)
+			isInserted, checked := inserted[key]
+			if !checked {
+				leaveAccrualRun := m.LeaveAccrualRun{
+					OrganizationId: organizationId,
+					PolicyId:       grant.PolicyId,
+					UserId:         grant.UserId,
+					Period:         grant.Period,
+					Hour:           totalHours[key],
+				}
+
+				res, errTx := tx.Model(&leaveAccrualRun).OnConflict("DO NOTHING").Insert()
+				if errTx != nil {
+					return errTx
+				}
+
+				isInserted = res.RowsAffected() > 0
+				inserted[key] = isInserted
+				runIds[key] = leaveAccrualRun.ID
+			}
+
+			if !isInserted {
+				continue
+			}
+
+			leaveBonus := m.UserLeaveBonus{
+				OrganizationID:       organizationId,
+				UserID:               grant.UserId,
+				LeaveBonusTypeID:     grant.LeaveBonusTypeId,
+				CreatedBy:            createdBy,
+				UpdatedBy:            createdBy,
+				YearBelong:           grant.YearBelong,
+				Reason:               grant.Reason,
+				Hour:                 grant.Hour,
+				HourRemaining:        grant.Hour,
+				ExpireBonusLeaveDate: grant.ExpireBonusLeaveDate,
+			}
+
+			if errTx := tx.Insert(&leaveBonus); errTx != nil {
+				return errTx
+			}
+
+			leaveLedgerEntry := leaveBonusLedgerEntry(leaveBonus)
+			leaveLedgerEntry.SourceType = cf.AccrualRunLedgerSource
+			leaveLedgerEntry.SourceId = runIds[key]
+			if errTx := repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry); errTx != nil {
+				return errTx
+			}
+
+			insertedCount++
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+		return 0, err
+	}
+
+	return insertedCount, nil
+}
+
+func leaveAccrualKey(policyId int, userId int, period string) string {
+	return strconv.Itoa(policyId) + ":" + strconv.Itoa(userId) + ":" + period
+}
+
+// SelectLeaveLedgerEntries : Select ledger entries of organization, oldest first so that running balance can be computed
+func (repo *PgLeaveRepository) SelectLeaveLedgerEntries(
+	organizationId int,
+	params *param.LeaveLedgerParams,
+) ([]param.LeaveLedgerRecords, int, error) {
+	var records []param.LeaveLedgerRecords
+	queryObj := repo.DB.Model(&m.LeaveLedgerEntry{})
+	queryObj.Column("lle.id", "lle.user_id", "lle.entry_type", "lle.leave_bonus_type_id", "lle.year_belong", "lle.hour",
+		"lle.effective_date", "lle.source_type", "lle.source_id", "lle.note", "lle.created_at")
+	queryObj.ColumnExpr("up.first_name || ' ' || up.last_name full_name")
+	queryObj.Join("JOIN user_profiles AS up ON up.user_id = lle.user_id")
+	queryObj.Where("lle.organization_id = ?", organizationId)
+
+	if params.UserId != 0 {
+		queryObj.Where("lle.user_id = ?", params.UserId)
+	}
+
+	if params.EntryType != 0 {
+		queryObj.Where("lle.entry_type = ?", params.EntryType)
+	}
+
+	if params.DateFrom != "" {
+		queryObj.Where("lle.effective_date >= to_date(?,'YYYY-MM-DD')", params.DateFrom)
+	}
+
+	if params.DateTo != "" {
+		queryObj.Where("lle.effective_date <= to_date(?,'YYYY-MM-DD')", params.DateTo)
+	}
+
+	queryObj.Order("lle.effective_date ASC", "lle.id ASC")
+	queryObj.Offset((params.CurrentPage - 1) * params.RowPerPage)
+	queryObj.Limit(params.RowPerPage)
+	totalRow, err := queryObj.SelectAndCount(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, totalRow, err
+}
+
+// SelectLeaveLedgerBalance : Sum hours of entries of user which are effective at date
+func (repo *PgLeaveRepository) SelectLeaveLedgerBalance(organizationId int, userId int, date string) (float64, error) {
+	var hour float64
+	err := repo.DB.Model(&m.LeaveLedgerEntry{}).
+		ColumnExpr("COALESCE(SUM(hour), 0)").
+		Where("organization_id = ?", organizationId).
+		Where("user_id = ?", userId).
+		Where("effective_date <= to_date(?,'YYYY-MM-DD')", date).
+		Select(&hour)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return hour, err
+}
+
+// SelectLeaveLedgerBalances : Sum hours of ledger by user and year, userId 0 is all users of organization
+func (repo *PgLeaveRepository) SelectLeaveLedgerBalances(organizationId int, userId int) ([]param.LeaveBalanceRecord, error) {
+	var records []param.LeaveBalanceRecord
+	queryObj := repo.DB.Model(&m.LeaveLedgerEntry{}).
+		Column("user_id", "year_belong").
+		ColumnExpr("SUM(hour) AS hour").
+		Where("organization_id = ?", organizationId)
+
+	if userId != 0 {
+		queryObj.Where("user_id = ?", userId)
+	}
+
+	err := queryObj.Group("user_id", "year_belong").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectLeaveHistoryBalances : Sum hours by user and year from leave bonuses and leave requests which ledger is built from
+func (repo *PgLeaveRepository) SelectLeaveHistoryBalances(organizationId int, userId int) ([]param.LeaveBalanceRecord, error) {
+	var records []param.LeaveBalanceRecord
+	q := `SELECT COALESCE(b.user_id, r.user_id) AS user_id, COALESCE(b.year_belong, r.year_belong) AS year_belong,
+		COALESCE(b.hour, 0) - COALESCE(r.hour, 0) AS hour
+		FROM (
+			SELECT user_id, year_belong, SUM(hour) AS hour FROM user_leave_bonus
+			WHERE organization_id = ?0 AND deleted_at IS NULL AND (?1 = 0 OR user_id = ?1)
+			GROUP BY user_id, year_belong
+		) AS b
+		FULL OUTER JOIN (
+			SELECT user_id, date_part('year', datetime_leave_from)::integer AS year_belong, SUM(hour) AS hour FROM user_leave_requests
+			WHERE organization_id = ?0 AND deleted_at IS NULL AND (?1 = 0 OR user_id = ?1)
+			GROUP BY user_id, date_part('year', datetime_leave_from)
+		) AS r ON r.user_id = b.user_id AND r.year_belong = b.year_belong`
+
+	_, err := repo.DB.Query(&records, q, organizationId, userId)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// InsertLeaveLedgerEntries : Append entries to ledger
+func (repo *PgLeaveRepository) InsertLeaveLedgerEntries(leaveLedgerEntries []m.LeaveLedgerEntry) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		for _, leaveLedgerEntry := range leaveLedgerEntries {
+			if err := repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// insertLeaveLedgerEntryWithTx : Entry without hour does not change balance and is not recorded
+func (repo *PgLeaveRepository) insertLeaveLedgerEntryWithTx(tx *pg.Tx, leaveLedgerEntry m.LeaveLedgerEntry) error {
+	if leaveLedgerEntry.Hour == 0 {
+		return nil
+	}
+
+	if leaveLedgerEntry.EffectiveDate.IsZero() {
+		leaveLedgerEntry.EffectiveDate = time.Now()
+	}
+
+	err := tx.Insert(&leaveLedgerEntry)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func leaveBonusLedgerEntry(leaveBonus m.UserLeaveBonus) m.LeaveLedgerEntry {
+	entryType := cf.GrantLedgerEntry
+	switch {
+	case leaveBonus.LeaveBonusTypeID == cf.ClearLeave:
+		entryType = cf.ExpiryLedgerEntry
+	case leaveBonus.LeaveBonusTypeID == cf.OvertimeLeave:
+		entryType = cf.OvertimeLedgerEntry
+	case leaveBonus.Hour < 0:
+		entryType = cf.AdjustmentLedgerEntry
+	}
+
+	return m.LeaveLedgerEntry{
+		OrganizationId:   leaveBonus.OrganizationID,
+		UserId:           leaveBonus.UserID,
+		EntryType:        entryType,
+		LeaveBonusTypeId: leaveBonus.LeaveBonusTypeID,
+		YearBelong:       leaveBonus.YearBelong,
+		Hour:             leaveBonus.Hour,
+		SourceType:       cf.LeaveBonusLedgerSource,
+		SourceId:         leaveBonus.ID,
+		Note:             leaveBonus.Reason,
+		CreatedBy:        leaveBonus.CreatedBy,
+	}
+}
+
+func (repo *PgLeaveRepository) InsertLeaveRequestChange(leaveRequestChange *m.LeaveRequestChange) error {
+	err := repo.DB.Insert(leaveRequestChange)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) SelectLeaveRequestChangeById(id int) (m.LeaveRequestChange, error) {
+	var leaveRequestChange m.LeaveRequestChange
+	err := repo.DB.Model(&leaveRequestChange).
+		Where("id = ?", id).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return leaveRequestChange, err
+}
+
+func (repo *PgLeaveRepository) CountPendingLeaveRequestChanges(leaveRequestId int) (int, error) {
+	count, err := repo.DB.Model(&m.LeaveRequestChange{}).
+		Where("leave_request_id = ?", leaveRequestId).
+		Where("status = ?", cf.PendingRequestStatus).
+		Count()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return count, err
+}
+
+func (repo *PgLeaveRepository) SelectLeaveRequestChanges(
+	organizationId int,
+	params *param.GetLeaveChangesParams,
+) ([]param.LeaveChangeRecords, int, error) {
+	var records []param.LeaveChangeRecords
+	queryObj := repo.DB.Model(&m.LeaveRequestChange{})
+	queryObj.Column("lrc.id", "lrc.leave_request_id", "lrc.user_id", "lrc.change_type", "lrc.datetime_leave_from",
+		"lrc.datetime_leave_to", "lrc.hour", "lrc.reason", "lrc.status", "lrc.created_at")
+	queryObj.ColumnExpr("ulr.datetime_leave_from AS leave_from, ulr.datetime_leave_to AS leave_to, ulr.hour AS leave_hour")
+	queryObj.ColumnExpr("up.first_name || ' ' || up.last_name full_name")
+	queryObj.Join("JOIN user_leave_requests AS ulr ON ulr.id = lrc.leave_request_id")
+	queryObj.Join("JOIN user_profiles AS up ON up.user_id = lrc.user_id")
+	queryObj.Where("lrc.organization_id = ?", organizationId)
+
+	if params.Status != 0 {
+		queryObj.Where("lrc.status = ?", params.Status)
+	}
+
+	if params.UserId != 0 {
+		queryObj.Where("lrc.user_id = ?", params.UserId)
+	}
+
+	queryObj.Order("lrc.created_at DESC")
+	queryObj.Offset((params.CurrentPage - 1) * params.RowPerPage)
+	queryObj.Limit(params.RowPerPage)
+	totalRow, err := queryObj.SelectAndCount(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, totalRow, err
+}
+
+func (repo *PgLeaveRepository) UpdateLeaveRequestChangeStatus(id int, status int, approvedBy int) error {
+	leaveRequestChange := m.LeaveRequestChange{
+		Status:     status,
+		ApprovedBy: approvedBy,
+		ApprovedAt: utils.TimeNowUTC(),
+	}
+
+	_, err := repo.DB.Model(&leaveRequestChange).
+		Column("status", "approved_by", "approved_at", "updated_at").
+		Where("id = ?", id).
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// AmendLeaveRequest : Change period and hour of leave request, old usage is reversed and new usage is recorded in ledger
+func (repo *PgLeaveRepository) AmendLeaveRequest(
+	leaveRequestId int,
+	datetimeLeaveFrom time.Time,
+	datetimeLeaveTo time.Time,
+	hour float64,
+	updatedBy int,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var leaveRequest m.UserLeaveRequest
+		err := tx.Model(&leaveRequest).
+			Column("id", "organization_id", "user_id", "datetime_leave_from", "hour", "reason").
+			Where("id = ?", leaveRequestId).
+			Select()
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Model(&m.UserLeaveRequest{
+			DatetimeLeaveFrom: datetimeLeaveFrom,
+			DatetimeLeaveTo:   datetimeLeaveTo,
+			Hour:              hour,
+			UpdatedBy:         updatedBy,
+		}).
+			Column("datetime_leave_from", "datetime_leave_to", "hour", "updated_by", "updated_at").
+			Where("id = ?", leaveRequestId).
+			Update()
+		if err != nil {
+			return err
+		}
+
+		leaveLedgerEntries := []m.LeaveLedgerEntry{
+			{
+				EntryType:     cf.ReversalLedgerEntry,
+				YearBelong:    leaveRequest.DatetimeLeaveFrom.Year(),
+				Hour:          leaveRequest.Hour,
+				EffectiveDate: leaveRequest.DatetimeLeaveFrom,
+				Note:          "Amend leave request",
+			},
+			{
+				EntryType:     cf.UsageLedgerEntry,
+				YearBelong:    datetimeLeaveFrom.Year(),
+				Hour:          -hour,
+				EffectiveDate: datetimeLeaveFrom,
+				Note:          leaveRequest.Reason,
+			},
+		}
+
+		for _, leaveLedgerEntry := range leaveLedgerEntries {
+			leaveLedgerEntry.OrganizationId = leaveRequest.OrganizationID
+			leaveLedgerEntry.UserId = leaveRequest.UserID
+			leaveLedgerEntry.SourceType = cf.LeaveRequestLedgerSource
+			leaveLedgerEntry.SourceId = leaveRequest.ID
+			leaveLedgerEntry.CreatedBy = updatedBy
+			if err := repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// InsertLeaveNotifications : Insert notification of leave to receivers except sender
+func (repo *PgLeaveRepository) InsertLeaveNotifications(
+	organizationId int,
+	sender int,
+	notificationRepo rp.NotificationRepository,
+	receivers []int,
+	notificationParams param.InsertNotificationParam,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		for _, receiver := range receivers {
+			if receiver == sender {
+				continue
+			}
+
+			notificationParams.Receiver = receiver
+			if err := notificationRepo.InsertNotificationWithTx(tx, organizationId, sender, &notificationParams); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// SaveProjectStaffingRule : Insert staffing rule of project or update it when project has one
+func (repo *PgLeaveRepository) SaveProjectStaffingRule(organizationId int, params *param.SaveProjectStaffingRuleParams) error {
+	projectStaffingRule := m.ProjectStaffingRule{
+		OrganizationId: organizationId,
+		ProjectId:      params.ProjectId,
+		MinStaff:       params.MinStaff,
+		ConflictAction: params.ConflictAction,
+	}
+
+	_, err := repo.DB.Model(&projectStaffingRule).
+		OnConflict("(project_id) DO UPDATE").
+		Set("min_staff = EXCLUDED.min_staff").
+		Set("conflict_action = EXCLUDED.conflict_action").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) DeleteProjectStaffingRule(organizationId int, projectId int) error {
+	_, err := repo.DB.Model(&m.ProjectStaffingRule{}).
+		Where("organization_id = ?", organizationId).
+		Where("project_id = ?", projectId).
+		ForceDelete()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) SelectProjectStaffingRules(organizationId int) ([]param.ProjectStaffingRuleRecords, error) {
+	var records []param.ProjectStaffingRuleRecords
+	err := repo.DB.Model(&m.ProjectStaffingRule{}).
+		Column("psr.project_id", "psr.min_staff", "psr.conflict_action").
+		ColumnExpr("prj.name AS project_name").
+		Join("JOIN projects AS prj ON prj.id = psr.project_id").
+		Where("psr.organization_id = ?", organizationId).
+		Order("prj.name ASC").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+func (repo *PgLeaveRepository) SelectProjectStaffingRulesByProjectIds(projectIds []int) ([]m.ProjectStaffingRule, error) {
+	var projectStaffingRules []m.ProjectStaffingRule
+	if len(projectIds) == 0 {
+		return projectStaffingRules, nil
+	}
+
+	err := repo.DB.Model(&projectStaffingRules).
+		Column("project_id", "min_staff", "conflict_action").
+		Where("project_id IN (?)", pg.In(projectIds)).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return projectStaffingRules, err
+}
+
+// SelectTeamLeaveRequests : Select leave requests overlapping dates, filtered by users when userIds is not empty
+// and by branch when branch is not 0
+func (repo *PgLeaveRepository) SelectTeamLeaveRequests(
+	organizationId int,
+	userIds []int,
+	branch int,
+	dateFrom time.Time,
+	dateTo time.Time,
+) ([]param.TeamLeaveRecords, error) {
+	var records []param.TeamLeaveRecords
+	queryObj := repo.DB.Model(&m.UserLeaveRequest{})
+	queryObj.Column("ulr.id", "ulr.user_id", "ulr.leave_request_type_id", "ulr.datetime_leave_from", "ulr.datetime_leave_to", "ulr.hour")
+	queryObj.ColumnExpr("up.first_name || ' ' || up.last_name full_name")
+	queryObj.Join("JOIN user_profiles AS up ON up.user_id = ulr.user_id")
+	queryObj.Where("ulr.organization_id = ?", organizationId)
+	queryObj.Where("date(ulr.datetime_leave_from) <= DATE(?)", dateTo)
+	queryObj.Where("date(COALESCE(ulr.datetime_leave_to, ulr.datetime_leave_from)) >= DATE(?)", dateFrom)
+
+	if len(userIds) > 0 {
+		queryObj.Where("ulr.user_id IN (?)", pg.In(userIds))
+	}
+
+	if branch != 0 {
+		queryObj.Where("up.branch = ?", branch)
+	}
+
+	err := queryObj.Order("ulr.datetime_leave_from ASC").Select(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectDueKanbanTasks : Select undone tasks of projects assigned to user which are due between dates
+func (repo *PgLeaveRepository) SelectDueKanbanTasks(
+	projectIds []int,
+	userId int,
+	dateFrom time.Time,
+	dateTo time.Time,
+) ([]param.DueTaskRecords, error) {
+	var records []param.DueTaskRecords
+	if len(projectIds) == 0 {
+		return records, nil
+	}
+
+	err := repo.DB.Model(&m.KanbanTask{}).
+		Column("kt.id", "kt.title", "kt.due_date").
+		ColumnExpr("kb.project_id").
+		Join("JOIN kanban_lists AS kl ON kl.id = kt.kanban_list_id").
+		Join("JOIN kanban_boards AS kb ON kb.id = kl.kanban_board_id").
+		Where("kb.project_id IN (?)", pg.In(projectIds)).
+		Where("? = ANY(kt.assignees)", userId).
+		Where("kt.status != ?", cf.DONE).
+		Where("date(kt.due_date) >= DATE(?)", dateFrom).
+		Where("date(kt.due_date) <= DATE(?)", dateTo).
+		Order("kt.due_date ASC").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectLeaveBonusBuckets : Select bonuses of user which have not expired, expiring first comes first
+func (repo *PgLeaveRepository) SelectLeaveBonusBuckets(organizationId int, userId int) ([]param.LeaveBonusBucketRecords, error) {
+	var records []param.LeaveBonusBucketRecords
+	err := repo.DB.Model(&m.UserLeaveBonus{}).
+		Column("ulb.id", "ulb.leave_bonus_type_id", "ulb.hour", "ulb.hour_remaining", "ulb.expire_bonus_leave_date").
+		Where("ulb.organization_id = ?", organizationId).
+		Where("ulb.user_id = ?", userId).
+		Where("ulb.hour_remaining IS NOT NULL").
+		Where("ulb.expire_bonus_leave_date IS NULL OR date(ulb.expire_bonus_leave_date) >= current_date").
+		OrderExpr("ulb.expire_bonus_leave_date ASC NULLS LAST").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SaveStatutoryLeaveRule : Insert statutory leave rule of leave bonus type or update it when organization has one
+func (repo *PgLeaveRepository) SaveStatutoryLeaveRule(organizationId int, params *param.SaveStatutoryLeaveRuleParams) error {
+	statutoryLeaveRule := m.StatutoryLeaveRule{
+		OrganizationId:     organizationId,
+		LeaveBonusTypeId:   params.LeaveBonusTypeId,
+		EntitlementDay:     params.EntitlementDay,
+		IsCalendarDay:      params.IsCalendarDay,
+		MaxMonth:           params.MaxMonth,
+		RequiredDocuments:  params.RequiredDocuments,
+		CountAgainstAnnual: params.CountAgainstAnnual,
+	}
+
+	_, err := repo.DB.Model(&statutoryLeaveRule).
+		OnConflict("(organization_id, leave_bonus_type_id) DO UPDATE").
+		Set("entitlement_day = EXCLUDED.entitlement_day").
+		Set("is_calendar_day = EXCLUDED.is_calendar_day").
+		Set("max_month = EXCLUDED.max_month").
+		Set("required_documents = EXCLUDED.required_documents").
+		Set("count_against_annual = EXCLUDED.count_against_annual").
+		Set("updated_at = EXCLUDED.updated_at").
+		Insert()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) DeleteStatutoryLeaveRule(organizationId int, leaveBonusTypeId int) error {
+	_, err := repo.DB.Model(&m.StatutoryLeaveRule{}).
+		Where("organization_id = ?", organizationId).
+		Where("leave_bonus_type_id = ?", leaveBonusTypeId).
+		ForceDelete()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) SelectStatutoryLeaveRules(organizationId int) ([]m.StatutoryLeaveRule, error) {
+	var statutoryLeaveRules []m.StatutoryLeaveRule
+	err := repo.DB.Model(&statutoryLeaveRules).
+		Where("organization_id = ?", organizationId).
+		Order("leave_bonus_type_id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return statutoryLeaveRules, err
+}
+
+func (repo *PgLeaveRepository) SelectStatutoryLeaveRule(organizationId int, leaveBonusTypeId int) (m.StatutoryLeaveRule, error) {
+	var statutoryLeaveRule m.StatutoryLeaveRule
+	err := repo.DB.Model(&statutoryLeaveRule).
+		Where("organization_id = ?", organizationId).
+		Where("leave_bonus_type_id = ?", leaveBonusTypeId).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return statutoryLeaveRule, err
+}
+
+// SumStatutoryLeaveDays : Sum days which user has taken for event, leave whose leave request was removed is not counted
+func (repo *PgLeaveRepository) SumStatutoryLeaveDays(organizationId int, userId int, leaveBonusTypeId int, eventDate string) (float64, error) {
+	var day float64
+	err := repo.DB.Model(&m.StatutoryLeave{}).
+		ColumnExpr("COALESCE(SUM(stl.day), 0)").
+		Join("JOIN user_leave_requests AS ulr ON ulr.id = stl.leave_request_id AND ulr.deleted_at IS NULL").
+		Where("stl.organization_id = ?", organizationId).
+		Where("stl.user_id = ?", userId).
+		Where("stl.leave_bonus_type_id = ?", leaveBonusTypeId).
+		Where("stl.event_date = DATE(?)", eventDate).
+		Select(&day)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return day, err
+}
+
+// InsertStatutoryLeave : Insert leave request of statutory leave, its usage in ledger when it counts against
+// annual leave, statutory leave and its documents
+func (repo *PgLeaveRepository) InsertStatutoryLeave(
+	statutoryLeave *m.StatutoryLeave,
+	leaveRequest *m.UserLeaveRequest,
+	documents []m.StatutoryLeaveDocument,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		if err := tx.Insert(leaveRequest); err != nil {
+			return err
+		}
+
+		err := repo.insertLeaveLedgerEntryWithTx(tx, m.LeaveLedgerEntry{
+			OrganizationId:   leaveRequest.OrganizationID,
+			UserId:           leaveRequest.UserID,
+			EntryType:        cf.UsageLedgerEntry,
+			LeaveBonusTypeId: statutoryLeave.LeaveBonusTypeId,
+			YearBelong:       leaveRequest.DatetimeLeaveFrom.Year(),
+			Hour:             -leaveRequest.Hour,
+			EffectiveDate:    leaveRequest.DatetimeLeaveFrom,
+			SourceType:       cf.LeaveRequestLedgerSource,
+			SourceId:         leaveRequest.ID,
+			Note:             leaveRequest.Reason,
+			CreatedBy:        leaveRequest.CreatedBy,
+		})
+		if err != nil {
+			return err
+		}
+
+		statutoryLeave.LeaveRequestId = leaveRequest.ID
+		if err := tx.Insert(statutoryLeave); err != nil {
+			return err
+		}
+
+		for i := range documents {
+			documents[i].StatutoryLeaveId = statutoryLeave.ID
+			if err := tx.Insert(&documents[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// SelectStatutoryLeaves : Select statutory leaves whose leave request has not been removed
+func (repo *PgLeaveRepository) SelectStatutoryLeaves(
+	organizationId int,
+	params *param.GetStatutoryLeavesParams,
+) ([]param.StatutoryLeaveRecords, int, error) {
+	var records []param.StatutoryLeaveRecords
+	queryObj := repo.DB.Model(&m.StatutoryLeave{})
+	queryObj.Column("stl.id", "stl.user_id", "stl.leave_bonus_type_id", "stl.leave_request_id", "stl.event_date",
+		"stl.datetime_leave_from", "stl.datetime_leave_to", "stl.day", "stl.hour", "stl.count_against_annual", "stl.reason")
+	queryObj.ColumnExpr("up.first_name || ' ' || up.last_name full_name")
+	queryObj.Join("JOIN user_leave_requests AS ulr ON ulr.id = stl.leave_request_id AND ulr.deleted_at IS NULL")
+	queryObj.Join("JOIN user_profiles AS up ON up.user_id = stl.user_id")
+	queryObj.Where("stl.organization_id = ?", organizationId)
+
+	if params.UserId != 0 {
+		queryObj.Where("stl.user_id = ?", params.UserId)
+	}
+
+	if params.LeaveBonusTypeId != 0 {
+		queryObj.Where("stl.leave_bonus_type_id = ?", params.LeaveBonusTypeId)
+	}
+
+	if params.Year != 0 {
+		queryObj.Where("EXTRACT(YEAR FROM stl.datetime_leave_from) <= ?", params.Year)
+		queryObj.Where("EXTRACT(YEAR FROM stl.datetime_leave_to) >= ?", params.Year)
+	}
+
+	queryObj.Order("stl.datetime_leave_from DESC")
+	queryObj.Offset((params.CurrentPage - 1) * params.RowPerPage)
+	queryObj.Limit(params.RowPerPage)
+	totalRow, err := queryObj.SelectAndCount(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, totalRow, err
+}
+
+func (repo *PgLeaveRepository) SelectStatutoryLeaveDocuments(statutoryLeaveIds []int) ([]m.StatutoryLeaveDocument, error) {
+	var documents []m.StatutoryLeaveDocument
+	if len(statutoryLeaveIds) == 0 {
+		return documents, nil
+	}
+
+	err := repo.DB.Model(&documents).
+		Column("id", "statutory_leave_id", "document_name", "file_name").
+		Where("statutory_leave_id IN (?)", pg.In(statutoryLeaveIds)).
+		Order("id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return documents, err
+}
+
+func (repo *PgLeaveRepository) SelectStatutoryLeaveDocumentById(id int) (param.StatutoryLeaveDocumentRecord, error) {
+	var record param.StatutoryLeaveDocumentRecord
+	err := repo.DB.Model(&m.StatutoryLeaveDocument{}).
+		Column("sld.id", "sld.document_name", "sld.file_name").
+		ColumnExpr("stl.organization_id, stl.user_id").
+		Join("JOIN statutory_leaves AS stl ON stl.id = sld.statutory_leave_id").
+		Where("sld.id = ?", id).
+		Limit(1).
+		Select(&record)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return record, err
+}
+
+// SelectLeaveDelegates : Select delegates of users who are on leave on date, all users of organization when userIds is empty
+func (repo *PgLeaveRepository) SelectLeaveDelegates(organizationId int, userIds []int, date string) ([]param.LeaveDelegateRecords, error) {
+	var records []param.LeaveDelegateRecords
+	queryObj := repo.leaveDelegateQuery(organizationId, date)
+	if len(userIds) > 0 {
+		queryObj.Where("ulr.user_id IN (?)", pg.In(userIds))
+	}
+
+	err := queryObj.Select(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectLeaveDelegatesByDelegate : Select leaves on date which delegate user covers
+func (repo *PgLeaveRepository) SelectLeaveDelegatesByDelegate(organizationId int, delegateUserId int, date string) ([]param.LeaveDelegateRecords, error) {
+	var records []param.LeaveDelegateRecords
+	err := repo.leaveDelegateQuery(organizationId, date).
+		Where("ulr.delegate_user_id = ?", delegateUserId).
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectUnnotifiedLeaveDelegates : Select leaves which have started on date and whose delegate has not been notified
+func (repo *PgLeaveRepository) SelectUnnotifiedLeaveDelegates(organizationId int, date string) ([]param.LeaveDelegateRecords, error) {
+	var records []param.LeaveDelegateRecords
+	err := repo.leaveDelegateQuery(organizationId, date).
+		Where("ulr.delegate_notified_at IS NULL").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+func (repo *PgLeaveRepository) UpdateLeaveDelegatesNotified(leaveRequestIds []int) error {
+	if len(leaveRequestIds) == 0 {
+		return nil
+	}
+
+	_, err := repo.DB.Model(&m.UserLeaveRequest{DelegateNotifiedAt: utils.TimeNowUTC()}).
+		Column("delegate_notified_at").
+		Where("id IN (?)", pg.In(leaveRequestIds)).
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) leaveDelegateQuery(organizationId int, date string) *orm.Query {
+	return repo.DB.Model(&m.UserLeaveRequest{}).
+		Column("ulr.user_id", "ulr.delegate_user_id", "ulr.handover_note", "ulr.datetime_leave_from", "ulr.datetime_leave_to").
+		ColumnExpr("ulr.id AS leave_request_id").
+		ColumnExpr("up.first_name || ' ' || up.last_name full_name").
+		ColumnExpr("dup.first_name || ' ' || dup.last_name delegate_name").
+		Join("JOIN user_profiles AS up ON up.user_id = ulr.user_id").
+		Join("JOIN user_profiles AS dup ON dup.user_id = ulr.delegate_user_id").
+		Where("ulr.organization_id = ?", organizationId).
+		Where("ulr.delegate_user_id IS NOT NULL").
+		Where("ulr.leave_request_type_id IN (?)", pg.In(cf.AbsentLeaveTypes)).
+		Where("date(ulr.datetime_leave_from) <= DATE(?)", date).
+		Where("date(ulr.datetime_leave_to) >= DATE(?)", date).
+		Order("ulr.datetime_leave_from ASC")
+}
+
+// SelectBulkLeaveUserIds : Select users of organization or branch who have no absent leave overlapping dates
+func (repo *PgLeaveRepository) SelectBulkLeaveUserIds(
+	organizationId int,
+	branchId int,
+	datetimeLeaveFrom time.Time,
+	datetimeLeaveTo time.Time,
+) ([]int, error) {
+	var userIds []int
+	queryObj := repo.DB.Model(&m.User{})
+	queryObj.Column("usr.id")
+	queryObj.Join("JOIN user_profiles AS up ON up.user_id = usr.id")
+	queryObj.Where("usr.organization_id = ?", organizationId)
+	queryObj.Where("up.status != ?", cf.SeveranceStatus)
+	queryObj.Where("NOT EXISTS (SELECT 1 FROM user_leave_requests AS ulr WHERE ulr.user_id = usr.id AND ulr.deleted_at IS NULL "+
+		"AND ulr.leave_request_type_id IN (?) AND date(ulr.datetime_leave_from) <= DATE(?) "+
+		"AND date(COALESCE(ulr.datetime_leave_to, ulr.datetime_leave_from)) >= DATE(?))",
+		pg.In(cf.AbsentLeaveTypes), datetimeLeaveTo, datetimeLeaveFrom)
+
+	if branchId != 0 {
+		queryObj.Where("up.branch = ?", branchId)
+	}
+
+	err := queryObj.Order("usr.id ASC").Select(&userIds)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return userIds, err
+}
+
+// InsertLeaveBatch : Insert batch, leave request with its usage in ledger and notification for every user in one transaction.
+// Subtracted hours are taken from bonuses which expire first, as for single leave request
+func (repo *PgLeaveRepository) InsertLeaveBatch(
+	leaveBatch *m.LeaveBatch,
+	userIds []int,
+	notificationRepo rp.NotificationRepository,
+	notificationParams param.InsertNotificationParam,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		leaveBatch.UserCount = len(userIds)
+		if err := tx.Insert(leaveBatch); err != nil {
+			return err
+		}
+
+		for _, userId := range userIds {
+			leaveRequest := m.UserLeaveRequest{
+				OrganizationID:       leaveBatch.OrganizationId,
+				UserID:               userId,
+				LeaveRequestTypeID:   leaveBatch.LeaveRequestTypeId,
+				DatetimeLeaveFrom:    leaveBatch.DatetimeLeaveFrom,
+				DatetimeLeaveTo:      leaveBatch.DatetimeLeaveTo,
+				CreatedBy:            leaveBatch.CreatedBy,
+				UpdatedBy:            leaveBatch.CreatedBy,
+				SubtractDayOffTypeID: leaveBatch.SubtractDayOffTypeId,
+				Reason:               leaveBatch.Reason,
+				Hour:                 leaveBatch.Hour,
+				LeaveBatchId:         leaveBatch.ID,
+			}
+			if err := tx.Insert(&leaveRequest); err != nil {
+				return err
+			}
+
+			err := repo.insertLeaveLedgerEntryWithTx(tx, m.LeaveLedgerEntry{
+				OrganizationId: leaveRequest.OrganizationID,
+				UserId:         leaveRequest.UserID,
+				EntryType:      cf.UsageLedgerEntry,
+				YearBelong:     leaveRequest.DatetimeLeaveFrom.Year(),
+				Hour:           -leaveRequest.Hour,
+				EffectiveDate:  leaveRequest.DatetimeLeaveFrom,
+				SourceType:     cf.LeaveRequestLedgerSource,
+				SourceId:       leaveRequest.ID,
+				Note:           leaveRequest.Reason,
+				CreatedBy:      leaveRequest.CreatedBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := repo.consumeLeaveHourWithTx(tx, leaveRequest.OrganizationID, userId, leaveRequest.Hour); err != nil {
+				return err
+			}
+
+			if userId == leaveBatch.CreatedBy {
+				continue
+			}
+
+			notificationParams.Receiver = userId
+			if err := notificationRepo.InsertNotificationWithTx(tx, leaveBatch.OrganizationId, leaveBatch.CreatedBy, &notificationParams); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) UpdateLeaveBatchCalendarEvent(id int, calendarEventId string) error {
+	_, err := repo.DB.Model(&m.LeaveBatch{CalendarEventId: calendarEventId}).
+		Column("calendar_event_id", "updated_at").
+		Where("id = ?", id).
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) SelectLeaveBatchById(id int) (m.LeaveBatch, error) {
+	var leaveBatch m.LeaveBatch
+	err := repo.DB.Model(&leaveBatch).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return leaveBatch, err
+}
+
+// UndoLeaveBatch : Remove leave requests of batch with reversal in ledger and give hours back to bonuses,
+// returns users whose leave was removed
+func (repo *PgLeaveRepository) UndoLeaveBatch(leaveBatchId int, undoneBy int) ([]int, error) {
+	var userIds []int
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var leaveRequests []m.UserLeaveRequest
+		err := tx.Model(&leaveRequests).
+			Column("id", "organization_id", "user_id", "datetime_leave_from", "hour").
+			Where("leave_batch_id = ?", leaveBatchId).
+			Select()
+		if err != nil {
+			return err
+		}
+
+		if len(leaveRequests) > 0 {
+			_, err = tx.Model(&m.UserLeaveRequest{}).
+				Where("leave_batch_id = ?", leaveBatchId).
+				Delete()
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, leaveRequest := range leaveRequests {
+			userIds = append(userIds, leaveRequest.UserID)
+			err := repo.insertLeaveLedgerEntryWithTx(tx, m.LeaveLedgerEntry{
+				OrganizationId: leaveRequest.OrganizationID,
+				UserId:         leaveRequest.UserID,
+				EntryType:      cf.ReversalLedgerEntry,
+				YearBelong:     leaveRequest.DatetimeLeaveFrom.Year(),
+				Hour:           leaveRequest.Hour,
+				EffectiveDate:  leaveRequest.DatetimeLeaveFrom,
+				SourceType:     cf.LeaveRequestLedgerSource,
+				SourceId:       leaveRequest.ID,
+				Note:           "Undo bulk leave",
+				CreatedBy:      undoneBy,
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := repo.refundLeaveHourWithTx(tx, leaveRequest.OrganizationID, leaveRequest.UserID, leaveRequest.Hour); err != nil {
+				return err
+			}
+		}
+
+		_, err = tx.Model(&m.LeaveBatch{UndoneBy: undoneBy, UndoneAt: utils.TimeNowUTC()}).
+			Column("undone_by", "undone_at", "updated_at").
+			Where("id = ?", leaveBatchId).
+			Update()
+
+		return err
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return userIds, err
+}
+
+// consumeLeaveHourWithTx : Lower hour remaining of valid bonuses of user, bonus which expires first is used first
+func (repo *PgLeaveRepository) consumeLeaveHourWithTx(tx *pg.Tx, organizationId int, userId int, hour float64) error {
+	if hour <= 0 {
+		return nil
+	}
+
+	validDateRecords, err := repo.selectValidLeaveBonusesWithTx(tx, organizationId, userId)
+	if err != nil {
+		return err
+	}
+
+	for _, validDate := range validDateRecords {
+		used := validDate.HourRemaining
+		if hour < used {
+			used = hour
+		}
+
+		if used <= 0 {
+			continue
+		}
+
+		if err := repo.updateHourRemainingWithTx(tx, validDate.Id, validDate.HourRemaining-used); err != nil {
+			return err
+		}
+
+		hour -= used
+		if hour <= 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// refundLeaveHourWithTx : Give used hours back to valid bonuses of user which expire first
+func (repo *PgLeaveRepository) refundLeaveHourWithTx(tx *pg.Tx, organizationId int, userId int, hour float64) error {
+	if hour <= 0 {
+		return nil
+	}
+
+	validDateRecords, err := repo.selectValidLeaveBonusesWithTx(tx, organizationId, userId)
+	if err != nil {
+		return err
+	}
+
+	for _, validDate := range validDateRecords {
+		refunded := validDate.Hour - validDate.HourRemaining
+		if hour < refunded {
+			refunded = hour
+		}
+
+		if refunded <= 0 {
+			continue
+		}
+
+		if err := repo.updateHourRemainingWithTx(tx, validDate.Id, validDate.HourRemaining+refunded); err != nil {
+			return err
+		}
+
+		hour -= refunded
+		if hour <= 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (repo *PgLeaveRepository) selectValidLeaveBonusesWithTx(tx *pg.Tx, organizationId int, userId int) ([]param.ValidLeaveBonusRecords, error) {
+	var validLeaveBonusRecords []param.ValidLeaveBonusRecords
+	err := tx.Model(&m.UserLeaveBonus{}).
+		Column("ulb.id", "ulb.hour", "ulb.hour_remaining").
+		Where("user_id = ?", userId).
+		Where("organization_id = ?", organizationId).
+		Where("date(ulb.expire_bonus_leave_date) >= DATE(?)", time.Now()).
+		Where("ulb.hour_remaining is not null").
+		Order("ulb.expire_bonus_leave_date ASC").
+		Select(&validLeaveBonusRecords)
+
+	return validLeaveBonusRecords, err
+}
+
+func (repo *PgLeaveRepository) updateHourRemainingWithTx(tx *pg.Tx, id int, hourRemaining float64) error {
+	_, err := tx.Model(&m.UserLeaveBonus{}).
+		Set("hour_remaining = ?", hourRemaining).
+		Where("id = ?", id).
+		Update()
+
+	return err
+}
+
+func (repo *PgLeaveRepository) SelectLeaveBatches(organizationId int, params *param.GetBulkLeavesParams) ([]param.LeaveBatchRecords, int, error) {
+	var records []param.LeaveBatchRecords
+	queryObj := repo.DB.Model(&m.LeaveBatch{})
+	queryObj.Column("lvb.id", "lvb.branch_id", "lvb.leave_request_type_id", "lvb.datetime_leave_from", "lvb.datetime_leave_to",
+		"lvb.subtract_day_off_type_id", "lvb.reason", "lvb.hour", "lvb.user_count", "lvb.created_by", "lvb.undone_at", "lvb.created_at")
+	queryObj.ColumnExpr("br.name AS branch_name")
+	queryObj.ColumnExpr("up.first_name || ' ' || up.last_name created_by_name")
+	queryObj.Join("LEFT JOIN branches AS br ON br.id = lvb.branch_id")
+	queryObj.Join("JOIN user_profiles AS up ON up.user_id = lvb.created_by")
+	queryObj.Where("lvb.organization_id = ?", organizationId)
+	queryObj.Order("lvb.created_at DESC")
+	queryObj.Offset((params.CurrentPage - 1) * params.RowPerPage)
+	queryObj.Limit(params.RowPerPage)
+	totalRow, err := queryObj.SelectAndCount(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, totalRow, err
+}
+
+// settlementBonus : Leave bonus appended by settlement with entry type of its ledger entry
+type settlementBonus struct {
+	entryType  int
+	yearBelong int
+	hour       float64
+	reason     string
+	expireDate string
+}
+
+func (repo *PgLeaveRepository) SelectLeaveSettlementByYear(organizationId int, year int) (m.LeaveSettlement, error) {
+	var leaveSettlement m.LeaveSettlement
+	err := repo.DB.Model(&leaveSettlement).
+		Where("organization_id = ?", organizationId).
+		Where("year = ?", year).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return leaveSettlement, err
+}
+
+func (repo *PgLeaveRepository) SelectLeaveSettlementItems(leaveSettlementId int) ([]param.LeaveSettlementRecords, error) {
+	var records []param.LeaveSettlementRecords
+	err := repo.DB.Model(&m.LeaveSettlementItem{}).
+		Column("lsi.user_id", "lsi.unused_hour", "lsi.carry_over_hour", "lsi.payout_hour", "lsi.payout_amount", "lsi.forfeit_hour").
+		ColumnExpr("up.employee_id").
+		ColumnExpr("up.first_name || ' ' || up.last_name full_name").
+		Join("JOIN user_profiles AS up ON up.user_id = lsi.user_id").
+		Where("lsi.leave_settlement_id = ?", leaveSettlementId).
+		Order("lsi.user_id ASC").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// InsertLeaveSettlement : Save settlement and take unused hours out of settled year as leave bonuses,
+// carried hours are granted again in next year and expire at expireBonusLeaveDate.
+// Hour remaining of grants of settled year is cleared so that expiry does not take the hours out twice
+func (repo *PgLeaveRepository) InsertLeaveSettlement(
+	leaveSettlement *m.LeaveSettlement,
+	leaveSettlementItems []m.LeaveSettlementItem,
+	expireBonusLeaveDate string,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		if err := tx.Insert(leaveSettlement); err != nil {
+			return err
+		}
+
+		for _, item := range leaveSettlementItems {
+			item.LeaveSettlementId = leaveSettlement.ID
+			if err := tx.Insert(&item); err != nil {
+				return err
+			}
+
+			// Unused hours of settled year are taken out by bonuses below, so that its grants must not expire them again
+			_, err := tx.Model(&m.UserLeaveBonus{}).
+				Set("hour_remaining = 0").
+				Where("organization_id = ?", leaveSettlement.OrganizationId).
+				Where("user_id = ?", item.UserId).
+				Where("year_belong = ?", leaveSettlement.Year).
+				Where("hour_remaining IS NOT NULL").
+				Update()
+			if err != nil {
+				return err
+			}
+
+			year := strconv.Itoa(leaveSettlement.Year)
+			bonuses := []settlementBonus{
+				{cf.PayoutLedgerEntry, leaveSettlement.Year, -item.PayoutHour, "Leave payout " + year, ""},
+				{cf.ExpiryLedgerEntry, leaveSettlement.Year, -item.ForfeitHour, "Leave forfeit " + year, ""},
+				{cf.CarryOverLedgerEntry, leaveSettlement.Year, -item.CarryOverHour, "Leave carry-over " + year, ""},
+				{cf.CarryOverLedgerEntry, leaveSettlement.Year + 1, item.CarryOverHour, "Leave carry-over " + year, expireBonusLeaveDate},
+			}
+
+			for _, bonus := range bonuses {
+				if bonus.hour == 0 {
+					continue
+				}
+
+				leaveBonus := m.UserLeaveBonus{
+					OrganizationID:       leaveSettlement.OrganizationId,
+					UserID:               item.UserId,
+					LeaveBonusTypeID:     cf.AnnualLeave,
+					CreatedBy:            leaveSettlement.SignedBy,
+					UpdatedBy:            leaveSettlement.SignedBy,
+					YearBelong:           bonus.yearBelong,
+					Reason:               bonus.reason,
+					Hour:                 bonus.hour,
+					ExpireBonusLeaveDate: bonus.expireDate,
+				}
+				if bonus.hour > 0 {
+					leaveBonus.HourRemaining = bonus.hour
+				}
+
+				if err := tx.Insert(&leaveBonus); err != nil {
+					return err
+				}
+
+				leaveLedgerEntry := leaveBonusLedgerEntry(leaveBonus)
+				leaveLedgerEntry.EntryType = bonus.entryType
+				leaveLedgerEntry.SourceType = cf.SettlementLedgerSource
+				leaveLedgerEntry.SourceId = item.ID
+				if err := repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgLeaveRepository) SelectSeveranceLeaveSettlement(organizationId int, userId int) (m.SeveranceLeaveSettlement, error) {
+	var severanceLeaveSettlement m.SeveranceLeaveSettlement
+	err := repo.DB.Model(&severanceLeaveSettlement).
+		Where("organization_id = ?", organizationId).
+		Where("user_id = ?", userId).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return severanceLeaveSettlement, err
+}
+
+// SelectLeaveRequestsAfter : Select leave requests of user which end after date, leave which starts before date overlaps it
+func (repo *PgLeaveRepository) SelectLeaveRequestsAfter(userId int, date time.Time) ([]m.UserLeaveRequest, error) {
+	var leaveRequests []m.UserLeaveRequest
+	err := repo.DB.Model(&leaveRequests).
+		Column("id", "calendar_event_id", "organization_id", "user_id", "hour", "leave_request_type_id", "datetime_leave_from",
+			"datetime_leave_to", "subtract_day_off_type_id", "reason").
+		Where("user_id = ?", userId).
+		Where("DATE(datetime_leave_to) > DATE(?)", date).
+		Order("datetime_leave_from ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return leaveRequests, err
+}
+
+// InsertSeveranceLeaveSettlement : Save settlement, remove leave after severance, shorten leave which overlaps it
+// to its new end and hour, deny pending leave changes and take unearned and paid out hours out of balance by year
+func (repo *PgLeaveRepository) InsertSeveranceLeaveSettlement(
+	severanceLeaveSettlement *m.SeveranceLeaveSettlement,
+	leaveRequests []m.UserLeaveRequest,
+	amendedLeaveRequests []m.UserLeaveRequest,
+	payoutHours map[int]float64,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		if err := tx.Insert(severanceLeaveSettlement); err != nil {
+			return err
+		}
+
+		for _, leaveRequest := range leaveRequests {
+			_, err := tx.Model(&m.UserLeaveRequest{}).
+				Where("id = ?", leaveRequest.ID).
+				Delete()
+			if err != nil {
+				return err
+			}
+
+			err = repo.insertLeaveLedgerEntryWithTx(tx, m.LeaveLedgerEntry{
+				OrganizationId: leaveRequest.OrganizationID,
+				UserId:         leaveRequest.UserID,
+				EntryType:      cf.ReversalLedgerEntry,
+				YearBelong:     leaveRequest.DatetimeLeaveFrom.Year(),
+				Hour:           leaveRequest.Hour,
+				EffectiveDate:  leaveRequest.DatetimeLeaveFrom,
+				SourceType:     cf.LeaveRequestLedgerSource,
+				SourceId:       leaveRequest.ID,
+				Note:           "Cancel leave after severance",
+				CreatedBy:      severanceLeaveSettlement.SettledBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, amendedLeaveRequest := range amendedLeaveRequests {
+			var leaveRequest m.UserLeaveRequest
+			err := tx.Model(&leaveRequest).
+				Column("id", "hour").
+				Where("id = ?", amendedLeaveRequest.ID).
+				Select()
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Model(&m.UserLeaveRequest{}).
+				Set("datetime_leave_to = ?", amendedLeaveRequest.DatetimeLeaveTo).
+				Set("hour = ?", amendedLeaveRequest.Hour).
+				Set("updated_by = ?", severanceLeaveSettlement.SettledBy).
+				Set("updated_at = ?", utils.TimeNowUTC()).
+				Where("id = ?", amendedLeaveRequest.ID).
+				Update()
+			if err != nil {
+				return err
+			}
+
+			err = repo.insertLeaveLedgerEntryWithTx(tx, m.LeaveLedgerEntry{
+				OrganizationId: amendedLeaveRequest.OrganizationID,
+				UserId:         amendedLeaveRequest.UserID,
+				EntryType:      cf.ReversalLedgerEntry,
+				YearBelong:     amendedLeaveRequest.DatetimeLeaveFrom.Year(),
+				Hour:           leaveRequest.Hour - amendedLeaveRequest.Hour,
+				EffectiveDate:  severanceLeaveSettlement.DateSeverance,
+				SourceType:     cf.LeaveRequestLedgerSource,
+				SourceId:       amendedLeaveRequest.ID,
+				Note:           "Shorten leave to severance",
+				CreatedBy:      severanceLeaveSettlement.SettledBy,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.Model(&m.LeaveRequestChange{Status: cf.DenyRequestStatus, ApprovedBy: severanceLeaveSettlement.SettledBy, ApprovedAt: time.Now()}).
+			Column("status", "approved_by", "approved_at", "updated_at").
+			Where("user_id = ?", severanceLeaveSettlement.UserId).
+			Where("status = ?", cf.PendingRequestStatus).
+			Update()
+		if err != nil {
+			return err
+		}
+
+		bonuses := []settlementBonus{
+			{cf.AdjustmentLedgerEntry, severanceLeaveSettlement.DateSeverance.Year(), -severanceLeaveSettlement.UnearnedHour, "Pro-rata on severance", ""},
+		}
+
+		for year, hour := range payoutHours {
+			bonuses = append(bonuses, settlementBonus{cf.PayoutLedgerEntry, year, -hour, "Leave payout on severance", ""})
+		}
+
+		for _, bonus := range bonuses {
+			if bonus.hour == 0 {
+				continue
+			}
+
+			leaveBonus := m.UserLeaveBonus{
+				OrganizationID:   severanceLeaveSettlement.OrganizationId,
+				UserID:           severanceLeaveSettlement.UserId,
+				LeaveBonusTypeID: cf.AnnualLeave,
+				CreatedBy:        severanceLeaveSettlement.SettledBy,
+				UpdatedBy:        severanceLeaveSettlement.SettledBy,
+				YearBelong:       bonus.yearBelong,
+				Reason:           bonus.reason,
+				Hour:             bonus.hour,
+			}
+			if err := tx.Insert(&leaveBonus); err != nil {
+				return err
+			}
+
+			leaveLedgerEntry := leaveBonusLedgerEntry(leaveBonus)
+			leaveLedgerEntry.EntryType = bonus.entryType
+			leaveLedgerEntry.EffectiveDate = severanceLeaveSettlement.DateSeverance
+			leaveLedgerEntry.SourceType = cf.SeveranceLedgerSource
+			leaveLedgerEntry.SourceId = severanceLeaveSettlement.ID
+			if err := repo.insertLeaveLedgerEntryWithTx(tx, leaveLedgerEntry); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
@@ -1,8 +1,10 @@
 package overtime
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/360EntSecGroup-Skylar/excelize/v2"
@@ -35,6 +37,7 @@ type Controller struct {
 	NotificationRepo rp.NotificationRepository
 	UserProjectRepo  rp.UserProjectRepository
 	FcmTokenRepo     rp.FcmTokenRepository
+	ShiftRepo        rp.ShiftRepository
 }
 
 // NewOvertimeController : Init Overtime Controller
@@ -50,12 +53,13 @@ func NewOvertimeController(
 	notificationRepo rp.NotificationRepository,
 	userProjectRepo rp.UserProjectRepository,
 	fcmTokenRepo rp.FcmTokenRepository,
+	shiftRepo rp.ShiftRepository,
 ) (ctr *Controller) {
 	ctr = &Controller{
 		cm.BaseController{}, email.SMTPGoMail{}, afb.FirebaseCloudMessage{},
 		overtimeRepo, orgRepo, userRepo, projectRepo,
 		branchRepo, leaveRepo, holidayRepo, notificationRepo,
-		userProjectRepo, fcmTokenRepo,
+		userProjectRepo, fcmTokenRepo, shiftRepo,
 	}
 	ctr.Init(logger)
 	ctr.InitFcm()
@@ -90,6 +94,22 @@ func (ctr *Controller) CreateOvertimeRequest(c echo.Context) error {
 		})
 	}
 
+	// Delegates of managers who are on leave are notified too
+	var delegateIds []int
+	if len(usersIdGmAndManager) > 0 {
+		leaveDelegations, err := ctr.leaveRepo.SelectLeaveDelegates(userProfile.OrganizationID, usersIdGmAndManager, time.Now().Format(cf.FormatDateDatabase))
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System error",
+			})
+		}
+
+		for _, leaveDelegation := range leaveDelegations {
+			delegateIds = append(delegateIds, leaveDelegation.DelegateUserId)
+		}
+	}
+
 	var uniqueUsersId []int
 	for _, overtimeRequest := range *createOvertimeParams {
 		overtimeRequest.Status = cf.PendingRequestStatus
@@ -111,6 +131,51 @@ func (ctr *Controller) CreateOvertimeRequest(c echo.Context) error {
 		if len(overtimeRequest.UsersIdNotification) > 0 || len(usersIdGmAndManager) > 0 {
 			uniqueUsersId = utils.AppendUniqueSlice(overtimeRequest.UsersIdNotification, usersIdGmAndManager)
 		}
+		uniqueUsersId = utils.AppendUniqueSlice(uniqueUsersId, delegateIds)
+
+		from, errFrom := time.Parse(cf.FormatDateNoSec, overtimeRequest.DatetimeOvertimeFrom)
+		to, errTo := time.Parse(cf.FormatDateNoSec, overtimeRequest.DatetimeOvertimeTo)
+		if errFrom != nil || errTo != nil || !to.After(from) {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+			})
+		}
+
+		exceededCaps, exceedAction, err := ctr.checkOvertimeCap(
+			userProfile.OrganizationID,
+			overtimeRequest.UserId,
+			from,
+			to,
+			overtimeRequest.WorkAtNoon,
+			0,
+		)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System error",
+			})
+		}
+
+		if len(exceededCaps) > 0 {
+			if exceedAction == cf.BlockExceedOvertimeCap {
+				return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "Overtime request exceeds overtime cap",
+					Data:    map[string]interface{}{"exceeded_caps": exceededCaps},
+				})
+			}
+
+			overtimeRequest.ExceedCap = true
+		}
+
+		approvalChain, err := ctr.OvertimeRepo.SelectApprovalChainOfProject(userProfile.OrganizationID, overtimeRequest.ProjectId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System error",
+			})
+		}
 
 		body, link, err := ctr.OvertimeRepo.InsertOvertimeRequest(
 			&overtimeRequest,
@@ -118,6 +183,7 @@ func (ctr *Controller) CreateOvertimeRequest(c echo.Context) error {
 			ctr.NotificationRepo,
 			ctr.UserRepo,
 			uniqueUsersId,
+			approvalChain.ID,
 		)
 
 		if err != nil {
@@ -199,7 +265,12 @@ func (ctr *Controller) UpdateOvertimeRequestStatus(c echo.Context) error {
 		})
 	}
 
-	userOvertimeRequestBefore, err := ctr.OvertimeRepo.SelectOvertimeRequestById(updateRequestStatusParams.RequestID)
+	// Request of other organization is not found, so that approvers of caller's organization are never resolved for it
+	userProfile := c.Get("user_profile").(m.User)
+	userOvertimeRequestBefore, err := ctr.OvertimeRepo.SelectOvertimeRequestOfOrganization(
+		userProfile.OrganizationID,
+		updateRequestStatusParams.RequestID,
+	)
 	if err != nil {
 		if err.Error() == pg.ErrNoRows.Error() {
 			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
@@ -222,13 +293,101 @@ func (ctr *Controller) UpdateOvertimeRequestStatus(c echo.Context) error {
 		})
 	}
 
-	userProfile := c.Get("user_profile").(m.User)
+	if userOvertimeRequestBefore.UserId == userProfile.UserProfile.UserID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You can not approve your own overtime request",
+		})
+	}
+
+	approvers, totalStep, err := ctr.getStepApprovers(userProfile.OrganizationID, userOvertimeRequestBefore)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	onBehalfOf, isApprover := approvers[userProfile.UserProfile.UserID]
+	if userOvertimeRequestBefore.Status != cf.PendingRequestStatus {
+		// Only general manager can change a request which has already been processed
+		isApprover = userProfile.RoleID == cf.GeneralManagerRoleID
+		onBehalfOf = 0
+	}
+
+	if !isApprover {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to approve this step of overtime request",
+		})
+	}
+
+	if userOvertimeRequestBefore.ExceedCap &&
+		updateRequestStatusParams.Status == cf.AcceptRequestStatus &&
+		userOvertimeRequestBefore.CurrentStep >= totalStep &&
+		userProfile.RoleID != cf.GeneralManagerRoleID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Overtime request exceeds overtime cap, only general manager can accept it",
+		})
+	}
+
+	if userOvertimeRequestBefore.Status == cf.PendingRequestStatus &&
+		updateRequestStatusParams.Status == cf.AcceptRequestStatus &&
+		userOvertimeRequestBefore.CurrentStep < totalStep {
+		nextRequest := userOvertimeRequestBefore
+		nextRequest.CurrentStep++
+		nextApprovers, _, err := ctr.getStepApprovers(userProfile.OrganizationID, nextRequest)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		var nextApproversId []int
+		for approverId := range nextApprovers {
+			nextApproversId = append(nextApproversId, approverId)
+		}
+
+		body, link, err := ctr.OvertimeRepo.ApproveOvertimeRequestStep(
+			userProfile.OrganizationID,
+			userProfile.UserProfile.UserID,
+			onBehalfOf,
+			userOvertimeRequestBefore,
+			updateRequestStatusParams.Note,
+			ctr.NotificationRepo,
+			nextApproversId,
+		)
+		if err != nil && err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "This step of overtime request has already been processed",
+			})
+		}
+
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System error",
+			})
+		}
+
+		ctr.sendOvertimeNotification(userProfile, append(nextApproversId, userOvertimeRequestBefore.UserId), body, link)
+
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.SuccessResponseCode,
+			Message: "Approve step of request successful",
+		})
+	}
+
 	year := userOvertimeRequestBefore.DatetimeOvertimeFrom.Year()
 	holidayDates := ctr.getHolidayCurrentYear(userProfile.OrganizationID, year)
 	cld := calendar.NewCalendar(holidayDates)
-	hour, _, _ := ctr.calculateActualHourOvertime(
+	hour, _, breakdowns := ctr.calculateActualHourOvertime(
 		cld,
 		userProfile.OrganizationID,
+		userOvertimeRequestBefore.UserId,
 		userOvertimeRequestBefore.DatetimeOvertimeFrom,
 		userOvertimeRequestBefore.DatetimeOvertimeTo,
 		userOvertimeRequestBefore.WorkAtNoon,
@@ -249,6 +408,9 @@ func (ctr *Controller) UpdateOvertimeRequestStatus(c echo.Context) error {
 		userProfile.OrganizationID,
 		userProfile.UserProfile.UserID,
 		hour,
+		breakdowns,
+		userOvertimeRequestBefore.CurrentStep,
+		onBehalfOf,
 	)
 
 	if err != nil {
@@ -283,6 +445,80 @@ func (ctr *Controller) UpdateOvertimeRequestStatus(c echo.Context) error {
 	})
 }
 
+func (ctr *Controller) GetOvertimeRequestHistories(c echo.Context) error {
+	getOvertimeRequestParam := new(param.GetOvertimeRequestParam)
+	if err := c.Bind(getOvertimeRequestParam); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(getOvertimeRequestParam)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	userOvertimeRequest, err := ctr.OvertimeRepo.SelectOvertimeRequestOfOrganization(
+		userProfile.OrganizationID,
+		getOvertimeRequestParam.Id,
+	)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "No result",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if userOvertimeRequest.UserId != userProfile.UserProfile.UserID &&
+		userProfile.RoleID != cf.GeneralManagerRoleID && userProfile.RoleID != cf.ManagerRoleID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to view histories of overtime request",
+		})
+	}
+
+	records, err := ctr.OvertimeRepo.SelectOvertimeRequestHistories(getOvertimeRequestParam.Id)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var responses []map[string]interface{}
+	for _, record := range records {
+		responses = append(responses, map[string]interface{}{
+			"step":              record.Step,
+			"action":            cf.MapOvertimeAction[record.Action],
+			"status":            utils.GetNameStatusRegistRequests(record.Status),
+			"actor_id":          record.ActorId,
+			"actor_name":        record.ActorName,
+			"on_behalf_of":      record.OnBehalfOf,
+			"on_behalf_of_name": record.OnBehalfOfName,
+			"note":              record.Note,
+			"created_at":        record.CreatedAt.Format(cf.FormatDateNoSec),
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data:    responses,
+	})
+}
+
 func (ctr *Controller) GetOvertimeRequests(c echo.Context) error {
 	getOvertimeRequestsParams := new(param.GetOvertimeRequestsParams)
 	if err := c.Bind(getOvertimeRequestsParams); err != nil {
@@ -318,7 +554,14 @@ func (ctr *Controller) GetOvertimeRequests(c echo.Context) error {
 	for _, record := range otRecords {
 		holidayDates := ctr.getHolidayCurrentYear(userProfile.OrganizationID, record.DatetimeOvertimeFrom.Year())
 		cld := calendar.NewCalendar(holidayDates)
-		_, hour, _ := ctr.calculateActualHourOvertime(cld, userProfile.OrganizationID, record.DatetimeOvertimeFrom, record.DatetimeOvertimeTo, record.WorkAtNoon)
+		_, hour, _ := ctr.calculateActualHourOvertime(
+			cld,
+			userProfile.OrganizationID,
+			record.UserId,
+			record.DatetimeOvertimeFrom,
+			record.DatetimeOvertimeTo,
+			record.WorkAtNoon,
+		)
 		res := map[string]interface{}{
 			"id":            record.Id,
 			"full_name":     record.FullName,
@@ -331,6 +574,8 @@ func (ctr *Controller) GetOvertimeRequests(c echo.Context) error {
 				"-" + utils.ConvertTwoChar(record.HourTo) + ":" + utils.ConvertTwoChar(record.MinuteTo),
 			"week_day":     record.DatetimeOvertimeFrom.Weekday().String(),
 			"working_time": hour,
+			"current_step": record.CurrentStep,
+			"exceed_cap":   record.ExceedCap,
 		}
 
 		otResponses = append(otResponses, res)
@@ -534,14 +779,63 @@ func (ctr *Controller) EditOvertimeRequest(c echo.Context) error {
 	}
 
 	userProfile := c.Get("user_profile").(m.User)
-	if updateOvertimeRequestParams.UserId != userProfile.UserProfile.UserID {
+	if updateOvertimeRequestParams.UserId != userProfile.UserProfile.UserID ||
+		userOvertimeRequest.UserId != userProfile.UserProfile.UserID {
 		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "You not have permission to update overtime request",
 		})
 	}
 
-	err = ctr.OvertimeRepo.UpdateOvertimeRequest(updateOvertimeRequestParams)
+	from, errFrom := time.Parse(cf.FormatDateNoSec, updateOvertimeRequestParams.DatetimeOvertimeFrom)
+	to, errTo := time.Parse(cf.FormatDateNoSec, updateOvertimeRequestParams.DatetimeOvertimeTo)
+	if errFrom != nil || errTo != nil || !to.After(from) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	exceededCaps, exceedAction, err := ctr.checkOvertimeCap(
+		userProfile.OrganizationID,
+		userOvertimeRequest.UserId,
+		from,
+		to,
+		userOvertimeRequest.WorkAtNoon,
+		userOvertimeRequest.ID,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if len(exceededCaps) > 0 && exceedAction == cf.BlockExceedOvertimeCap {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Overtime request exceeds overtime cap",
+			Data:    map[string]interface{}{"exceeded_caps": exceededCaps},
+		})
+	}
+
+	approvalChain, err := ctr.OvertimeRepo.SelectApprovalChainOfProject(userProfile.OrganizationID, updateOvertimeRequestParams.ProjectId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	updateOvertimeRequestParams.ExceedCap = len(exceededCaps) > 0
+	err = ctr.OvertimeRepo.UpdateOvertimeRequest(updateOvertimeRequestParams, approvalChain.ID)
+	if err != nil && err.Error() == pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Can't change overtime request",
+		})
+	}
+
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
@@ -621,33 +915,39 @@ func (ctr *Controller) ExportExcel(c echo.Context) error {
 	_ = f.SetColWidth("Sheet1", "I", "I", 15)
 	_ = f.SetColWidth("Sheet1", "J", "J", 30)
 	_ = f.SetColWidth("Sheet1", "K", "M", 15)
+	_ = f.SetColWidth("Sheet1", "N", "N", 45)
 
 	titleStyle, _ := f.NewStyle(`{
 		"font":{"bold":true, "size":16},
 		"alignment":{"horizontal":"center", "vertical":"center"}
 	}`)
 	contentStyle, _ := f.NewStyle(`{"alignment":{"horizontal":"center", "vertical":"center"}}`)
+	breakdownStyle, _ := f.NewStyle(`{"alignment":{"vertical":"center", "wrap_text":true}}`)
 
 	var categoriesByLanguage map[string]string
 	var dayOfWeekByLanguage map[string]string
 	var overtimeTypeLanguage map[int]string
 	var mapStatusOvertimeType map[int]string
+	var overtimeBandLanguage map[int]string
 
 	if userProfile.LanguageId == cf.EnLanguageId {
 		categoriesByLanguage = cf.EnOvertimeCategories
 		dayOfWeekByLanguage = cf.EnWeekDay
 		overtimeTypeLanguage = cf.MapOvertimeType
 		mapStatusOvertimeType = cf.MapStatusOvertimeType
+		overtimeBandLanguage = cf.MapOvertimeBand
 	} else if userProfile.LanguageId == cf.VnLanguageId {
 		categoriesByLanguage = cf.VnOvertimeCategories
 		dayOfWeekByLanguage = cf.VnWeekDay
 		overtimeTypeLanguage = cf.VnMapOvertimeType
 		mapStatusOvertimeType = cf.VnMapStatusOvertimeType
+		overtimeBandLanguage = cf.VnMapOvertimeBand
 	} else {
 		categoriesByLanguage = cf.JpOvertimeCategories
 		dayOfWeekByLanguage = cf.JpWeekDay
 		overtimeTypeLanguage = cf.JpMapOvertimeType
 		mapStatusOvertimeType = cf.JpMapStatusOvertimeType
+		overtimeBandLanguage = cf.JpMapOvertimeBand
 	}
 
 	categories := map[string]string{
@@ -664,11 +964,12 @@ func (ctr *Controller) ExportExcel(c echo.Context) error {
 		"K1": categoriesByLanguage["Type"],
 		"L1": categoriesByLanguage["Status"],
 		"M1": categoriesByLanguage["Note"],
+		"N1": categoriesByLanguage["Breakdown"],
 	}
 	for k, v := range categories {
 		_ = f.SetCellValue("Sheet1", k, v)
 	}
-	_ = f.SetCellStyle("Sheet1", "A1", "M1", titleStyle)
+	_ = f.SetCellStyle("Sheet1", "A1", "N1", titleStyle)
 	_ = f.SetColStyle("Sheet1", "A", contentStyle)
 	_ = f.SetColStyle("Sheet1", "C", contentStyle)
 	_ = f.SetColStyle("Sheet1", "D", contentStyle)
@@ -680,20 +981,34 @@ func (ctr *Controller) ExportExcel(c echo.Context) error {
 	_ = f.SetColStyle("Sheet1", "J", contentStyle)
 	_ = f.SetColStyle("Sheet1", "K", contentStyle)
 	_ = f.SetColStyle("Sheet1", "L", contentStyle)
+	_ = f.SetColStyle("Sheet1", "N", breakdownStyle)
 
 	idx := 2
 	for i, record := range otRecords {
 		pos := idx + i
 		holidayDates := ctr.getHolidayCurrentYear(userProfile.OrganizationID, record.DatetimeOvertimeFrom.Year())
 		cld := calendar.NewCalendar(holidayDates)
-		actualHour, hour, weight := ctr.calculateActualHourOvertime(
+		actualHour, hour, breakdowns := ctr.calculateActualHourOvertime(
 			cld,
 			userProfile.OrganizationID,
+			record.UserId,
 			record.DatetimeOvertimeFrom,
 			record.DatetimeOvertimeTo,
 			record.WorkAtNoon,
 		)
 
+		var weight float64
+		if hour > 0 {
+			weight = actualHour / hour
+		}
+
+		var breakdownLines []string
+		for _, breakdown := range breakdowns {
+			breakdownLines = append(breakdownLines, breakdown.WorkDate.Format(cf.FormatDateDisplay)+" "+
+				overtimeBandLanguage[breakdown.Band]+": "+strconv.FormatFloat(breakdown.Hour, 'f', 2, 64)+
+				"h x "+strconv.FormatFloat(breakdown.Weight, 'f', 2, 64))
+		}
+
 		values := map[string]interface{}{
 			"A" + strconv.Itoa(pos): record.EmployeeId,
 			"B" + strconv.Itoa(pos): record.FullName,
@@ -708,6 +1023,7 @@ func (ctr *Controller) ExportExcel(c echo.Context) error {
 			"J" + strconv.Itoa(pos): actualHour,
 			"K" + strconv.Itoa(pos): overtimeTypeLanguage[record.OvertimeType],
 			"L" + strconv.Itoa(pos): mapStatusOvertimeType[record.Status],
+			"N" + strconv.Itoa(pos): strings.Join(breakdownLines, "\n"),
 		}
 
 		for k, v := range values {
@@ -732,7 +1048,8 @@ func (ctr *Controller) CreateOvertimeWeight(c echo.Context) error {
 	if err != nil ||
 		!valid.IsPositive(params.NormalDayWeight) ||
 		!valid.IsPositive(params.WeekendWeight) ||
-		!valid.IsPositive(params.HolidayWeight) {
+		!valid.IsPositive(params.HolidayWeight) ||
+		!isValidNightBand(params.NightWeight, params.WeekendNightWeight, params.HolidayNightWeight, &params.NightStart, &params.NightEnd) {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid field value",
@@ -789,7 +1106,8 @@ func (ctr *Controller) EditOvertimeWeight(c echo.Context) error {
 
 	if (params.NormalDayWeight != 0 && !valid.IsPositive(params.NormalDayWeight)) ||
 		(params.WeekendWeight != 0 && !valid.IsPositive(params.WeekendWeight)) ||
-		(params.HolidayWeight != 0 && !valid.IsPositive(params.HolidayWeight)) {
+		(params.HolidayWeight != 0 && !valid.IsPositive(params.HolidayWeight)) ||
+		!isValidNightBand(params.NightWeight, params.WeekendNightWeight, params.HolidayNightWeight, &params.NightStart, &params.NightEnd) {
 		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
 			Status:  cf.FailResponseCode,
 			Message: "Invalid field value",
@@ -846,10 +1164,15 @@ func (ctr *Controller) GetOvertimeWeight(c echo.Context) error {
 	}
 
 	dataResponse := map[string]interface{}{
-		"id":                record.ID,
-		"normal_day_weight": record.NormalDayWeight,
-		"weekend_weight":    record.WeekendWeight,
-		"holiday_weight":    record.HolidayWeight,
+		"id":                   record.ID,
+		"normal_day_weight":    record.NormalDayWeight,
+		"weekend_weight":       record.WeekendWeight,
+		"holiday_weight":       record.HolidayWeight,
+		"night_weight":         record.NightWeight,
+		"weekend_night_weight": record.WeekendNightWeight,
+		"holiday_night_weight": record.HolidayNightWeight,
+		"night_start":          record.NightStart,
+		"night_end":            record.NightEnd,
 	}
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
@@ -859,20 +1182,926 @@ func (ctr *Controller) GetOvertimeWeight(c echo.Context) error {
 	})
 }
 
-func (ctr *Controller) calculateActualHourOvertime(
-	c *calendar.Calendar,
-	organizationId int,
-	from time.Time,
-	to time.Time,
-	workAtNoon int,
-) (float64, float64, float64) {
-	overtimeWeight, err := ctr.OvertimeRepo.SelectOvertimeWeightByOrganizationId(organizationId)
+func (ctr *Controller) CreateOvertimeCap(c echo.Context) error {
+	params := new(param.CreateOvertimeCapParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil || params.DailyHour < 0 || params.MonthlyHour < 0 || params.YearlyHour < 0 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	count, err := ctr.OvertimeRepo.CountOvertimeCapByField("organization_id", userProfile.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if count > 0 {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Overtime cap already exist",
+		})
+	}
+
+	err = ctr.OvertimeRepo.InsertOvertimeCap(userProfile.OrganizationID, params)
 	if err != nil {
-		return -1, -1, 0
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create overtime cap successful",
+	})
+}
+
+func (ctr *Controller) EditOvertimeCap(c echo.Context) error {
+	params := new(param.EditOvertimeCapParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil || params.DailyHour < 0 || params.MonthlyHour < 0 || params.YearlyHour < 0 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	overtimeCap, err := ctr.OvertimeRepo.SelectOvertimeCapByOrganizationId(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if overtimeCap.ID != params.Id {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Overtime cap does not yet exist",
+		})
+	}
+
+	err = ctr.OvertimeRepo.UpdateOvertimeCap(params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Edit overtime cap successful",
+	})
+}
+
+func (ctr *Controller) GetOvertimeCap(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	overtimeCap, err := ctr.getOvertimeCap(userProfile.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	dataResponse := map[string]interface{}{
+		"id":            overtimeCap.ID,
+		"daily_hour":    overtimeCap.DailyHour,
+		"monthly_hour":  overtimeCap.MonthlyHour,
+		"yearly_hour":   overtimeCap.YearlyHour,
+		"exceed_action": overtimeCap.ExceedAction,
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get overtime cap successful",
+		Data:    dataResponse,
+	})
+}
+
+func (ctr *Controller) GetOvertimeAllowance(c echo.Context) error {
+	params := new(param.GetOvertimeAllowanceParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	date := time.Now()
+	if params.Date != "" {
+		var err error
+		date, err = time.Parse(cf.FormatDateDatabase, params.Date)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+			})
+		}
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	isManager := userProfile.RoleID == cf.GeneralManagerRoleID || userProfile.RoleID == cf.ManagerRoleID
+	if len(params.UsersId) == 0 {
+		params.UsersId = []int{userProfile.UserProfile.UserID}
+	}
+
+	if !isManager && (len(params.UsersId) > 1 || params.UsersId[0] != userProfile.UserProfile.UserID) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to view overtime allowance of other users",
+		})
+	}
+
+	overtimeCap, err := ctr.getOvertimeCap(userProfile.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userList := make(map[int]string)
+	for _, user := range users {
+		userList[user.UserID] = user.FullName
+	}
+
+	var responses []map[string]interface{}
+	for _, userId := range params.UsersId {
+		fullName, ok := userList[userId]
+		if !ok {
+			continue
+		}
+
+		_, monthlyHour, yearlyHour, err := ctr.sumCountedOvertimeHours(userProfile.OrganizationID, userId, date, 0)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		responses = append(responses, map[string]interface{}{
+			"user_id":           userId,
+			"full_name":         fullName,
+			"monthly_used":      monthlyHour,
+			"monthly_cap":       overtimeCap.MonthlyHour,
+			"monthly_remaining": remainingOvertimeHour(overtimeCap.MonthlyHour, monthlyHour),
+			"yearly_used":       yearlyHour,
+			"yearly_cap":        overtimeCap.YearlyHour,
+			"yearly_remaining":  remainingOvertimeHour(overtimeCap.YearlyHour, yearlyHour),
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data:    responses,
+	})
+}
+
+func (ctr *Controller) CreateApprovalChain(c echo.Context) error {
+	createApprovalChainParams := new(param.CreateApprovalChainParams)
+	if err := c.Bind(createApprovalChainParams); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(createApprovalChainParams)
+	if err != nil || !isValidApprovalSteps(createApprovalChainParams.Steps) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if createApprovalChainParams.ProjectId != 0 {
+		project, err := ctr.ProjectRepo.GetProjectByID(createApprovalChainParams.ProjectId)
+		if err != nil || project.OrganizationID != userProfile.OrganizationID {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Project does not exist",
+			})
+		}
+	}
+
+	count, err := ctr.OvertimeRepo.CountApprovalChainByProject(userProfile.OrganizationID, createApprovalChainParams.ProjectId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if count > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Approval chain of this project already exists",
+		})
+	}
+
+	err = ctr.OvertimeRepo.InsertApprovalChain(userProfile.OrganizationID, createApprovalChainParams)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create approval chain successful",
+	})
+}
+
+func (ctr *Controller) EditApprovalChain(c echo.Context) error {
+	editApprovalChainParams := new(param.EditApprovalChainParams)
+	if err := c.Bind(editApprovalChainParams); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(editApprovalChainParams)
+	if err != nil || !isValidApprovalSteps(editApprovalChainParams.Steps) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	chain, err := ctr.OvertimeRepo.SelectApprovalChainById(editApprovalChainParams.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Approval chain does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if chain.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to edit this approval chain",
+		})
+	}
+
+	if editApprovalChainParams.Name == "" {
+		editApprovalChainParams.Name = chain.Name
+	}
+
+	err = ctr.OvertimeRepo.UpdateApprovalChain(editApprovalChainParams)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Edit approval chain successful",
+	})
+}
+
+func (ctr *Controller) RemoveApprovalChain(c echo.Context) error {
+	removeApprovalChainParams := new(param.RemoveApprovalChainParams)
+	if err := c.Bind(removeApprovalChainParams); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(removeApprovalChainParams)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	chain, err := ctr.OvertimeRepo.SelectApprovalChainById(removeApprovalChainParams.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Approval chain does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if chain.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to remove this approval chain",
+		})
+	}
+
+	err = ctr.OvertimeRepo.DeleteApprovalChain(removeApprovalChainParams.Id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove approval chain successful",
+	})
+}
+
+func (ctr *Controller) GetApprovalChains(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	chains, err := ctr.OvertimeRepo.SelectApprovalChains(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var responses []map[string]interface{}
+	for _, chain := range chains {
+		steps, err := ctr.OvertimeRepo.SelectApprovalSteps(chain.ID)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		var stepResponses []map[string]interface{}
+		for _, step := range steps {
+			stepResponses = append(stepResponses, map[string]interface{}{
+				"step_order":         step.StepOrder,
+				"approver_type":      step.ApproverType,
+				"approver_type_name": cf.MapOvertimeApproverType[step.ApproverType],
+				"approver_id":        step.ApproverId,
+			})
+		}
+
+		responses = append(responses, map[string]interface{}{
+			"id":         chain.ID,
+			"project_id": chain.ProjectId,
+			"name":       chain.Name,
+			"steps":      stepResponses,
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data:    responses,
+	})
+}
+
+func (ctr *Controller) CreateApprovalDelegation(c echo.Context) error {
+	createApprovalDelegationParams := new(param.CreateApprovalDelegationParams)
+	if err := c.Bind(createApprovalDelegationParams); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(createApprovalDelegationParams)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if createApprovalDelegationParams.ApproverId == 0 || userProfile.RoleID != cf.GeneralManagerRoleID {
+		createApprovalDelegationParams.ApproverId = userProfile.UserProfile.UserID
+	}
+
+	dateFrom, errFrom := time.Parse(cf.FormatDateDatabase, createApprovalDelegationParams.DateFrom)
+	dateTo, errTo := time.Parse(cf.FormatDateDatabase, createApprovalDelegationParams.DateTo)
+	if errFrom != nil || errTo != nil || dateTo.Before(dateFrom) ||
+		createApprovalDelegationParams.ApproverId == createApprovalDelegationParams.DelegateId {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	delegate, err := ctr.UserRepo.GetUserProfile(createApprovalDelegationParams.DelegateId)
+	if err != nil || delegate.OrganizationID != userProfile.OrganizationID {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Delegate does not exist",
+		})
+	}
+
+	err = ctr.OvertimeRepo.InsertApprovalDelegation(userProfile.OrganizationID, createApprovalDelegationParams)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create approval delegation successful",
+	})
+}
+
+func (ctr *Controller) RemoveApprovalDelegation(c echo.Context) error {
+	removeApprovalDelegationParams := new(param.RemoveApprovalDelegationParams)
+	if err := c.Bind(removeApprovalDelegationParams); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(removeApprovalDelegationParams)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	delegation, err := ctr.OvertimeRepo.SelectApprovalDelegationById(removeApprovalDelegationParams.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Approval delegation does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if delegation.OrganizationId != userProfile.OrganizationID ||
+		(delegation.ApproverId != userProfile.UserProfile.UserID && userProfile.RoleID != cf.GeneralManagerRoleID) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to remove this approval delegation",
+		})
+	}
+
+	err = ctr.OvertimeRepo.DeleteApprovalDelegation(removeApprovalDelegationParams.Id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove approval delegation successful",
+	})
+}
+
+func (ctr *Controller) GetApprovalDelegations(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	userId := userProfile.UserProfile.UserID
+	if userProfile.RoleID == cf.GeneralManagerRoleID {
+		userId = 0
+	}
+
+	records, err := ctr.OvertimeRepo.SelectApprovalDelegations(userProfile.OrganizationID, userId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var responses []map[string]interface{}
+	for _, record := range records {
+		responses = append(responses, map[string]interface{}{
+			"id":            record.Id,
+			"approver_id":   record.ApproverId,
+			"approver_name": record.ApproverName,
+			"delegate_id":   record.DelegateId,
+			"delegate_name": record.DelegateName,
+			"date_from":     record.DateFrom.Format(cf.FormatDateDisplay),
+			"date_to":       record.DateTo.Format(cf.FormatDateDisplay),
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Success",
+		Data:    responses,
+	})
+}
+
+// getStepApprovers : Get users who can approve the current step of request, mapped to the approver they act on behalf of
+// Request without approval chain is approved by general manager in a single step, as is step which resolves no approver
+func (ctr *Controller) getStepApprovers(organizationId int, userOvertimeRequest m.UserOvertimeRequest) (map[int]int, int, error) {
+	steps := []m.OvertimeApprovalStep{{StepOrder: 1, ApproverType: cf.GeneralManagerApprover}}
+	if userOvertimeRequest.ApprovalChainId != 0 {
+		chainSteps, err := ctr.OvertimeRepo.SelectApprovalSteps(userOvertimeRequest.ApprovalChainId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return nil, 0, err
+		}
+
+		if len(chainSteps) > 0 {
+			steps = chainSteps
+		}
+	}
+
+	stepIndex := userOvertimeRequest.CurrentStep - 1
+	if stepIndex < 0 {
+		stepIndex = 0
+	} else if stepIndex >= len(steps) {
+		stepIndex = len(steps) - 1
+	}
+
+	var approverIds []int
+	switch steps[stepIndex].ApproverType {
+	case cf.ProjectManagerApprover:
+		project, err := ctr.ProjectRepo.GetProjectByID(userOvertimeRequest.ProjectId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return nil, 0, err
+		}
+
+		if project.ManagedBy != 0 {
+			approverIds = append(approverIds, project.ManagedBy)
+		}
+	case cf.GeneralManagerApprover:
+		gmIds, err := ctr.UserRepo.SelectIdsOfGM(organizationId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return nil, 0, err
+		}
+
+		approverIds = gmIds
+	case cf.SpecificUserApprover:
+		approverIds = append(approverIds, steps[stepIndex].ApproverId)
+	}
+
+	// Requester can not approve own request, step which has no other approver falls back to general manager
+	var otherApproverIds []int
+	for _, approverId := range approverIds {
+		if approverId != userOvertimeRequest.UserId {
+			otherApproverIds = append(otherApproverIds, approverId)
+		}
+	}
+
+	approverIds = otherApproverIds
+	if len(approverIds) == 0 && steps[stepIndex].ApproverType != cf.GeneralManagerApprover {
+		gmIds, err := ctr.UserRepo.SelectIdsOfGM(organizationId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return nil, 0, err
+		}
+
+		approverIds = gmIds
+	}
+
+	approvers := make(map[int]int)
+	for _, approverId := range approverIds {
+		approvers[approverId] = 0
+	}
+
+	today := time.Now().Format(cf.FormatDateDatabase)
+	delegations, err := ctr.OvertimeRepo.SelectActiveDelegations(organizationId, approverIds, today)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return nil, 0, err
+	}
+
+	for _, delegation := range delegations {
+		if _, ok := approvers[delegation.DelegateId]; !ok {
+			approvers[delegation.DelegateId] = delegation.ApproverId
+		}
+	}
+
+	// Delegate of leave request covers approver who is on leave
+	if len(approverIds) > 0 {
+		leaveDelegations, err := ctr.leaveRepo.SelectLeaveDelegates(organizationId, approverIds, today)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return nil, 0, err
+		}
+
+		for _, leaveDelegation := range leaveDelegations {
+			if _, ok := approvers[leaveDelegation.DelegateUserId]; !ok {
+				approvers[leaveDelegation.DelegateUserId] = leaveDelegation.UserId
+			}
+		}
+	}
+
+	delete(approvers, userOvertimeRequest.UserId)
+
+	return approvers, len(steps), nil
+}
+
+func (ctr *Controller) sendOvertimeNotification(userProfile m.User, usersId []int, body string, link string) {
+	registrationTokens, err := ctr.FcmTokenRepo.SelectMultiFcmTokens(usersId, userProfile.UserProfile.UserID)
+	if err != nil || len(registrationTokens) == 0 {
+		return
+	}
+
+	body = userProfile.UserProfile.FirstName + " " + userProfile.UserProfile.LastName + " " + body
+	for _, token := range registrationTokens {
+		err := ctr.SendMessageToSpecificUser(token, "Micro Erp New Notification", body, link)
+		if err != nil && err.Error() == "http error status: 400; reason: request contains an invalid argument; "+
+			"code: invalid-argument; details: The registration token is not a valid FCM registration token" {
+			_ = ctr.FcmTokenRepo.DeleteFcmToken(token)
+		}
+	}
+}
+
+// isValidNightBand : Night weights must not be negative, empty night time is set to default band
+func isValidNightBand(nightWeight float64, weekendNightWeight float64, holidayNightWeight float64, nightStart *string, nightEnd *string) bool {
+	if nightWeight < 0 || weekendNightWeight < 0 || holidayNightWeight < 0 {
+		return false
+	}
+
+	if *nightStart == "" {
+		*nightStart = cf.DefaultOvertimeNightStart
+	}
+
+	if *nightEnd == "" {
+		*nightEnd = cf.DefaultOvertimeNightEnd
+	}
+
+	_, errStart := time.Parse(cf.FormatShiftTime, *nightStart)
+	_, errEnd := time.Parse(cf.FormatShiftTime, *nightEnd)
+
+	return errStart == nil && errEnd == nil && *nightStart != *nightEnd
+}
+
+// getOvertimeCap : Get overtime cap of organization, statutory limits are flagged when it has not been set
+func (ctr *Controller) getOvertimeCap(organizationId int) (m.OvertimeCap, error) {
+	overtimeCap, err := ctr.OvertimeRepo.SelectOvertimeCapByOrganizationId(organizationId)
+	if err != nil {
+		if err.Error() != pg.ErrNoRows.Error() {
+			return overtimeCap, err
+		}
+
+		overtimeCap = m.OvertimeCap{
+			OrganizationId: organizationId,
+			DailyHour:      cf.DefaultOvertimeDailyCap,
+			MonthlyHour:    cf.DefaultOvertimeMonthlyCap,
+			YearlyHour:     cf.DefaultOvertimeYearlyCap,
+			ExceedAction:   cf.FlagExceedOvertimeCap,
+		}
+	}
+
+	return overtimeCap, nil
+}
+
+// sumCountedOvertimeHours : Sum actual hours of pending and accepted requests in the day, month and year of date
+func (ctr *Controller) sumCountedOvertimeHours(
+	organizationId int,
+	userId int,
+	date time.Time,
+	exceptId int,
+) (float64, float64, float64, error) {
+	var dailyHour, monthlyHour, yearlyHour float64
+	yearStart := time.Date(date.Year(), 1, 1, 0, 0, 0, 0, date.Location())
+	records, err := ctr.OvertimeRepo.SelectCountedOvertimeRequests(userId, yearStart, yearStart.AddDate(1, 0, 0), exceptId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return 0, 0, 0, err
+	}
+
+	cld := calendar.NewCalendar(ctr.getHolidayCurrentYear(organizationId, date.Year()))
+	for _, record := range records {
+		hour, err := ctr.calculateClockHourOvertime(
+			cld,
+			organizationId,
+			record.UserId,
+			record.DatetimeOvertimeFrom,
+			record.DatetimeOvertimeTo,
+			record.WorkAtNoon,
+		)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		yearlyHour += hour
+		if record.DatetimeOvertimeFrom.Month() == date.Month() {
+			monthlyHour += hour
+		}
+
+		if utils.CompareEqualDate(record.DatetimeOvertimeFrom, date) {
+			dailyHour += hour
+		}
+	}
+
+	return dailyHour, monthlyHour, yearlyHour, nil
+}
+
+// checkOvertimeCap : Get caps which would be exceeded by adding the request, and action of organization for it
+func (ctr *Controller) checkOvertimeCap(
+	organizationId int,
+	userId int,
+	from time.Time,
+	to time.Time,
+	workAtNoon int,
+	exceptId int,
+) ([]string, int, error) {
+	overtimeCap, err := ctr.getOvertimeCap(organizationId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if overtimeCap.DailyHour == 0 && overtimeCap.MonthlyHour == 0 && overtimeCap.YearlyHour == 0 {
+		return nil, overtimeCap.ExceedAction, nil
+	}
+
+	cld := calendar.NewCalendar(ctr.getHolidayCurrentYear(organizationId, from.Year()))
+	hour, err := ctr.calculateClockHourOvertime(cld, organizationId, userId, from, to, workAtNoon)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dailyHour, monthlyHour, yearlyHour, err := ctr.sumCountedOvertimeHours(organizationId, userId, from, exceptId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var exceededCaps []string
+	if overtimeCap.DailyHour > 0 && dailyHour+hour > overtimeCap.DailyHour {
+		exceededCaps = append(exceededCaps, "daily")
+	}
+
+	if overtimeCap.MonthlyHour > 0 && monthlyHour+hour > overtimeCap.MonthlyHour {
+		exceededCaps = append(exceededCaps, "monthly")
+	}
+
+	if overtimeCap.YearlyHour > 0 && yearlyHour+hour > overtimeCap.YearlyHour {
+		exceededCaps = append(exceededCaps, "yearly")
+	}
+
+	return exceededCaps, overtimeCap.ExceedAction, nil
+}
+
+// remainingOvertimeHour : Return nil when cap is unlimited
+func remainingOvertimeHour(capHour float64, usedHour float64) interface{} {
+	if capHour == 0 {
+		return nil
+	}
+
+	if usedHour >= capHour {
+		return 0
+	}
+
+	return capHour - usedHour
+}
+
+func isValidApprovalSteps(steps []param.ApprovalStepParams) bool {
+	if len(steps) == 0 {
+		return false
+	}
+
+	for _, step := range steps {
+		if _, err := valid.ValidateStruct(step); err != nil {
+			return false
+		}
+
+		if step.ApproverType == cf.SpecificUserApprover && step.ApproverId == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (ctr *Controller) calculateActualHourOvertime(
+	c *calendar.Calendar,
+	organizationId int,
+	userId int,
+	from time.Time,
+	to time.Time,
+	workAtNoon int,
+) (float64, float64, []m.OvertimeHourBreakdown) {
+	overtimeWeight, err := ctr.OvertimeRepo.SelectOvertimeWeightByOrganizationId(organizationId)
+	if err != nil {
+		return -1, -1, nil
+	}
+
+	return ctr.calculateHourOvertimeByWeight(c, overtimeWeight, organizationId, userId, from, to, workAtNoon)
+}
+
+// calculateClockHourOvertime : Worked hour of overtime without weight, which caps are measured on.
+// Weight only gives bounds of night then, default bounds are used when organization has no weight
+func (ctr *Controller) calculateClockHourOvertime(
+	c *calendar.Calendar,
+	organizationId int,
+	userId int,
+	from time.Time,
+	to time.Time,
+	workAtNoon int,
+) (float64, error) {
+	overtimeWeight, err := ctr.OvertimeRepo.SelectOvertimeWeightByOrganizationId(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return 0, err
+	}
+
+	_, hour, _ := ctr.calculateHourOvertimeByWeight(c, overtimeWeight, organizationId, userId, from, to, workAtNoon)
+	if hour < 0 {
+		return 0, errors.New("can not calculate hour of overtime request")
+	}
+
+	return hour, nil
+}
+
+func (ctr *Controller) calculateHourOvertimeByWeight(
+	c *calendar.Calendar,
+	overtimeWeight m.OvertimeWeight,
+	organizationId int,
+	userId int,
+	from time.Time,
+	to time.Time,
+	workAtNoon int,
+) (float64, float64, []m.OvertimeHourBreakdown) {
+	userShift, err := ctr.getUserShiftOfOvertime(organizationId, userId, from)
+	if err != nil {
+		return -1, -1, nil
+	}
+
+	if userShift.Id != 0 {
+		return calendar.CalculateHourBonusOvertimeInShift(
+			c, from, to, overtimeWeight, workAtNoon, userShift.BreakStart, userShift.BreakEnd,
+		)
+	}
+
+	return calendar.CalculateHourBonusOvertime(c, from, to, overtimeWeight, workAtNoon)
+}
+
+// getUserShiftOfOvertime : Get shift assigned on the overtime date,
+// or yesterday's overnight shift that is still running at from time
+func (ctr *Controller) getUserShiftOfOvertime(organizationId int, userId int, from time.Time) (param.RosterRecord, error) {
+	records, err := ctr.ShiftRepo.SelectUserShiftsByDates(
+		organizationId,
+		userId,
+		from.AddDate(0, 0, -1).Format(cf.FormatDateDatabase),
+		from.Format(cf.FormatDateDatabase),
+	)
+	if err != nil {
+		return param.RosterRecord{}, err
+	}
+
+	for i := len(records) - 1; i >= 0; i-- {
+		start, end := calendar.ShiftPeriod(records[i].ShiftDate, records[i].StartTime, records[i].EndTime, from.Location())
+		if utils.CompareEqualDate(records[i].ShiftDate, from) || (!from.Before(start) && from.Before(end)) {
+			return records[i], nil
+		}
 	}
 
-	actualHour, hour, weight := calendar.CalculateHourBonusOvertime(c, from, to, overtimeWeight, workAtNoon)
-	return actualHour, hour, weight
+	return param.RosterRecord{}, nil
 }
 
 func (ctr *Controller) getHolidayCurrentYear(organizationId int, year int) []time.Time {
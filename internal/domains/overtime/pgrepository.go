@@ -33,12 +33,16 @@ func (repo *PgOvertimeRepository) InsertOvertimeRequest(
 	notificationRepo rp.NotificationRepository,
 	userRepo rp.UserRepository,
 	uniqueUsersId []int,
+	approvalChainId int,
 ) (string, string, error) {
 	var body string
 	var link string
 	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
 		var transErr error
 		userOvertimeRequest := m.UserOvertimeRequest{
+			ApprovalChainId:      approvalChainId,
+			CurrentStep:          1,
+			ExceedCap:            createOvertimeParams.ExceedCap,
 			UserId:               createOvertimeParams.UserId,
 			ProjectId:            createOvertimeParams.ProjectId,
 			Status:               createOvertimeParams.Status,
@@ -58,6 +62,17 @@ func (repo *PgOvertimeRepository) InsertOvertimeRequest(
 			return transErr
 		}
 
+		transErr = repo.insertOvertimeRequestHistoryWithTx(tx, m.OvertimeRequestHistory{
+			OvertimeRequestId: userOvertimeRequest.ID,
+			Step:              userOvertimeRequest.CurrentStep,
+			Action:            cf.SubmitOvertimeAction,
+			Status:            userOvertimeRequest.Status,
+			ActorId:           createOvertimeParams.UserId,
+		})
+		if transErr != nil {
+			return transErr
+		}
+
 		notificationParams := new(param.InsertNotificationParam)
 		notificationParams.Content = "has just created a overtime request"
 		notificationParams.RedirectUrl = "/request/manage-overtime?id=" + strconv.Itoa(userOvertimeRequest.ID)
@@ -94,6 +109,9 @@ func (repo *PgOvertimeRepository) UpdateStatusOvertimeRequest(
 	organizationId int,
 	userId int,
 	hour float64,
+	breakdowns []m.OvertimeHourBreakdown,
+	step int,
+	onBehalfOf int,
 ) (string, string, error) {
 	var body string
 	var link string
@@ -109,6 +127,25 @@ func (repo *PgOvertimeRepository) UpdateStatusOvertimeRequest(
 			return transErr
 		}
 
+		action := cf.ApproveOvertimeAction
+		if updateRequestStatusParams.Status == cf.DenyRequestStatus {
+			action = cf.DenyOvertimeAction
+		}
+
+		transErr = repo.insertOvertimeRequestHistoryWithTx(tx, m.OvertimeRequestHistory{
+			OvertimeRequestId: updateRequestStatusParams.RequestID,
+			Step:              step,
+			Action:            action,
+			Status:            updateRequestStatusParams.Status,
+			ActorId:           userId,
+			OnBehalfOf:        onBehalfOf,
+			Note:              updateRequestStatusParams.Note,
+		})
+		if transErr != nil {
+			repo.Logger.Error(transErr)
+			return transErr
+		}
+
 		userOvertimeRequest, transErr := repo.SelectOvertimeRequestById(updateRequestStatusParams.RequestID)
 		if transErr != nil {
 			repo.Logger.Error(transErr)
@@ -129,6 +166,25 @@ func (repo *PgOvertimeRepository) UpdateStatusOvertimeRequest(
 			return transErr
 		}
 
+		_, transErr = tx.Model(&m.OvertimeHourBreakdown{}).
+			Where("overtime_request_id = ?", updateRequestStatusParams.RequestID).
+			Delete()
+		if transErr != nil {
+			repo.Logger.Error(transErr)
+			return transErr
+		}
+
+		if updateRequestStatusParams.Status == cf.AcceptRequestStatus {
+			for _, breakdown := range breakdowns {
+				breakdown.OvertimeRequestId = updateRequestStatusParams.RequestID
+				transErr = tx.Insert(&breakdown)
+				if transErr != nil {
+					repo.Logger.Error(transErr)
+					return transErr
+				}
+			}
+		}
+
 		now := time.Now()
 		if updateRequestStatusParams.Status == cf.AcceptRequestStatus && userOvertimeRequest.OvertimeType == cf.DayOffTypeOvertime {
 			leaveBonusParams := param.LeaveBonus{
@@ -142,6 +198,7 @@ func (repo *PgOvertimeRepository) UpdateStatusOvertimeRequest(
 				Hour:             hour,
 				HourRemaining:    hour,
 				ExpireBonusLeaveDate: now.AddDate(0, 3, 0).Format("2006-01-02 15:04:05"),
+				OvertimeRequestId:    updateRequestStatusParams.RequestID,
 			}
 			transErr = leaveRepo.InsertLeaveBonusOvertimeWithTx(tx, &leaveBonusParams)
 			if transErr != nil {
@@ -169,7 +226,7 @@ func (repo *PgOvertimeRepository) SelectOvertimeRequests(
 ) ([]param.OvertimeRequestsRecords, int, error) {
 	var records []param.OvertimeRequestsRecords
 	q := repo.DB.Model(&m.UserOvertimeRequest{})
-	q.Column("uotr.id", "uotr.status", "uotr.datetime_overtime_from", "uotr.datetime_overtime_to",
+	q.Column("uotr.id", "uotr.user_id", "uotr.status", "uotr.current_step", "uotr.exceed_cap", "uotr.datetime_overtime_from", "uotr.datetime_overtime_to",
 		"uotr.overtime_type", "uotr.work_at_noon", "up.employee_id").
 		ColumnExpr("EXTRACT(HOUR FROM datetime_overtime_from) AS hour_from").
 		ColumnExpr("EXTRACT(MINUTE FROM datetime_overtime_from) AS minute_from").
@@ -238,8 +295,9 @@ func (repo *PgOvertimeRepository) SelectOvertimeRequests(
 func (repo *PgOvertimeRepository) SelectOvertimeRequestById(id int) (m.UserOvertimeRequest, error) {
 	var userOvertimeRequest m.UserOvertimeRequest
 	err := repo.DB.Model(&userOvertimeRequest).
-		Column("user_id", "project_id", "datetime_overtime_from", "datetime_overtime_to", "status",
-			"email_title", "email_content", "reason", "overtime_type", "work_at_noon", "send_to", "send_cc").
+		Column("id", "user_id", "project_id", "datetime_overtime_from", "datetime_overtime_to", "status",
+			"email_title", "email_content", "reason", "overtime_type", "work_at_noon", "send_to", "send_cc",
+			"approval_chain_id", "current_step", "exceed_cap").
 		Where("id = ?", id).
 		Select()
 
@@ -250,7 +308,27 @@ func (repo *PgOvertimeRepository) SelectOvertimeRequestById(id int) (m.UserOvert
 	return userOvertimeRequest, err
 }
 
-func (repo *PgOvertimeRepository) UpdateOvertimeRequest(params *param.UpdateOvertimeRequestParams) error {
+// SelectOvertimeRequestOfOrganization : Overtime request whose user is in organization
+func (repo *PgOvertimeRepository) SelectOvertimeRequestOfOrganization(organizationId int, id int) (m.UserOvertimeRequest, error) {
+	var userOvertimeRequest m.UserOvertimeRequest
+	err := repo.DB.Model(&userOvertimeRequest).
+		Column("uotr.id", "uotr.user_id", "uotr.project_id", "uotr.datetime_overtime_from", "uotr.datetime_overtime_to",
+			"uotr.status", "uotr.email_title", "uotr.email_content", "uotr.reason", "uotr.overtime_type", "uotr.work_at_noon",
+			"uotr.send_to", "uotr.send_cc", "uotr.approval_chain_id", "uotr.current_step", "uotr.exceed_cap").
+		Join("JOIN users AS u ON u.id = uotr.user_id").
+		Where("uotr.id = ?", id).
+		Where("u.organization_id = ?", organizationId).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return userOvertimeRequest, err
+}
+
+// UpdateOvertimeRequest : Edited request goes back to first step of its approval chain, as approvers have not seen new hours
+func (repo *PgOvertimeRepository) UpdateOvertimeRequest(params *param.UpdateOvertimeRequestParams, approvalChainId int) error {
 	userOvertimeRequest := m.UserOvertimeRequest{
 		ProjectId:            params.ProjectId,
 		DatetimeOvertimeFrom: calendar.ParseTime(cf.FormatDateNoSec, params.DatetimeOvertimeFrom),
@@ -261,13 +339,34 @@ func (repo *PgOvertimeRepository) UpdateOvertimeRequest(params *param.UpdateOver
 		OvertimeType:         params.OvertimeType,
 		SendTo:               params.SendTo,
 		SendCc:               params.SendCc,
+		ExceedCap:            params.ExceedCap,
+		ApprovalChainId:      approvalChainId,
+		CurrentStep:          1,
 	}
 
-	_, err := repo.DB.Model(&userOvertimeRequest).
-		Column("project_id", "datetime_overtime_from", "datetime_overtime_to", "email_title",
-			"email_content", "reason", "overtime_type", "send_to", "send_cc", "updated_at").
-		Where("id = ?", params.Id).
-		Update()
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		res, transErr := tx.Model(&userOvertimeRequest).
+			Column("project_id", "datetime_overtime_from", "datetime_overtime_to", "email_title", "email_content", "reason",
+				"overtime_type", "send_to", "send_cc", "exceed_cap", "approval_chain_id", "current_step", "updated_at").
+			Where("id = ?", params.Id).
+			Where("status = ?", cf.PendingRequestStatus).
+			Update()
+		if transErr != nil {
+			return transErr
+		}
+
+		if res.RowsAffected() == 0 {
+			return pg.ErrNoRows
+		}
+
+		return repo.insertOvertimeRequestHistoryWithTx(tx, m.OvertimeRequestHistory{
+			OvertimeRequestId: params.Id,
+			Step:              1,
+			Action:            cf.EditOvertimeAction,
+			Status:            cf.PendingRequestStatus,
+			ActorId:           params.UserId,
+		})
+	})
 
 	if err != nil {
 		repo.Logger.Error(err)
@@ -285,10 +384,15 @@ func (repo *PgOvertimeRepository) InsertOvertimeWeight(
 	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
 		var transErr error
 		overtimeWeight := m.OvertimeWeight{
-			OrganizationId:  organizationId,
-			NormalDayWeight: params.NormalDayWeight,
-			WeekendWeight:   params.WeekendWeight,
-			HolidayWeight:   params.HolidayWeight,
+			OrganizationId:     organizationId,
+			NormalDayWeight:    params.NormalDayWeight,
+			WeekendWeight:      params.WeekendWeight,
+			HolidayWeight:      params.HolidayWeight,
+			NightWeight:        params.NightWeight,
+			WeekendNightWeight: params.WeekendNightWeight,
+			HolidayNightWeight: params.HolidayNightWeight,
+			NightStart:         params.NightStart,
+			NightEnd:           params.NightEnd,
 		}
 
 		transErr = tx.Insert(&overtimeWeight)
@@ -319,13 +423,19 @@ func (repo *PgOvertimeRepository) InsertOvertimeWeight(
 
 func (repo *PgOvertimeRepository) UpdateOvertimeWeight(params *param.EditOvertimeWeightParams) error {
 	overtimeWeight := m.OvertimeWeight{
-		NormalDayWeight: params.NormalDayWeight,
-		WeekendWeight:   params.WeekendWeight,
-		HolidayWeight:   params.HolidayWeight,
+		NormalDayWeight:    params.NormalDayWeight,
+		WeekendWeight:      params.WeekendWeight,
+		HolidayWeight:      params.HolidayWeight,
+		NightWeight:        params.NightWeight,
+		WeekendNightWeight: params.WeekendNightWeight,
+		HolidayNightWeight: params.HolidayNightWeight,
+		NightStart:         params.NightStart,
+		NightEnd:           params.NightEnd,
 	}
 
 	_, err := repo.DB.Model(&overtimeWeight).
-		Column("normal_day_weight", "weekend_weight", "holiday_weight", "updated_at").
+		Column("normal_day_weight", "weekend_weight", "holiday_weight", "night_weight", "weekend_night_weight",
+			"holiday_night_weight", "night_start", "night_end", "updated_at").
 		Where("id = ?", params.Id).
 		Update()
 
@@ -349,7 +459,8 @@ func (repo *PgOvertimeRepository) CountOvertimeWeightByField(field string, value
 func (repo *PgOvertimeRepository) SelectOvertimeWeightByOrganizationId(organizationId int) (m.OvertimeWeight, error) {
 	var overtimeWeight m.OvertimeWeight
 	err := repo.DB.Model(&overtimeWeight).
-		Column("id", "normal_day_weight", "weekend_weight", "holiday_weight").
+		Column("id", "normal_day_weight", "weekend_weight", "holiday_weight", "night_weight",
+			"weekend_night_weight", "holiday_night_weight", "night_start", "night_end").
 		Where("organization_id = ?", organizationId).
 		Select()
 
@@ -359,3 +470,444 @@ func (repo *PgOvertimeRepository) SelectOvertimeWeightByOrganizationId(organizat
 
 	return overtimeWeight, err
 }
+
+// ApproveOvertimeRequestStep : Move pending request to next step, pg.ErrNoRows when its step is no longer current step
+func (repo *PgOvertimeRepository) ApproveOvertimeRequestStep(
+	organizationId int,
+	userId int,
+	onBehalfOf int,
+	userOvertimeRequest m.UserOvertimeRequest,
+	note string,
+	notificationRepo rp.NotificationRepository,
+	nextApprovers []int,
+) (string, string, error) {
+	var body string
+	var link string
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		res, transErr := tx.Model(&m.UserOvertimeRequest{CurrentStep: userOvertimeRequest.CurrentStep + 1}).
+			Column("current_step", "updated_at").
+			Where("id = ?", userOvertimeRequest.ID).
+			Where("status = ?", cf.PendingRequestStatus).
+			Where("current_step = ?", userOvertimeRequest.CurrentStep).
+			Update()
+		if transErr != nil {
+			return transErr
+		}
+
+		// Step was approved by other approver or request was changed meanwhile
+		if res.RowsAffected() == 0 {
+			return pg.ErrNoRows
+		}
+
+		transErr = repo.insertOvertimeRequestHistoryWithTx(tx, m.OvertimeRequestHistory{
+			OvertimeRequestId: userOvertimeRequest.ID,
+			Step:              userOvertimeRequest.CurrentStep,
+			Action:            cf.ApproveOvertimeAction,
+			Status:            cf.PendingRequestStatus,
+			ActorId:           userId,
+			OnBehalfOf:        onBehalfOf,
+			Note:              note,
+		})
+		if transErr != nil {
+			return transErr
+		}
+
+		notificationParams := new(param.InsertNotificationParam)
+		notificationParams.RedirectUrl = "/request/manage-overtime?id=" + strconv.Itoa(userOvertimeRequest.ID)
+		notificationParams.Content = "has just approved step " + strconv.Itoa(userOvertimeRequest.CurrentStep) + " of a overtime request"
+		notificationParams.Receiver = userOvertimeRequest.UserId
+		transErr = notificationRepo.InsertNotificationWithTx(tx, organizationId, userId, notificationParams)
+		if transErr != nil {
+			return transErr
+		}
+
+		notificationParams.Content = "has just sent a overtime request to you for approval"
+		for _, approverId := range nextApprovers {
+			if approverId == userId {
+				continue
+			}
+			notificationParams.Receiver = approverId
+			transErr = notificationRepo.InsertNotificationWithTx(tx, organizationId, userId, notificationParams)
+			if transErr != nil {
+				return transErr
+			}
+		}
+
+		body = notificationParams.Content
+		link = notificationParams.RedirectUrl
+
+		return transErr
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return body, link, err
+}
+
+func (repo *PgOvertimeRepository) SelectOvertimeRequestHistories(overtimeRequestId int) ([]param.OvertimeRequestHistoryRecord, error) {
+	var records []param.OvertimeRequestHistoryRecord
+	err := repo.DB.Model(&m.OvertimeRequestHistory{}).
+		Column("orh.step", "orh.action", "orh.status", "orh.actor_id", "orh.on_behalf_of", "orh.note", "orh.created_at").
+		ColumnExpr("aup.first_name || ' ' || aup.last_name actor_name").
+		ColumnExpr("bup.first_name || ' ' || bup.last_name on_behalf_of_name").
+		Join("JOIN user_profiles AS aup ON aup.user_id = orh.actor_id").
+		Join("LEFT JOIN user_profiles AS bup ON bup.user_id = orh.on_behalf_of").
+		Where("orh.overtime_request_id = ?", overtimeRequestId).
+		Order("orh.created_at ASC").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+func (repo *PgOvertimeRepository) insertOvertimeRequestHistoryWithTx(tx *pg.Tx, history m.OvertimeRequestHistory) error {
+	err := tx.Insert(&history)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) InsertApprovalChain(organizationId int, params *param.CreateApprovalChainParams) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		chain := m.OvertimeApprovalChain{
+			OrganizationId: organizationId,
+			ProjectId:      params.ProjectId,
+			Name:           params.Name,
+		}
+
+		transErr := tx.Insert(&chain)
+		if transErr != nil {
+			return transErr
+		}
+
+		return repo.insertApprovalStepsWithTx(tx, chain.ID, params.Steps)
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) UpdateApprovalChain(params *param.EditApprovalChainParams) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		_, transErr := tx.Model(&m.OvertimeApprovalChain{Name: params.Name}).
+			Column("name", "updated_at").
+			Where("id = ?", params.Id).
+			Update()
+		if transErr != nil {
+			return transErr
+		}
+
+		_, transErr = tx.Model(&m.OvertimeApprovalStep{}).
+			Where("chain_id = ?", params.Id).
+			Delete()
+		if transErr != nil {
+			return transErr
+		}
+
+		return repo.insertApprovalStepsWithTx(tx, params.Id, params.Steps)
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) insertApprovalStepsWithTx(tx *pg.Tx, chainId int, steps []param.ApprovalStepParams) error {
+	for i, step := range steps {
+		approvalStep := m.OvertimeApprovalStep{
+			ChainId:      chainId,
+			StepOrder:    i + 1,
+			ApproverType: step.ApproverType,
+			ApproverId:   step.ApproverId,
+		}
+
+		if err := tx.Insert(&approvalStep); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *PgOvertimeRepository) DeleteApprovalChain(id int) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		_, transErr := tx.Model(&m.OvertimeApprovalStep{}).
+			Where("chain_id = ?", id).
+			Delete()
+		if transErr != nil {
+			return transErr
+		}
+
+		_, transErr = tx.Model(&m.OvertimeApprovalChain{}).
+			Where("id = ?", id).
+			Delete()
+
+		return transErr
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) SelectApprovalChainById(id int) (m.OvertimeApprovalChain, error) {
+	var chain m.OvertimeApprovalChain
+	err := repo.DB.Model(&chain).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return chain, err
+}
+
+func (repo *PgOvertimeRepository) SelectApprovalChains(organizationId int) ([]m.OvertimeApprovalChain, error) {
+	var chains []m.OvertimeApprovalChain
+	err := repo.DB.Model(&chains).
+		Where("organization_id = ?", organizationId).
+		Order("project_id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return chains, err
+}
+
+// SelectApprovalChainOfProject : Get chain of project, or default chain of organization when project has no chain
+func (repo *PgOvertimeRepository) SelectApprovalChainOfProject(organizationId int, projectId int) (m.OvertimeApprovalChain, error) {
+	var chain m.OvertimeApprovalChain
+	err := repo.DB.Model(&chain).
+		Where("organization_id = ?", organizationId).
+		Where("project_id IN (?, 0)", projectId).
+		Order("project_id DESC").
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return chain, err
+}
+
+func (repo *PgOvertimeRepository) CountApprovalChainByProject(organizationId int, projectId int) (int, error) {
+	count, err := repo.DB.Model(&m.OvertimeApprovalChain{}).
+		Where("organization_id = ?", organizationId).
+		Where("project_id = ?", projectId).
+		Count()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return count, err
+}
+
+func (repo *PgOvertimeRepository) SelectApprovalSteps(chainId int) ([]m.OvertimeApprovalStep, error) {
+	var steps []m.OvertimeApprovalStep
+	err := repo.DB.Model(&steps).
+		Where("chain_id = ?", chainId).
+		Order("step_order ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return steps, err
+}
+
+func (repo *PgOvertimeRepository) InsertApprovalDelegation(organizationId int, params *param.CreateApprovalDelegationParams) error {
+	delegation := m.OvertimeApprovalDelegation{
+		OrganizationId: organizationId,
+		ApproverId:     params.ApproverId,
+		DelegateId:     params.DelegateId,
+		DateFrom:       calendar.ParseTime(cf.FormatDateDatabase, params.DateFrom),
+		DateTo:         calendar.ParseTime(cf.FormatDateDatabase, params.DateTo),
+	}
+
+	err := repo.DB.Insert(&delegation)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) DeleteApprovalDelegation(id int) error {
+	_, err := repo.DB.Model(&m.OvertimeApprovalDelegation{}).
+		Where("id = ?", id).
+		Delete()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) SelectApprovalDelegationById(id int) (m.OvertimeApprovalDelegation, error) {
+	var delegation m.OvertimeApprovalDelegation
+	err := repo.DB.Model(&delegation).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return delegation, err
+}
+
+func (repo *PgOvertimeRepository) SelectApprovalDelegations(organizationId int, userId int) ([]param.ApprovalDelegationRecord, error) {
+	var records []param.ApprovalDelegationRecord
+	q := repo.DB.Model(&m.OvertimeApprovalDelegation{}).
+		Column("oad.id", "oad.approver_id", "oad.delegate_id", "oad.date_from", "oad.date_to").
+		ColumnExpr("aup.first_name || ' ' || aup.last_name approver_name").
+		ColumnExpr("dup.first_name || ' ' || dup.last_name delegate_name").
+		Join("JOIN user_profiles AS aup ON aup.user_id = oad.approver_id").
+		Join("JOIN user_profiles AS dup ON dup.user_id = oad.delegate_id").
+		Where("oad.organization_id = ?", organizationId)
+
+	if userId != 0 {
+		q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("oad.approver_id = ?", userId).
+				WhereOr("oad.delegate_id = ?", userId)
+			return q, nil
+		})
+	}
+
+	err := q.Order("oad.date_from DESC").Select(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectActiveDelegations : Get delegations of approvers which cover the date
+func (repo *PgOvertimeRepository) SelectActiveDelegations(
+	organizationId int,
+	approverIds []int,
+	date string,
+) ([]m.OvertimeApprovalDelegation, error) {
+	var delegations []m.OvertimeApprovalDelegation
+	if len(approverIds) == 0 {
+		return delegations, nil
+	}
+
+	err := repo.DB.Model(&delegations).
+		Where("organization_id = ?", organizationId).
+		Where("approver_id IN (?)", pg.In(approverIds)).
+		Where("date_from <= to_date(?,'YYYY-MM-DD')", date).
+		Where("date_to >= to_date(?,'YYYY-MM-DD')", date).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return delegations, err
+}
+
+func (repo *PgOvertimeRepository) InsertOvertimeCap(organizationId int, params *param.CreateOvertimeCapParams) error {
+	overtimeCap := m.OvertimeCap{
+		OrganizationId: organizationId,
+		DailyHour:      params.DailyHour,
+		MonthlyHour:    params.MonthlyHour,
+		YearlyHour:     params.YearlyHour,
+		ExceedAction:   params.ExceedAction,
+	}
+
+	err := repo.DB.Insert(&overtimeCap)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) UpdateOvertimeCap(params *param.EditOvertimeCapParams) error {
+	overtimeCap := m.OvertimeCap{
+		DailyHour:    params.DailyHour,
+		MonthlyHour:  params.MonthlyHour,
+		YearlyHour:   params.YearlyHour,
+		ExceedAction: params.ExceedAction,
+	}
+
+	_, err := repo.DB.Model(&overtimeCap).
+		Column("daily_hour", "monthly_hour", "yearly_hour", "exceed_action", "updated_at").
+		Where("id = ?", params.Id).
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgOvertimeRepository) CountOvertimeCapByField(field string, value int) (int, error) {
+	count, err := repo.DB.Model(&m.OvertimeCap{}).Where(field+" = ?", value).Count()
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return count, err
+}
+
+func (repo *PgOvertimeRepository) SelectOvertimeCapByOrganizationId(organizationId int) (m.OvertimeCap, error) {
+	var overtimeCap m.OvertimeCap
+	err := repo.DB.Model(&overtimeCap).
+		Column("id", "daily_hour", "monthly_hour", "yearly_hour", "exceed_action").
+		Where("organization_id = ?", organizationId).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return overtimeCap, err
+}
+
+// SelectCountedOvertimeRequests : Get requests of user which are not denied and start in [from, to)
+func (repo *PgOvertimeRepository) SelectCountedOvertimeRequests(
+	userId int,
+	from time.Time,
+	to time.Time,
+	exceptId int,
+) ([]m.UserOvertimeRequest, error) {
+	var records []m.UserOvertimeRequest
+	err := repo.DB.Model(&records).
+		Column("id", "user_id", "datetime_overtime_from", "datetime_overtime_to", "work_at_noon").
+		Where("user_id = ?", userId).
+		Where("status != ?", cf.DenyRequestStatus).
+		Where("datetime_overtime_from >= ?", from).
+		Where("datetime_overtime_from < ?", to).
+		Where("id != ?", exceptId).
+		Order("datetime_overtime_from ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
@@ -1,13 +1,21 @@
 package recruitment
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"html"
+	"math"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/360EntSecGroup-Skylar/excelize/v2"
 	valid "github.com/asaskevich/govalidator"
 	"github.com/go-pg/pg/v9"
 	"github.com/labstack/echo/v4"
@@ -18,10 +26,14 @@ import (
 	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
 	afb "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/appfirebase"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/captcha"
 	gc "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/cloud"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/document"
 	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/email"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/ratelimit"
 	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils"
 	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils/calendar"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils/jobfeed"
 )
 
 type Controller struct {
@@ -36,6 +48,10 @@ type Controller struct {
 	UserRepo         rp.UserRepository
 	NotificationRepo rp.NotificationRepository
 	FcmTokenRepo     rp.FcmTokenRepository
+	TechnologyRepo   rp.TechnologyRepository
+	OrgRepo          rp.OrgRepository
+	TimekeepingRepo  rp.TimekeepingRepository
+	Captcha          captcha.Verifier
 }
 
 func NewRecruitmentController(
@@ -47,9 +63,13 @@ func NewRecruitmentController(
 	userRepo rp.UserRepository,
 	notificationRepo rp.NotificationRepository,
 	fcmTokenRepo rp.FcmTokenRepository,
+	technologyRepo rp.TechnologyRepository,
+	orgRepo rp.OrgRepository,
+	timekeepingRepo rp.TimekeepingRepository,
 ) (ctr *Controller) {
 	ctr = &Controller{cm.BaseController{}, email.SMTPGoMail{}, afb.FirebaseCloudMessage{}, cloud,
-		recruitmentRepo, projectRepo, branchRepo, userRepo, notificationRepo, fcmTokenRepo}
+		recruitmentRepo, projectRepo, branchRepo, userRepo, notificationRepo, fcmTokenRepo, technologyRepo,
+		orgRepo, timekeepingRepo, captcha.NewVerifier(logger)}
 	ctr.Init(logger)
 	ctr.InitFcm()
 	return
@@ -643,7 +663,14 @@ func (ctr *Controller) UploadCv(c echo.Context) error {
 
 	userProfile := c.Get("user_profile").(m.User)
 	if len(params.CvFields) > 0 {
-		for _, cv := range params.CvFields {
+		for i, cv := range params.CvFields {
+			params.CvFields[i].CvText = ctr.extractCvText(cv.FileName, cv.Content)
+			info := document.ParseCv(params.CvFields[i].CvText, nil)
+			params.CvFields[i].Contact = param.CandidateContact{
+				FullName:    info.FullName,
+				Email:       info.Email,
+				PhoneNumber: info.PhoneNumber,
+			}
 			err := ctr.Cloud.UploadFileToCloud(
 				cv.Content,
 				cv.FileName,
@@ -785,6 +812,7 @@ func (ctr *Controller) CreateCv(c echo.Context) error {
 		Salary:          params.Salary,
 		ContactLink:     params.ContactLink,
 		MediaIdOther:    params.MediaIdOther,
+		CvText:          ctr.extractCvText(params.FileName, params.FileContent),
 	}
 	
 	body, link, err := ctr.RecruitmentRepo.CreateCvNoti(&cv, userProfile.OrganizationID, userProfile.UserProfile.UserID, params, ctr.NotificationRepo)
@@ -893,6 +921,14 @@ func (ctr *Controller) GetCvs(c echo.Context) error {
 		"row_per_page": params.RowPerPage,
 	}
 
+	stages, err := ctr.recruitmentStages(userProfile.OrganizationID, params.RecruitmentId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
 	var responses []map[string]interface{}
 	for _, record := range cvsRecord {
 
@@ -913,6 +949,9 @@ func (ctr *Controller) GetCvs(c echo.Context) error {
 			"status":          record.StatusCV,
 			"file_content":    base64ContentFile,
 			"media_id_other":	record.MediaIDOther,
+			"stage_id":        record.StageId,
+			"stage_name":      stages[cvStageIndex(stages, record.StageId, record.StatusCV)].Name,
+			"days_in_stage":   daysInStage(record.StageEnteredAt, record.DateReceiptCv),
 		}
 		responses = append(responses, res)
 	}
@@ -1140,6 +1179,19 @@ func (ctr *Controller) GetCvById(c echo.Context) error {
 			strings.Replace(recruitment.JobName, " ", "_", -1) + cv.FileName
 	}
 
+	scorecards, pendingRounds, _, err := ctr.visibleScorecards(userProfile, cv.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var scorecardSummaryResp map[string]interface{}
+	if len(pendingRounds) == 0 {
+		scorecardSummaryResp = scorecardSummary(scorecards)
+	}
+
 	resp := map[string]interface{}{
 		"recruitment_id":   cv.RecruitmentId,
 		"full_name":        cv.FullName,
@@ -1154,6 +1206,8 @@ func (ctr *Controller) GetCvById(c echo.Context) error {
 		"file_name":        cv.FileName,
 		"file_content":     byteArr,
 		"file_path": 		filePath,
+		"scorecard_summary": scorecardSummaryResp,
+		"candidate_id":      cv.CandidateId,
 	}
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
@@ -1467,7 +1521,7 @@ func (ctr *Controller) GetLogCvStatus(c echo.Context) error {
 		})
 	}
 
-	columns := []string{"status", "update_day"}
+	columns := []string{"status", "update_day", "stage_id", "reject_reason"}
 	logCvStates, err := ctr.RecruitmentRepo.SelectLogCvStates(params.CvId, columns...)
 	if err != nil && err.Error() != pg.ErrNoRows.Error() {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
@@ -1476,11 +1530,23 @@ func (ctr *Controller) GetLogCvStatus(c echo.Context) error {
 		})
 	}
 
+	sort.SliceStable(logCvStates, func(i, j int) bool {
+		return logCvStates[i].UpdateDay.Before(logCvStates[j].UpdateDay)
+	})
+
 	var dataResponse []map[string]interface{}
-	for _, logcvstatus := range logCvStates {
+	for i, logcvstatus := range logCvStates {
+		leftAt := time.Now()
+		if i+1 < len(logCvStates) {
+			leftAt = logCvStates[i+1].UpdateDay
+		}
+
 		data := map[string]interface{}{
 			"datetime_update": logcvstatus.UpdateDay.Format(cf.FormatTimeDisplay),
 			"status":          logcvstatus.Status,
+			"stage_id":        logcvstatus.StageId,
+			"reject_reason":   logcvstatus.RejectReason,
+			"days_in_stage":   int(leftAt.Sub(logcvstatus.UpdateDay).Hours() / 24),
 		}
 
 		dataResponse = append(dataResponse, data)
@@ -1794,7 +1860,7 @@ func (ctr *Controller) CreateLogCvStatus(c echo.Context) error {
 		})
 	}
 
-	_, err = ctr.RecruitmentRepo.FindCvById(params.CvId)
+	cv, err := ctr.RecruitmentRepo.FindCvById(params.CvId)
 	if err != nil {
 		if err.Error() == pg.ErrNoRows.Error() {
 			return c.JSON(http.StatusNotFound, cf.JsonResponse{
@@ -1810,6 +1876,31 @@ func (ctr *Controller) CreateLogCvStatus(c echo.Context) error {
 	}
 	userProfile := c.Get("user_profile").(m.User)
 
+	if params.StageId != 0 {
+		stages, err := ctr.recruitmentStages(userProfile.OrganizationID, cv.RecruitmentId)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		stage, message := checkStageTransition(stages, cv, params.StageId, params.RejectReason)
+		if message != "" {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: message,
+			})
+		}
+
+		params.Status = stage.CvStatus
+	} else if params.Status == 0 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
 	logCvState := m.LogCvState{
 		CvId:      params.CvId,
 		Status:    params.Status,
@@ -1878,3 +1969,4507 @@ func (ctr *Controller) CreateLogCvStatus(c echo.Context) error {
 		Message: "Create log cv status successful",
 	})
 }
+
+// SaveRecruitmentStages : Save ordered pipeline stages of organization or of recruitment
+func (ctr *Controller) SaveRecruitmentStages(c echo.Context) error {
+	params := new(param.SaveRecruitmentStagesParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil || len(params.Stages) == 0 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	var stages []m.RecruitmentStage
+	for i, stageParams := range params.Stages {
+		if _, err := valid.ValidateStruct(stageParams); err != nil || !isValidRecruitmentStage(stageParams, i+1, len(params.Stages)) {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+				Data:    i,
+			})
+		}
+
+		cvStatus := stageParams.CvStatus
+		if cvStatus == 0 {
+			cvStatus = cf.StageTypeCvStatuses[stageParams.StageType]
+		}
+
+		stages = append(stages, m.RecruitmentStage{
+			BaseModel:     cm.BaseModel{ID: stageParams.Id},
+			Name:          stageParams.Name,
+			StageType:     stageParams.StageType,
+			Position:      i + 1,
+			CvStatus:      cvStatus,
+			NextPositions: stageParams.NextPositions,
+			RejectReasons: stageParams.RejectReasons,
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if params.RecruitmentId != 0 {
+		recruitment, err := ctr.RecruitmentRepo.SelectJob(params.RecruitmentId, "organization_id")
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if err != nil || recruitment.OrganizationId != userProfile.OrganizationID {
+			return c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Recruitment does not exist",
+			})
+		}
+	}
+
+	currentStages, err := ctr.RecruitmentRepo.SelectRecruitmentStages(userProfile.OrganizationID, params.RecruitmentId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	removedIds := make(map[int]bool)
+	for _, stage := range currentStages {
+		removedIds[stage.ID] = true
+	}
+
+	for _, stage := range stages {
+		if stage.ID == 0 {
+			continue
+		}
+
+		if !removedIds[stage.ID] {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Stage does not exist",
+			})
+		}
+
+		delete(removedIds, stage.ID)
+	}
+
+	if len(removedIds) > 0 {
+		var stageIds []int
+		for id := range removedIds {
+			stageIds = append(stageIds, id)
+		}
+
+		count, err := ctr.RecruitmentRepo.CountCvsInStages(stageIds)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if count > 0 {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Stage which has cvs can not be removed",
+			})
+		}
+	}
+
+	if err := ctr.RecruitmentRepo.SaveRecruitmentStages(userProfile.OrganizationID, params.RecruitmentId, stages); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Save recruitment stages successful",
+	})
+}
+
+// GetRecruitmentStages : Get pipeline of recruitment, which falls back to pipeline of organization and then to cv statuses
+func (ctr *Controller) GetRecruitmentStages(c echo.Context) error {
+	params := new(param.GetRecruitmentStagesParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	stages, err := ctr.recruitmentStages(userProfile.OrganizationID, params.RecruitmentId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var responses []map[string]interface{}
+	for _, stage := range stages {
+		responses = append(responses, recruitmentStageResponse(stage))
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get recruitment stages successful",
+		Data: map[string]interface{}{
+			"stages":      responses,
+			"stage_types": cf.RecruitmentStageTypes,
+			"cv_statuses": cf.CvStatuses,
+		},
+	})
+}
+
+// GetCvPipeline : Get cvs of recruitment grouped by stage for kanban view
+func (ctr *Controller) GetCvPipeline(c echo.Context) error {
+	params := new(param.GetCvPipelineParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(params.RecruitmentId, "assignees", "organization_id")
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Recruitment does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if recruitment.OrganizationId != userProfile.OrganizationID ||
+		(userProfile.RoleID != cf.GeneralManagerRoleID &&
+			userProfile.RoleID != cf.ManagerRoleID &&
+			!utils.FindIntInSlice(recruitment.Assignees, userProfile.UserProfile.UserID)) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to get cvs",
+		})
+	}
+
+	stages, err := ctr.recruitmentStages(userProfile.OrganizationID, params.RecruitmentId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	cvsRecord, _, err := ctr.RecruitmentRepo.SelectCvs(params.RecruitmentId, &param.GetCvsParam{
+		RecruitmentId: params.RecruitmentId,
+		NameApplicant: params.NameApplicant,
+		MediaID:       params.MediaID,
+		DateReceiptCv: params.DateReceiptCv,
+	})
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	durations, err := ctr.RecruitmentRepo.SelectStageDurations(params.RecruitmentId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	averageDays := make(map[int]float64)
+	for _, duration := range durations {
+		averageDays[duration.StageId] = duration.AverageDay
+	}
+
+	stageCvs := make([][]map[string]interface{}, len(stages))
+	for _, record := range cvsRecord {
+		idx := cvStageIndex(stages, record.StageId, record.StatusCV)
+		stageCvs[idx] = append(stageCvs[idx], map[string]interface{}{
+			"id":              record.Id,
+			"full_name":       record.FullName,
+			"date_receipt_cv": record.DateReceiptCv.Format(cf.FormatDateDisplay),
+			"media_id":        record.MediaID,
+			"media_id_other":  record.MediaIDOther,
+			"status":          record.StatusCV,
+			"days_in_stage":   daysInStage(record.StageEnteredAt, record.DateReceiptCv),
+		})
+	}
+
+	var columns []map[string]interface{}
+	for i, stage := range stages {
+		column := recruitmentStageResponse(stage)
+		column["average_day"] = math.Round(averageDays[stage.ID]*10) / 10
+		column["cv_count"] = len(stageCvs[i])
+		column["cvs"] = stageCvs[i]
+		columns = append(columns, column)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get cv pipeline successful",
+		Data: map[string]interface{}{
+			"stages": columns,
+		},
+	})
+}
+
+// recruitmentStages : Stages of recruitment, else default stages of organization, else stages built from cv statuses
+func (ctr *Controller) recruitmentStages(organizationId int, recruitmentId int) ([]m.RecruitmentStage, error) {
+	scopes := []int{0}
+	if recruitmentId != 0 {
+		scopes = []int{recruitmentId, 0}
+	}
+
+	for _, scope := range scopes {
+		stages, err := ctr.RecruitmentRepo.SelectRecruitmentStages(organizationId, scope)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return nil, err
+		}
+
+		if len(stages) > 0 {
+			return stages, nil
+		}
+	}
+
+	var stages []m.RecruitmentStage
+	defaultStages := [][2]int{
+		{cf.CVPENDING, cf.ScreeningStage},
+		{cf.CVINTERVIEW, cf.InterviewStage},
+		{cf.CVPASSROUNDONE, cf.InterviewStage},
+		{cf.CVPASSFINAL, cf.HiredStage},
+		{cf.CVNOTPASS, cf.RejectedStage},
+		{cf.CVREJECT, cf.RejectedStage},
+	}
+	for i, defaultStage := range defaultStages {
+		stages = append(stages, m.RecruitmentStage{
+			OrganizationId: organizationId,
+			Name:           cf.CvStatuses[defaultStage[0]],
+			StageType:      defaultStage[1],
+			Position:       i + 1,
+			CvStatus:       defaultStage[0],
+		})
+	}
+
+	return stages, nil
+}
+
+// checkStageTransition : Stage which cv can move to with message when move is not allowed
+func checkStageTransition(stages []m.RecruitmentStage, cv *m.Cv, stageId int, rejectReason string) (m.RecruitmentStage, string) {
+	nextIdx := -1
+	for i, stage := range stages {
+		if stage.ID != 0 && stage.ID == stageId {
+			nextIdx = i
+		}
+	}
+
+	if nextIdx == -1 {
+		return m.RecruitmentStage{}, "Stage does not exist"
+	}
+
+	current := stages[cvStageIndex(stages, cv.StageId, cv.StatusCv)]
+	next := stages[nextIdx]
+	if current.ID == next.ID && cv.StageId != 0 {
+		return next, "Cv is already in this stage"
+	}
+
+	if len(current.NextPositions) > 0 {
+		if !utils.FindIntInSlice(current.NextPositions, next.Position) {
+			return next, "Cv can not move from " + current.Name + " to " + next.Name
+		}
+	} else if cv.StageId != 0 && (current.StageType == cf.HiredStage || current.StageType == cf.RejectedStage) {
+		return next, "Cv can not leave " + current.Name
+	}
+
+	if next.StageType == cf.RejectedStage {
+		if rejectReason == "" {
+			return next, "Reject reason is required"
+		}
+
+		if _, ok := utils.FindStringInArray(next.RejectReasons, rejectReason); len(next.RejectReasons) > 0 && !ok {
+			return next, "Reject reason is invalid"
+		}
+	}
+
+	return next, ""
+}
+
+// cvStageIndex : Index of stage which cv is in, cv without stage is in first stage which sets its status
+func cvStageIndex(stages []m.RecruitmentStage, stageId int, statusCv int) int {
+	for i, stage := range stages {
+		if stageId != 0 && stage.ID == stageId {
+			return i
+		}
+	}
+
+	if stageId == 0 {
+		for i, stage := range stages {
+			if stage.CvStatus == statusCv {
+				return i
+			}
+		}
+	}
+
+	return 0
+}
+
+func daysInStage(stageEnteredAt time.Time, dateReceiptCv time.Time) int {
+	if stageEnteredAt.IsZero() {
+		stageEnteredAt = dateReceiptCv
+	}
+
+	if stageEnteredAt.IsZero() {
+		return 0
+	}
+
+	return int(time.Since(stageEnteredAt).Hours() / 24)
+}
+
+func isValidRecruitmentStage(params param.RecruitmentStageParams, position int, stageCount int) bool {
+	if _, ok := cf.RecruitmentStageTypes[params.StageType]; !ok {
+		return false
+	}
+
+	if _, ok := cf.CvStatuses[params.CvStatus]; params.CvStatus != 0 && !ok {
+		return false
+	}
+
+	for _, nextPosition := range params.NextPositions {
+		if nextPosition < 1 || nextPosition > stageCount || nextPosition == position {
+			return false
+		}
+	}
+
+	return true
+}
+
+func recruitmentStageResponse(stage m.RecruitmentStage) map[string]interface{} {
+	return map[string]interface{}{
+		"id":              stage.ID,
+		"name":            stage.Name,
+		"stage_type":      stage.StageType,
+		"stage_type_name": cf.RecruitmentStageTypes[stage.StageType],
+		"position":        stage.Position,
+		"cv_status":       stage.CvStatus,
+		"next_positions":  stage.NextPositions,
+		"reject_reasons":  stage.RejectReasons,
+	}
+}
+
+// CreateInterview : Schedule interview of cv with panel and email icalendar invite to candidate and panel
+func (ctr *Controller) CreateInterview(c echo.Context) error {
+	params := new(param.CreateInterviewParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	startTime, endTime, ok := parseInterviewSlot(params.StartTime, params.EndTime)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field start_time or end_time",
+		})
+	}
+
+	cv, err := ctr.RecruitmentRepo.FindCvById(params.CvId)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Cv does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(cv.RecruitmentId, "organization_id", "assignees", "job_name")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if !canManageCvs(userProfile, recruitment) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to schedule interview",
+		})
+	}
+
+	panel, message, err := ctr.interviewPanel(userProfile.OrganizationID, params.Interviewers)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if message != "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: message,
+		})
+	}
+
+	conflicts, err := ctr.RecruitmentRepo.SelectInterviewConflicts(userProfile.OrganizationID, 0, cv.ID, params.Interviewers, startTime, endTime)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if len(conflicts) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Interview slot conflicts with leave or other interviews",
+			Data:    conflicts,
+		})
+	}
+
+	interview := m.Interview{
+		OrganizationId: userProfile.OrganizationID,
+		RecruitmentId:  cv.RecruitmentId,
+		CvId:           cv.ID,
+		Round:          params.Round,
+		Interviewers:   params.Interviewers,
+		StartTime:      startTime,
+		EndTime:        endTime,
+		Location:       params.Location,
+		MeetingLink:    params.MeetingLink,
+		Note:           params.Note,
+		Status:         cf.InterviewScheduled,
+		CreatedBy:      userProfile.UserProfile.UserID,
+	}
+	if err := ctr.RecruitmentRepo.InsertInterview(&interview); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	languageId := candidateEmailLanguage(params.LanguageId, userProfile)
+	ctr.sendInterviewInvite(userProfile, interview, cv, recruitment.JobName, panel, calendar.IcsRequest, true, languageId)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create interview successful",
+		Data:    map[string]interface{}{"id": interview.ID},
+	})
+}
+
+// RescheduleInterview : Move interview to new slot or panel, panel members who are removed receive cancellation
+func (ctr *Controller) RescheduleInterview(c echo.Context) error {
+	params := new(param.RescheduleInterviewParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	startTime, endTime, ok := parseInterviewSlot(params.StartTime, params.EndTime)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field start_time or end_time",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	interview, cv, recruitment, response := ctr.findInterview(c, userProfile, params.Id)
+	if response != nil || interview.ID == 0 {
+		return response
+	}
+
+	if interview.Status != cf.InterviewScheduled {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Only scheduled interview can be rescheduled",
+		})
+	}
+
+	panel, message, err := ctr.interviewPanel(userProfile.OrganizationID, params.Interviewers)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if message != "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: message,
+		})
+	}
+
+	conflicts, err := ctr.RecruitmentRepo.SelectInterviewConflicts(userProfile.OrganizationID, interview.ID, cv.ID, params.Interviewers, startTime, endTime)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if len(conflicts) > 0 {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Interview slot conflicts with leave or other interviews",
+			Data:    conflicts,
+		})
+	}
+
+	var removedIds []int
+	for _, userId := range interview.Interviewers {
+		if !utils.FindIntInSlice(params.Interviewers, userId) {
+			removedIds = append(removedIds, userId)
+		}
+	}
+
+	removedPanel, _, err := ctr.interviewPanel(userProfile.OrganizationID, removedIds)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	interview.Interviewers = params.Interviewers
+	interview.StartTime = startTime
+	interview.EndTime = endTime
+	interview.Location = params.Location
+	interview.MeetingLink = params.MeetingLink
+	interview.Note = params.Note
+	interview.Sequence++
+	err = ctr.RecruitmentRepo.UpdateInterview(
+		&interview,
+		"interviewers", "start_time", "end_time", "location", "meeting_link", "note", "sequence",
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	languageId := candidateEmailLanguage(params.LanguageId, userProfile)
+	ctr.sendInterviewInvite(userProfile, interview, cv, recruitment.JobName, removedPanel, calendar.IcsCancel, false, languageId)
+	ctr.sendInterviewInvite(userProfile, interview, cv, recruitment.JobName, panel, calendar.IcsRequest, true, languageId)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Reschedule interview successful",
+	})
+}
+
+// CancelInterview : Cancel scheduled interview and email cancellation to candidate and panel
+func (ctr *Controller) CancelInterview(c echo.Context) error {
+	params := new(param.CancelInterviewParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	interview, cv, recruitment, response := ctr.findInterview(c, userProfile, params.Id)
+	if response != nil || interview.ID == 0 {
+		return response
+	}
+
+	if interview.Status != cf.InterviewScheduled {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Only scheduled interview can be cancelled",
+		})
+	}
+
+	panel, _, err := ctr.interviewPanel(userProfile.OrganizationID, interview.Interviewers)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	interview.Status = cf.InterviewCancelled
+	interview.CancelReason = params.CancelReason
+	interview.Sequence++
+	if err := ctr.RecruitmentRepo.UpdateInterview(&interview, "status", "cancel_reason", "sequence"); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	ctr.sendInterviewInvite(userProfile, interview, cv, recruitment.JobName, panel, calendar.IcsCancel, true, candidateEmailLanguage(0, userProfile))
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Cancel interview successful",
+	})
+}
+
+// UpdateInterviewOutcome : Record outcome of interview, which completes it
+func (ctr *Controller) UpdateInterviewOutcome(c echo.Context) error {
+	params := new(param.UpdateInterviewOutcomeParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	if _, ok := cf.InterviewOutcomes[params.Outcome]; !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field outcome",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	interview, _, _, response := ctr.findInterview(c, userProfile, params.Id)
+	if response != nil || interview.ID == 0 {
+		return response
+	}
+
+	if interview.Status == cf.InterviewCancelled {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Interview was cancelled",
+		})
+	}
+
+	interview.Status = cf.InterviewCompleted
+	interview.Outcome = params.Outcome
+	interview.OutcomeNote = params.OutcomeNote
+	if err := ctr.RecruitmentRepo.UpdateInterview(&interview, "status", "outcome", "outcome_note"); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Update interview outcome successful",
+	})
+}
+
+// GetInterviews : Get interviews, user who is not manager only gets interviews which user sits on
+func (ctr *Controller) GetInterviews(c echo.Context) error {
+	params := new(param.GetInterviewsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if userProfile.RoleID != cf.GeneralManagerRoleID && userProfile.RoleID != cf.ManagerRoleID {
+		canViewRecruitment := false
+		if params.RecruitmentId != 0 {
+			recruitment, err := ctr.RecruitmentRepo.SelectJob(params.RecruitmentId, "organization_id", "assignees")
+			if err != nil && err.Error() != pg.ErrNoRows.Error() {
+				return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "System Error",
+				})
+			}
+
+			canViewRecruitment = err == nil && canManageCvs(userProfile, recruitment)
+		}
+
+		if !canViewRecruitment {
+			params.Interviewer = userProfile.UserProfile.UserID
+		}
+	}
+
+	records, err := ctr.RecruitmentRepo.SelectInterviews(userProfile.OrganizationID, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userNames := make(map[int]string)
+	for _, user := range users {
+		userNames[user.UserID] = user.FullName
+	}
+
+	var responses []map[string]interface{}
+	for _, record := range records {
+		var interviewers []map[string]interface{}
+		for _, userId := range record.Interviewers {
+			interviewers = append(interviewers, map[string]interface{}{
+				"user_id":   userId,
+				"full_name": userNames[userId],
+			})
+		}
+
+		responses = append(responses, map[string]interface{}{
+			"id":             record.Id,
+			"recruitment_id": record.RecruitmentId,
+			"job_name":       record.JobName,
+			"cv_id":          record.CvId,
+			"full_name":      record.FullName,
+			"email":          record.Email,
+			"round":          record.Round,
+			"interviewers":   interviewers,
+			"start_time":     record.StartTime.Format(cf.FormatDateNoSec),
+			"end_time":       record.EndTime.Format(cf.FormatDateNoSec),
+			"location":       record.Location,
+			"meeting_link":   record.MeetingLink,
+			"note":           record.Note,
+			"status":         record.Status,
+			"status_name":    cf.InterviewStatuses[record.Status],
+			"outcome":        record.Outcome,
+			"outcome_name":   cf.InterviewOutcomes[record.Outcome],
+			"outcome_note":   record.OutcomeNote,
+			"cancel_reason":  record.CancelReason,
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get interviews successful",
+		Data:    responses,
+	})
+}
+
+// CheckInterviewAvailability : Get leave requests and interviews which conflict with slot of panel and candidate
+func (ctr *Controller) CheckInterviewAvailability(c echo.Context) error {
+	params := new(param.CheckInterviewAvailabilityParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	startTime, endTime, ok := parseInterviewSlot(params.StartTime, params.EndTime)
+	if !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field start_time or end_time",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	conflicts, err := ctr.RecruitmentRepo.SelectInterviewConflicts(userProfile.OrganizationID, params.Id, params.CvId, params.Interviewers, startTime, endTime)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Check interview availability successful",
+		Data: map[string]interface{}{
+			"available": len(conflicts) == 0,
+			"conflicts": conflicts,
+		},
+	})
+}
+
+// findInterview : Interview of organization with its cv and recruitment, interview id is 0 when response was written
+func (ctr *Controller) findInterview(c echo.Context, userProfile m.User, id int) (m.Interview, *m.Cv, m.Recruitment, error) {
+	interview, err := ctr.RecruitmentRepo.SelectInterview(id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return m.Interview{}, nil, m.Recruitment{}, c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Interview does not exist",
+			})
+		}
+
+		return m.Interview{}, nil, m.Recruitment{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if interview.OrganizationId != userProfile.OrganizationID {
+		return m.Interview{}, nil, m.Recruitment{}, c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Interview does not exist",
+		})
+	}
+
+	cv, err := ctr.RecruitmentRepo.FindCvById(interview.CvId)
+	if err != nil {
+		return m.Interview{}, nil, m.Recruitment{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(interview.RecruitmentId, "organization_id", "assignees", "job_name")
+	if err != nil {
+		return m.Interview{}, nil, m.Recruitment{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if !canManageCvs(userProfile, recruitment) {
+		return m.Interview{}, nil, m.Recruitment{}, c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to manage interview",
+		})
+	}
+
+	return interview, cv, recruitment, nil
+}
+
+// interviewPanel : Users of panel, message is not empty when some interviewer is not in organization
+func (ctr *Controller) interviewPanel(organizationId int, interviewers []int) ([]param.AllUserName, string, error) {
+	if len(interviewers) == 0 {
+		return nil, "", nil
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return nil, "", err
+	}
+
+	var panel []param.AllUserName
+	for _, user := range users {
+		if utils.FindIntInSlice(interviewers, user.UserID) {
+			panel = append(panel, user)
+		}
+	}
+
+	if len(panel) != len(interviewers) {
+		return panel, "Interviewer does not exist", nil
+	}
+
+	return panel, "", nil
+}
+
+// getOrgLocation : Timezone of organization from timekeeping setting, default is Asia/Ho_Chi_Minh when not yet setting
+func (ctr *Controller) getOrgLocation(orgID int) *time.Location {
+	timezone := cf.InterviewTimezone
+	setting, err := ctr.TimekeepingRepo.SelectTimekeepingSettingByOrganizationId(orgID)
+	if err == nil && setting.Timezone != "" {
+		timezone = setting.Timezone
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		ctr.Logger.Error(err)
+		if loc, err = time.LoadLocation(cf.InterviewTimezone); err != nil {
+			return time.UTC
+		}
+	}
+
+	return loc
+}
+
+// sendInterviewInvite : Email icalendar invite, or cancellation, to panel and to candidate when notifyCandidate is true.
+// Invite of candidate is written from interview invite template in language of candidate and is logged to cv
+func (ctr *Controller) sendInterviewInvite(
+	userProfile m.User,
+	interview m.Interview,
+	cv *m.Cv,
+	jobName string,
+	panel []param.AllUserName,
+	method string,
+	notifyCandidate bool,
+	languageId int,
+) {
+	if userProfile.Organization.Email == "" || userProfile.Organization.EmailPassword == "" {
+		return
+	}
+
+	var panelEmails []string
+	for _, user := range panel {
+		panelEmails = append(panelEmails, user.Email)
+	}
+
+	attendees := panelEmails
+	if notifyCandidate && cv.Email != "" {
+		attendees = append(attendees, cv.Email)
+	}
+
+	if len(attendees) == 0 {
+		return
+	}
+
+	loc := ctr.getOrgLocation(interview.OrganizationId)
+
+	summary := fmt.Sprintf("Interview round %d: %s - %s", interview.Round, cv.FullName, jobName)
+	ics := calendar.BuildIcs(calendar.IcsEvent{
+		Uid:            fmt.Sprintf("interview-%d-%d@micro-erp", interview.OrganizationId, interview.ID),
+		Sequence:       interview.Sequence,
+		Method:         method,
+		Summary:        summary,
+		Description:    interview.Note,
+		Location:       interview.Location,
+		Url:            interview.MeetingLink,
+		Start:          inLocation(interview.StartTime, loc),
+		End:            inLocation(interview.EndTime, loc),
+		OrganizerName:  userProfile.Organization.Name,
+		OrganizerEmail: userProfile.Organization.Email,
+		Attendees:      attendees,
+	})
+
+	subject := "【Notification】【Micro erp】Interview invitation"
+	action := "You are invited to"
+	if method == calendar.IcsCancel {
+		subject = "【Notification】【Micro erp】Interview cancelled"
+		action = "Cancelled:"
+	} else if interview.Sequence > 0 {
+		subject = "【Notification】【Micro erp】Interview rescheduled"
+		action = "Rescheduled:"
+	}
+
+	content := action + " " + summary + " from " + interview.StartTime.Format(cf.FormatTimeDisplay) +
+		" to " + interview.EndTime.Format(cf.FormatTimeDisplay)
+	if interview.Location != "" {
+		content += ". Location: " + interview.Location
+	}
+
+	if interview.Note != "" {
+		content += ". " + interview.Note
+	}
+
+	attachments := []email.Attachment{{
+		FileName:    "invite.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=" + method,
+		Content:     ics,
+	}}
+
+	if len(panelEmails) > 0 {
+		ctr.InitSmtp(userProfile.Organization.Email, userProfile.Organization.EmailPassword)
+		sampleData := new(param.SampleData)
+		sampleData.SendTo = panelEmails
+		sampleData.Content = content
+		if method != calendar.IcsCancel {
+			sampleData.URL = interview.MeetingLink
+		}
+
+		if err := ctr.SendMailWithAttachment(subject, sampleData, cf.InterviewTemplate, attachments); err != nil {
+			ctr.Logger.Error(err)
+		}
+	}
+
+	if !notifyCandidate || cv.Email == "" {
+		return
+	}
+
+	communication := m.CvCommunication{
+		Type:       cf.InterviewInviteEmail,
+		LanguageId: languageId,
+		Subject:    subject,
+		Body:       content,
+		SentBy:     userProfile.UserProfile.UserID,
+	}
+
+	url := ""
+	if method != calendar.IcsCancel {
+		url = interview.MeetingLink
+		templateSubject, templateBody, err := ctr.candidateEmailContent(userProfile.OrganizationID, cf.InterviewInviteEmail, languageId)
+		if err != nil {
+			ctr.Logger.Error(err)
+			return
+		}
+
+		fields := candidateEmailFields(cv, jobName, userProfile)
+		fields["{{interview_time}}"] = interview.StartTime.Format(cf.FormatTimeDisplay) + " - " +
+			interview.EndTime.Format(cf.FormatTimeDisplay)
+		fields["{{interview_location}}"] = interview.Location
+		fields["{{meeting_link}}"] = interview.MeetingLink
+		communication.Subject = renderCandidateEmail(templateSubject, fields)
+		communication.Body = renderCandidateEmail(templateBody, fields)
+	}
+
+	if err := ctr.sendCandidateEmail(userProfile.Organization, cv, &communication, url, attachments); err != nil {
+		ctr.Logger.Error(err)
+	}
+}
+
+// canManageCvs : Manager or assignee of recruitment in organization of user
+func canManageCvs(userProfile m.User, recruitment m.Recruitment) bool {
+	return recruitment.OrganizationId == userProfile.OrganizationID &&
+		(userProfile.RoleID == cf.GeneralManagerRoleID ||
+			userProfile.RoleID == cf.ManagerRoleID ||
+			utils.FindIntInSlice(recruitment.Assignees, userProfile.UserProfile.UserID))
+}
+
+func parseInterviewSlot(start string, end string) (time.Time, time.Time, bool) {
+	startTime, err := time.Parse(cf.FormatDateNoSec, start)
+	if err != nil {
+		return startTime, startTime, false
+	}
+
+	endTime, err := time.Parse(cf.FormatDateNoSec, end)
+	if err != nil || !endTime.After(startTime) {
+		return startTime, endTime, false
+	}
+
+	return startTime, endTime, true
+}
+
+// inLocation : Time which has same wall clock as t in loc, as slot is entered in local time of organization
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
+
+// SaveScorecardTemplate : Save competencies which interviewers rate candidates of recruitment on
+func (ctr *Controller) SaveScorecardTemplate(c echo.Context) error {
+	params := new(param.SaveScorecardTemplateParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	var competencies []m.ScorecardCompetency
+	for _, competency := range params.Competencies {
+		if _, err := valid.ValidateStruct(competency); err != nil {
+			return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Invalid field value",
+			})
+		}
+
+		for _, added := range competencies {
+			if strings.EqualFold(added.Name, competency.Name) {
+				return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "Competency " + competency.Name + " is duplicated",
+				})
+			}
+		}
+
+		competencies = append(competencies, m.ScorecardCompetency{
+			Name:         competency.Name,
+			Description:  competency.Description,
+			NoteRequired: competency.NoteRequired,
+		})
+	}
+
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(params.RecruitmentId, "organization_id", "assignees")
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Recruitment does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	if !canManageCvs(userProfile, recruitment) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to save scorecard template",
+		})
+	}
+
+	template, err := ctr.RecruitmentRepo.SelectScorecardTemplate(params.RecruitmentId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	template.OrganizationId = userProfile.OrganizationID
+	template.RecruitmentId = params.RecruitmentId
+	template.Competencies = competencies
+	if err := ctr.RecruitmentRepo.SaveScorecardTemplate(&template); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Save scorecard template successful",
+	})
+}
+
+func (ctr *Controller) GetScorecardTemplate(c echo.Context) error {
+	params := new(param.GetScorecardTemplateParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(params.RecruitmentId, "organization_id")
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || recruitment.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Recruitment does not exist",
+		})
+	}
+
+	template, err := ctr.RecruitmentRepo.SelectScorecardTemplate(params.RecruitmentId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get scorecard template successful",
+		Data: map[string]interface{}{
+			"competencies":    template.Competencies,
+			"min_score":       cf.MinCompetencyScore,
+			"max_score":       cf.MaxCompetencyScore,
+			"recommendations": cf.ScorecardRecommendations,
+		},
+	})
+}
+
+// SaveScorecard : Save scorecard of logged in interviewer for round of interview, as draft or submitted
+func (ctr *Controller) SaveScorecard(c echo.Context) error {
+	params := new(param.SaveScorecardParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	if _, ok := cf.ScorecardRecommendations[params.Recommendation]; params.Recommendation != 0 && !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field recommendation",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	interview, err := ctr.RecruitmentRepo.SelectInterview(params.InterviewId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || interview.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Interview does not exist",
+		})
+	}
+
+	if !utils.FindIntInSlice(interview.Interviewers, userProfile.UserProfile.UserID) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You are not interviewer of this interview",
+		})
+	}
+
+	if interview.Status == cf.InterviewCancelled {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Interview was cancelled",
+		})
+	}
+
+	scorecards, err := ctr.RecruitmentRepo.SelectScorecards(interview.CvId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	scorecard := m.Scorecard{
+		OrganizationId: interview.OrganizationId,
+		RecruitmentId:  interview.RecruitmentId,
+		CvId:           interview.CvId,
+		InterviewId:    interview.ID,
+		Round:          interview.Round,
+		InterviewerId:  userProfile.UserProfile.UserID,
+	}
+	for _, existing := range scorecards {
+		if existing.Round == interview.Round && existing.InterviewerId == userProfile.UserProfile.UserID {
+			scorecard = existing
+		}
+	}
+
+	if scorecard.Status == cf.ScorecardSubmitted {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Scorecard was submitted",
+		})
+	}
+
+	template, err := ctr.RecruitmentRepo.SelectScorecardTemplate(interview.RecruitmentId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	ratings, message := scorecardRatings(template.Competencies, params)
+	if message != "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: message,
+		})
+	}
+
+	scorecard.Ratings = ratings
+	scorecard.Recommendation = params.Recommendation
+	scorecard.Note = params.Note
+	scorecard.Status = cf.ScorecardDraft
+	if params.Submit {
+		scorecard.Status = cf.ScorecardSubmitted
+		scorecard.SubmittedAt = utils.TimeNowUTC()
+	}
+
+	if err := ctr.RecruitmentRepo.SaveScorecard(&scorecard); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Save scorecard successful",
+		Data:    map[string]interface{}{"id": scorecard.ID},
+	})
+}
+
+// GetScorecards : Get scorecards of cv, scorecards of round are hidden from interviewer until interviewer submits own one
+func (ctr *Controller) GetScorecards(c echo.Context) error {
+	params := new(param.GetScorecardsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	cv, err := ctr.RecruitmentRepo.FindCvById(params.CvId)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Cv does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(cv.RecruitmentId, "organization_id", "assignees")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	scorecards, pendingRounds, isInterviewer, err := ctr.visibleScorecards(userProfile, cv.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if !canManageCvs(userProfile, recruitment) && !isInterviewer {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to get scorecards",
+		})
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userNames := make(map[int]string)
+	for _, user := range users {
+		userNames[user.UserID] = user.FullName
+	}
+
+	var responses []map[string]interface{}
+	for _, scorecard := range scorecards {
+		res := map[string]interface{}{
+			"id":             scorecard.ID,
+			"interview_id":   scorecard.InterviewId,
+			"round":          scorecard.Round,
+			"interviewer_id": scorecard.InterviewerId,
+			"full_name":      userNames[scorecard.InterviewerId],
+			"status":         scorecard.Status,
+			"hidden":         pendingRounds[scorecard.Round] && scorecard.InterviewerId != userProfile.UserProfile.UserID,
+		}
+
+		if !res["hidden"].(bool) {
+			res["ratings"] = scorecard.Ratings
+			res["recommendation"] = scorecard.Recommendation
+			res["recommendation_name"] = cf.ScorecardRecommendations[scorecard.Recommendation]
+			res["note"] = scorecard.Note
+			if !scorecard.SubmittedAt.IsZero() {
+				res["submitted_at"] = scorecard.SubmittedAt.Format(cf.FormatTimeDisplay)
+			}
+		}
+
+		responses = append(responses, res)
+	}
+
+	var summary map[string]interface{}
+	if len(pendingRounds) == 0 {
+		summary = scorecardSummary(scorecards)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get scorecards successful",
+		Data: map[string]interface{}{
+			"scorecards": responses,
+			"summary":    summary,
+		},
+	})
+}
+
+// visibleScorecards : Submitted scorecards of cv and own draft of user, with rounds which user sits on but has not submitted
+func (ctr *Controller) visibleScorecards(userProfile m.User, cvId int) ([]m.Scorecard, map[int]bool, bool, error) {
+	userId := userProfile.UserProfile.UserID
+	scorecards, err := ctr.RecruitmentRepo.SelectScorecards(cvId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return nil, nil, false, err
+	}
+
+	interviews, err := ctr.RecruitmentRepo.SelectInterviews(userProfile.OrganizationID, &param.GetInterviewsParams{
+		CvId:        cvId,
+		Interviewer: userId,
+	})
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return nil, nil, false, err
+	}
+
+	pendingRounds := make(map[int]bool)
+	for _, interview := range interviews {
+		if interview.Status != cf.InterviewCancelled {
+			pendingRounds[interview.Round] = true
+		}
+	}
+
+	var visible []m.Scorecard
+	for _, scorecard := range scorecards {
+		if scorecard.InterviewerId == userId && scorecard.Status == cf.ScorecardSubmitted {
+			delete(pendingRounds, scorecard.Round)
+		}
+
+		if scorecard.Status == cf.ScorecardSubmitted || scorecard.InterviewerId == userId {
+			visible = append(visible, scorecard)
+		}
+	}
+
+	return visible, pendingRounds, len(interviews) > 0, nil
+}
+
+// scorecardRatings : Ratings in order of competencies of template, all competencies are rated when scorecard is submitted
+func scorecardRatings(competencies []m.ScorecardCompetency, params *param.SaveScorecardParams) ([]m.ScorecardRating, string) {
+	paramsRatings := make(map[string]param.ScorecardRatingParams)
+	for _, rating := range params.Ratings {
+		paramsRatings[strings.ToLower(rating.Name)] = rating
+	}
+
+	var ratings []m.ScorecardRating
+	for _, competency := range competencies {
+		rating, ok := paramsRatings[strings.ToLower(competency.Name)]
+		delete(paramsRatings, strings.ToLower(competency.Name))
+		if rating.Score != 0 && (rating.Score < cf.MinCompetencyScore || rating.Score > cf.MaxCompetencyScore) {
+			return nil, "Score of " + competency.Name + " must be from " + strconv.Itoa(cf.MinCompetencyScore) +
+				" to " + strconv.Itoa(cf.MaxCompetencyScore)
+		}
+
+		if params.Submit {
+			if !ok || rating.Score == 0 {
+				return nil, "Competency " + competency.Name + " is not rated"
+			}
+
+			if competency.NoteRequired && strings.TrimSpace(rating.Note) == "" {
+				return nil, "Note of " + competency.Name + " is required"
+			}
+		}
+
+		ratings = append(ratings, m.ScorecardRating{
+			Name:  competency.Name,
+			Score: rating.Score,
+			Note:  rating.Note,
+		})
+	}
+
+	for name := range paramsRatings {
+		return nil, "Competency " + name + " does not exist"
+	}
+
+	if params.Submit && params.Recommendation == 0 {
+		return nil, "Recommendation is required"
+	}
+
+	return ratings, ""
+}
+
+// scorecardSummary : Average score of each competency and recommendation of cv over submitted scorecards
+func scorecardSummary(scorecards []m.Scorecard) map[string]interface{} {
+	var (
+		competencyNames      []string
+		competencyTotals     = make(map[string]float64)
+		competencyCounts     = make(map[string]int)
+		recommendationCounts = make(map[string]int)
+		recommendationTotal  float64
+		submittedCount       int
+		scoreTotal           float64
+		scoreCount           int
+	)
+
+	for _, scorecard := range scorecards {
+		if scorecard.Status != cf.ScorecardSubmitted {
+			continue
+		}
+
+		submittedCount++
+		recommendationTotal += float64(scorecard.Recommendation)
+		recommendationCounts[cf.ScorecardRecommendations[scorecard.Recommendation]]++
+		for _, rating := range scorecard.Ratings {
+			if rating.Score == 0 {
+				continue
+			}
+
+			if _, ok := competencyCounts[rating.Name]; !ok {
+				competencyNames = append(competencyNames, rating.Name)
+			}
+
+			competencyTotals[rating.Name] += float64(rating.Score)
+			competencyCounts[rating.Name]++
+			scoreTotal += float64(rating.Score)
+			scoreCount++
+		}
+	}
+
+	if submittedCount == 0 {
+		return map[string]interface{}{
+			"submitted_count": 0,
+		}
+	}
+
+	var competencies []map[string]interface{}
+	for _, name := range competencyNames {
+		competencies = append(competencies, map[string]interface{}{
+			"name":          name,
+			"average_score": math.Round(competencyTotals[name]/float64(competencyCounts[name])*100) / 100,
+		})
+	}
+
+	averageScore := 0.0
+	if scoreCount > 0 {
+		averageScore = math.Round(scoreTotal/float64(scoreCount)*100) / 100
+	}
+
+	recommendation := int(math.Round(recommendationTotal / float64(submittedCount)))
+
+	return map[string]interface{}{
+		"submitted_count":       submittedCount,
+		"average_score":         averageScore,
+		"competencies":          competencies,
+		"recommendation_counts": recommendationCounts,
+		"recommendation":        recommendation,
+		"recommendation_name":   cf.ScorecardRecommendations[recommendation],
+	}
+}
+
+// ParseCv : Read text of pdf or docx cv and guess details of candidate, which recruiter confirms before CreateCv
+func (ctr *Controller) ParseCv(c echo.Context) error {
+	params := new(param.ParseCvParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	content, err := base64.StdEncoding.DecodeString(params.FileContent)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field file_content",
+		})
+	}
+
+	text, err := document.ExtractText(params.FileName, content)
+	if err != nil {
+		ctr.Logger.Error(err)
+		message := "Can not read text of cv file"
+		if err == document.ErrUnsupportedFile {
+			message = "Only pdf and docx cv can be read"
+		}
+
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: message,
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	technologyRecords, err := ctr.TechnologyRepo.SelectTechnologies(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var technologies []string
+	for _, technology := range technologyRecords {
+		technologies = append(technologies, technology.Name)
+	}
+
+	info := document.ParseCv(text, technologies)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Parse cv successful",
+		Data: map[string]interface{}{
+			"full_name":           info.FullName,
+			"email":               info.Email,
+			"phone_number":        info.PhoneNumber,
+			"skills":              info.Skills,
+			"years_of_experience": info.YearsOfExperience,
+			"cv_text":             text,
+		},
+	})
+}
+
+// extractCvText : Text of base64 cv file which is stored for search, cv which can not be read is stored without text
+func (ctr *Controller) extractCvText(fileName string, fileContent string) string {
+	content, err := base64.StdEncoding.DecodeString(fileContent)
+	if err != nil {
+		ctr.Logger.Error(err)
+		return ""
+	}
+
+	text, err := document.ExtractText(fileName, content)
+	if err != nil && err != document.ErrUnsupportedFile {
+		ctr.Logger.Error(err)
+	}
+
+	return text
+}
+
+// GetCandidateHistory : Applications of candidate across recruitments, comments and status logs of cvs which user can manage
+func (ctr *Controller) GetCandidateHistory(c echo.Context) error {
+	params := new(param.GetCandidateParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	candidate, applications, err := ctr.findCandidate(c, userProfile, params)
+	if err != nil || candidate.ID == 0 {
+		return err
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userNames := make(map[int]string)
+	for _, user := range users {
+		userNames[user.UserID] = user.FullName
+	}
+
+	location, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
+	var applicationResponses []map[string]interface{}
+	for _, application := range applications {
+		res := map[string]interface{}{
+			"cv_id":           application.CvId,
+			"recruitment_id":  application.RecruitmentId,
+			"job_name":        application.JobName,
+			"date_receipt_cv": application.DateReceiptCv.Format(cf.FormatDateDisplay),
+			"status_cv":       application.StatusCv,
+			"stage_id":        application.StageId,
+			"media_id":        application.MediaId,
+			"media_id_other":  application.MediaIdOther,
+			"can_view":        false,
+		}
+
+		recruitment := m.Recruitment{OrganizationId: userProfile.OrganizationID, Assignees: application.Assignees}
+		if !canManageCvs(userProfile, recruitment) {
+			applicationResponses = append(applicationResponses, res)
+			continue
+		}
+
+		cvComments, err := ctr.RecruitmentRepo.SelectCvCommentsByCvId(application.CvId, "id", "created_by", "comment", "created_at")
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		logCvStates, err := ctr.RecruitmentRepo.SelectLogCvStates(application.CvId, "status", "update_day", "stage_id", "reject_reason")
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		sort.SliceStable(logCvStates, func(i, j int) bool {
+			return logCvStates[i].UpdateDay.Before(logCvStates[j].UpdateDay)
+		})
+
+		var comments []map[string]interface{}
+		for _, cvComment := range cvComments {
+			comments = append(comments, map[string]interface{}{
+				"id":         cvComment.ID,
+				"comment":    cvComment.Comment,
+				"created_by": cvComment.CreatedBy,
+				"full_name":  userNames[cvComment.CreatedBy],
+				"created_at": cvComment.CreatedAt.In(location).Format(cf.FormatTimeDisplay),
+			})
+		}
+
+		var statusLogs []map[string]interface{}
+		for _, logCvState := range logCvStates {
+			statusLogs = append(statusLogs, map[string]interface{}{
+				"datetime_update": logCvState.UpdateDay.Format(cf.FormatTimeDisplay),
+				"status":          logCvState.Status,
+				"stage_id":        logCvState.StageId,
+				"reject_reason":   logCvState.RejectReason,
+			})
+		}
+
+		res["can_view"] = true
+		res["full_name"] = application.FullName
+		res["email"] = application.Email
+		res["phone_number"] = application.PhoneNumber
+		res["file_name"] = application.FileName
+		res["comments"] = comments
+		res["status_logs"] = statusLogs
+		applicationResponses = append(applicationResponses, res)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get candidate history successful",
+		Data: map[string]interface{}{
+			"candidate_id": candidate.ID,
+			"full_name":    candidate.FullName,
+			"email":        candidate.Email,
+			"phone_number": candidate.PhoneNumber,
+			"applications": applicationResponses,
+		},
+	})
+}
+
+// GetCandidateDuplicates : Other candidates with same email or phone number, or with similar name, who may be the same person
+func (ctr *Controller) GetCandidateDuplicates(c echo.Context) error {
+	params := new(param.GetCandidateParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	candidate, _, err := ctr.findCandidate(c, userProfile, params)
+	if err != nil || candidate.ID == 0 {
+		return err
+	}
+
+	candidates, err := ctr.RecruitmentRepo.SelectCandidates(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	type duplicate struct {
+		candidate  m.Candidate
+		reasons    []string
+		similarity float64
+	}
+
+	var duplicates []duplicate
+	for _, other := range candidates {
+		if other.ID == candidate.ID {
+			continue
+		}
+
+		var reasons []string
+		if candidate.NormalizedEmail != "" && other.NormalizedEmail == candidate.NormalizedEmail {
+			reasons = append(reasons, "email")
+		}
+
+		if candidate.NormalizedPhone != "" && other.NormalizedPhone == candidate.NormalizedPhone {
+			reasons = append(reasons, "phone_number")
+		}
+
+		similarity := utils.NameSimilarity(candidate.NormalizedName, other.NormalizedName)
+		if similarity >= cf.CandidateNameSimilarity {
+			reasons = append(reasons, "full_name")
+		}
+
+		if len(reasons) > 0 {
+			duplicates = append(duplicates, duplicate{other, reasons, similarity})
+		}
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		if len(duplicates[i].reasons) != len(duplicates[j].reasons) {
+			return len(duplicates[i].reasons) > len(duplicates[j].reasons)
+		}
+
+		return duplicates[i].similarity > duplicates[j].similarity
+	})
+
+	var responses []map[string]interface{}
+	for _, d := range duplicates {
+		responses = append(responses, map[string]interface{}{
+			"candidate_id":    d.candidate.ID,
+			"full_name":       d.candidate.FullName,
+			"email":           d.candidate.Email,
+			"phone_number":    d.candidate.PhoneNumber,
+			"reasons":         d.reasons,
+			"name_similarity": math.Round(d.similarity*100) / 100,
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get candidate duplicates successful",
+		Data:    responses,
+	})
+}
+
+// MergeCandidates : Merge candidates who are the same person, user must be able to manage all of their cvs
+func (ctr *Controller) MergeCandidates(c echo.Context) error {
+	params := new(param.MergeCandidatesParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil || len(params.MergedCandidateIds) == 0 ||
+		utils.FindIntInSlice(params.MergedCandidateIds, params.CandidateId) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	var candidates []m.Candidate
+	for _, id := range append([]int{params.CandidateId}, params.MergedCandidateIds...) {
+		candidate, err := ctr.RecruitmentRepo.SelectCandidate(userProfile.OrganizationID, id)
+		if err != nil {
+			if err.Error() == pg.ErrNoRows.Error() {
+				return c.JSON(http.StatusNotFound, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "Candidate does not exist",
+				})
+			}
+
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		applications, err := ctr.RecruitmentRepo.SelectCandidateApplications(id)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		for _, application := range applications {
+			recruitment := m.Recruitment{OrganizationId: userProfile.OrganizationID, Assignees: application.Assignees}
+			if !canManageCvs(userProfile, recruitment) {
+				return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "You do not have permission to merge candidates",
+				})
+			}
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	candidate := candidates[0]
+	if err := ctr.RecruitmentRepo.MergeCandidates(&candidate, candidates[1:]); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Merge candidates successful",
+		Data: map[string]interface{}{
+			"candidate_id": candidate.ID,
+			"full_name":    candidate.FullName,
+			"email":        candidate.Email,
+			"phone_number": candidate.PhoneNumber,
+		},
+	})
+}
+
+// findCandidate : Candidate by id or by cv, cv which was created before candidates is linked on the way.
+// Candidate id is 0 when response was written
+func (ctr *Controller) findCandidate(
+	c echo.Context,
+	userProfile m.User,
+	params *param.GetCandidateParams,
+) (m.Candidate, []param.CandidateApplicationRecords, error) {
+	if params.CandidateId == 0 && params.CvId == 0 {
+		return m.Candidate{}, nil, c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	candidateId := params.CandidateId
+	if params.CvId != 0 {
+		cv, err := ctr.RecruitmentRepo.FindCvById(params.CvId)
+		if err != nil {
+			if err.Error() == pg.ErrNoRows.Error() {
+				return m.Candidate{}, nil, c.JSON(http.StatusNotFound, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "Cv does not exist",
+				})
+			}
+
+			return m.Candidate{}, nil, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		recruitment, err := ctr.RecruitmentRepo.SelectJob(cv.RecruitmentId, "organization_id", "assignees")
+		if err != nil {
+			return m.Candidate{}, nil, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if !canManageCvs(userProfile, recruitment) {
+			return m.Candidate{}, nil, c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "You do not have permission to get candidate",
+			})
+		}
+
+		candidateId = cv.CandidateId
+		if candidateId == 0 {
+			contact := param.CandidateContact{FullName: cv.FullName, Email: cv.Email, PhoneNumber: cv.PhoneNumber}
+			if contact.Email == "" && contact.PhoneNumber == "" {
+				info := document.ParseCv(cv.CvText, nil)
+				contact = param.CandidateContact{FullName: info.FullName, Email: info.Email, PhoneNumber: info.PhoneNumber}
+			}
+
+			candidateId, err = ctr.RecruitmentRepo.LinkCvCandidate(recruitment.OrganizationId, cv.ID, contact)
+			if err != nil {
+				return m.Candidate{}, nil, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "System Error",
+				})
+			}
+		}
+	}
+
+	candidate, err := ctr.RecruitmentRepo.SelectCandidate(userProfile.OrganizationID, candidateId)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return m.Candidate{}, nil, c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Candidate does not exist",
+			})
+		}
+
+		return m.Candidate{}, nil, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	applications, err := ctr.RecruitmentRepo.SelectCandidateApplications(candidate.ID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return m.Candidate{}, nil, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	canView := params.CvId != 0 || userProfile.RoleID == cf.GeneralManagerRoleID || userProfile.RoleID == cf.ManagerRoleID
+	for _, application := range applications {
+		recruitment := m.Recruitment{OrganizationId: userProfile.OrganizationID, Assignees: application.Assignees}
+		canView = canView || canManageCvs(userProfile, recruitment)
+	}
+
+	if !canView {
+		return m.Candidate{}, nil, c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to get candidate",
+		})
+	}
+
+	return candidate, applications, nil
+}
+
+// GetCareerJobs : Public list of jobs which organization is recruiting for now
+func (ctr *Controller) GetCareerJobs(c echo.Context) error {
+	params := new(param.GetCareerJobsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	organization, err := ctr.findCareerOrganization(c, params.OrganizationTag)
+	if err != nil || organization.ID == 0 {
+		return err
+	}
+
+	if params.CurrentPage < 1 {
+		params.CurrentPage = 1
+	}
+
+	records, totalRow, err := ctr.RecruitmentRepo.SelectActiveJobs(organization.ID, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var jobs []map[string]interface{}
+	for _, record := range records {
+		jobs = append(jobs, careerJobResponse(record))
+	}
+
+	pagination := map[string]interface{}{
+		"current_page": params.CurrentPage,
+		"total_row":    totalRow,
+		"row_per_page": params.RowPerPage,
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get jobs successful",
+		Data: map[string]interface{}{
+			"organization_name": organization.Name,
+			"pagination":        pagination,
+			"jobs":              jobs,
+		},
+	})
+}
+
+// GetCareerJob : Public detail of job which organization is recruiting for now
+func (ctr *Controller) GetCareerJob(c echo.Context) error {
+	params := new(param.GetCareerJobParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	organization, err := ctr.findCareerOrganization(c, params.OrganizationTag)
+	if err != nil || organization.ID == 0 {
+		return err
+	}
+
+	record, err := ctr.RecruitmentRepo.SelectActiveJob(organization.ID, params.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Job does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	job := careerJobResponse(record)
+	job["organization_name"] = organization.Name
+	job["job_posting"] = jobfeed.BuildJobPosting(careerFeedJob(organization, record, cf.CAREERSPAGE))
+	job["profile_recipients"] = record.ProfileRecipients
+	job["email"] = record.Email
+	job["phone_number"] = record.PhoneNumber
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get job successful",
+		Data:    job,
+	})
+}
+
+// ApplyCareerJob : Cv which candidate sends from careers page, it is saved with careers page media and consent of candidate
+func (ctr *Controller) ApplyCareerJob(c echo.Context) error {
+	params := new(param.ApplyCareerJobParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	// Bot which filled honeypot gets success so that it does not try another way
+	if params.Website != "" {
+		ctr.Logger.Warnf("Application from %s was dropped by honeypot", ratelimit.ClientIP(c))
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.SuccessResponseCode,
+			Message: "Apply job successful",
+		})
+	}
+
+	isHuman, err := ctr.Captcha.Verify(params.CaptchaToken, ratelimit.ClientIP(c))
+	if err != nil {
+		ctr.Logger.Error(err)
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if !isHuman {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Captcha is invalid",
+		})
+	}
+
+	if !params.Consent {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Consent to processing of personal data is required",
+		})
+	}
+
+	organization, err := ctr.findCareerOrganization(c, params.OrganizationTag)
+	if err != nil || organization.ID == 0 {
+		return err
+	}
+
+	job, err := ctr.RecruitmentRepo.SelectActiveJob(organization.ID, params.RecruitmentId)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Job does not exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	content, err := base64.StdEncoding.DecodeString(params.FileContent)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field file_content",
+		})
+	}
+
+	if message := checkCareerCvFile(params.FileName, content); message != "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: message,
+		})
+	}
+
+	// Files of candidates often have the same name as CV.pdf, so time is added to name
+	fileName := strconv.FormatInt(time.Now().UnixNano(), 10) + "_" + filepath.Base(params.FileName)
+	err = ctr.Cloud.UploadFileToCloud(
+		params.FileContent,
+		fileName,
+		cf.CVFOLDERGCS+strconv.Itoa(organization.ID)+"/"+strings.Replace(job.JobName, " ", "_", -1),
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	// Source of tracking link in job feed attributes application to job board
+	mediaId := cf.CAREERSPAGE
+	if _, ok := cf.MediasRecruitment[params.Source]; ok {
+		mediaId = params.Source
+	}
+
+	now := utils.TimeNowUTC()
+	cv := m.Cv{
+		RecruitmentId: job.Id,
+		MediaId:       mediaId,
+		FileName:      fileName,
+		FullName:      params.FullName,
+		PhoneNumber:   params.PhoneNumber,
+		Email:         params.Email,
+		DateReceiptCv: now,
+		CvText:        ctr.extractCvText(params.FileName, params.FileContent),
+	}
+
+	consent := m.CandidateConsent{
+		OrganizationId: organization.ID,
+		ConsentText:    params.ConsentText,
+		IpAddress:      ratelimit.ClientIP(c),
+		UserAgent:      c.Request().UserAgent(),
+		ConsentedAt:    now,
+	}
+
+	if err := ctr.RecruitmentRepo.InsertCareerCv(organization.ID, &cv, &consent); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if organization.Email != "" && organization.EmailPassword != "" && len(job.Assignees) > 0 {
+		ctr.InitSmtp(organization.Email, organization.EmailPassword)
+		emails, _ := ctr.UserRepo.SelectEmailByUserIds(job.Assignees)
+		sampleData := new(param.SampleData)
+		sampleData.SendTo = emails
+		sampleData.Content = "Hi there, " + params.FullName + " has applied for " + job.JobName +
+			" from careers page. Please click the button below for more information"
+		sampleData.URL = os.Getenv("BASE_SPA_URL") + "/recruitment/recruitment-details?recruitment_id=" +
+			strconv.Itoa(job.Id) + "&cv_id=" + strconv.Itoa(cv.ID)
+		if err := ctr.SendMail("【Notification】【Micro erp】New application", sampleData, cf.Recruitment); err != nil {
+			ctr.Logger.Error(err)
+		}
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Apply job successful",
+	})
+}
+
+// findCareerOrganization : Organization of careers page, organization id is 0 when response was written
+func (ctr *Controller) findCareerOrganization(c echo.Context, tag string) (m.Organization, error) {
+	tag = strings.ToUpper(tag)
+	if !valid.IsAlphanumeric(tag) {
+		return m.Organization{}, c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	organization, err := ctr.OrgRepo.FindOrganizationByTag(tag)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return m.Organization{}, c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Organization does not exist",
+			})
+		}
+
+		return m.Organization{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return organization, nil
+}
+
+// checkCareerCvFile : Message when cv file is not accepted, extension must match content so that renamed files are rejected
+func checkCareerCvFile(fileName string, content []byte) string {
+	signature, ok := cf.CareerCvSignatures[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return "Only pdf, doc and docx cv are accepted"
+	}
+
+	if len(content) == 0 || len(content) > cf.CareerCvMaxSize {
+		return "Size of cv must be at most " + strconv.Itoa(cf.CareerCvMaxSize>>20) + "MB"
+	}
+
+	if !strings.HasPrefix(string(content), signature) {
+		return "Content of cv does not match its file type"
+	}
+
+	return ""
+}
+
+func careerJobResponse(record param.CareerJobRecords) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           record.Id,
+		"job_name":     record.JobName,
+		"start_date":   record.StartDate.Format(cf.FormatDateDatabase),
+		"expiry_date":  record.ExpiryDate.Format(cf.FormatDateDatabase),
+		"amount":       record.Amount,
+		"address":      record.Address,
+		"place":        record.Place,
+		"role":         record.Role,
+		"gender":       record.Gender,
+		"type_of_work": record.TypeOfWork,
+		"experience":   record.Experience,
+		"salary_type":  record.SalaryType,
+		"salary_from":  record.SalaryFrom,
+		"salary_to":    record.SalaryTo,
+		"description":  record.Description,
+	}
+}
+
+// GetIndeedJobFeed : Indeed XML feed of active jobs, links of jobs are tracked with source of feed
+func (ctr *Controller) GetIndeedJobFeed(c echo.Context) error {
+	organization, records, source, err := ctr.jobFeedRecords(c)
+	if err != nil || organization.ID == 0 {
+		return err
+	}
+
+	var jobs []jobfeed.Job
+	for _, record := range records {
+		jobs = append(jobs, careerFeedJob(organization, record, source))
+	}
+
+	content, err := jobfeed.BuildIndeedXml(organization.Name, careersPageUrl(organization.Tag), jobs, time.Now())
+	if err != nil {
+		ctr.Logger.Error(err)
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationXMLCharsetUTF8, content)
+}
+
+// GetJobPostingFeed : schema.org JobPosting JSON-LD of active jobs, links of jobs are tracked with source of feed
+func (ctr *Controller) GetJobPostingFeed(c echo.Context) error {
+	organization, records, source, err := ctr.jobFeedRecords(c)
+	if err != nil || organization.ID == 0 {
+		return err
+	}
+
+	postings := []map[string]interface{}{}
+	for _, record := range records {
+		postings = append(postings, jobfeed.BuildJobPosting(careerFeedJob(organization, record, source)))
+	}
+
+	content, err := json.Marshal(postings)
+	if err != nil {
+		ctr.Logger.Error(err)
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.Blob(http.StatusOK, "application/ld+json; charset=UTF-8", content)
+}
+
+// jobFeedRecords : Active jobs and media source of feed, organization id is 0 when response was written
+func (ctr *Controller) jobFeedRecords(c echo.Context) (m.Organization, []param.CareerJobRecords, int, error) {
+	params := new(param.GetJobFeedParams)
+	if err := c.Bind(params); err != nil {
+		return m.Organization{}, nil, 0, c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, ok := cf.MediasRecruitment[params.Source]; params.Source != 0 && !ok {
+		return m.Organization{}, nil, 0, c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return m.Organization{}, nil, 0, c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	organization, err := ctr.findCareerOrganization(c, params.OrganizationTag)
+	if err != nil || organization.ID == 0 {
+		return organization, nil, 0, err
+	}
+
+	records, _, err := ctr.RecruitmentRepo.SelectActiveJobs(organization.ID, &param.GetCareerJobsParams{})
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return m.Organization{}, nil, 0, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	source := params.Source
+	if source == 0 {
+		source = cf.CAREERSPAGE
+	}
+
+	return organization, records, source, nil
+}
+
+func careerFeedJob(organization m.Organization, record param.CareerJobRecords, source int) jobfeed.Job {
+	job := jobfeed.Job{
+		ReferenceNumber: organization.Tag + "-" + strconv.Itoa(record.Id),
+		Title:           record.JobName,
+		Company:         organization.Name,
+		CompanyUrl:      careersPageUrl(organization.Tag),
+		Url:             careerJobUrl(organization.Tag, record.Id, source),
+		Description:     record.Description,
+		Addresses:       record.Address,
+		Country:         cf.JobFeedCountry,
+		TypeOfWork:      record.TypeOfWork,
+		SalaryFrom:      record.SalaryFrom,
+		SalaryTo:        record.SalaryTo,
+		Currency:        cf.JobFeedCurrency,
+		ExperienceYears: record.Experience,
+		Openings:        record.Amount,
+		DatePosted:      record.StartDate,
+		ValidThrough:    record.ExpiryDate,
+	}
+
+	if len(record.Place) > 0 {
+		job.City = record.Place[0]
+	}
+
+	return job
+}
+
+// careersPageUrl : Careers page of organization on CAREERS_PAGE_URL, which is under spa when it is not set
+func careersPageUrl(tag string) string {
+	baseUrl := os.Getenv("CAREERS_PAGE_URL")
+	if baseUrl == "" {
+		baseUrl = os.Getenv("BASE_SPA_URL") + "/careers"
+	}
+
+	return strings.TrimRight(baseUrl, "/") + "/" + strings.ToLower(tag)
+}
+
+// careerJobUrl : Link of job on careers page, source is sent back by apply job so that cv has media of job board
+func careerJobUrl(tag string, id int, source int) string {
+	query := url.Values{}
+	query.Set("source", strconv.Itoa(source))
+	query.Set("utm_source", strings.ToLower(strings.Replace(cf.MediasRecruitment[source], " ", "_", -1)))
+	query.Set("utm_medium", "job_feed")
+
+	return careersPageUrl(tag) + "/jobs/" + strconv.Itoa(id) + "?" + query.Encode()
+}
+
+// funnelStageTypes : Stage types of funnel in order, cv which reached a stage is counted in all stages before it
+var funnelStageTypes = []int{cf.ScreeningStage, cf.TestStage, cf.InterviewStage, cf.OfferStage, cf.HiredStage}
+
+// statusStageTypes : Stage type of cv status, for logs which were written before pipeline stages
+var statusStageTypes = map[int]int{
+	cf.CVPENDING:      cf.ScreeningStage,
+	cf.CVINTERVIEW:    cf.InterviewStage,
+	cf.CVPASSROUNDONE: cf.OfferStage,
+	cf.CVPASSFINAL:    cf.HiredStage,
+	cf.CVREJECT:       cf.RejectedStage,
+	cf.CVNOTPASS:      cf.RejectedStage,
+}
+
+// GetRecruitmentAnalytics : Funnel, time to hire, time in stage, source effectiveness, offer acceptance and interviewer load
+func (ctr *Controller) GetRecruitmentAnalytics(c echo.Context) error {
+	params := new(param.RecruitmentAnalyticsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if !isValidAnalyticsPeriod(params) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	analytics, err := ctr.recruitmentAnalytics(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get recruitment analytics successful",
+		Data:    analytics,
+	})
+}
+
+// ExportRecruitmentAnalytics : Recruitment analytics in xlsx, one sheet for each report
+func (ctr *Controller) ExportRecruitmentAnalytics(c echo.Context) error {
+	params := new(param.RecruitmentAnalyticsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if !isValidAnalyticsPeriod(params) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	analytics, err := ctr.recruitmentAnalytics(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	f := excelize.NewFile()
+	titleStyle, _ := f.NewStyle(`{
+		"font":{"bold":true},
+		"alignment":{"horizontal":"center", "vertical":"center"}
+	}`)
+
+	sheets := []struct {
+		name    string
+		headers []interface{}
+		rows    [][]interface{}
+	}{
+		{name: "Funnel", headers: []interface{}{"Stage", "Cvs", "Conversion rate (%)", "Overall rate (%)"}},
+		{name: "Time in stage", headers: []interface{}{"Stage", "Cvs", "Average days"}},
+		{name: "Time to hire", headers: []interface{}{"Hired", "Average days", "Median days"}},
+		{name: "Sources", headers: []interface{}{
+			"Source", "Cvs", "Interviewed", "Offered", "Hired", "Hire rate (%)", "Average time to hire (days)",
+		}},
+		{name: "Offers", headers: []interface{}{"Offered", "Accepted", "Declined", "Pending", "Acceptance rate (%)"}},
+		{name: "Interviewers", headers: []interface{}{"Interviewer", "Scheduled", "Completed", "Cancelled", "Hours"}},
+	}
+
+	for _, stage := range analytics.Funnel {
+		sheets[0].rows = append(sheets[0].rows, []interface{}{stage.Name, stage.CvCount, stage.ConversionRate, stage.OverallRate})
+	}
+
+	for _, stage := range analytics.TimeInStages {
+		sheets[1].rows = append(sheets[1].rows, []interface{}{stage.Name, stage.CvCount, stage.AverageDays})
+	}
+
+	timeToHire := analytics.TimeToHire
+	sheets[2].rows = append(sheets[2].rows, []interface{}{timeToHire.HiredCount, timeToHire.AverageDays, timeToHire.MedianDays})
+	for _, source := range analytics.Sources {
+		sheets[3].rows = append(sheets[3].rows, []interface{}{
+			source.Name, source.CvCount, source.InterviewedCount, source.OfferedCount,
+			source.HiredCount, source.HireRate, source.AverageTimeToHire,
+		})
+	}
+
+	offers := analytics.Offers
+	sheets[4].rows = append(sheets[4].rows, []interface{}{
+		offers.OfferedCount, offers.AcceptedCount, offers.DeclinedCount, offers.PendingCount, offers.AcceptanceRate,
+	})
+	for _, load := range analytics.InterviewerLoads {
+		sheets[5].rows = append(sheets[5].rows, []interface{}{
+			load.FullName, load.ScheduledCount, load.CompletedCount, load.CancelledCount, load.Hours,
+		})
+	}
+
+	for i, sheet := range sheets {
+		if i == 0 {
+			f.SetSheetName("Sheet1", sheet.name)
+		} else {
+			f.NewSheet(sheet.name)
+		}
+
+		lastColumn, _ := excelize.ColumnNumberToName(len(sheet.headers))
+		_ = f.SetColWidth(sheet.name, "A", lastColumn, 22)
+		_ = f.SetSheetRow(sheet.name, "A1", &sheet.headers)
+		_ = f.SetCellStyle(sheet.name, "A1", lastColumn+"1", titleStyle)
+		for j, row := range sheet.rows {
+			row := row
+			_ = f.SetSheetRow(sheet.name, "A"+strconv.Itoa(j+2), &row)
+		}
+	}
+
+	buf, _ := f.WriteToBuffer()
+	return c.Blob(http.StatusOK, "application/octet-stream", buf.Bytes())
+}
+
+func isValidAnalyticsPeriod(params *param.RecruitmentAnalyticsParams) bool {
+	var fromDate, toDate time.Time
+	var err error
+	if params.FromDate != "" {
+		if fromDate, err = time.Parse(cf.FormatDateDatabase, params.FromDate); err != nil {
+			return false
+		}
+	}
+
+	if params.ToDate != "" {
+		if toDate, err = time.Parse(cf.FormatDateDatabase, params.ToDate); err != nil {
+			return false
+		}
+	}
+
+	return fromDate.IsZero() || toDate.IsZero() || !toDate.Before(fromDate)
+}
+
+func (ctr *Controller) recruitmentAnalytics(
+	organizationId int,
+	params *param.RecruitmentAnalyticsParams,
+) (param.RecruitmentAnalyticsRecords, error) {
+	var analytics param.RecruitmentAnalyticsRecords
+	cvs, err := ctr.RecruitmentRepo.SelectAnalyticsCvs(organizationId, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return analytics, err
+	}
+
+	var cvIds []int
+	for _, cv := range cvs {
+		cvIds = append(cvIds, cv.Id)
+	}
+
+	logCvStates, err := ctr.RecruitmentRepo.SelectLogCvStatesByCvIds(cvIds)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return analytics, err
+	}
+
+	stages, err := ctr.RecruitmentRepo.SelectOrganizationStages(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return analytics, err
+	}
+
+	interviews, err := ctr.RecruitmentRepo.SelectAnalyticsInterviews(organizationId, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return analytics, err
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(organizationId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return analytics, err
+	}
+
+	stageTypes := make(map[int]int)
+	for _, stage := range stages {
+		stageTypes[stage.ID] = stage.StageType
+	}
+
+	userNames := make(map[int]string)
+	for _, user := range users {
+		userNames[user.UserID] = user.FullName
+	}
+
+	analytics = buildRecruitmentAnalytics(cvs, logCvStates, stageTypes, time.Now())
+	analytics.InterviewerLoads = interviewerLoads(interviews, userNames)
+	return analytics, nil
+}
+
+// cvStageEvent : Stage type which cv entered at time
+type cvStageEvent struct {
+	stageType int
+	at        time.Time
+}
+
+// buildRecruitmentAnalytics : Reports from stage events of cvs, cv without logs is in its current stage since it was received
+func buildRecruitmentAnalytics(
+	cvs []param.AnalyticsCvRecords,
+	logCvStates []m.LogCvState,
+	stageTypes map[int]int,
+	now time.Time,
+) param.RecruitmentAnalyticsRecords {
+	eventStageType := func(stageId int, status int) int {
+		if stageType, ok := stageTypes[stageId]; ok && stageId != 0 {
+			return stageType
+		}
+
+		return statusStageTypes[status]
+	}
+
+	cvEvents := make(map[int][]cvStageEvent)
+	for _, logCvState := range logCvStates {
+		if stageType := eventStageType(logCvState.StageId, logCvState.Status); stageType != 0 {
+			cvEvents[logCvState.CvId] = append(cvEvents[logCvState.CvId], cvStageEvent{stageType, logCvState.UpdateDay})
+		}
+	}
+
+	funnelIndex := make(map[int]int)
+	for i, stageType := range funnelStageTypes {
+		funnelIndex[stageType] = i
+	}
+
+	reachedCounts := make([]int, len(funnelStageTypes))
+	stageDays := make(map[int][]float64)
+	var hireDays []float64
+	var offers param.OfferAcceptanceRecords
+	sources := make(map[int]*param.SourceEffectivenessRecords)
+	sourceHireDays := make(map[int][]float64)
+	for _, cv := range cvs {
+		receivedAt := cv.DateReceiptCv
+		if receivedAt.IsZero() {
+			receivedAt = cv.CreatedAt
+		}
+
+		events := cvEvents[cv.Id]
+		if len(events) == 0 {
+			stageType := eventStageType(cv.StageId, cv.StatusCv)
+			if stageType == 0 {
+				stageType = cf.ScreeningStage
+			}
+
+			events = []cvStageEvent{{stageType, receivedAt}}
+		}
+
+		furthest := 0
+		var hiredAt time.Time
+		for i, event := range events {
+			if index, ok := funnelIndex[event.stageType]; ok && index > furthest {
+				furthest = index
+			}
+
+			if event.stageType == cf.HiredStage && hiredAt.IsZero() {
+				hiredAt = event.at
+			}
+
+			// Cv stays in hired or rejected stage, so time after it is not time in stage
+			leftAt := now
+			if i+1 < len(events) {
+				leftAt = events[i+1].at
+			} else if event.stageType == cf.HiredStage || event.stageType == cf.RejectedStage {
+				continue
+			}
+
+			if leftAt.After(event.at) {
+				stageDays[event.stageType] = append(stageDays[event.stageType], leftAt.Sub(event.at).Hours()/24)
+			}
+		}
+
+		for i := 0; i <= furthest; i++ {
+			reachedCounts[i]++
+		}
+
+		source, ok := sources[cv.MediaId]
+		if !ok {
+			source = &param.SourceEffectivenessRecords{MediaId: cv.MediaId, Name: cf.MediasRecruitment[cv.MediaId]}
+			if source.Name == "" {
+				source.Name = "Other"
+			}
+			sources[cv.MediaId] = source
+		}
+
+		source.CvCount++
+		if furthest >= funnelIndex[cf.InterviewStage] {
+			source.InterviewedCount++
+		}
+
+		if furthest >= funnelIndex[cf.OfferStage] {
+			source.OfferedCount++
+			offers.OfferedCount++
+			switch {
+			case !hiredAt.IsZero():
+				offers.AcceptedCount++
+			case events[len(events)-1].stageType == cf.RejectedStage:
+				offers.DeclinedCount++
+			default:
+				offers.PendingCount++
+			}
+		}
+
+		if !hiredAt.IsZero() {
+			days := math.Max(hiredAt.Sub(receivedAt).Hours()/24, 0)
+			hireDays = append(hireDays, days)
+			source.HiredCount++
+			sourceHireDays[cv.MediaId] = append(sourceHireDays[cv.MediaId], days)
+		}
+	}
+
+	analytics := param.RecruitmentAnalyticsRecords{CvCount: len(cvs), Offers: offers}
+	for i, stageType := range funnelStageTypes {
+		stage := param.FunnelStageRecords{
+			StageType:   stageType,
+			Name:        cf.RecruitmentStageTypes[stageType],
+			CvCount:     reachedCounts[i],
+			OverallRate: percentage(reachedCounts[i], len(cvs)),
+		}
+
+		stage.ConversionRate = 100
+		if i > 0 {
+			stage.ConversionRate = percentage(reachedCounts[i], reachedCounts[i-1])
+		}
+
+		analytics.Funnel = append(analytics.Funnel, stage)
+	}
+
+	for _, stageType := range append(funnelStageTypes, cf.RejectedStage) {
+		days := stageDays[stageType]
+		if len(days) == 0 {
+			continue
+		}
+
+		analytics.TimeInStages = append(analytics.TimeInStages, param.TimeInStageRecords{
+			StageType:   stageType,
+			Name:        cf.RecruitmentStageTypes[stageType],
+			AverageDays: roundOne(average(days)),
+			CvCount:     len(days),
+		})
+	}
+
+	analytics.TimeToHire = param.TimeToHireRecords{
+		HiredCount:  len(hireDays),
+		AverageDays: roundOne(average(hireDays)),
+		MedianDays:  roundOne(median(hireDays)),
+	}
+
+	for mediaId, source := range sources {
+		source.HireRate = percentage(source.HiredCount, source.CvCount)
+		source.AverageTimeToHire = roundOne(average(sourceHireDays[mediaId]))
+		analytics.Sources = append(analytics.Sources, *source)
+	}
+
+	sort.Slice(analytics.Sources, func(i, j int) bool {
+		return analytics.Sources[i].CvCount > analytics.Sources[j].CvCount ||
+			(analytics.Sources[i].CvCount == analytics.Sources[j].CvCount && analytics.Sources[i].MediaId < analytics.Sources[j].MediaId)
+	})
+
+	if offers.AcceptedCount+offers.DeclinedCount > 0 {
+		analytics.Offers.AcceptanceRate = percentage(offers.AcceptedCount, offers.AcceptedCount+offers.DeclinedCount)
+	}
+
+	return analytics
+}
+
+// interviewerLoads : Interviews of each interviewer, hours of cancelled interviews are not counted
+func interviewerLoads(interviews []m.Interview, userNames map[int]string) []param.InterviewerLoadRecords {
+	loads := make(map[int]*param.InterviewerLoadRecords)
+	for _, interview := range interviews {
+		for _, userId := range interview.Interviewers {
+			load, ok := loads[userId]
+			if !ok {
+				load = &param.InterviewerLoadRecords{UserId: userId, FullName: userNames[userId]}
+				loads[userId] = load
+			}
+
+			switch interview.Status {
+			case cf.InterviewScheduled:
+				load.ScheduledCount++
+			case cf.InterviewCompleted:
+				load.CompletedCount++
+			case cf.InterviewCancelled:
+				load.CancelledCount++
+				continue
+			}
+
+			load.Hours += interview.EndTime.Sub(interview.StartTime).Hours()
+		}
+	}
+
+	var records []param.InterviewerLoadRecords
+	for _, load := range loads {
+		load.Hours = roundOne(load.Hours)
+		records = append(records, *load)
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		totalI := records[i].ScheduledCount + records[i].CompletedCount
+		totalJ := records[j].ScheduledCount + records[j].CompletedCount
+		return totalI > totalJ || (totalI == totalJ && records[i].UserId < records[j].UserId)
+	})
+
+	return records
+}
+
+func percentage(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return roundOne(float64(count) * 100 / float64(total))
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sum := 0.0
+	for _, value := range values {
+		sum += value
+	}
+
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+}
+
+func roundOne(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+// SaveOfferTemplate : Save offer letter of organization, placeholders are replaced by fields of candidate and job
+func (ctr *Controller) SaveOfferTemplate(c echo.Context) error {
+	params := new(param.SaveOfferTemplateParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	template, err := ctr.RecruitmentRepo.SelectOfferTemplate(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	template.OrganizationId = userProfile.OrganizationID
+	template.Content = params.Content
+	if err := ctr.RecruitmentRepo.SaveOfferTemplate(&template); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Save offer template successful",
+	})
+}
+
+func (ctr *Controller) GetOfferTemplate(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	template, err := ctr.RecruitmentRepo.SelectOfferTemplate(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	content := template.Content
+	if content == "" {
+		content = cf.DefaultOfferTemplate
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get offer template successful",
+		Data: map[string]interface{}{
+			"content":      content,
+			"placeholders": cf.OfferPlaceholders,
+		},
+	})
+}
+
+// CreateOffer : Generate offer letter for cv which passed final, offer is sent to candidate when send is true
+func (ctr *Controller) CreateOffer(c echo.Context) error {
+	params := new(param.CreateOfferParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	startDate, err := time.Parse(cf.FormatDateDatabase, params.StartDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field start_date",
+		})
+	}
+
+	expiredDate, err := time.Parse(cf.FormatDateDatabase, params.ExpiredDate)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field expired_date",
+		})
+	}
+
+	if expiredDate.Format(cf.FormatDateDatabase) < utils.TimeNowUTC().Format(cf.FormatDateDatabase) ||
+		startDate.Before(expiredDate) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Expired date must be from today and not after start date",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	cv, recruitment, err := ctr.findManagedCv(c, userProfile, params.CvId)
+	if err != nil || cv == nil {
+		return err
+	}
+
+	if cv.StatusCv != cf.CVPASSFINAL {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer can only be made to cv which passed final",
+		})
+	}
+
+	offers, err := ctr.RecruitmentRepo.SelectOffersByCvId(cv.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	for _, offer := range offers {
+		if offer.Status == cf.OfferDraft || offer.Status == cf.OfferSent || offer.Status == cf.OfferAccepted {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Cv already has an offer which is " + strings.ToLower(cf.OfferStatuses[offer.Status]),
+			})
+		}
+	}
+
+	template, err := ctr.RecruitmentRepo.SelectOfferTemplate(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if template.Content == "" {
+		template.Content = cf.DefaultOfferTemplate
+	}
+
+	offer := m.Offer{
+		OrganizationId: userProfile.OrganizationID,
+		RecruitmentId:  cv.RecruitmentId,
+		CvId:           cv.ID,
+		CandidateId:    cv.CandidateId,
+		JobTitle:       params.JobTitle,
+		Salary:         params.Salary,
+		CurrencyUnit:   params.CurrencyUnit,
+		ContractTypeId: params.ContractTypeId,
+		StartDate:      startDate,
+		ExpiredDate:    expiredDate,
+		Status:         cf.OfferDraft,
+		CreatedBy:      userProfile.UserProfile.UserID,
+	}
+	offer.Content = renderOffer(template.Content, offer, cv, recruitment.JobName, userProfile.Organization.Name)
+
+	if err := ctr.RecruitmentRepo.InsertOffer(&offer); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if params.Send {
+		languageId := candidateEmailLanguage(params.LanguageId, userProfile)
+		if err := ctr.sendOffer(c, userProfile, &offer, cv, recruitment.JobName, languageId); err != nil || offer.Status != cf.OfferSent {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create offer successful",
+		Data:    offerResponse(offer),
+	})
+}
+
+// SendOffer : Email offer letter to candidate with link to respond, link of offer which was sent before stops working
+func (ctr *Controller) SendOffer(c echo.Context) error {
+	params := new(param.SendOfferParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	offer, cv, recruitment, err := ctr.findOffer(c, userProfile, params.Id)
+	if err != nil || offer.ID == 0 {
+		return err
+	}
+
+	if offer.Status != cf.OfferDraft && offer.Status != cf.OfferSent {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer which is " + strings.ToLower(cf.OfferStatuses[offer.Status]) + " can not be sent",
+		})
+	}
+
+	if offer.ExpiredDate.Format(cf.FormatDateDatabase) < utils.TimeNowUTC().Format(cf.FormatDateDatabase) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer has expired",
+		})
+	}
+
+	languageId := candidateEmailLanguage(params.LanguageId, userProfile)
+	if err := ctr.sendOffer(c, userProfile, &offer, cv, recruitment.JobName, languageId); err != nil || offer.Status != cf.OfferSent {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Send offer successful",
+		Data:    offerResponse(offer),
+	})
+}
+
+func (ctr *Controller) GetOffers(c echo.Context) error {
+	params := new(param.GetOffersParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	cv, _, err := ctr.findManagedCv(c, userProfile, params.CvId)
+	if err != nil || cv == nil {
+		return err
+	}
+
+	offers, err := ctr.RecruitmentRepo.SelectOffersByCvId(cv.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	responses := make([]map[string]interface{}, 0, len(offers))
+	for _, offer := range offers {
+		responses = append(responses, offerResponse(offer))
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get offers successful",
+		Data: map[string]interface{}{
+			"offers":   responses,
+			"statuses": cf.OfferStatuses,
+		},
+	})
+}
+
+func (ctr *Controller) WithdrawOffer(c echo.Context) error {
+	params := new(param.OfferIdParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	offer, _, _, err := ctr.findOffer(c, userProfile, params.Id)
+	if err != nil || offer.ID == 0 {
+		return err
+	}
+
+	if offer.Status != cf.OfferDraft && offer.Status != cf.OfferSent {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer which is " + strings.ToLower(cf.OfferStatuses[offer.Status]) + " can not be withdrawn",
+		})
+	}
+
+	offer.Status = cf.OfferWithdrawn
+	if err := ctr.RecruitmentRepo.UpdateOffer(&offer, "status"); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Withdraw offer successful",
+	})
+}
+
+// RespondOffer : Record response of candidate who replied to offer by email or phone
+func (ctr *Controller) RespondOffer(c echo.Context) error {
+	params := new(param.RespondOfferParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	offer, cv, _, err := ctr.findOffer(c, userProfile, params.Id)
+	if err != nil || offer.ID == 0 {
+		return err
+	}
+
+	return ctr.respondOffer(c, userProfile.Organization, offer, cv, params.Accept, params.DeclineReason)
+}
+
+// GetCareerOffer : Offer letter which candidate opens from link in email, no login is required
+func (ctr *Controller) GetCareerOffer(c echo.Context) error {
+	params := new(param.GetCareerOfferParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	offer, organization, err := ctr.findCareerOffer(c, params.Token)
+	if err != nil || offer.ID == 0 {
+		return err
+	}
+
+	data := offerResponse(offer)
+	data["organization_name"] = organization.Name
+	delete(data, "contract_type_id")
+	delete(data, "user_id")
+	delete(data, "contract_id")
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get offer successful",
+		Data:    data,
+	})
+}
+
+// RespondCareerOffer : Candidate accepts or declines offer from link in email
+func (ctr *Controller) RespondCareerOffer(c echo.Context) error {
+	params := new(param.RespondCareerOfferParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	offer, organization, err := ctr.findCareerOffer(c, params.Token)
+	if err != nil || offer.ID == 0 {
+		return err
+	}
+
+	cv, err := ctr.RecruitmentRepo.FindCvById(offer.CvId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return ctr.respondOffer(c, organization, offer, cv, params.Accept, params.DeclineReason)
+}
+
+// findManagedCv : Cv which user can manage with its recruitment, cv is nil when response was written
+func (ctr *Controller) findManagedCv(c echo.Context, userProfile m.User, cvId int) (*m.Cv, m.Recruitment, error) {
+	cv, err := ctr.RecruitmentRepo.FindCvById(cvId)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return nil, m.Recruitment{}, c.JSON(http.StatusNotFound, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Cv does not exist",
+			})
+		}
+
+		return nil, m.Recruitment{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(cv.RecruitmentId, "organization_id", "assignees", "job_name")
+	if err != nil {
+		return nil, m.Recruitment{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if recruitment.OrganizationId != userProfile.OrganizationID {
+		return nil, m.Recruitment{}, c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Cv does not exist",
+		})
+	}
+
+	if !canManageCvs(userProfile, recruitment) {
+		return nil, m.Recruitment{}, c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to manage cv",
+		})
+	}
+
+	return cv, recruitment, nil
+}
+
+// findOffer : Offer which user can manage with its cv and recruitment, offer id is 0 when response was written
+func (ctr *Controller) findOffer(c echo.Context, userProfile m.User, id int) (m.Offer, *m.Cv, m.Recruitment, error) {
+	offer, err := ctr.RecruitmentRepo.SelectOffer(id)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return m.Offer{}, nil, m.Recruitment{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || offer.OrganizationId != userProfile.OrganizationID {
+		return m.Offer{}, nil, m.Recruitment{}, c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer does not exist",
+		})
+	}
+
+	cv, recruitment, err := ctr.findManagedCv(c, userProfile, offer.CvId)
+	if err != nil || cv == nil {
+		return m.Offer{}, nil, m.Recruitment{}, err
+	}
+
+	return offer, cv, recruitment, nil
+}
+
+// findCareerOffer : Offer of token which was sent to candidate, offer id is 0 when response was written
+func (ctr *Controller) findCareerOffer(c echo.Context, token string) (m.Offer, m.Organization, error) {
+	offer, err := ctr.RecruitmentRepo.SelectOfferByToken(token)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return m.Offer{}, m.Organization{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	// Link of withdrawn offer is treated as wrong link, so that candidate does not learn more
+	if err != nil || offer.Status == cf.OfferDraft || offer.Status == cf.OfferWithdrawn {
+		return m.Offer{}, m.Organization{}, c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer does not exist",
+		})
+	}
+
+	organization, err := ctr.OrgRepo.GetOrganizationByID(offer.OrganizationId)
+	if err != nil {
+		return m.Offer{}, m.Organization{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return offer, organization, nil
+}
+
+// sendOffer : Email offer letter with new link to respond, offer status is sent when it was sent
+func (ctr *Controller) sendOffer(
+	c echo.Context,
+	userProfile m.User,
+	offer *m.Offer,
+	cv *m.Cv,
+	jobName string,
+	languageId int,
+) error {
+	if cv.Email == "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Cv has no email to send offer to",
+		})
+	}
+
+	organization := userProfile.Organization
+	if organization.Email == "" || organization.EmailPassword == "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Email of organization has not been set",
+		})
+	}
+
+	subject, body, err := ctr.candidateEmailContent(organization.ID, cf.OfferEmail, languageId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	token := utils.GetUniqueString()
+	sent := *offer
+	sent.Token = utils.GetSHA256Hash(token)
+	sent.Status = cf.OfferSent
+	sent.SentAt = utils.TimeNowUTC()
+	if err := ctr.RecruitmentRepo.UpdateOffer(&sent, "token", "status", "sent_at"); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	attachments := []email.Attachment{{
+		FileName:    "offer_letter.html",
+		ContentType: "text/html; charset=UTF-8",
+		Content:     []byte(offer.Content),
+	}}
+
+	fields := candidateEmailFields(cv, jobName, userProfile)
+	fields["{{job_title}}"] = offer.JobTitle
+	fields["{{start_date}}"] = offer.StartDate.Format(cf.FormatDateDisplay)
+	fields["{{expired_date}}"] = offer.ExpiredDate.Format(cf.FormatDateDisplay)
+	communication := m.CvCommunication{
+		Type:       cf.OfferEmail,
+		LanguageId: languageId,
+		Subject:    renderCandidateEmail(subject, fields),
+		Body:       renderCandidateEmail(body, fields),
+		SentBy:     userProfile.UserProfile.UserID,
+	}
+
+	url := careersPageUrl(organization.Tag) + "/offers/" + token
+	if err := ctr.sendCandidateEmail(organization, cv, &communication, url, attachments); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	*offer = sent
+	return nil
+}
+
+// respondOffer : Decline offer, or accept it and convert candidate to employee
+func (ctr *Controller) respondOffer(
+	c echo.Context,
+	organization m.Organization,
+	offer m.Offer,
+	cv *m.Cv,
+	accept bool,
+	declineReason string,
+) error {
+	if offer.Status != cf.OfferSent {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer has already been " + strings.ToLower(cf.OfferStatuses[offer.Status]),
+		})
+	}
+
+	if offer.ExpiredDate.Format(cf.FormatDateDatabase) < utils.TimeNowUTC().Format(cf.FormatDateDatabase) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Offer has expired",
+		})
+	}
+
+	offer.RespondedAt = utils.TimeNowUTC()
+	if !accept {
+		offer.Status = cf.OfferDeclined
+		offer.DeclineReason = declineReason
+		if err := ctr.RecruitmentRepo.UpdateOffer(&offer, "status", "responded_at", "decline_reason"); err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		ctr.notifyOfferResponse(organization, offer, cv)
+		return c.JSON(http.StatusOK, cf.JsonResponse{
+			Status:  cf.SuccessResponseCode,
+			Message: "Decline offer successful",
+		})
+	}
+
+	onboarding, err := ctr.acceptOffer(c, organization, &offer, cv)
+	if err != nil || onboarding.ID == 0 {
+		return err
+	}
+
+	ctr.notifyOfferResponse(organization, offer, cv)
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Accept offer successful",
+		Data: map[string]interface{}{
+			"user_id":       onboarding.UserId,
+			"contract_id":   onboarding.ContractId,
+			"onboarding_id": onboarding.ID,
+		},
+	})
+}
+
+// acceptOffer : Create user, profile, draft contract and onboarding from cv, then invite user to set password.
+// Onboarding id is 0 when response was written
+func (ctr *Controller) acceptOffer(c echo.Context, organization m.Organization, offer *m.Offer, cv *m.Cv) (m.Onboarding, error) {
+	if cv.Email == "" {
+		return m.Onboarding{}, c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Cv has no email to create user with",
+		})
+	}
+
+	_, err := ctr.UserRepo.GetUserByEmailOrganizationID(cv.Email, organization.ID)
+	if err == nil {
+		return m.Onboarding{}, c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "User with email " + cv.Email + " already exists",
+		})
+	}
+
+	if err.Error() != pg.ErrNoRows.Error() {
+		return m.Onboarding{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(offer.RecruitmentId, "job_name", "branch_ids")
+	if err != nil {
+		return m.Onboarding{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	comments, err := ctr.RecruitmentRepo.SelectCvCommentsByCvId(cv.ID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return m.Onboarding{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	now := utils.TimeNowUTC()
+	inviteCode := utils.GetUniqueString()
+	user := m.User{
+		OrganizationID:    organization.ID,
+		Email:             cv.Email,
+		Password:          utils.GetSHA256Hash(utils.GetUniqueString()),
+		RoleID:            cf.UserRoleID,
+		LastLoginTime:     now,
+		ResetPasswordCode: utils.GetSHA256Hash(inviteCode),
+		CodeExpiredAt:     now.Add(cf.OfferInviteExpiredHours * time.Hour),
+		LanguageId:        cf.EnLanguageId,
+	}
+
+	firstName, lastName := splitFullName(cv.FullName)
+	userProfile := m.UserProfile{
+		FirstName:         firstName,
+		LastName:          lastName,
+		PhoneNumber:       cv.PhoneNumber,
+		JobPosition:       offer.JobTitle,
+		CompanyJoinedDate: offer.StartDate,
+	}
+
+	if len(recruitment.BranchIds) == 1 {
+		userProfile.Branch = recruitment.BranchIds[0]
+	}
+
+	contract := m.Contract{
+		OrganizationId:    organization.ID,
+		ContractTypeId:    offer.ContractTypeId,
+		InsuranceSalary:   offer.Salary,
+		ContractStartDate: offer.StartDate,
+		CurrencyUnit:      offer.CurrencyUnit,
+		IsDraft:           true,
+	}
+
+	onboarding := m.Onboarding{
+		OrganizationId: organization.ID,
+		OfferId:        offer.ID,
+		CvId:           cv.ID,
+		CvFileName:     cv.FileName,
+		CvFilePath:     cf.CVFOLDERGCS + strconv.Itoa(organization.ID) + "/" + strings.Replace(recruitment.JobName, " ", "_", -1),
+	}
+
+	for _, task := range cf.DefaultOnboardingChecklist {
+		onboarding.Checklist = append(onboarding.Checklist, m.OnboardingTask{Name: task})
+	}
+
+	for _, comment := range comments {
+		onboarding.Notes = append(onboarding.Notes, m.OnboardingNote{
+			CreatedBy: comment.CreatedBy,
+			Comment:   comment.Comment,
+			CreatedAt: comment.CreatedAt,
+		})
+	}
+
+	if err := ctr.RecruitmentRepo.AcceptOffer(offer, &user, &userProfile, &contract, &onboarding); err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return m.Onboarding{}, c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Offer has already been responded",
+			})
+		}
+
+		return m.Onboarding{}, c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	// User was created, so invite which fails to be sent is logged and can be sent again by forgot password
+	if organization.Email != "" && organization.EmailPassword != "" {
+		ctr.InitSmtp(organization.Email, organization.EmailPassword)
+		sampleData := new(param.SampleData)
+		sampleData.SendTo = []string{user.Email}
+		sampleData.OrgTag = organization.Tag
+		sampleData.Content = "Welcome to " + organization.Name + ", " + cv.FullName + ". Your account has been created, " +
+			"please click the button below to set your password"
+		sampleData.URL = os.Getenv("BASE_SPA_URL") + "/organization/ResetPassword/" + inviteCode
+		if err := ctr.SendMail("【Micro erp】Welcome to "+organization.Name, sampleData, cf.Recruitment); err != nil {
+			ctr.Logger.Error(err)
+		}
+	}
+
+	return onboarding, nil
+}
+
+// notifyOfferResponse : Email assignees of recruitment that candidate accepted or declined offer
+func (ctr *Controller) notifyOfferResponse(organization m.Organization, offer m.Offer, cv *m.Cv) {
+	if organization.Email == "" || organization.EmailPassword == "" {
+		return
+	}
+
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(offer.RecruitmentId, "assignees")
+	if err != nil || len(recruitment.Assignees) == 0 {
+		return
+	}
+
+	emails, err := ctr.UserRepo.SelectEmailByUserIds(recruitment.Assignees)
+	if err != nil || len(emails) == 0 {
+		return
+	}
+
+	ctr.InitSmtp(organization.Email, organization.EmailPassword)
+	sampleData := new(param.SampleData)
+	sampleData.SendTo = emails
+	sampleData.Content = cv.FullName + " has " + strings.ToLower(cf.OfferStatuses[offer.Status]) + " offer of " + offer.JobTitle
+	if offer.DeclineReason != "" {
+		sampleData.Content += ". Reason: " + offer.DeclineReason
+	}
+
+	sampleData.URL = os.Getenv("BASE_SPA_URL") + "/recruitment/recruitment-details?recruitment_id=" +
+		strconv.Itoa(offer.RecruitmentId) + "&cv_id=" + strconv.Itoa(offer.CvId)
+	if err := ctr.SendMail("【Notification】【Micro erp】Offer "+strings.ToLower(cf.OfferStatuses[offer.Status]), sampleData, cf.Recruitment); err != nil {
+		ctr.Logger.Error(err)
+	}
+}
+
+// renderOffer : Offer letter of template whose placeholders are replaced by escaped fields of candidate and job
+func renderOffer(template string, offer m.Offer, cv *m.Cv, jobName string, organizationName string) string {
+	replacer := strings.NewReplacer(
+		"{{candidate_name}}", html.EscapeString(cv.FullName),
+		"{{candidate_email}}", html.EscapeString(cv.Email),
+		"{{candidate_phone}}", html.EscapeString(cv.PhoneNumber),
+		"{{job_name}}", html.EscapeString(jobName),
+		"{{job_title}}", html.EscapeString(offer.JobTitle),
+		"{{salary}}", formatAmount(offer.Salary),
+		"{{start_date}}", offer.StartDate.Format(cf.FormatDateDisplay),
+		"{{expired_date}}", offer.ExpiredDate.Format(cf.FormatDateDisplay),
+		"{{organization_name}}", html.EscapeString(organizationName),
+	)
+
+	return replacer.Replace(template)
+}
+
+// formatAmount : Amount with thousands separator, as 15,000,000
+func formatAmount(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	for i := len(digits) - 3; i > 0; i -= 3 {
+		digits = digits[:i] + "," + digits[i:]
+	}
+
+	return sign + digits
+}
+
+// splitFullName : First name and last name of profile, last word of vietnamese full name is the given name
+func splitFullName(fullName string) (string, string) {
+	words := strings.Fields(fullName)
+	if len(words) < 2 {
+		return fullName, ""
+	}
+
+	return strings.Join(words[:len(words)-1], " "), words[len(words)-1]
+}
+
+func offerResponse(offer m.Offer) map[string]interface{} {
+	response := map[string]interface{}{
+		"id":               offer.ID,
+		"cv_id":            offer.CvId,
+		"recruitment_id":   offer.RecruitmentId,
+		"job_title":        offer.JobTitle,
+		"salary":           offer.Salary,
+		"currency_unit":    offer.CurrencyUnit,
+		"contract_type_id": offer.ContractTypeId,
+		"start_date":       offer.StartDate.Format(cf.FormatDateDatabase),
+		"expired_date":     offer.ExpiredDate.Format(cf.FormatDateDatabase),
+		"content":          offer.Content,
+		"status":           offer.Status,
+		"status_name":      cf.OfferStatuses[offer.Status],
+		"decline_reason":   offer.DeclineReason,
+		"user_id":          offer.UserId,
+		"contract_id":      offer.ContractId,
+	}
+
+	if !offer.SentAt.IsZero() {
+		response["sent_at"] = offer.SentAt.Format(cf.FormatDate)
+	}
+
+	if !offer.RespondedAt.IsZero() {
+		response["responded_at"] = offer.RespondedAt.Format(cf.FormatDate)
+	}
+
+	return response
+}
+
+// GetOnboardings : Onboardings of employees who were hired from offers, with cv file and comments of recruitment
+func (ctr *Controller) GetOnboardings(c echo.Context) error {
+	params := new(param.GetOnboardingsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	onboardings, err := ctr.RecruitmentRepo.SelectOnboardings(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userNames := make(map[int]string)
+	for _, user := range users {
+		userNames[user.UserID] = user.FullName
+	}
+
+	responses := make([]map[string]interface{}, 0, len(onboardings))
+	for _, onboarding := range onboardings {
+		var notes []map[string]interface{}
+		for _, note := range onboarding.Notes {
+			notes = append(notes, map[string]interface{}{
+				"created_by":      note.CreatedBy,
+				"created_by_name": userNames[note.CreatedBy],
+				"comment":         note.Comment,
+				"created_at":      note.CreatedAt.Format(cf.FormatDate),
+			})
+		}
+
+		response := map[string]interface{}{
+			"id":           onboarding.ID,
+			"user_id":      onboarding.UserId,
+			"full_name":    userNames[onboarding.UserId],
+			"offer_id":     onboarding.OfferId,
+			"cv_id":        onboarding.CvId,
+			"contract_id":  onboarding.ContractId,
+			"cv_file_name": onboarding.CvFileName,
+			"checklist":    onboarding.Checklist,
+			"notes":        notes,
+		}
+
+		if onboarding.CvFileName != "" {
+			response["cv_file_url"] = "https://storage.googleapis.com/" + os.Getenv("GOOGLE_STORAGE_BUCKET") + "/" +
+				onboarding.CvFilePath + "/" + onboarding.CvFileName
+		}
+
+		if !onboarding.CompletedAt.IsZero() {
+			response["completed_at"] = onboarding.CompletedAt.Format(cf.FormatDate)
+		}
+
+		responses = append(responses, response)
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get onboardings successful",
+		Data:    responses,
+	})
+}
+
+// UpdateOnboardingTask : Mark task of checklist as done or not done, onboarding is completed when all tasks are done
+func (ctr *Controller) UpdateOnboardingTask(c echo.Context) error {
+	params := new(param.UpdateOnboardingTaskParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	onboarding, err := ctr.RecruitmentRepo.SelectOnboarding(params.Id)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || onboarding.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Onboarding does not exist",
+		})
+	}
+
+	if params.Index < 0 || params.Index >= len(onboarding.Checklist) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field index",
+		})
+	}
+
+	now := utils.TimeNowUTC()
+	task := &onboarding.Checklist[params.Index]
+	task.Done = params.Done
+	task.DoneBy, task.DoneAt = 0, time.Time{}
+	if params.Done {
+		task.DoneBy, task.DoneAt = userProfile.UserProfile.UserID, now
+	}
+
+	onboarding.CompletedAt = now
+	for _, item := range onboarding.Checklist {
+		if !item.Done {
+			onboarding.CompletedAt = time.Time{}
+			break
+		}
+	}
+
+	if err := ctr.RecruitmentRepo.UpdateOnboardingChecklist(&onboarding); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Update onboarding task successful",
+	})
+}
+
+// GetCandidateEmailTemplates : Candidate email of every type and language, default is returned when organization has not saved one
+func (ctr *Controller) GetCandidateEmailTemplates(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	templates, err := ctr.RecruitmentRepo.SelectCandidateEmailTemplates(userProfile.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	saved := make(map[string]m.CandidateEmailTemplate)
+	for _, template := range templates {
+		saved[strconv.Itoa(template.Type)+"_"+strconv.Itoa(template.LanguageId)] = template
+	}
+
+	var responses []map[string]interface{}
+	for _, emailType := range []int{cf.InterviewInviteEmail, cf.RejectionEmail, cf.OfferEmail} {
+		for _, languageId := range []int{cf.EnLanguageId, cf.JpLanguageId, cf.VnLanguageId} {
+			template, ok := saved[strconv.Itoa(emailType)+"_"+strconv.Itoa(languageId)]
+			if !ok {
+				template.Subject = cf.DefaultCandidateEmailSubjects[emailType][languageId]
+				template.Body = cf.DefaultCandidateEmailBodies[emailType][languageId]
+			}
+
+			responses = append(responses, map[string]interface{}{
+				"type":        emailType,
+				"type_name":   cf.CandidateEmailTypes[emailType],
+				"language_id": languageId,
+				"subject":     template.Subject,
+				"body":        template.Body,
+				"is_default":  !ok,
+			})
+		}
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get candidate email templates successful",
+		Data: map[string]interface{}{
+			"templates":    responses,
+			"languages":    cf.CandidateEmailLanguages,
+			"merge_fields": cf.CandidateEmailMergeFields,
+		},
+	})
+}
+
+func (ctr *Controller) SaveCandidateEmailTemplate(c echo.Context) error {
+	params := new(param.SaveCandidateEmailTemplateParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	if _, ok := cf.DefaultCandidateEmailSubjects[params.Type]; !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field type",
+		})
+	}
+
+	if _, ok := cf.CandidateEmailLanguages[params.LanguageId]; !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field language_id",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	template, err := ctr.RecruitmentRepo.SelectCandidateEmailTemplate(userProfile.OrganizationID, params.Type, params.LanguageId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	template.OrganizationId = userProfile.OrganizationID
+	template.Type = params.Type
+	template.LanguageId = params.LanguageId
+	template.Subject = params.Subject
+	template.Body = params.Body
+	if err := ctr.RecruitmentRepo.SaveCandidateEmailTemplate(&template); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Save candidate email template successful",
+	})
+}
+
+// SendCandidateEmail : Email candidate of cv from template, or subject and body which recruiter wrote, and log it to cv
+func (ctr *Controller) SendCandidateEmail(c echo.Context) error {
+	params := new(param.SendCandidateEmailParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	if _, ok := cf.CandidateEmailTypes[params.Type]; !ok {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid value for field type",
+		})
+	}
+
+	if params.Type == cf.OtherCandidateEmail && (params.Subject == "" || params.Body == "") {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Subject and body are required for other email",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	cv, recruitment, err := ctr.findManagedCv(c, userProfile, params.CvId)
+	if err != nil || cv == nil {
+		return err
+	}
+
+	if cv.Email == "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Cv has no email to send to",
+		})
+	}
+
+	organization := userProfile.Organization
+	if organization.Email == "" || organization.EmailPassword == "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Email of organization has not been set",
+		})
+	}
+
+	languageId := candidateEmailLanguage(params.LanguageId, userProfile)
+	subject, body := params.Subject, params.Body
+	if subject == "" || body == "" {
+		templateSubject, templateBody, err := ctr.candidateEmailContent(organization.ID, params.Type, languageId)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if subject == "" {
+			subject = templateSubject
+		}
+
+		if body == "" {
+			body = templateBody
+		}
+	}
+
+	fields := candidateEmailFields(cv, recruitment.JobName, userProfile)
+	communication := m.CvCommunication{
+		Type:       params.Type,
+		LanguageId: languageId,
+		Subject:    renderCandidateEmail(subject, fields),
+		Body:       renderCandidateEmail(body, fields),
+		SentBy:     userProfile.UserProfile.UserID,
+	}
+
+	if err := ctr.sendCandidateEmail(organization, cv, &communication, "", nil); err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Email could not be sent",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Send candidate email successful",
+	})
+}
+
+// GetCvCommunications : Emails which were sent to candidate of cv, latest first
+func (ctr *Controller) GetCvCommunications(c echo.Context) error {
+	params := new(param.GetCvCommunicationsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	cv, _, err := ctr.findManagedCv(c, userProfile, params.CvId)
+	if err != nil || cv == nil {
+		return err
+	}
+
+	communications, err := ctr.RecruitmentRepo.SelectCvCommunications(cv.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	users, err := ctr.UserRepo.GetAllUserNameByOrgID(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userNames := make(map[int]string)
+	for _, user := range users {
+		userNames[user.UserID] = user.FullName
+	}
+
+	responses := make([]map[string]interface{}, 0, len(communications))
+	for _, communication := range communications {
+		responses = append(responses, map[string]interface{}{
+			"id":            communication.ID,
+			"type":          communication.Type,
+			"type_name":     cf.CandidateEmailTypes[communication.Type],
+			"language_id":   communication.LanguageId,
+			"subject":       communication.Subject,
+			"body":          communication.Body,
+			"sent_to":       communication.SentTo,
+			"sent_by":       communication.SentBy,
+			"sent_by_name":  userNames[communication.SentBy],
+			"status":        communication.Status,
+			"error_message": communication.ErrorMessage,
+			"sent_at":       communication.SentAt.Format(cf.FormatDate),
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get cv communications successful",
+		Data:    responses,
+	})
+}
+
+// SendRejectionEmails : Email rejection to candidates of job which is done and were not hired, once per cv
+func (ctr *Controller) SendRejectionEmails(c echo.Context) error {
+	params := new(param.SendRejectionEmailsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	if _, err := valid.ValidateStruct(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	recruitment, err := ctr.RecruitmentRepo.SelectJob(params.RecruitmentId, "organization_id", "assignees", "job_name", "expiry_date")
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || recruitment.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusNotFound, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Recruitment does not exist",
+		})
+	}
+
+	if !canManageCvs(userProfile, recruitment) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You do not have permission to send rejection emails",
+		})
+	}
+
+	// Job is done when its expiry date has passed, as in job status filter of jobs list
+	if recruitment.ExpiryDate.Format(cf.FormatDateDatabase) >= time.Now().Format(cf.FormatDateDatabase) {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Rejection emails can only be sent when job is done",
+		})
+	}
+
+	organization := userProfile.Organization
+	if organization.Email == "" || organization.EmailPassword == "" {
+		return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Email of organization has not been set",
+		})
+	}
+
+	languageId := candidateEmailLanguage(params.LanguageId, userProfile)
+	subject, body, err := ctr.candidateEmailContent(organization.ID, cf.RejectionEmail, languageId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	cvs, err := ctr.RecruitmentRepo.SelectRejectionCvs(params.RecruitmentId)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	sentCount, failedCount := 0, 0
+	for i := range cvs {
+		fields := candidateEmailFields(&cvs[i], recruitment.JobName, userProfile)
+		communication := m.CvCommunication{
+			Type:       cf.RejectionEmail,
+			LanguageId: languageId,
+			Subject:    renderCandidateEmail(subject, fields),
+			Body:       renderCandidateEmail(body, fields),
+			SentBy:     userProfile.UserProfile.UserID,
+		}
+
+		if err := ctr.sendCandidateEmail(organization, &cvs[i], &communication, "", nil); err != nil {
+			failedCount++
+			continue
+		}
+
+		sentCount++
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Send rejection emails successful",
+		Data: map[string]interface{}{
+			"sent_count":   sentCount,
+			"failed_count": failedCount,
+		},
+	})
+}
+
+// candidateEmailLanguage : Language of candidate email, language of sender when it is not given
+func candidateEmailLanguage(languageId int, userProfile m.User) int {
+	if _, ok := cf.CandidateEmailLanguages[languageId]; ok {
+		return languageId
+	}
+
+	if _, ok := cf.CandidateEmailLanguages[userProfile.LanguageId]; ok {
+		return userProfile.LanguageId
+	}
+
+	return cf.EnLanguageId
+}
+
+// candidateEmailContent : Subject and body of template of organization, default ones when organization has not saved template
+func (ctr *Controller) candidateEmailContent(organizationId int, emailType int, languageId int) (string, string, error) {
+	template, err := ctr.RecruitmentRepo.SelectCandidateEmailTemplate(organizationId, emailType, languageId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return "", "", err
+	}
+
+	if err != nil {
+		return cf.DefaultCandidateEmailSubjects[emailType][languageId], cf.DefaultCandidateEmailBodies[emailType][languageId], nil
+	}
+
+	return template.Subject, template.Body, nil
+}
+
+// candidateEmailFields : Merge fields of candidate and job, fields of interview and offer are set by caller
+func candidateEmailFields(cv *m.Cv, jobName string, userProfile m.User) map[string]string {
+	return map[string]string{
+		"{{candidate_name}}":    cv.FullName,
+		"{{job_name}}":          jobName,
+		"{{organization_name}}": userProfile.Organization.Name,
+		"{{sender_name}}":       userProfile.UserProfile.FirstName + " " + userProfile.UserProfile.LastName,
+	}
+}
+
+// renderCandidateEmail : Text whose merge fields are replaced, merge field which has no value is removed
+func renderCandidateEmail(text string, fields map[string]string) string {
+	var pairs []string
+	for field := range cf.CandidateEmailMergeFields {
+		pairs = append(pairs, field, fields[field])
+	}
+
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// sendCandidateEmail : Email candidate of cv through smtp of organization, email is logged to cv whether it was sent or not
+func (ctr *Controller) sendCandidateEmail(
+	organization m.Organization,
+	cv *m.Cv,
+	communication *m.CvCommunication,
+	url string,
+	attachments []email.Attachment,
+) error {
+	ctr.InitSmtp(organization.Email, organization.EmailPassword)
+	sampleData := new(param.SampleData)
+	sampleData.SendTo = []string{cv.Email}
+	sampleData.Content = communication.Body
+	sampleData.URL = url
+	sendErr := ctr.SendMailWithAttachment(communication.Subject, sampleData, cf.CandidateEmailTemplate, attachments)
+
+	communication.OrganizationId = organization.ID
+	communication.CvId = cv.ID
+	communication.SentTo = cv.Email
+	communication.SentAt = utils.TimeNowUTC()
+	communication.Status = cf.CommunicationSent
+	if sendErr != nil {
+		ctr.Logger.Error(sendErr)
+		communication.Status = cf.CommunicationFailed
+		communication.ErrorMessage = sendErr.Error()
+	}
+
+	if err := ctr.RecruitmentRepo.InsertCvCommunication(communication); err != nil {
+		ctr.Logger.Error(err)
+	}
+
+	return sendErr
+}
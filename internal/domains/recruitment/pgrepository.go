@@ -3,11 +3,13 @@ package recruitment
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils"
 
 	"github.com/go-pg/pg/v9"
+	"github.com/go-pg/pg/v9/orm"
 	"github.com/labstack/echo/v4"
 	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
 	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
@@ -259,10 +261,20 @@ func (repo *PgRecruitmentRepository) CreateCvNoti(
 			InterviewMethod: params.InterviewMethod,
 			Salary:          params.Salary,
 			ContactLink:     params.ContactLink,
+			CvText:          cv.CvText,
 		}
 		if err := tx.Insert(&cv); err != nil {
 			return err
 		}
+
+		contact := param.CandidateContact{
+			FullName:    params.FullName,
+			Email:       params.Email,
+			PhoneNumber: params.PhoneNumber,
+		}
+		if _, err := repo.linkCandidate(tx, organizationId, cv.ID, contact); err != nil {
+			return err
+		}
 		if len(params.Assignees) > 0 {
 			notificationParams := new(param.InsertNotificationParam)
 			notificationParams.Content = "has added new cv"
@@ -324,6 +336,7 @@ func (repo *PgRecruitmentRepository) InsertCv(
 				RecruitmentId: params.RecruitmentId,
 				MediaId:       cvField.MediaId,
 				FileName:      cvField.FileName,
+				CvText:        cvField.CvText,
 			}
 
 			errTx := tx.Insert(&cv)
@@ -331,6 +344,10 @@ func (repo *PgRecruitmentRepository) InsertCv(
 				repo.Logger.Error(errTx)
 				return errTx
 			}
+
+			if _, errTx = repo.linkCandidate(tx, organizationId, cv.ID, cvField.Contact); errTx != nil {
+				return errTx
+			}
 		}
 
 		if len(params.Assignees) > 0 {
@@ -368,7 +385,8 @@ func (repo *PgRecruitmentRepository) SelectCvs(recruitmentId int, params *param.
 	q := repo.DB.Model(&m.Cv{})
 	var totalRow int
 
-	q.Column("c.id", "c.full_name", "c.date_receipt_cv", "c.file_name", "c.media_id", "c.media_id_other", "c.status_cv").
+	q.Column("c.id", "c.full_name", "c.date_receipt_cv", "c.file_name", "c.media_id", "c.media_id_other", "c.status_cv",
+		"c.stage_id", "c.stage_entered_at").
 		ColumnExpr("c.updated_at as last_updated_at").
 		Where("c.recruitment_id = ?", recruitmentId)
 
@@ -388,9 +406,20 @@ func (repo *PgRecruitmentRepository) SelectCvs(recruitmentId int, params *param.
 		q.Where("c.status_cv = ?", params.Status)
 	}
 
-	q.Offset((params.CurrentPage - 1) * params.RowPerPage).
-		Order("c.updated_at DESC").
-		Limit(params.RowPerPage)
+	if params.StageId != 0 {
+		q.Where("c.stage_id = ?", params.StageId)
+	}
+
+	if params.Keyword != "" {
+		q.Where("to_tsvector('simple', coalesce(c.cv_text, '')) @@ plainto_tsquery('simple', ?)", params.Keyword)
+	}
+
+	if params.RowPerPage != 0 {
+		q.Offset((params.CurrentPage - 1) * params.RowPerPage).
+			Limit(params.RowPerPage)
+	}
+
+	q.Order("c.updated_at DESC")
 
 	totalRow, err := q.SelectAndCount(&cvs)
 
@@ -645,13 +674,26 @@ func (repo *PgRecruitmentRepository) CreateLogCvStatusModel(
 	var errTx error
 	t, _ := time.Parse(cf.FormatDateNoSec, params.UpdateDay)
 		logCvState := m.LogCvState{
-			CvId:      params.CvId,
-			Status:    params.Status,
-			UpdateDay: t,
+			CvId:         params.CvId,
+			Status:       params.Status,
+			UpdateDay:    t,
+			StageId:      params.StageId,
+			RejectReason: params.RejectReason,
+			CreatedBy:    createdBy,
 		}
 		if err := tx.Insert(&logCvState); err != nil {
 			return err
 		}
+
+		if params.StageId != 0 {
+			_, err := tx.Model(&m.Cv{StageId: params.StageId, StageEnteredAt: t}).
+				Column("stage_id", "stage_entered_at", "updated_at").
+				Where("id = ?", params.CvId).
+				Update()
+			if err != nil {
+				return err
+			}
+		}
 		if len(params.Assignees) > 0 {
 			notificationParams := new(param.InsertNotificationParam)
 			notificationParams.Content = "has update cv status"
@@ -682,3 +724,1044 @@ func (repo *PgRecruitmentRepository) CreateLogCvStatusModel(
 
 	return body, link, err
 }
+
+// SelectRecruitmentStages : Select stages of recruitment, recruitment id 0 selects default pipeline of organization
+func (repo *PgRecruitmentRepository) SelectRecruitmentStages(organizationId int, recruitmentId int) ([]m.RecruitmentStage, error) {
+	var stages []m.RecruitmentStage
+	q := repo.DB.Model(&stages).
+		Where("organization_id = ?", organizationId)
+
+	if recruitmentId != 0 {
+		q.Where("recruitment_id = ?", recruitmentId)
+	} else {
+		q.Where("recruitment_id IS NULL")
+	}
+
+	err := q.Order("position ASC").Select()
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return stages, err
+}
+
+// SaveRecruitmentStages : Replace pipeline, stages without id are inserted and stages which are not given are removed
+func (repo *PgRecruitmentRepository) SaveRecruitmentStages(organizationId int, recruitmentId int, stages []m.RecruitmentStage) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var keptIds []int
+		for _, stage := range stages {
+			if stage.ID != 0 {
+				keptIds = append(keptIds, stage.ID)
+			}
+		}
+
+		q := tx.Model(&m.RecruitmentStage{}).
+			Where("organization_id = ?", organizationId)
+
+		if recruitmentId != 0 {
+			q.Where("recruitment_id = ?", recruitmentId)
+		} else {
+			q.Where("recruitment_id IS NULL")
+		}
+
+		if len(keptIds) > 0 {
+			q.Where("id NOT IN (?)", pg.In(keptIds))
+		}
+
+		if _, err := q.Delete(); err != nil {
+			return err
+		}
+
+		for _, stage := range stages {
+			stage.OrganizationId = organizationId
+			stage.RecruitmentId = recruitmentId
+			if stage.ID == 0 {
+				if err := tx.Insert(&stage); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			_, err := tx.Model(&stage).
+				Column("name", "stage_type", "position", "cv_status", "next_positions", "reject_reasons", "updated_at").
+				WherePK().
+				Update()
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) CountCvsInStages(stageIds []int) (int, error) {
+	count, err := repo.DB.Model(&m.Cv{}).
+		Where("stage_id IN (?)", pg.In(stageIds)).
+		Count()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return count, err
+}
+
+// SelectStageDurations : Average days which cvs of recruitment spent in each stage, stage which cv is still in counts until now
+func (repo *PgRecruitmentRepository) SelectStageDurations(recruitmentId int) ([]param.StageDurationRecords, error) {
+	var records []param.StageDurationRecords
+	q := `SELECT stage_id, AVG(EXTRACT(EPOCH FROM (COALESCE(left_at, NOW()) - entered_at)) / 86400) AS average_day, COUNT(*) AS cv_count
+		FROM (
+			SELECT lcs.stage_id, lcs.update_day AS entered_at,
+				LEAD(lcs.update_day) OVER (PARTITION BY lcs.cv_id ORDER BY lcs.update_day, lcs.id) AS left_at
+			FROM log_cv_states AS lcs
+			JOIN cvs AS c ON c.id = lcs.cv_id
+			WHERE c.recruitment_id = ? AND c.deleted_at IS NULL AND lcs.deleted_at IS NULL
+		) AS t
+		WHERE stage_id IS NOT NULL
+		GROUP BY stage_id`
+
+	_, err := repo.DB.Query(&records, q, recruitmentId)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+func (repo *PgRecruitmentRepository) InsertInterview(interview *m.Interview) error {
+	err := repo.DB.Insert(interview)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) SelectInterview(id int) (m.Interview, error) {
+	var interview m.Interview
+	err := repo.DB.Model(&interview).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return interview, err
+}
+
+func (repo *PgRecruitmentRepository) UpdateInterview(interview *m.Interview, columns ...string) error {
+	_, err := repo.DB.Model(interview).
+		Column(append(columns, "updated_at")...).
+		WherePK().
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) SelectInterviews(organizationId int, params *param.GetInterviewsParams) ([]param.InterviewRecords, error) {
+	var records []param.InterviewRecords
+	q := repo.DB.Model(&m.Interview{}).
+		Column("itv.id", "itv.recruitment_id", "itv.cv_id", "itv.round", "itv.interviewers", "itv.start_time", "itv.end_time",
+			"itv.location", "itv.meeting_link", "itv.note", "itv.status", "itv.outcome", "itv.outcome_note", "itv.cancel_reason").
+		ColumnExpr("c.full_name, c.email, rc.job_name").
+		Join("JOIN cvs AS c ON c.id = itv.cv_id").
+		Join("JOIN recruitments AS rc ON rc.id = itv.recruitment_id").
+		Where("itv.organization_id = ?", organizationId)
+
+	if params.RecruitmentId != 0 {
+		q.Where("itv.recruitment_id = ?", params.RecruitmentId)
+	}
+
+	if params.CvId != 0 {
+		q.Where("itv.cv_id = ?", params.CvId)
+	}
+
+	if params.Interviewer != 0 {
+		q.Where("? = ANY(itv.interviewers)", params.Interviewer)
+	}
+
+	if params.Status != 0 {
+		q.Where("itv.status = ?", params.Status)
+	}
+
+	if params.FromDate != "" {
+		q.Where("DATE(itv.start_time) >= to_date(?,'YYYY-MM-DD')", params.FromDate)
+	}
+
+	if params.ToDate != "" {
+		q.Where("DATE(itv.start_time) <= to_date(?,'YYYY-MM-DD')", params.ToDate)
+	}
+
+	err := q.Order("itv.start_time ASC").Select(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectInterviewConflicts : Leave requests and scheduled interviews of panel or of candidate which overlap slot
+func (repo *PgRecruitmentRepository) SelectInterviewConflicts(
+	organizationId int,
+	interviewId int,
+	cvId int,
+	userIds []int,
+	startTime time.Time,
+	endTime time.Time,
+) ([]param.InterviewConflictRecords, error) {
+	var records []param.InterviewConflictRecords
+	if len(userIds) > 0 {
+		var leaveRecords []param.InterviewConflictRecords
+		err := repo.DB.Model(&m.UserLeaveRequest{}).
+			ColumnExpr("ulr.user_id, 'leave' AS type, ulr.id AS reference_id").
+			ColumnExpr("ulr.datetime_leave_from AS start_time, ulr.datetime_leave_to AS end_time").
+			Where("ulr.organization_id = ?", organizationId).
+			WhereIn("ulr.user_id IN (?)", userIds).
+			Where("ulr.leave_request_type_id != ?", cf.WorkAtHome).
+			Where("ulr.datetime_leave_from < ?", endTime).
+			Where("ulr.datetime_leave_to > ?", startTime).
+			Select(&leaveRecords)
+		if err != nil {
+			repo.Logger.Error(err)
+			return records, err
+		}
+
+		records = append(records, leaveRecords...)
+	}
+
+	var interviews []m.Interview
+	q := repo.DB.Model(&interviews).
+		Column("id", "cv_id", "interviewers", "start_time", "end_time").
+		Where("organization_id = ?", organizationId).
+		Where("status = ?", cf.InterviewScheduled).
+		Where("start_time < ?", endTime).
+		Where("end_time > ?", startTime).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q.WhereOr("cv_id = ?", cvId)
+			if len(userIds) > 0 {
+				q.WhereOr("interviewers && ?", pg.Array(userIds))
+			}
+
+			return q, nil
+		})
+
+	if interviewId != 0 {
+		q.Where("id != ?", interviewId)
+	}
+
+	if err := q.Select(); err != nil {
+		repo.Logger.Error(err)
+		return records, err
+	}
+
+	for _, interview := range interviews {
+		conflict := param.InterviewConflictRecords{
+			Type:        "interview",
+			ReferenceId: interview.ID,
+			StartTime:   interview.StartTime,
+			EndTime:     interview.EndTime,
+		}
+
+		if interview.CvId == cvId {
+			records = append(records, conflict)
+		}
+
+		for _, userId := range interview.Interviewers {
+			if utils.FindIntInSlice(userIds, userId) {
+				conflict.UserId = userId
+				records = append(records, conflict)
+			}
+		}
+	}
+
+	return records, nil
+}
+
+func (repo *PgRecruitmentRepository) SelectScorecardTemplate(recruitmentId int) (m.ScorecardTemplate, error) {
+	var template m.ScorecardTemplate
+	err := repo.DB.Model(&template).
+		Where("recruitment_id = ?", recruitmentId).
+		First()
+
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		repo.Logger.Error(err)
+	}
+
+	return template, err
+}
+
+func (repo *PgRecruitmentRepository) SaveScorecardTemplate(template *m.ScorecardTemplate) error {
+	var err error
+	if template.ID == 0 {
+		err = repo.DB.Insert(template)
+	} else {
+		_, err = repo.DB.Model(template).
+			Column("competencies", "updated_at").
+			WherePK().
+			Update()
+	}
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) SelectScorecards(cvId int) ([]m.Scorecard, error) {
+	var scorecards []m.Scorecard
+	err := repo.DB.Model(&scorecards).
+		Where("cv_id = ?", cvId).
+		Order("round ASC", "submitted_at ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return scorecards, err
+}
+
+func (repo *PgRecruitmentRepository) SaveScorecard(scorecard *m.Scorecard) error {
+	var err error
+	if scorecard.ID == 0 {
+		err = repo.DB.Insert(scorecard)
+	} else {
+		_, err = repo.DB.Model(scorecard).
+			Column("ratings", "recommendation", "note", "status", "submitted_at", "updated_at").
+			WherePK().
+			Update()
+	}
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// LinkCvCandidate : Link cv which was created before candidates with candidate
+func (repo *PgRecruitmentRepository) LinkCvCandidate(organizationId int, cvId int, contact param.CandidateContact) (int, error) {
+	candidateId, err := repo.linkCandidate(repo.DB, organizationId, cvId, contact)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return candidateId, err
+}
+
+// linkCandidate : Cv is linked with candidate who has the same email or phone number, new candidate is created when nobody matches
+func (repo *PgRecruitmentRepository) linkCandidate(db orm.DB, organizationId int, cvId int, contact param.CandidateContact) (int, error) {
+	candidate := m.Candidate{
+		OrganizationId:  organizationId,
+		FullName:        strings.TrimSpace(contact.FullName),
+		Email:           strings.TrimSpace(contact.Email),
+		PhoneNumber:     strings.TrimSpace(contact.PhoneNumber),
+		NormalizedEmail: utils.NormalizeEmail(contact.Email),
+		NormalizedPhone: utils.NormalizeVietnamesePhone(contact.PhoneNumber),
+		NormalizedName:  utils.NormalizeName(contact.FullName),
+	}
+
+	var matched m.Candidate
+	if candidate.NormalizedEmail != "" || candidate.NormalizedPhone != "" {
+		err := db.Model(&matched).
+			Where("organization_id = ?", organizationId).
+			WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+				if candidate.NormalizedEmail != "" {
+					q.WhereOr("normalized_email = ?", candidate.NormalizedEmail)
+				}
+
+				if candidate.NormalizedPhone != "" {
+					q.WhereOr("normalized_phone = ?", candidate.NormalizedPhone)
+				}
+
+				return q, nil
+			}).
+			Order("id ASC").
+			Limit(1).
+			Select()
+
+		if err != nil && err != pg.ErrNoRows {
+			return 0, err
+		}
+	}
+
+	var err error
+	if matched.ID != 0 {
+		columns := fillCandidate(&matched, candidate)
+		if len(columns) > 0 {
+			_, err = db.Model(&matched).
+				Column(append(columns, "updated_at")...).
+				WherePK().
+				Update()
+		}
+
+		candidate = matched
+	} else {
+		err = db.Insert(&candidate)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = db.Model(&m.Cv{}).
+		Set("candidate_id = ?", candidate.ID).
+		Where("id = ?", cvId).
+		Update()
+
+	return candidate.ID, err
+}
+
+// fillCandidate : Details which candidate does not have are taken from other, columns which are changed are returned
+func fillCandidate(candidate *m.Candidate, other m.Candidate) []string {
+	var columns []string
+	if candidate.FullName == "" && other.FullName != "" {
+		candidate.FullName = other.FullName
+		candidate.NormalizedName = other.NormalizedName
+		columns = append(columns, "full_name", "normalized_name")
+	}
+
+	if candidate.Email == "" && other.Email != "" {
+		candidate.Email = other.Email
+		candidate.NormalizedEmail = other.NormalizedEmail
+		columns = append(columns, "email", "normalized_email")
+	}
+
+	if candidate.PhoneNumber == "" && other.PhoneNumber != "" {
+		candidate.PhoneNumber = other.PhoneNumber
+		candidate.NormalizedPhone = other.NormalizedPhone
+		columns = append(columns, "phone_number", "normalized_phone")
+	}
+
+	return columns
+}
+
+func (repo *PgRecruitmentRepository) SelectCandidate(organizationId int, id int) (m.Candidate, error) {
+	candidate := m.Candidate{}
+	err := repo.DB.Model(&candidate).
+		Where("id = ?", id).
+		Where("organization_id = ?", organizationId).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return candidate, err
+}
+
+func (repo *PgRecruitmentRepository) SelectCandidates(organizationId int) ([]m.Candidate, error) {
+	var candidates []m.Candidate
+	err := repo.DB.Model(&candidates).
+		Where("organization_id = ?", organizationId).
+		Order("id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return candidates, err
+}
+
+// MergeCandidates : Cvs of merged candidates are moved to candidate, merged candidates are deleted logic
+func (repo *PgRecruitmentRepository) MergeCandidates(candidate *m.Candidate, mergedCandidates []m.Candidate) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var columns []string
+		for i := range mergedCandidates {
+			merged := &mergedCandidates[i]
+			_, err := tx.Model(&m.Cv{}).
+				Set("candidate_id = ?", candidate.ID).
+				Where("candidate_id = ?", merged.ID).
+				Update()
+			if err != nil {
+				return err
+			}
+
+			// Candidates which were merged into merged candidate before now point to candidate
+			_, err = tx.Model(&m.Candidate{}).
+				Set("merged_into_id = ?", candidate.ID).
+				Where("merged_into_id = ?", merged.ID).
+				AllWithDeleted().
+				Update()
+			if err != nil {
+				return err
+			}
+
+			merged.MergedIntoId = candidate.ID
+			_, err = tx.Model(merged).
+				Column("merged_into_id", "updated_at").
+				WherePK().
+				Update()
+			if err != nil {
+				return err
+			}
+
+			if _, err = tx.Model(merged).WherePK().Delete(); err != nil {
+				return err
+			}
+
+			columns = append(columns, fillCandidate(candidate, *merged)...)
+		}
+
+		if len(columns) == 0 {
+			return nil
+		}
+
+		_, err := tx.Model(candidate).
+			Column(append(columns, "updated_at")...).
+			WherePK().
+			Update()
+
+		return err
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// SelectCandidateApplications : Cvs of candidate across recruitments, newest first
+func (repo *PgRecruitmentRepository) SelectCandidateApplications(candidateId int) ([]param.CandidateApplicationRecords, error) {
+	var records []param.CandidateApplicationRecords
+	err := repo.DB.Model(&m.Cv{}).
+		ColumnExpr("c.id AS cv_id, c.recruitment_id, rec.job_name, rec.assignees").
+		ColumnExpr("c.full_name, c.email, c.phone_number, c.media_id, c.media_id_other").
+		ColumnExpr("c.status_cv, c.stage_id, c.date_receipt_cv, c.file_name").
+		Join("JOIN recruitments AS rec ON rec.id = c.recruitment_id").
+		Where("c.candidate_id = ?", candidateId).
+		Order("c.date_receipt_cv DESC", "c.id DESC").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectActiveJobs : Jobs of organization which are open today with their detail, all jobs are selected when row per page is 0
+func (repo *PgRecruitmentRepository) SelectActiveJobs(organizationId int, params *param.GetCareerJobsParams) ([]param.CareerJobRecords, int, error) {
+	var records []param.CareerJobRecords
+	q := repo.activeJobsQuery(organizationId)
+	if params.Keyword != "" {
+		q.Where("vietnamese_unaccent(LOWER(rec.job_name)) LIKE vietnamese_unaccent(LOWER(?))", "%"+params.Keyword+"%")
+	}
+
+	if params.RowPerPage != 0 {
+		q.Offset((params.CurrentPage - 1) * params.RowPerPage).
+			Limit(params.RowPerPage)
+	}
+
+	totalRow, err := q.Order("rec.start_date DESC", "rec.id DESC").SelectAndCount(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, totalRow, err
+}
+
+func (repo *PgRecruitmentRepository) SelectActiveJob(organizationId int, id int) (param.CareerJobRecords, error) {
+	var record param.CareerJobRecords
+	err := repo.activeJobsQuery(organizationId).
+		Where("rec.id = ?", id).
+		Limit(1).
+		Select(&record)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return record, err
+}
+
+func (repo *PgRecruitmentRepository) activeJobsQuery(organizationId int) *orm.Query {
+	now := time.Now().Format(cf.FormatDateDatabase)
+	return repo.DB.Model(&m.Recruitment{}).
+		ColumnExpr("rec.id, rec.job_name, rec.start_date, rec.expiry_date, rec.assignees").
+		ColumnExpr("djr.amount, djr.address, djr.place, djr.role, djr.gender, djr.type_of_work, djr.experience").
+		ColumnExpr("djr.salary_type, djr.salary_from, djr.salary_to, djr.profile_recipients, djr.email, djr.phone_number, djr.description").
+		Join("LEFT JOIN detailed_job_recruitments AS djr ON djr.recruitment_id = rec.id AND djr.deleted_at IS NULL").
+		Where("rec.organization_id = ?", organizationId).
+		Where("DATE(rec.start_date) <= to_date(?,'YYYY-MM-DD')", now).
+		Where("DATE(rec.expiry_date) >= to_date(?,'YYYY-MM-DD')", now)
+}
+
+// InsertCareerCv : Insert cv which candidate sent from careers page with consent of candidate
+func (repo *PgRecruitmentRepository) InsertCareerCv(organizationId int, cv *m.Cv, consent *m.CandidateConsent) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		if err := tx.Insert(cv); err != nil {
+			return err
+		}
+
+		contact := param.CandidateContact{FullName: cv.FullName, Email: cv.Email, PhoneNumber: cv.PhoneNumber}
+		candidateId, err := repo.linkCandidate(tx, organizationId, cv.ID, contact)
+		if err != nil {
+			return err
+		}
+
+		cv.CandidateId = candidateId
+		consent.CvId = cv.ID
+		consent.CandidateId = candidateId
+		return tx.Insert(consent)
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// SelectAnalyticsCvs : Cvs of organization which were received in period of params
+func (repo *PgRecruitmentRepository) SelectAnalyticsCvs(organizationId int, params *param.RecruitmentAnalyticsParams) ([]param.AnalyticsCvRecords, error) {
+	var records []param.AnalyticsCvRecords
+	q := repo.DB.Model(&m.Cv{}).
+		Column("c.id", "c.recruitment_id", "c.media_id", "c.status_cv", "c.stage_id", "c.date_receipt_cv", "c.created_at").
+		Join("JOIN recruitments AS rec ON rec.id = c.recruitment_id").
+		Where("rec.organization_id = ?", organizationId).
+		Where("rec.deleted_at IS NULL")
+
+	if params.RecruitmentId != 0 {
+		q.Where("c.recruitment_id = ?", params.RecruitmentId)
+	}
+
+	if params.BranchId != 0 {
+		q.Where("? = ANY (rec.branch_ids)", params.BranchId)
+	}
+
+	if params.FromDate != "" {
+		q.Where("DATE(COALESCE(c.date_receipt_cv, c.created_at)) >= to_date(?,'YYYY-MM-DD')", params.FromDate)
+	}
+
+	if params.ToDate != "" {
+		q.Where("DATE(COALESCE(c.date_receipt_cv, c.created_at)) <= to_date(?,'YYYY-MM-DD')", params.ToDate)
+	}
+
+	err := q.Order("c.id ASC").Select(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+// SelectLogCvStatesByCvIds : Status logs of cvs in order of time
+func (repo *PgRecruitmentRepository) SelectLogCvStatesByCvIds(cvIds []int) ([]m.LogCvState, error) {
+	var logCvStates []m.LogCvState
+	if len(cvIds) == 0 {
+		return logCvStates, nil
+	}
+
+	err := repo.DB.Model(&logCvStates).
+		Column("id", "cv_id", "status", "stage_id", "update_day").
+		Where("cv_id IN (?)", pg.In(cvIds)).
+		Order("cv_id ASC", "update_day ASC", "id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return logCvStates, err
+}
+
+// SelectOrganizationStages : Stages of all pipelines of organization, removed stages are included as old logs still refer to them
+func (repo *PgRecruitmentRepository) SelectOrganizationStages(organizationId int) ([]m.RecruitmentStage, error) {
+	var stages []m.RecruitmentStage
+	err := repo.DB.Model(&stages).
+		Column("id", "stage_type").
+		Where("organization_id = ?", organizationId).
+		AllWithDeleted().
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return stages, err
+}
+
+// SelectAnalyticsInterviews : Interviews of organization which started in period of params
+func (repo *PgRecruitmentRepository) SelectAnalyticsInterviews(organizationId int, params *param.RecruitmentAnalyticsParams) ([]m.Interview, error) {
+	var interviews []m.Interview
+	q := repo.DB.Model(&interviews).
+		Column("itv.id", "itv.interviewers", "itv.start_time", "itv.end_time", "itv.status").
+		Join("JOIN recruitments AS rec ON rec.id = itv.recruitment_id").
+		Where("itv.organization_id = ?", organizationId).
+		Where("rec.deleted_at IS NULL")
+
+	if params.RecruitmentId != 0 {
+		q.Where("itv.recruitment_id = ?", params.RecruitmentId)
+	}
+
+	if params.BranchId != 0 {
+		q.Where("? = ANY (rec.branch_ids)", params.BranchId)
+	}
+
+	if params.FromDate != "" {
+		q.Where("DATE(itv.start_time) >= to_date(?,'YYYY-MM-DD')", params.FromDate)
+	}
+
+	if params.ToDate != "" {
+		q.Where("DATE(itv.start_time) <= to_date(?,'YYYY-MM-DD')", params.ToDate)
+	}
+
+	err := q.Select()
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return interviews, err
+}
+
+func (repo *PgRecruitmentRepository) SelectOfferTemplate(organizationId int) (m.OfferTemplate, error) {
+	var template m.OfferTemplate
+	err := repo.DB.Model(&template).
+		Where("organization_id = ?", organizationId).
+		First()
+
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		repo.Logger.Error(err)
+	}
+
+	return template, err
+}
+
+func (repo *PgRecruitmentRepository) SaveOfferTemplate(template *m.OfferTemplate) error {
+	var err error
+	if template.ID == 0 {
+		err = repo.DB.Insert(template)
+	} else {
+		_, err = repo.DB.Model(template).
+			Column("content", "updated_at").
+			WherePK().
+			Update()
+	}
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) InsertOffer(offer *m.Offer) error {
+	err := repo.DB.Insert(offer)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) SelectOffer(id int) (m.Offer, error) {
+	var offer m.Offer
+	err := repo.DB.Model(&offer).
+		Where("id = ?", id).
+		First()
+
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		repo.Logger.Error(err)
+	}
+
+	return offer, err
+}
+
+// SelectOfferByToken : Offer which was sent with token, token is stored as hash
+func (repo *PgRecruitmentRepository) SelectOfferByToken(token string) (m.Offer, error) {
+	var offer m.Offer
+	err := repo.DB.Model(&offer).
+		Where("token = ?", utils.GetSHA256Hash(token)).
+		First()
+
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		repo.Logger.Error(err)
+	}
+
+	return offer, err
+}
+
+func (repo *PgRecruitmentRepository) SelectOffersByCvId(cvId int) ([]m.Offer, error) {
+	var offers []m.Offer
+	err := repo.DB.Model(&offers).
+		Where("cv_id = ?", cvId).
+		Order("created_at DESC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return offers, err
+}
+
+func (repo *PgRecruitmentRepository) UpdateOffer(offer *m.Offer, columns ...string) error {
+	_, err := repo.DB.Model(offer).
+		Column(append(columns, "updated_at")...).
+		WherePK().
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// AcceptOffer : Create user, profile, permissions, draft contract and onboarding of candidate who accepted offer.
+// Error is pg.ErrNoRows when offer was responded meanwhile
+func (repo *PgRecruitmentRepository) AcceptOffer(
+	offer *m.Offer,
+	user *m.User,
+	userProfile *m.UserProfile,
+	contract *m.Contract,
+	onboarding *m.Onboarding,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		if err := tx.Insert(user); err != nil {
+			return err
+		}
+
+		// Invite is recorded as registration request which is registered, as user was created from offer
+		registrationRequest := m.RegistrationRequest{
+			Type:           cf.AdminInviteType,
+			Status:         cf.RegisteredRequestStatus,
+			Email:          user.Email,
+			OrganizationID: user.OrganizationID,
+			Message:        "Hired from offer of " + offer.JobTitle,
+		}
+
+		if err := tx.Insert(&registrationRequest); err != nil {
+			return err
+		}
+
+		userProfile.UserID = user.ID
+		if err := tx.Insert(userProfile); err != nil {
+			return err
+		}
+
+		if err := insertOrganizationPermissions(tx, user.OrganizationID, user.ID); err != nil {
+			return err
+		}
+
+		contract.UserId = user.ID
+		if err := tx.Insert(contract); err != nil {
+			return err
+		}
+
+		offer.Status = cf.OfferAccepted
+		offer.UserId = user.ID
+		offer.ContractId = contract.ID
+		res, err := tx.Model(offer).
+			Column("status", "responded_at", "user_id", "contract_id", "updated_at").
+			WherePK().
+			Where("status = ?", cf.OfferSent).
+			Update()
+
+		if err != nil {
+			return err
+		}
+
+		if res.RowsAffected() == 0 {
+			return pg.ErrNoRows
+		}
+
+		onboarding.UserId = user.ID
+		onboarding.ContractId = contract.ID
+		return tx.Insert(onboarding)
+	})
+
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// insertOrganizationPermissions : Give user access to functions of modules which organization uses, as registration does
+func insertOrganizationPermissions(tx *pg.Tx, organizationId int, userId int) error {
+	var functions []param.FunctionRecord
+	q := "SELECT f.id, f.name " +
+		"FROM organization_modules orgm, JSON_ARRAY_ELEMENTS(orgm.modules::json) orgms " +
+		"JOIN modules as m ON (orgms->>'module_id')::int = m.id " +
+		"JOIN functions AS f ON m.id = f.module_id " +
+		"WHERE (orgms->>'status'='true') AND orgm.organization_id = ? " +
+		"AND orgm.deleted_at IS NULL " +
+		"ORDER BY f.id"
+
+	if _, err := tx.Query(&functions, q, organizationId); err != nil {
+		return err
+	}
+
+	for _, function := range functions {
+		userPermission := m.UserPermission{
+			OrganizationId: organizationId,
+			UserID:         userId,
+			FunctionID:     function.Id,
+			Status:         cf.ACCESSFUNC,
+		}
+
+		if err := tx.Insert(&userPermission); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo *PgRecruitmentRepository) SelectOnboardings(organizationId int, params *param.GetOnboardingsParams) ([]m.Onboarding, error) {
+	var onboardings []m.Onboarding
+	q := repo.DB.Model(&onboardings).
+		Where("organization_id = ?", organizationId)
+
+	if params.UserId != 0 {
+		q.Where("user_id = ?", params.UserId)
+	}
+
+	if params.Completed {
+		q.Where("completed_at IS NOT NULL")
+	} else {
+		q.Where("completed_at IS NULL")
+	}
+
+	err := q.Order("created_at DESC").Select()
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return onboardings, err
+}
+
+func (repo *PgRecruitmentRepository) SelectOnboarding(id int) (m.Onboarding, error) {
+	var onboarding m.Onboarding
+	err := repo.DB.Model(&onboarding).
+		Where("id = ?", id).
+		First()
+
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		repo.Logger.Error(err)
+	}
+
+	return onboarding, err
+}
+
+func (repo *PgRecruitmentRepository) UpdateOnboardingChecklist(onboarding *m.Onboarding) error {
+	_, err := repo.DB.Model(onboarding).
+		Column("checklist", "completed_at", "updated_at").
+		WherePK().
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) SelectCandidateEmailTemplates(organizationId int) ([]m.CandidateEmailTemplate, error) {
+	var templates []m.CandidateEmailTemplate
+	err := repo.DB.Model(&templates).
+		Where("organization_id = ?", organizationId).
+		Order("type ASC", "language_id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return templates, err
+}
+
+func (repo *PgRecruitmentRepository) SelectCandidateEmailTemplate(organizationId int, emailType int, languageId int) (m.CandidateEmailTemplate, error) {
+	var template m.CandidateEmailTemplate
+	err := repo.DB.Model(&template).
+		Where("organization_id = ?", organizationId).
+		Where("type = ?", emailType).
+		Where("language_id = ?", languageId).
+		First()
+
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		repo.Logger.Error(err)
+	}
+
+	return template, err
+}
+
+func (repo *PgRecruitmentRepository) SaveCandidateEmailTemplate(template *m.CandidateEmailTemplate) error {
+	var err error
+	if template.ID == 0 {
+		err = repo.DB.Insert(template)
+	} else {
+		_, err = repo.DB.Model(template).
+			Column("subject", "body", "updated_at").
+			WherePK().
+			Update()
+	}
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) InsertCvCommunication(communication *m.CvCommunication) error {
+	err := repo.DB.Insert(communication)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgRecruitmentRepository) SelectCvCommunications(cvId int) ([]m.CvCommunication, error) {
+	var communications []m.CvCommunication
+	err := repo.DB.Model(&communications).
+		Where("cv_id = ?", cvId).
+		Order("sent_at DESC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return communications, err
+}
+
+// SelectRejectionCvs : Cvs of recruitment which were not hired and whose candidates have not received rejection email
+func (repo *PgRecruitmentRepository) SelectRejectionCvs(recruitmentId int) ([]m.Cv, error) {
+	var cvs []m.Cv
+	err := repo.DB.Model(&cvs).
+		Column("c.id", "c.recruitment_id", "c.full_name", "c.email", "c.status_cv").
+		Where("c.recruitment_id = ?", recruitmentId).
+		Where("c.status_cv != ?", cf.CVPASSFINAL).
+		Where("COALESCE(c.email, '') != ''").
+		Where("NOT EXISTS (SELECT 1 FROM cv_communications AS cvm "+
+			"WHERE cvm.cv_id = c.id AND cvm.type = ? AND cvm.status = ? AND cvm.deleted_at IS NULL)",
+			cf.RejectionEmail, cf.CommunicationSent).
+		Order("c.id ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return cvs, err
+}
@@ -0,0 +1,716 @@
+package shift
+
+import (
+	"net/http"
+	"time"
+
+	valid "github.com/asaskevich/govalidator"
+	"github.com/go-pg/pg/v9"
+	"github.com/labstack/echo/v4"
+	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+	rp "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/repository"
+	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
+	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
+	afb "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/appfirebase"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils"
+)
+
+type Controller struct {
+	cm.BaseController
+	afb.FirebaseCloudMessage
+
+	ShiftRepo        rp.ShiftRepository
+	UserRepo         rp.UserRepository
+	NotificationRepo rp.NotificationRepository
+	FcmTokenRepo     rp.FcmTokenRepository
+}
+
+// NewShiftController : Init Shift Controller
+func NewShiftController(
+	logger echo.Logger,
+	shiftRepo rp.ShiftRepository,
+	userRepo rp.UserRepository,
+	notificationRepo rp.NotificationRepository,
+	fcmTokenRepo rp.FcmTokenRepository,
+) (ctr *Controller) {
+	ctr = &Controller{
+		cm.BaseController{}, afb.FirebaseCloudMessage{},
+		shiftRepo, userRepo, notificationRepo, fcmTokenRepo,
+	}
+	ctr.Init(logger)
+	ctr.InitFcm()
+
+	return
+}
+
+func (ctr *Controller) CreateShift(c echo.Context) error {
+	params := new(param.CreateShiftParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil || !isValidShiftTimes(params.StartTime, params.EndTime, params.BreakStart, params.BreakEnd) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	err = ctr.ShiftRepo.InsertShift(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create shift successful",
+	})
+}
+
+func (ctr *Controller) EditShift(c echo.Context) error {
+	params := new(param.EditShiftParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	shift, err := ctr.ShiftRepo.SelectShiftById(params.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Shift does not yet exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if shift.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Shift does not yet exist",
+		})
+	}
+
+	if params.Name == "" {
+		params.Name = shift.Name
+	}
+	if params.StartTime == "" {
+		params.StartTime = shift.StartTime
+	}
+	if params.EndTime == "" {
+		params.EndTime = shift.EndTime
+	}
+
+	if !isValidShiftTimes(params.StartTime, params.EndTime, params.BreakStart, params.BreakEnd) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	err = ctr.ShiftRepo.UpdateShift(params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Edit shift successful",
+	})
+}
+
+func (ctr *Controller) RemoveShift(c echo.Context) error {
+	params := new(param.RemoveShiftParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	shift, err := ctr.ShiftRepo.SelectShiftById(params.Id)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || shift.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Shift does not yet exist",
+		})
+	}
+
+	count, err := ctr.ShiftRepo.CountUserShiftsByShiftId(params.Id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if count > 0 {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Shift is assigned in roster",
+		})
+	}
+
+	err = ctr.ShiftRepo.DeleteShift(params.Id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove shift successful",
+	})
+}
+
+func (ctr *Controller) GetShifts(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	shifts, err := ctr.ShiftRepo.SelectShifts(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var responses []map[string]interface{}
+	for _, shift := range shifts {
+		responses = append(responses, map[string]interface{}{
+			"id":          shift.ID,
+			"name":        shift.Name,
+			"start_time":  shift.StartTime,
+			"end_time":    shift.EndTime,
+			"break_start": shift.BreakStart,
+			"break_end":   shift.BreakEnd,
+			"overnight":   shift.EndTime <= shift.StartTime,
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get shifts successful",
+		Data:    responses,
+	})
+}
+
+func (ctr *Controller) CreateRoster(c echo.Context) error {
+	params := new(param.CreateRosterParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	dateFrom, errFrom := time.Parse(cf.FormatDateDatabase, params.DateFrom)
+	dateTo, errTo := time.Parse(cf.FormatDateDatabase, params.DateTo)
+	if errFrom != nil || errTo != nil || dateTo.Before(dateFrom) || dateTo.Sub(dateFrom).Hours()/24 >= cf.MaxRosterDays {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	shift, err := ctr.ShiftRepo.SelectShiftById(params.ShiftId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || shift.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Shift does not yet exist",
+		})
+	}
+
+	var shiftDates []string
+	for date := dateFrom; !date.After(dateTo); date = date.AddDate(0, 0, 1) {
+		if len(params.Weekdays) > 0 && !utils.FindIntInSlice(params.Weekdays, int(date.Weekday())) {
+			continue
+		}
+		shiftDates = append(shiftDates, date.Format(cf.FormatDateDatabase))
+	}
+
+	if len(shiftDates) == 0 {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "No date match weekdays",
+		})
+	}
+
+	err = ctr.ShiftRepo.UpsertUserShifts(
+		userProfile.OrganizationID,
+		userProfile.UserProfile.UserID,
+		params.ShiftId,
+		params.UsersId,
+		shiftDates,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create roster successful",
+	})
+}
+
+func (ctr *Controller) RemoveRoster(c echo.Context) error {
+	params := new(param.RemoveRosterParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	userShift, err := ctr.ShiftRepo.SelectUserShiftById(params.Id)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil || userShift.OrganizationId != userProfile.OrganizationID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Roster does not yet exist",
+		})
+	}
+
+	err = ctr.ShiftRepo.DeleteUserShift(params.Id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Remove roster successful",
+	})
+}
+
+func (ctr *Controller) GetRoster(c echo.Context) error {
+	params := new(param.GetRosterParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	records, err := ctr.ShiftRepo.SelectRoster(userProfile.OrganizationID, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	var responses []map[string]interface{}
+	for _, record := range records {
+		responses = append(responses, map[string]interface{}{
+			"id":          record.Id,
+			"user_id":     record.UserId,
+			"full_name":   record.FullName,
+			"shift_id":    record.ShiftId,
+			"shift_name":  record.ShiftName,
+			"shift_date":  record.ShiftDate.Format(cf.FormatDateDisplay),
+			"week_day":    record.ShiftDate.Weekday().String(),
+			"start_time":  record.StartTime,
+			"end_time":    record.EndTime,
+			"break_start": record.BreakStart,
+			"break_end":   record.BreakEnd,
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get roster successful",
+		Data:    responses,
+	})
+}
+
+func (ctr *Controller) CreateShiftSwapRequest(c echo.Context) error {
+	params := new(param.CreateShiftSwapRequestParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	requesterShift, err := ctr.ShiftRepo.SelectUserShiftById(params.RequesterShiftId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil ||
+		requesterShift.OrganizationId != userProfile.OrganizationID ||
+		requesterShift.UserId != userProfile.UserProfile.UserID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to swap this shift",
+		})
+	}
+
+	targetShift, err := ctr.ShiftRepo.SelectUserShiftById(params.TargetShiftId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if err != nil ||
+		targetShift.OrganizationId != userProfile.OrganizationID ||
+		targetShift.UserId == userProfile.UserProfile.UserID {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Target shift is invalid",
+		})
+	}
+
+	usersIdGmAndManager, err := ctr.UserRepo.SelectIdsOfGMAndManager(userProfile.OrganizationID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	uniqueUsersId := utils.AppendUniqueSlice([]int{targetShift.UserId}, usersIdGmAndManager)
+	body, link, err := ctr.ShiftRepo.InsertShiftSwapRequest(
+		userProfile.OrganizationID,
+		userProfile.UserProfile.UserID,
+		targetShift.UserId,
+		params,
+		ctr.NotificationRepo,
+		uniqueUsersId,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	registrationTokens, err := ctr.FcmTokenRepo.SelectMultiFcmTokens(uniqueUsersId, userProfile.UserProfile.UserID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	ctr.sendNotification(registrationTokens, userProfile, body, link)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create shift swap request successful",
+	})
+}
+
+func (ctr *Controller) UpdateShiftSwapRequestStatus(c echo.Context) error {
+	params := new(param.UpdateShiftSwapRequestStatusParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	swapRequest, err := ctr.ShiftRepo.SelectShiftSwapRequestById(params.Id)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "No result",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	isManager := userProfile.RoleID == cf.GeneralManagerRoleID || userProfile.RoleID == cf.ManagerRoleID
+	if swapRequest.OrganizationId != userProfile.OrganizationID ||
+		(swapRequest.TargetId != userProfile.UserProfile.UserID && !isManager) {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "You not have permission to update this request",
+		})
+	}
+
+	if swapRequest.Status != cf.PendingRequestStatus {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Request has been processed",
+		})
+	}
+
+	if params.Status == cf.AcceptRequestStatus {
+		requesterShift, err := ctr.ShiftRepo.SelectUserShiftById(swapRequest.RequesterShiftId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+		requesterShiftChanged := err != nil || requesterShift.UserId != swapRequest.RequesterId
+
+		targetShift, err := ctr.ShiftRepo.SelectUserShiftById(swapRequest.TargetShiftId)
+		if err != nil && err.Error() != pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "System Error",
+			})
+		}
+
+		if requesterShiftChanged || err != nil || targetShift.UserId != swapRequest.TargetId {
+			return c.JSON(http.StatusUnprocessableEntity, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Roster has been changed",
+			})
+		}
+	}
+
+	body, link, err := ctr.ShiftRepo.UpdateStatusShiftSwapRequest(
+		userProfile.OrganizationID,
+		userProfile.UserProfile.UserID,
+		params,
+		swapRequest,
+		ctr.NotificationRepo,
+	)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	registrationTokens, err := ctr.FcmTokenRepo.SelectFcmTokenByUserId(swapRequest.RequesterId)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	ctr.sendNotification(registrationTokens, userProfile, body, link)
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Update status request successful",
+	})
+}
+
+func (ctr *Controller) GetShiftSwapRequests(c echo.Context) error {
+	params := new(param.GetShiftSwapRequestsParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+			Data:    err,
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	userId := userProfile.UserProfile.UserID
+	if userProfile.RoleID == cf.GeneralManagerRoleID || userProfile.RoleID == cf.ManagerRoleID {
+		userId = 0
+	}
+
+	records, totalRow, err := ctr.ShiftRepo.SelectShiftSwapRequests(userProfile.OrganizationID, userId, params)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	pagination := map[string]interface{}{
+		"current_page": params.CurrentPage,
+		"total_row":    totalRow,
+		"row_per_page": params.RowPerPage,
+	}
+
+	var responses []map[string]interface{}
+	for _, record := range records {
+		responses = append(responses, map[string]interface{}{
+			"id":                   record.Id,
+			"requester_id":         record.RequesterId,
+			"requester_name":       record.RequesterName,
+			"requester_shift_id":   record.RequesterShiftId,
+			"requester_shift_name": record.RequesterShiftName,
+			"requester_shift_date": record.RequesterShiftDate.Format(cf.FormatDateDisplay),
+			"target_id":            record.TargetId,
+			"target_name":          record.TargetName,
+			"target_shift_id":      record.TargetShiftId,
+			"target_shift_name":    record.TargetShiftName,
+			"target_shift_date":    record.TargetShiftDate.Format(cf.FormatDateDisplay),
+			"status":               utils.GetNameStatusRegistRequests(record.Status),
+			"reason":               record.Reason,
+			"created_at":           record.CreatedAt.Format(cf.FormatDateDisplay),
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get shift swap requests successful",
+		Data: map[string]interface{}{
+			"pagination":    pagination,
+			"swap_requests": responses,
+		},
+	})
+}
+
+func (ctr *Controller) sendNotification(registrationTokens []string, userProfile m.User, body string, link string) {
+	if len(registrationTokens) == 0 {
+		return
+	}
+
+	body = userProfile.UserProfile.FirstName + " " + userProfile.UserProfile.LastName + " " + body
+	for _, token := range registrationTokens {
+		err := ctr.SendMessageToSpecificUser(token, "Micro Erp New Notification", body, link)
+		if err != nil && err.Error() == "http error status: 400; reason: request contains an invalid argument; "+
+			"code: invalid-argument; details: The registration token is not a valid FCM registration token" {
+			_ = ctr.FcmTokenRepo.DeleteFcmToken(token)
+		}
+	}
+}
+
+func isValidShiftTimes(startTime string, endTime string, breakStart string, breakEnd string) bool {
+	if _, err := time.Parse(cf.FormatShiftTime, startTime); err != nil {
+		return false
+	}
+
+	if _, err := time.Parse(cf.FormatShiftTime, endTime); err != nil {
+		return false
+	}
+
+	if breakStart == "" && breakEnd == "" {
+		return true
+	}
+
+	if _, err := time.Parse(cf.FormatShiftTime, breakStart); err != nil {
+		return false
+	}
+
+	_, err := time.Parse(cf.FormatShiftTime, breakEnd)
+
+	return err == nil
+}
@@ -0,0 +1,432 @@
+package shift
+
+import (
+	"strconv"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/go-pg/pg/v9/orm"
+	"github.com/labstack/echo/v4"
+	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+	rp "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/repository"
+	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
+	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils/calendar"
+)
+
+type PgShiftRepository struct {
+	cm.AppRepository
+}
+
+func NewPgShiftRepository(logger echo.Logger) (repo *PgShiftRepository) {
+	repo = &PgShiftRepository{}
+	repo.Init(logger)
+	return
+}
+
+func (repo *PgShiftRepository) InsertShift(organizationId int, params *param.CreateShiftParams) error {
+	shift := m.Shift{
+		OrganizationId: organizationId,
+		Name:           params.Name,
+		StartTime:      params.StartTime,
+		EndTime:        params.EndTime,
+		BreakStart:     params.BreakStart,
+		BreakEnd:       params.BreakEnd,
+	}
+
+	err := repo.DB.Insert(&shift)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgShiftRepository) UpdateShift(params *param.EditShiftParams) error {
+	shift := m.Shift{
+		Name:       params.Name,
+		StartTime:  params.StartTime,
+		EndTime:    params.EndTime,
+		BreakStart: params.BreakStart,
+		BreakEnd:   params.BreakEnd,
+	}
+
+	_, err := repo.DB.Model(&shift).
+		Column("name", "start_time", "end_time", "break_start", "break_end", "updated_at").
+		Where("id = ?", params.Id).
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgShiftRepository) DeleteShift(id int) error {
+	_, err := repo.DB.Model(&m.Shift{}).
+		Where("id = ?", id).
+		Delete()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgShiftRepository) SelectShiftById(id int) (m.Shift, error) {
+	var shift m.Shift
+	err := repo.DB.Model(&shift).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return shift, err
+}
+
+func (repo *PgShiftRepository) SelectShifts(organizationId int) ([]m.Shift, error) {
+	var shifts []m.Shift
+	err := repo.DB.Model(&shifts).
+		Where("organization_id = ?", organizationId).
+		Order("start_time ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return shifts, err
+}
+
+func (repo *PgShiftRepository) CountUserShiftsByShiftId(shiftId int) (int, error) {
+	count, err := repo.DB.Model(&m.UserShift{}).
+		Where("shift_id = ?", shiftId).
+		Where("shift_date >= CURRENT_DATE").
+		Count()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return count, err
+}
+
+func (repo *PgShiftRepository) UpsertUserShifts(
+	organizationId int,
+	createdBy int,
+	shiftId int,
+	usersId []int,
+	shiftDates []string,
+) error {
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var transErr error
+		for _, userId := range usersId {
+			for _, shiftDate := range shiftDates {
+				var userShift m.UserShift
+				transErr = tx.Model(&userShift).
+					Where("organization_id = ?", organizationId).
+					Where("user_id = ?", userId).
+					Where("shift_date = ?::date", shiftDate).
+					First()
+
+				if transErr != nil && transErr.Error() != pg.ErrNoRows.Error() {
+					return transErr
+				}
+
+				if transErr == nil {
+					userShift.ShiftId = shiftId
+					userShift.CreatedBy = createdBy
+					_, transErr = tx.Model(&userShift).
+						Column("shift_id", "created_by", "updated_at").
+						WherePK().
+						Update()
+					if transErr != nil {
+						return transErr
+					}
+					continue
+				}
+
+				userShift = m.UserShift{
+					OrganizationId: organizationId,
+					UserId:         userId,
+					ShiftId:        shiftId,
+					ShiftDate:      calendar.ParseTime(cf.FormatDateDatabase, shiftDate),
+					CreatedBy:      createdBy,
+				}
+
+				transErr = tx.Insert(&userShift)
+				if transErr != nil {
+					return transErr
+				}
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgShiftRepository) DeleteUserShift(id int) error {
+	_, err := repo.DB.Model(&m.UserShift{}).
+		Where("id = ?", id).
+		Delete()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+func (repo *PgShiftRepository) SelectUserShiftById(id int) (m.UserShift, error) {
+	var userShift m.UserShift
+	err := repo.DB.Model(&userShift).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return userShift, err
+}
+
+func (repo *PgShiftRepository) SelectRoster(organizationId int, params *param.GetRosterParams) ([]param.RosterRecord, error) {
+	var records []param.RosterRecord
+	q := repo.rosterQuery(organizationId).
+		Where("usft.shift_date >= to_date(?,'YYYY-MM-DD')", params.DateFrom).
+		Where("usft.shift_date <= to_date(?,'YYYY-MM-DD')", params.DateTo)
+
+	if len(params.UsersId) > 0 {
+		q.Where("usft.user_id IN (?)", pg.In(params.UsersId))
+	}
+
+	err := q.Order("usft.shift_date ASC", "sft.start_time ASC").Select(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+func (repo *PgShiftRepository) SelectUserShiftsByDates(
+	organizationId int,
+	userId int,
+	dateFrom string,
+	dateTo string,
+) ([]param.RosterRecord, error) {
+	var records []param.RosterRecord
+	err := repo.rosterQuery(organizationId).
+		Where("usft.user_id = ?", userId).
+		Where("usft.shift_date >= to_date(?,'YYYY-MM-DD')", dateFrom).
+		Where("usft.shift_date <= to_date(?,'YYYY-MM-DD')", dateTo).
+		Order("usft.shift_date ASC").
+		Select(&records)
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, err
+}
+
+func (repo *PgShiftRepository) rosterQuery(organizationId int) *orm.Query {
+	return repo.DB.Model(&m.UserShift{}).
+		Column("usft.id", "usft.user_id", "usft.shift_id", "usft.shift_date").
+		ColumnExpr("up.first_name || ' ' || up.last_name full_name").
+		ColumnExpr("sft.name AS shift_name").
+		ColumnExpr("sft.start_time, sft.end_time, sft.break_start, sft.break_end").
+		Join("JOIN shifts AS sft ON sft.id = usft.shift_id").
+		Join("JOIN user_profiles AS up ON up.user_id = usft.user_id").
+		Where("usft.organization_id = ?", organizationId)
+}
+
+func (repo *PgShiftRepository) InsertShiftSwapRequest(
+	organizationId int,
+	requesterId int,
+	targetId int,
+	params *param.CreateShiftSwapRequestParams,
+	notificationRepo rp.NotificationRepository,
+	usersIdNotification []int,
+) (string, string, error) {
+	var body string
+	var link string
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var transErr error
+		swapRequest := m.ShiftSwapRequest{
+			OrganizationId:   organizationId,
+			RequesterId:      requesterId,
+			RequesterShiftId: params.RequesterShiftId,
+			TargetId:         targetId,
+			TargetShiftId:    params.TargetShiftId,
+			Status:           cf.PendingRequestStatus,
+			Reason:           params.Reason,
+		}
+
+		transErr = tx.Insert(&swapRequest)
+		if transErr != nil {
+			return transErr
+		}
+
+		notificationParams := new(param.InsertNotificationParam)
+		notificationParams.Content = "has just created a shift swap request"
+		notificationParams.RedirectUrl = "/request/manage-shift-swap?id=" + strconv.Itoa(swapRequest.ID)
+
+		for _, userId := range usersIdNotification {
+			if userId == requesterId {
+				continue
+			}
+			notificationParams.Receiver = userId
+			transErr = notificationRepo.InsertNotificationWithTx(tx, organizationId, requesterId, notificationParams)
+			if transErr != nil {
+				return transErr
+			}
+		}
+
+		body = notificationParams.Content
+		link = notificationParams.RedirectUrl
+
+		return transErr
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return body, link, err
+}
+
+func (repo *PgShiftRepository) SelectShiftSwapRequestById(id int) (m.ShiftSwapRequest, error) {
+	var swapRequest m.ShiftSwapRequest
+	err := repo.DB.Model(&swapRequest).
+		Where("id = ?", id).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return swapRequest, err
+}
+
+func (repo *PgShiftRepository) UpdateStatusShiftSwapRequest(
+	organizationId int,
+	updatedBy int,
+	params *param.UpdateShiftSwapRequestStatusParams,
+	swapRequest m.ShiftSwapRequest,
+	notificationRepo rp.NotificationRepository,
+) (string, string, error) {
+	var body string
+	var link string
+	err := repo.DB.RunInTransaction(func(tx *pg.Tx) error {
+		var transErr error
+		_, transErr = tx.Model(&m.ShiftSwapRequest{Status: params.Status, UpdatedBy: updatedBy}).
+			Column("status", "updated_by", "updated_at").
+			Where("id = ?", params.Id).
+			Update()
+		if transErr != nil {
+			return transErr
+		}
+
+		if params.Status == cf.AcceptRequestStatus {
+			_, transErr = tx.Model(&m.UserShift{UserId: swapRequest.TargetId}).
+				Column("user_id", "updated_at").
+				Where("id = ?", swapRequest.RequesterShiftId).
+				Update()
+			if transErr != nil {
+				return transErr
+			}
+
+			_, transErr = tx.Model(&m.UserShift{UserId: swapRequest.RequesterId}).
+				Column("user_id", "updated_at").
+				Where("id = ?", swapRequest.TargetShiftId).
+				Update()
+			if transErr != nil {
+				return transErr
+			}
+		}
+
+		notificationParams := new(param.InsertNotificationParam)
+		if params.Status == cf.AcceptRequestStatus {
+			notificationParams.Content = "has just accepted a shift swap request"
+		} else {
+			notificationParams.Content = "has just denied a shift swap request"
+		}
+		notificationParams.RedirectUrl = "/request/manage-shift-swap?id=" + strconv.Itoa(swapRequest.ID)
+		notificationParams.Receiver = swapRequest.RequesterId
+
+		if swapRequest.RequesterId != updatedBy {
+			transErr = notificationRepo.InsertNotificationWithTx(tx, organizationId, updatedBy, notificationParams)
+			if transErr != nil {
+				return transErr
+			}
+		}
+
+		body = notificationParams.Content
+		link = notificationParams.RedirectUrl
+
+		return transErr
+	})
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return body, link, err
+}
+
+func (repo *PgShiftRepository) SelectShiftSwapRequests(
+	organizationId int,
+	userId int,
+	params *param.GetShiftSwapRequestsParams,
+) ([]param.ShiftSwapRequestRecord, int, error) {
+	var records []param.ShiftSwapRequestRecord
+	q := repo.DB.Model(&m.ShiftSwapRequest{})
+	q.Column("ssr.id", "ssr.requester_id", "ssr.requester_shift_id", "ssr.target_id",
+		"ssr.target_shift_id", "ssr.status", "ssr.reason", "ssr.created_at").
+		ColumnExpr("rup.first_name || ' ' || rup.last_name requester_name").
+		ColumnExpr("tup.first_name || ' ' || tup.last_name target_name").
+		ColumnExpr("rsft.name AS requester_shift_name").
+		ColumnExpr("rusft.shift_date AS requester_shift_date").
+		ColumnExpr("tsft.name AS target_shift_name").
+		ColumnExpr("tusft.shift_date AS target_shift_date").
+		Join("JOIN user_profiles AS rup ON rup.user_id = ssr.requester_id").
+		Join("JOIN user_profiles AS tup ON tup.user_id = ssr.target_id").
+		Join("JOIN user_shifts AS rusft ON rusft.id = ssr.requester_shift_id").
+		Join("JOIN shifts AS rsft ON rsft.id = rusft.shift_id").
+		Join("JOIN user_shifts AS tusft ON tusft.id = ssr.target_shift_id").
+		Join("JOIN shifts AS tsft ON tsft.id = tusft.shift_id").
+		Where("ssr.organization_id = ?", organizationId)
+
+	if userId != 0 {
+		q.WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			q = q.WhereOr("ssr.requester_id = ?", userId).
+				WhereOr("ssr.target_id = ?", userId)
+			return q, nil
+		})
+	}
+
+	if params.Status != 0 {
+		q.Where("ssr.status = ?", params.Status)
+	}
+
+	q.OrderExpr("ssr.created_at DESC").
+		Offset((params.CurrentPage - 1) * params.RowPerPage).
+		Limit(params.RowPerPage)
+
+	totalRow, err := q.SelectAndCount(&records)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return records, totalRow, err
+}
@@ -2,6 +2,7 @@ package timekeeping
 
 import (
 	"github.com/360EntSecGroup-Skylar/excelize/v2"
+	valid "github.com/asaskevich/govalidator"
 	"net/http"
 	"strconv"
 	"time"
@@ -14,22 +15,41 @@ import (
 	rp "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/repository"
 	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
+	afb "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/appfirebase"
+	cr "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/cron"
 	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils"
+	"gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/platform/utils/calendar"
 )
 
 // TkController : Timekeeping Controller
 type TkController struct {
 	cm.BaseController
+	cr.EtrCron
+	afb.FirebaseCloudMessage
 
 	TimekeepingRepo rp.TimekeepingRepository
 	UserRepo        rp.UserRepository
 	BranchRepo      rp.BranchRepository
+	ShiftRepo       rp.ShiftRepository
+	FcmTokenRepo    rp.FcmTokenRepository
 }
 
 // NewTimekeepingController : Init Timekeeping Controller
-func NewTimekeepingController(logger echo.Logger, timekeepingRepo rp.TimekeepingRepository, userRepo rp.UserRepository, branchRepo rp.BranchRepository) (ctr *TkController) {
-	ctr = &TkController{cm.BaseController{}, timekeepingRepo, userRepo, branchRepo}
+func NewTimekeepingController(
+	logger echo.Logger,
+	timekeepingRepo rp.TimekeepingRepository,
+	userRepo rp.UserRepository,
+	branchRepo rp.BranchRepository,
+	shiftRepo rp.ShiftRepository,
+	fcmTokenRepo rp.FcmTokenRepository,
+) (ctr *TkController) {
+	ctr = &TkController{
+		cm.BaseController{}, cr.EtrCron{}, afb.FirebaseCloudMessage{},
+		timekeepingRepo, userRepo, branchRepo, shiftRepo, fcmTokenRepo,
+	}
 	ctr.Init(logger)
+	ctr.InitCron(cf.DefaultTimekeepingTimezone)
+	ctr.InitFcm()
 	return
 }
 
@@ -39,7 +59,23 @@ func (ctr *TkController) CheckIn(c echo.Context) error {
 
 	orgID, userID := userProfile.OrganizationID, userProfile.UserProfile.UserID
 
-	timekeeping, err := ctr.TimekeepingRepo.GetLastTimekeepingToday(orgID, userID)
+	location, err := ctr.getOrgLocation(orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userShift, err := ctr.getCurrentUserShift(orgID, userID, location)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	timekeeping, err := ctr.getLastTimekeeping(orgID, userID, userShift, location)
 
 	if err != nil && err.Error() != pg.ErrNoRows.Error() {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
@@ -55,7 +91,7 @@ func (ctr *TkController) CheckIn(c echo.Context) error {
 		})
 	}
 
-	err = ctr.TimekeepingRepo.InsertCheckInTime(orgID, userID)
+	err = ctr.TimekeepingRepo.InsertCheckInTime(orgID, userID, userShift.Id)
 
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
@@ -77,7 +113,23 @@ func (ctr *TkController) CheckOut(c echo.Context) error {
 
 	orgID, userID := userProfile.OrganizationID, userProfile.UserProfile.UserID
 
-	timekeeping, err := ctr.TimekeepingRepo.GetLastTimekeepingToday(orgID, userID)
+	location, err := ctr.getOrgLocation(orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userShift, err := ctr.getCurrentUserShift(orgID, userID, location)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	timekeeping, err := ctr.getLastTimekeeping(orgID, userID, userShift, location)
 
 	if err != nil && err.Error() != pg.ErrNoRows.Error() {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
@@ -109,7 +161,23 @@ func (ctr *TkController) GetTimekeeping(c echo.Context) error {
 
 	orgID, userID := userProfile.OrganizationID, userProfile.ID
 
-	timekeeping, err := ctr.TimekeepingRepo.GetLastTimekeepingToday(orgID, userID)
+	location, err := ctr.getOrgLocation(orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	userShift, err := ctr.getCurrentUserShift(orgID, userID, location)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	timekeeping, err := ctr.getLastTimekeeping(orgID, userID, userShift, location)
 
 	if err != nil && err.Error() != pg.ErrNoRows.Error() {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
@@ -118,23 +186,34 @@ func (ctr *TkController) GetTimekeeping(c echo.Context) error {
 		})
 	}
 
-	utcLocation, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
 	currentTime := utils.TimeNowUTC()
 
 	checkinTimeVN := ""
 	if !timekeeping.CheckInTime.IsZero() {
-		checkinTimeVN = timekeeping.CheckInTime.In(utcLocation).Format(cf.FormatDisplayTimekeeping)
+		checkinTimeVN = timekeeping.CheckInTime.In(location).Format(cf.FormatDisplayTimekeeping)
 	}
 
 	checkoutTimeVN := ""
 	if !timekeeping.CheckOutTime.IsZero() {
-		checkoutTimeVN = timekeeping.CheckOutTime.In(utcLocation).Format(cf.FormatDisplayTimekeeping)
+		checkoutTimeVN = timekeeping.CheckOutTime.In(location).Format(cf.FormatDisplayTimekeeping)
 	}
 
 	dataResponse := map[string]interface{}{
 		"check_in_time":  checkinTimeVN,
 		"check_out_time": checkoutTimeVN,
-		"time_server":    currentTime.In(utcLocation).Format(cf.FormatDisplayTimekeeping2),
+		"time_server":    currentTime.In(location).Format(cf.FormatDisplayTimekeeping2),
+	}
+
+	if userShift.Id != 0 {
+		dataResponse["shift"] = map[string]interface{}{
+			"id":          userShift.Id,
+			"shift_name":  userShift.ShiftName,
+			"shift_date":  userShift.ShiftDate.Format(cf.FormatDateDisplay),
+			"start_time":  userShift.StartTime,
+			"end_time":    userShift.EndTime,
+			"break_start": userShift.BreakStart,
+			"break_end":   userShift.BreakEnd,
+		}
 	}
 
 	return c.JSON(http.StatusOK, cf.JsonResponse{
@@ -160,7 +239,15 @@ func (ctr *TkController) GetAllTimekeepingUser(c echo.Context) error {
 	userProfile := c.Get("user_profile").(m.User)
 	orgID, userID := userProfile.OrganizationID, userProfile.UserProfile.UserID
 
-	timekeepings, totalRow, err := ctr.TimekeepingRepo.GetAllTimekeepingUser(orgID, userID, seachTimekeepingUserParams)
+	location, err := ctr.getOrgLocation(orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	timekeepings, totalRow, err := ctr.TimekeepingRepo.GetAllTimekeepingUser(orgID, userID, location.String(), seachTimekeepingUserParams)
 
 	if err != nil && err.Error() != pg.ErrNoRows.Error() {
 		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
@@ -181,23 +268,23 @@ func (ctr *TkController) GetAllTimekeepingUser(c echo.Context) error {
 		"row_per_page": seachTimekeepingUserParams.RowPerPage,
 	}
 
-	utcLocation, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
 	timekeepingsResponse := []map[string]interface{}{}
 
 	for i := 0; i < len(timekeepings); i++ {
 		checkinTimeVN := ""
 		if !timekeepings[i].CheckInTime.IsZero() {
-			checkinTimeVN = timekeepings[i].CheckInTime.In(utcLocation).Format(cf.FormatDisplayTimekeeping)
+			checkinTimeVN = timekeepings[i].CheckInTime.In(location).Format(cf.FormatDisplayTimekeeping)
 		}
 
 		checkoutTimeVN := ""
 		if !timekeepings[i].CheckOutTime.IsZero() {
-			checkoutTimeVN = timekeepings[i].CheckOutTime.In(utcLocation).Format(cf.FormatDisplayTimekeeping)
+			checkoutTimeVN = timekeepings[i].CheckOutTime.In(location).Format(cf.FormatDisplayTimekeeping)
 		}
 
 		timekeeping := map[string]interface{}{
 			"check_in_time":  checkinTimeVN,
 			"check_out_time": checkoutTimeVN,
+			"missing_punch":  isMissingPunch(timekeepings[i].CheckInTime, timekeepings[i].CheckOutTime, timekeepings[i].AutoClosed, location),
 		}
 
 		timekeepingsResponse = append(timekeepingsResponse, timekeeping)
@@ -229,7 +316,15 @@ func (ctr *TkController) GetAllTimekeeping(c echo.Context) error {
 	userProfile := c.Get("user_profile").(m.User)
 	orgID := userProfile.OrganizationID
 
-	timekeepings, totalRow, err := ctr.TimekeepingRepo.GetAllTimekeeping(orgID, seachAllTimekeepingParams)
+	location, err := ctr.getOrgLocation(orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	timekeepings, totalRow, err := ctr.TimekeepingRepo.GetAllTimekeeping(orgID, location.String(), seachAllTimekeepingParams)
 
 	if err != nil {
 		if err.Error() == pg.ErrNoRows.Error() {
@@ -258,7 +353,6 @@ func (ctr *TkController) GetAllTimekeeping(c echo.Context) error {
 	}
 
 	timekeepingsResponse := []map[string]interface{}{}
-	utcLocation, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
 
 	branchRecords, err := ctr.BranchRepo.SelectBranches(userProfile.OrganizationID)
 	if err != nil {
@@ -283,12 +377,12 @@ func (ctr *TkController) GetAllTimekeeping(c echo.Context) error {
 	for i := 0; i < len(timekeepings); i++ {
 		checkinTimeVN := ""
 		if !timekeepings[i].CheckInTime.IsZero() {
-			checkinTimeVN = timekeepings[i].CheckInTime.In(utcLocation).Format(cf.FormatDisplayTimekeeping)
+			checkinTimeVN = timekeepings[i].CheckInTime.In(location).Format(cf.FormatDisplayTimekeeping)
 		}
 
 		checkoutTimeVN := ""
 		if !timekeepings[i].CheckOutTime.IsZero() {
-			checkoutTimeVN = timekeepings[i].CheckOutTime.In(utcLocation).Format(cf.FormatDisplayTimekeeping)
+			checkoutTimeVN = timekeepings[i].CheckOutTime.In(location).Format(cf.FormatDisplayTimekeeping)
 		}
 
 		timekeeping := map[string]interface{}{
@@ -297,6 +391,7 @@ func (ctr *TkController) GetAllTimekeeping(c echo.Context) error {
 			"branch":         branches[timekeepings[i].Branch],
 			"check_in_time":  checkinTimeVN,
 			"check_out_time": checkoutTimeVN,
+			"missing_punch":  isMissingPunch(timekeepings[i].CheckInTime, timekeepings[i].CheckOutTime, timekeepings[i].AutoClosed, location),
 		}
 
 		timekeepingsResponse = append(timekeepingsResponse, timekeeping)
@@ -344,7 +439,15 @@ func (ctr *TkController) ExportExcel(c echo.Context) error {
 	}
 
 	userProfile := c.Get("user_profile").(m.User)
-	records, err := ctr.TimekeepingRepo.SelectTimekeepingsByDate(userProfile.OrganizationID, exportExcelParams)
+	location, err := ctr.getOrgLocation(userProfile.OrganizationID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	records, err := ctr.TimekeepingRepo.SelectTimekeepingsByDate(userProfile.OrganizationID, location.String(), exportExcelParams)
 	if err != nil {
 		if err.Error() == pg.ErrNoRows.Error() {
 			return c.JSON(http.StatusOK, cf.JsonResponse{
@@ -361,7 +464,7 @@ func (ctr *TkController) ExportExcel(c echo.Context) error {
 
 	f := excelize.NewFile()
 	_ = f.SetColWidth("Sheet1", "A", "A", 30)
-	_ = f.SetColWidth("Sheet1", "B", "D", 15)
+	_ = f.SetColWidth("Sheet1", "B", "E", 15)
 
 	titleStyle, _ := f.NewStyle(`{
 		"font":{"bold":true, "size":16},
@@ -370,16 +473,16 @@ func (ctr *TkController) ExportExcel(c echo.Context) error {
 
 	contentStyle, _ := f.NewStyle(`{"alignment":{"horizontal":"center", "vertical":"center"}}`)
 
-	categories := map[string]string{"A1": "Full name", "B1": "Date", "C1": "Check in", "D1": "Check out"}
+	categories := map[string]string{"A1": "Full name", "B1": "Date", "C1": "Check in", "D1": "Check out", "E1": "Missing punch"}
 	for k, v := range categories {
 		_ = f.SetCellValue("Sheet1", k, v)
 	}
-	_ = f.SetCellStyle("Sheet1", "A1", "D1", titleStyle)
+	_ = f.SetCellStyle("Sheet1", "A1", "E1", titleStyle)
 	_ = f.SetColStyle("Sheet1", "B", contentStyle)
 	_ = f.SetColStyle("Sheet1", "C", contentStyle)
 	_ = f.SetColStyle("Sheet1", "D", contentStyle)
+	_ = f.SetColStyle("Sheet1", "E", contentStyle)
 
-	location, _ := time.LoadLocation("Asia/Ho_Chi_Minh")
 	for i, record := range records {
 		firstTime, lastTime := "", ""
 		if !record.CheckInTime.IsZero() {
@@ -390,12 +493,18 @@ func (ctr *TkController) ExportExcel(c echo.Context) error {
 			lastTime = utils.ConvertTwoChar(record.CheckOutTime.In(location).Hour()) + ":" + utils.ConvertTwoChar(record.CheckOutTime.In(location).Minute())
 		}
 
+		missingPunch := ""
+		if isMissingPunch(record.CheckInTime, record.CheckOutTime, record.AutoClosed, location) {
+			missingPunch = "Yes"
+		}
+
 		pos := i + 2
 		values := map[string]interface{}{
 			"A" + strconv.Itoa(pos): record.FullName,
-			"B" + strconv.Itoa(pos): record.Date.In(location).Format(cf.FormatDateDisplay),
+			"B" + strconv.Itoa(pos): record.Date.Format(cf.FormatDateDisplay),
 			"C" + strconv.Itoa(pos): firstTime,
 			"D" + strconv.Itoa(pos): lastTime,
+			"E" + strconv.Itoa(pos): missingPunch,
 		}
 
 		for k, v := range values {
@@ -406,3 +515,424 @@ func (ctr *TkController) ExportExcel(c echo.Context) error {
 	buf, _ := f.WriteToBuffer()
 	return c.Blob(http.StatusOK, "application/octet-stream", buf.Bytes())
 }
+
+// CreateTimekeepingSetting : Create timekeeping setting of organization
+func (ctr *TkController) CreateTimekeepingSetting(c echo.Context) error {
+	params := new(param.CreateTimekeepingSettingParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil || !isValidTimekeepingSetting(params.Timezone, params.AutoCheckoutTime, params.ReminderBeforeMinute) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	_, err = ctr.TimekeepingRepo.SelectTimekeepingSettingByOrganizationId(userProfile.OrganizationID)
+	if err == nil {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Timekeeping setting already exist",
+		})
+	}
+
+	if err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	err = ctr.TimekeepingRepo.InsertTimekeepingSetting(userProfile.OrganizationID, params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create timekeeping setting successful",
+	})
+}
+
+// EditTimekeepingSetting : Edit timekeeping setting of organization
+func (ctr *TkController) EditTimekeepingSetting(c echo.Context) error {
+	params := new(param.EditTimekeepingSettingParams)
+	if err := c.Bind(params); err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid Params",
+		})
+	}
+
+	_, err := valid.ValidateStruct(params)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	userProfile := c.Get("user_profile").(m.User)
+	setting, err := ctr.TimekeepingRepo.SelectTimekeepingSettingByOrganizationId(userProfile.OrganizationID)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+				Status:  cf.FailResponseCode,
+				Message: "Timekeeping setting does not yet exist",
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if setting.ID != params.Id {
+		return c.JSON(http.StatusMethodNotAllowed, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Timekeeping setting does not yet exist",
+		})
+	}
+
+	if params.Timezone == "" {
+		params.Timezone = setting.Timezone
+	}
+	if params.AutoCheckoutTime == "" {
+		params.AutoCheckoutTime = setting.AutoCheckoutTime
+	}
+
+	if !isValidTimekeepingSetting(params.Timezone, params.AutoCheckoutTime, params.ReminderBeforeMinute) {
+		return c.JSON(http.StatusBadRequest, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "Invalid field value",
+		})
+	}
+
+	err = ctr.TimekeepingRepo.UpdateTimekeepingSetting(params)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	setting.Timezone = params.Timezone
+	setting.AutoCheckoutTime = params.AutoCheckoutTime
+	setting.ReminderBeforeMinute = params.ReminderBeforeMinute
+	err = ctr.registerAutoCheckoutCron(userProfile.OrganizationID, setting)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Edit timekeeping setting successful",
+	})
+}
+
+// GetTimekeepingSetting : Get timekeeping setting of organization
+func (ctr *TkController) GetTimekeepingSetting(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	setting, err := ctr.TimekeepingRepo.SelectTimekeepingSettingByOrganizationId(userProfile.OrganizationID)
+	if err != nil {
+		if err.Error() == pg.ErrNoRows.Error() {
+			return c.JSON(http.StatusOK, cf.JsonResponse{
+				Status:  cf.SuccessResponseCode,
+				Message: "Timekeeping setting does not yet exist",
+				Data: map[string]interface{}{
+					"id":                     0,
+					"timezone":               cf.DefaultTimekeepingTimezone,
+					"auto_checkout_time":     cf.DefaultAutoCheckoutTime,
+					"reminder_before_minute": 0,
+				},
+			})
+		}
+
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Get timekeeping setting successful",
+		Data: map[string]interface{}{
+			"id":                     setting.ID,
+			"timezone":               setting.Timezone,
+			"auto_checkout_time":     setting.AutoCheckoutTime,
+			"reminder_before_minute": setting.ReminderBeforeMinute,
+		},
+	})
+}
+
+// CronAutoCheckout : Cron close open timekeepings at auto checkout time and remind employee before that,
+// both run in timezone of organization
+func (ctr *TkController) CronAutoCheckout(c echo.Context) error {
+	userProfile := c.Get("user_profile").(m.User)
+	orgID := userProfile.OrganizationID
+
+	setting, err := ctr.TimekeepingRepo.SelectTimekeepingSettingByOrganizationId(orgID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	if setting.Timezone == "" {
+		setting.Timezone = cf.DefaultTimekeepingTimezone
+	}
+	if setting.AutoCheckoutTime == "" {
+		setting.AutoCheckoutTime = cf.DefaultAutoCheckoutTime
+	}
+
+	err = ctr.registerAutoCheckoutCron(orgID, setting)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, cf.JsonResponse{
+			Status:  cf.FailResponseCode,
+			Message: "System Error",
+		})
+	}
+
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Create cron auto checkout successfully.",
+	})
+}
+
+// registerAutoCheckoutCron : Replace auto checkout and reminder crons of organization by ones of setting
+func (ctr *TkController) registerAutoCheckoutCron(orgID int, setting m.TimekeepingSetting) error {
+	location, err := time.LoadLocation(setting.Timezone)
+	if err != nil {
+		return err
+	}
+
+	checkoutCronName := cf.AutoCheckoutCronName + " " + strconv.Itoa(orgID)
+	reminderCronName := cf.AutoCheckoutReminderCronName + " " + strconv.Itoa(orgID)
+	for _, entry := range ctr.GetEntries() {
+		if entry.Name == checkoutCronName || entry.Name == reminderCronName {
+			ctr.RemoveCron(entry.ID)
+		}
+	}
+
+	cutoff, _ := time.Parse(cf.FormatShiftTime, setting.AutoCheckoutTime)
+	_, err = ctr.AddFuncCron(cronSpecInTimezone(setting.Timezone, cutoff), checkoutCronName, func() {
+		ctr.autoCheckout(orgID, location)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if setting.ReminderBeforeMinute > 0 {
+		reminderBefore := time.Duration(setting.ReminderBeforeMinute) * time.Minute
+		remindAt := cutoff.Add(-reminderBefore)
+		_, err = ctr.AddFuncCron(cronSpecInTimezone(setting.Timezone, remindAt), reminderCronName, func() {
+			ctr.remindCheckout(orgID, location, setting.AutoCheckoutTime, reminderBefore)
+		})
+	}
+
+	return err
+}
+
+// CronAutoCheckoutStart : Start auto checkout cron
+func (ctr *TkController) CronAutoCheckoutStart(c echo.Context) error {
+	ctr.StartCron()
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Start cron auto checkout successfully.",
+	})
+}
+
+// CronAutoCheckoutStop : Stop auto checkout cron
+func (ctr *TkController) CronAutoCheckoutStop(c echo.Context) error {
+	ctr.StopCron()
+	return c.JSON(http.StatusOK, cf.JsonResponse{
+		Status:  cf.SuccessResponseCode,
+		Message: "Stop cron auto checkout successfully.",
+	})
+}
+
+// autoCheckout : Close open timekeepings and mark them auto closed
+func (ctr *TkController) autoCheckout(orgID int, location *time.Location) {
+	now := utils.TimeNowUTC()
+	timekeepings, err := ctr.TimekeepingRepo.SelectOpenTimekeepings(orgID, now)
+	if err != nil {
+		return
+	}
+
+	for _, timekeeping := range timekeepings {
+		checkOutTime, ok := ctr.autoCheckoutTime(timekeeping, now, location)
+		if !ok {
+			continue
+		}
+
+		if err := ctr.TimekeepingRepo.UpdateAutoCheckOutTime(timekeeping.ID, checkOutTime); err != nil {
+			ctr.Logger.Error(err)
+		}
+	}
+}
+
+// remindCheckout : Push notification to employees who will be auto checked out
+func (ctr *TkController) remindCheckout(orgID int, location *time.Location, autoCheckoutTime string, reminderBefore time.Duration) {
+	cutoffTime := utils.TimeNowUTC().Add(reminderBefore)
+	timekeepings, err := ctr.TimekeepingRepo.SelectOpenTimekeepings(orgID, cutoffTime)
+	if err != nil {
+		return
+	}
+
+	body := "You have not checked out yet. Your timekeeping will be closed automatically at " + autoCheckoutTime
+	var remindedUserIds []int
+	for _, timekeeping := range timekeepings {
+		if utils.FindIntInSlice(remindedUserIds, timekeeping.UserID) {
+			continue
+		}
+
+		if _, ok := ctr.autoCheckoutTime(timekeeping, cutoffTime, location); !ok {
+			continue
+		}
+		remindedUserIds = append(remindedUserIds, timekeeping.UserID)
+
+		registrationTokens, err := ctr.FcmTokenRepo.SelectFcmTokenByUserId(timekeeping.UserID)
+		if err != nil {
+			continue
+		}
+
+		for _, token := range registrationTokens {
+			err := ctr.SendMessageToSpecificUser(token, "Micro Erp New Notification", body, "/timekeeping")
+			if err != nil && err.Error() == "http error status: 400; reason: request contains an invalid argument; "+
+				"code: invalid-argument; details: The registration token is not a valid FCM registration token" {
+				_ = ctr.FcmTokenRepo.DeleteFcmToken(token)
+			}
+		}
+	}
+}
+
+// autoCheckoutTime : Get check out time to close timekeeping at cutoff.
+// Timekeeping in shift is closed at end of shift and is skipped while shift is still running.
+func (ctr *TkController) autoCheckoutTime(timekeeping m.UserTimekeeping, cutoff time.Time, location *time.Location) (time.Time, bool) {
+	if timekeeping.UserShiftId == 0 {
+		return cutoff, true
+	}
+
+	userShift, err := ctr.ShiftRepo.SelectUserShiftById(timekeeping.UserShiftId)
+	if err != nil {
+		return cutoff, err.Error() == pg.ErrNoRows.Error()
+	}
+
+	shift, err := ctr.ShiftRepo.SelectShiftById(userShift.ShiftId)
+	if err != nil {
+		return cutoff, err.Error() == pg.ErrNoRows.Error()
+	}
+
+	_, end := calendar.ShiftPeriod(userShift.ShiftDate, shift.StartTime, shift.EndTime, location)
+	margin := time.Duration(cf.ShiftCheckInMarginMinute) * time.Minute
+	if cutoff.Before(end.Add(margin)) {
+		return cutoff, false
+	}
+
+	return end, true
+}
+
+// getCurrentUserShift : Get shift assigned to user at current time.
+// Yesterday's shift is included so overnight shift can be checked out after midnight.
+func (ctr *TkController) getCurrentUserShift(orgID int, userID int, location *time.Location) (param.RosterRecord, error) {
+	now := utils.TimeNowUTC().In(location)
+	margin := time.Duration(cf.ShiftCheckInMarginMinute) * time.Minute
+
+	records, err := ctr.ShiftRepo.SelectUserShiftsByDates(
+		orgID,
+		userID,
+		now.AddDate(0, 0, -1).Format(cf.FormatDateDatabase),
+		now.Format(cf.FormatDateDatabase),
+	)
+	if err != nil {
+		return param.RosterRecord{}, err
+	}
+
+	for i := len(records) - 1; i >= 0; i-- {
+		start, end := calendar.ShiftPeriod(records[i].ShiftDate, records[i].StartTime, records[i].EndTime, location)
+		if !now.Before(start.Add(-margin)) && !now.After(end.Add(margin)) {
+			return records[i], nil
+		}
+	}
+
+	return param.RosterRecord{}, nil
+}
+
+// getLastTimekeeping : Get last timekeeping inside shift, or today when user has no shift
+func (ctr *TkController) getLastTimekeeping(
+	orgID int,
+	userID int,
+	userShift param.RosterRecord,
+	location *time.Location,
+) (m.UserTimekeeping, error) {
+	if userShift.Id == 0 {
+		return ctr.TimekeepingRepo.GetLastTimekeepingToday(orgID, userID, location.String())
+	}
+
+	start, _ := calendar.ShiftPeriod(userShift.ShiftDate, userShift.StartTime, userShift.EndTime, location)
+	margin := time.Duration(cf.ShiftCheckInMarginMinute) * time.Minute
+
+	return ctr.TimekeepingRepo.GetLastTimekeepingFrom(orgID, userID, start.Add(-margin))
+}
+
+// getOrgLocation : Get timezone of organization, default is Asia/Ho_Chi_Minh when not yet setting
+func (ctr *TkController) getOrgLocation(orgID int) (*time.Location, error) {
+	timezone := cf.DefaultTimekeepingTimezone
+	setting, err := ctr.TimekeepingRepo.SelectTimekeepingSettingByOrganizationId(orgID)
+	if err != nil && err.Error() != pg.ErrNoRows.Error() {
+		return nil, err
+	}
+
+	if err == nil && setting.Timezone != "" {
+		timezone = setting.Timezone
+	}
+
+	return time.LoadLocation(timezone)
+}
+
+// isMissingPunch : Record is closed by auto checkout or is still open from previous days
+func isMissingPunch(checkInTime time.Time, checkOutTime time.Time, autoClosed bool, location *time.Location) bool {
+	if autoClosed {
+		return true
+	}
+
+	if checkInTime.IsZero() || !checkOutTime.IsZero() {
+		return false
+	}
+
+	return checkInTime.In(location).Format(cf.FormatDateDatabase) != utils.TimeNowUTC().In(location).Format(cf.FormatDateDatabase)
+}
+
+func isValidTimekeepingSetting(timezone string, autoCheckoutTime string, reminderBeforeMinute int) bool {
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return false
+	}
+
+	if _, err := time.Parse(cf.FormatShiftTime, autoCheckoutTime); err != nil {
+		return false
+	}
+
+	return reminderBeforeMinute >= 0 && reminderBeforeMinute < 24*60
+}
+
+// cronSpecInTimezone : Daily cron spec at clock time in timezone
+func cronSpecInTimezone(timezone string, clock time.Time) string {
+	return "CRON_TZ=" + timezone + " " + strconv.Itoa(clock.Minute()) + " " + strconv.Itoa(clock.Hour()) + " * * *"
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"time"
 
+	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
 	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
 	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
@@ -23,11 +24,12 @@ func NewPgTimekeepingRepository(logger echo.Logger) (repo *PgTimekeepingReposito
 }
 
 // InsertCheckInTime : Insert check in time record to database
-func (repo *PgTimekeepingRepository) InsertCheckInTime(orgID int, userID int) error {
+func (repo *PgTimekeepingRepository) InsertCheckInTime(orgID int, userID int, userShiftID int) error {
 	checkInTime := m.UserTimekeeping{
 		OrganizationID: orgID,
 		UserID:         userID,
 		CheckInTime:    utils.TimeNowUTC(),
+		UserShiftId:    userShiftID,
 	}
 
 	err := repo.DB.Insert(&checkInTime)
@@ -77,9 +79,10 @@ func (repo *PgTimekeepingRepository) GetTimekeepingWithMaxCheckInTime(orgID int,
 }
 
 // GetLastTimekeepingToday : Get Timekeeping with max check_in_time & check_out_time
-// Param                     : OrgID, userID
+// Param                     : OrgID, userID, timezone of organization
 // Return                    : param.AllUserTimekeepingResponse, error
-func (repo *PgTimekeepingRepository) GetLastTimekeepingToday(orgID int, userID int) (m.UserTimekeeping, error) {
+func (repo *PgTimekeepingRepository) GetLastTimekeepingToday(orgID int, userID int, timezone string) (m.UserTimekeeping, error) {
+	location, _ := time.LoadLocation(timezone)
 	timekeeping := m.UserTimekeeping{}
 
 	err := repo.DB.Model(&timekeeping).
@@ -90,7 +93,8 @@ func (repo *PgTimekeepingRepository) GetLastTimekeepingToday(orgID int, userID i
 		Column("check_out_time").
 		Where("organization_id = ?", orgID).
 		Where("user_id = ?", userID).
-		Where("date(check_in_time) = date(?)", utils.TimeNowUTC()).
+		Where("date(check_in_time at time zone 'utc' at time zone ?) = to_date(?,'YYYY-MM-DD')",
+			timezone, utils.TimeNowUTC().In(location).Format(cf.FormatDateDatabase)).
 		Order("check_in_time DESC").
 		Limit(1).
 		Select()
@@ -102,10 +106,38 @@ func (repo *PgTimekeepingRepository) GetLastTimekeepingToday(orgID int, userID i
 	return timekeeping, err
 }
 
-// GetAllTimekeepingUser : Get all Timekeeping of user
+// GetLastTimekeepingFrom : Get Timekeeping with max check_in_time since from time
+// Param                     : OrgID, userID, from
+// Return                    : m.UserTimekeeping, error
+func (repo *PgTimekeepingRepository) GetLastTimekeepingFrom(orgID int, userID int, from time.Time) (m.UserTimekeeping, error) {
+	timekeeping := m.UserTimekeeping{}
+
+	err := repo.DB.Model(&timekeeping).
+		Column("id").
+		Column("organization_id").
+		Column("user_id").
+		Column("check_in_time").
+		Column("check_out_time").
+		Column("user_shift_id").
+		Where("organization_id = ?", orgID).
+		Where("user_id = ?", userID).
+		Where("check_in_time >= ?", from.UTC()).
+		Order("check_in_time DESC").
+		Limit(1).
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return timekeeping, err
+}
+
+// GetAllTimekeepingUser : Get all Timekeeping of user, dates are local dates of timezone of organization
 func (repo *PgTimekeepingRepository) GetAllTimekeepingUser(
 	orgID int,
 	userID int,
+	timezone string,
 	seachTimekeepingUserParams *param.SeachTimekeepingUserParams,
 ) ([]param.UserTimekeepingResponse, int, error) {
 	timekeepings := []m.UserTimekeeping{}
@@ -117,15 +149,18 @@ func (repo *PgTimekeepingRepository) GetAllTimekeepingUser(
 	queryObj.Column("organization_id")
 	queryObj.Column("check_in_time")
 	queryObj.Column("check_out_time")
+	queryObj.Column("auto_closed")
 	queryObj.Where("organization_id = ?", orgID)
 	queryObj.Where("user_id = ?", userID)
 
 	if seachTimekeepingUserParams.DateFrom != "" {
-		queryObj.Where("date(check_in_time) >= to_date(?,'YYYY-MM-DD')", seachTimekeepingUserParams.DateFrom)
+		queryObj.Where("date(check_in_time at time zone 'utc' at time zone ?) >= to_date(?,'YYYY-MM-DD')",
+			timezone, seachTimekeepingUserParams.DateFrom)
 	}
 
 	if seachTimekeepingUserParams.DateTo != "" {
-		queryObj.Where("date(check_in_time) <= to_date(?,'YYYY-MM-DD')", seachTimekeepingUserParams.DateTo)
+		queryObj.Where("date(check_in_time at time zone 'utc' at time zone ?) <= to_date(?,'YYYY-MM-DD')",
+			timezone, seachTimekeepingUserParams.DateTo)
 	}
 
 	queryObj.Offset((seachTimekeepingUserParams.CurrentPage - 1) * seachTimekeepingUserParams.RowPerPage)
@@ -141,9 +176,10 @@ func (repo *PgTimekeepingRepository) GetAllTimekeepingUser(
 	return records, totalRow, err
 }
 
-// GetAllTimekeeping : Get all Timekeeping
+// GetAllTimekeeping : Get all Timekeeping grouped by user and local date of timezone of organization
 func (repo *PgTimekeepingRepository) GetAllTimekeeping(
 	orgID int,
+	timezone string,
 	seachAllTimekeepingParams *param.SeachAllTimekeepingParams,
 ) ([]param.UserTimekeepingResponse, int, error) {
 	timekeepings := []m.UserTimekeeping{}
@@ -154,9 +190,10 @@ func (repo *PgTimekeepingRepository) GetAllTimekeeping(
 	queryObj.Column("utk.user_id")
 	queryObj.ColumnExpr("min(usr.first_name || ' ' || usr.last_name) as user_name")
 	queryObj.ColumnExpr("uss.email as email")
-	queryObj.ColumnExpr("date(utk.check_in_time) as date_timekeeping")
+	queryObj.ColumnExpr("date(utk.check_in_time at time zone 'utc' at time zone ?) as date_timekeeping", timezone)
 	queryObj.ColumnExpr("min(utk.check_in_time) as check_in_time")
 	queryObj.ColumnExpr("max(utk.check_out_time) as check_out_time")
+	queryObj.ColumnExpr("bool_or(utk.auto_closed) as auto_closed")
 	queryObj.ColumnExpr("min(usr.branch) as branch")
 	queryObj.Join("join user_profiles as usr on usr.user_id = utk.user_id")
 	queryObj.Join("join users as uss on uss.id = utk.user_id")
@@ -174,11 +211,13 @@ func (repo *PgTimekeepingRepository) GetAllTimekeeping(
 	}
 
 	if seachAllTimekeepingParams.FromDate != "" {
-		queryObj.Where("date(utk.check_in_time) >= to_date(?,'YYYY-MM-DD')", seachAllTimekeepingParams.FromDate)
+		queryObj.Where("date(utk.check_in_time at time zone 'utc' at time zone ?) >= to_date(?,'YYYY-MM-DD')",
+			timezone, seachAllTimekeepingParams.FromDate)
 	}
 
 	if seachAllTimekeepingParams.ToDate != "" {
-		queryObj.Where("date(utk.check_in_time) <= to_date(?,'YYYY-MM-DD')", seachAllTimekeepingParams.ToDate)
+		queryObj.Where("date(utk.check_in_time at time zone 'utc' at time zone ?) <= to_date(?,'YYYY-MM-DD')",
+			timezone, seachAllTimekeepingParams.ToDate)
 	}
 
 	queryObj.Group("utk.organization_id", "utk.user_id", "date_timekeeping", "uss.email")
@@ -195,22 +234,28 @@ func (repo *PgTimekeepingRepository) GetAllTimekeeping(
 	return records, totalRow, err
 }
 
-func (repo *PgTimekeepingRepository) SelectTimekeepingsByDate(organizationId int, exportExcelParams *param.TkExportExcelParams) ([]param.TkExportExcelRecords, error) {
+// SelectTimekeepingsByDate : Get first check in and last check out of users by local date of timezone of organization
+func (repo *PgTimekeepingRepository) SelectTimekeepingsByDate(
+	organizationId int,
+	timezone string,
+	exportExcelParams *param.TkExportExcelParams,
+) ([]param.TkExportExcelRecords, error) {
 	var records []param.TkExportExcelRecords
 	q := repo.DB.Model(&m.UserTimekeeping{})
 	q.ColumnExpr("up.first_name || ' ' || up.last_name full_name").
-		ColumnExpr("DATE(utk.check_in_time) AS date").
+		ColumnExpr("DATE(utk.check_in_time AT TIME ZONE 'utc' AT TIME ZONE ?) AS date", timezone).
 		ColumnExpr("MIN(utk.check_in_time) AS check_in_time").
 		ColumnExpr("MAX(utk.check_out_time) AS check_out_time").
+		ColumnExpr("BOOL_OR(utk.auto_closed) AS auto_closed").
 		Join("JOIN user_profiles AS up ON up.user_id = utk.user_id").
 		Where("utk.organization_id = ?", organizationId)
 
 	if exportExcelParams.DateFrom != "" {
-		q.Where("DATE(utk.check_in_time) >= to_date(?,'YYYY-MM-DD')", exportExcelParams.DateFrom)
+		q.Where("DATE(utk.check_in_time AT TIME ZONE 'utc' AT TIME ZONE ?) >= to_date(?,'YYYY-MM-DD')", timezone, exportExcelParams.DateFrom)
 	}
 
 	if exportExcelParams.DateTo != "" {
-		q.Where("DATE(utk.check_out_time) <= to_date(?,'YYYY-MM-DD')", exportExcelParams.DateTo)
+		q.Where("DATE(utk.check_out_time AT TIME ZONE 'utc' AT TIME ZONE ?) <= to_date(?,'YYYY-MM-DD')", timezone, exportExcelParams.DateTo)
 	}
 
 	q.GroupExpr("full_name, date")
@@ -224,3 +269,87 @@ func (repo *PgTimekeepingRepository) SelectTimekeepingsByDate(organizationId int
 
 	return records, err
 }
+
+// SelectOpenTimekeepings : Get timekeepings not yet checked out and checked in before time
+func (repo *PgTimekeepingRepository) SelectOpenTimekeepings(orgID int, before time.Time) ([]m.UserTimekeeping, error) {
+	var timekeepings []m.UserTimekeeping
+	err := repo.DB.Model(&timekeepings).
+		Column("id", "user_id", "organization_id", "check_in_time", "user_shift_id").
+		Where("organization_id = ?", orgID).
+		Where("check_out_time IS NULL").
+		Where("check_in_time < ?", before.UTC()).
+		Order("check_in_time ASC").
+		Select()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return timekeepings, err
+}
+
+// UpdateAutoCheckOutTime : Close timekeeping by auto checkout job
+func (repo *PgTimekeepingRepository) UpdateAutoCheckOutTime(ID int, checkOutTime time.Time) error {
+	_, err := repo.DB.Model(&m.UserTimekeeping{CheckOutTime: checkOutTime.UTC(), AutoClosed: true}).
+		Column("check_out_time", "auto_closed", "updated_at").
+		Where("id = ?", ID).
+		Where("check_out_time IS NULL").
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// InsertTimekeepingSetting : Insert timekeeping setting of organization
+func (repo *PgTimekeepingRepository) InsertTimekeepingSetting(orgID int, params *param.CreateTimekeepingSettingParams) error {
+	setting := m.TimekeepingSetting{
+		OrganizationId:       orgID,
+		Timezone:             params.Timezone,
+		AutoCheckoutTime:     params.AutoCheckoutTime,
+		ReminderBeforeMinute: params.ReminderBeforeMinute,
+	}
+
+	err := repo.DB.Insert(&setting)
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// UpdateTimekeepingSetting : Update timekeeping setting of organization
+func (repo *PgTimekeepingRepository) UpdateTimekeepingSetting(params *param.EditTimekeepingSettingParams) error {
+	setting := m.TimekeepingSetting{
+		Timezone:             params.Timezone,
+		AutoCheckoutTime:     params.AutoCheckoutTime,
+		ReminderBeforeMinute: params.ReminderBeforeMinute,
+	}
+
+	_, err := repo.DB.Model(&setting).
+		Column("timezone", "auto_checkout_time", "reminder_before_minute", "updated_at").
+		Where("id = ?", params.Id).
+		Update()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return err
+}
+
+// SelectTimekeepingSettingByOrganizationId : Get timekeeping setting of organization
+func (repo *PgTimekeepingRepository) SelectTimekeepingSettingByOrganizationId(orgID int) (m.TimekeepingSetting, error) {
+	var setting m.TimekeepingSetting
+	err := repo.DB.Model(&setting).
+		Where("organization_id = ?", orgID).
+		First()
+
+	if err != nil {
+		repo.Logger.Error(err)
+	}
+
+	return setting, err
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/go-pg/pg/v9"
 	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
@@ -45,4 +47,77 @@ type LeaveRepository interface {
 	SelectStartLeaveCurrentYear(orgID int) ([]param.StartDateLeaveRecords, error)
 	UpdateExpireLeaveDate(id int, hour float64, expireLeaveDate string) error
 	CountExpireDate(orgID int) ([]param.ExpireLeaveBonusRecords, error)
+	InsertLeavePolicy(organizationId int, params *param.CreateLeavePolicyParams) error
+	UpdateLeavePolicy(params *param.EditLeavePolicyParams) error
+	DeleteLeavePolicy(id int) error
+	SelectLeavePolicyById(id int) (m.LeavePolicy, error)
+	SelectLeavePolicies(organizationId int) ([]m.LeavePolicy, error)
+	SelectLeavePolicyTiers(policyId int) ([]m.LeavePolicyTier, error)
+	SelectLatestContractTypes(organizationId int) ([]param.UserContractTypeRecord, error)
+	SelectLeaveAccrualRuns(organizationId int, periods []string) ([]m.LeaveAccrualRun, error)
+	InsertLeaveAccrualGrants(organizationId int, createdBy int, grants []param.LeaveAccrualGrant) (int, error)
+	SelectLeaveLedgerEntries(organizationId int, params *param.LeaveLedgerParams) ([]param.LeaveLedgerRecords, int, error)
+	SelectLeaveLedgerBalance(organizationId int, userId int, date string) (float64, error)
+	SelectLeaveLedgerBalances(organizationId int, userId int) ([]param.LeaveBalanceRecord, error)
+	SelectLeaveHistoryBalances(organizationId int, userId int) ([]param.LeaveBalanceRecord, error)
+	InsertLeaveLedgerEntries(leaveLedgerEntries []m.LeaveLedgerEntry) error
+	InsertLeaveRequestChange(leaveRequestChange *m.LeaveRequestChange) error
+	SelectLeaveRequestChangeById(id int) (m.LeaveRequestChange, error)
+	CountPendingLeaveRequestChanges(leaveRequestId int) (int, error)
+	SelectLeaveRequestChanges(organizationId int, params *param.GetLeaveChangesParams) ([]param.LeaveChangeRecords, int, error)
+	UpdateLeaveRequestChangeStatus(id int, status int, approvedBy int) error
+	AmendLeaveRequest(leaveRequestId int, datetimeLeaveFrom time.Time, datetimeLeaveTo time.Time, hour float64, updatedBy int) error
+	SaveProjectStaffingRule(organizationId int, params *param.SaveProjectStaffingRuleParams) error
+	DeleteProjectStaffingRule(organizationId int, projectId int) error
+	SelectProjectStaffingRules(organizationId int) ([]param.ProjectStaffingRuleRecords, error)
+	SelectProjectStaffingRulesByProjectIds(projectIds []int) ([]m.ProjectStaffingRule, error)
+	SelectTeamLeaveRequests(organizationId int, userIds []int, branch int, dateFrom time.Time, dateTo time.Time) ([]param.TeamLeaveRecords, error)
+	SelectDueKanbanTasks(projectIds []int, userId int, dateFrom time.Time, dateTo time.Time) ([]param.DueTaskRecords, error)
+	SelectLeaveBonusBuckets(organizationId int, userId int) ([]param.LeaveBonusBucketRecords, error)
+	SaveStatutoryLeaveRule(organizationId int, params *param.SaveStatutoryLeaveRuleParams) error
+	DeleteStatutoryLeaveRule(organizationId int, leaveBonusTypeId int) error
+	SelectStatutoryLeaveRules(organizationId int) ([]m.StatutoryLeaveRule, error)
+	SelectStatutoryLeaveRule(organizationId int, leaveBonusTypeId int) (m.StatutoryLeaveRule, error)
+	SumStatutoryLeaveDays(organizationId int, userId int, leaveBonusTypeId int, eventDate string) (float64, error)
+	InsertStatutoryLeave(statutoryLeave *m.StatutoryLeave, leaveRequest *m.UserLeaveRequest, documents []m.StatutoryLeaveDocument) error
+	SelectStatutoryLeaves(organizationId int, params *param.GetStatutoryLeavesParams) ([]param.StatutoryLeaveRecords, int, error)
+	SelectStatutoryLeaveDocuments(statutoryLeaveIds []int) ([]m.StatutoryLeaveDocument, error)
+	SelectStatutoryLeaveDocumentById(id int) (param.StatutoryLeaveDocumentRecord, error)
+	SelectLeaveDelegates(organizationId int, userIds []int, date string) ([]param.LeaveDelegateRecords, error)
+	SelectLeaveDelegatesByDelegate(organizationId int, delegateUserId int, date string) ([]param.LeaveDelegateRecords, error)
+	SelectUnnotifiedLeaveDelegates(organizationId int, date string) ([]param.LeaveDelegateRecords, error)
+	UpdateLeaveDelegatesNotified(leaveRequestIds []int) error
+	SelectBulkLeaveUserIds(organizationId int, branchId int, datetimeLeaveFrom time.Time, datetimeLeaveTo time.Time) ([]int, error)
+	InsertLeaveBatch(
+		leaveBatch *m.LeaveBatch,
+		userIds []int,
+		notificationRepo NotificationRepository,
+		notificationParams param.InsertNotificationParam,
+	) error
+	UpdateLeaveBatchCalendarEvent(id int, calendarEventId string) error
+	SelectLeaveBatchById(id int) (m.LeaveBatch, error)
+	UndoLeaveBatch(leaveBatchId int, undoneBy int) ([]int, error)
+	SelectLeaveBatches(organizationId int, params *param.GetBulkLeavesParams) ([]param.LeaveBatchRecords, int, error)
+	SelectLeaveSettlementByYear(organizationId int, year int) (m.LeaveSettlement, error)
+	SelectLeaveSettlementItems(leaveSettlementId int) ([]param.LeaveSettlementRecords, error)
+	InsertLeaveSettlement(
+		leaveSettlement *m.LeaveSettlement,
+		leaveSettlementItems []m.LeaveSettlementItem,
+		expireBonusLeaveDate string,
+	) error
+	SelectSeveranceLeaveSettlement(organizationId int, userId int) (m.SeveranceLeaveSettlement, error)
+	SelectLeaveRequestsAfter(userId int, date time.Time) ([]m.UserLeaveRequest, error)
+	InsertSeveranceLeaveSettlement(
+		severanceLeaveSettlement *m.SeveranceLeaveSettlement,
+		leaveRequests []m.UserLeaveRequest,
+		amendedLeaveRequests []m.UserLeaveRequest,
+		payoutHours map[int]float64,
+	) error
+	InsertLeaveNotifications(
+		organizationId int,
+		sender int,
+		notificationRepo NotificationRepository,
+		receivers []int,
+		notificationParams param.InsertNotificationParam,
+	) error
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
 )
@@ -12,6 +14,7 @@ type OvertimeRepository interface {
 		notificationRepo NotificationRepository,
 		userRepo UserRepository,
 		uniqueUsersId []int,
+		approvalChainId int,
 	) (string, string, error)
 	UpdateStatusOvertimeRequest(
 		updateRequestStatusParams *param.UpdateRequestStatusParams,
@@ -21,13 +24,17 @@ type OvertimeRepository interface {
 		organizationId int,
 		userId int,
 		hour float64,
+		breakdowns []m.OvertimeHourBreakdown,
+		step int,
+		onBehalfOf int,
 	) (string, string, error)
 	SelectOvertimeRequests(
 		organizationId int,
 		getOvertimeRequestsParams *param.GetOvertimeRequestsParams,
 	) ([]param.OvertimeRequestsRecords, int, error)
 	SelectOvertimeRequestById(id int) (m.UserOvertimeRequest, error)
-	UpdateOvertimeRequest(params *param.UpdateOvertimeRequestParams) error
+	SelectOvertimeRequestOfOrganization(organizationId int, id int) (m.UserOvertimeRequest, error)
+	UpdateOvertimeRequest(params *param.UpdateOvertimeRequestParams, approvalChainId int) error
 	InsertOvertimeWeight(
 		organizationId int,
 		params *param.CreateOvertimeWeightParams,
@@ -37,4 +44,32 @@ type OvertimeRepository interface {
 	UpdateOvertimeWeight(params *param.EditOvertimeWeightParams) error
 	CountOvertimeWeightByField(field string, value int) (int, error)
 	SelectOvertimeWeightByOrganizationId(organizationId int) (m.OvertimeWeight, error)
+	ApproveOvertimeRequestStep(
+		organizationId int,
+		userId int,
+		onBehalfOf int,
+		userOvertimeRequest m.UserOvertimeRequest,
+		note string,
+		notificationRepo NotificationRepository,
+		nextApprovers []int,
+	) (string, string, error)
+	SelectOvertimeRequestHistories(overtimeRequestId int) ([]param.OvertimeRequestHistoryRecord, error)
+	InsertApprovalChain(organizationId int, params *param.CreateApprovalChainParams) error
+	UpdateApprovalChain(params *param.EditApprovalChainParams) error
+	DeleteApprovalChain(id int) error
+	SelectApprovalChainById(id int) (m.OvertimeApprovalChain, error)
+	SelectApprovalChains(organizationId int) ([]m.OvertimeApprovalChain, error)
+	SelectApprovalChainOfProject(organizationId int, projectId int) (m.OvertimeApprovalChain, error)
+	CountApprovalChainByProject(organizationId int, projectId int) (int, error)
+	SelectApprovalSteps(chainId int) ([]m.OvertimeApprovalStep, error)
+	InsertApprovalDelegation(organizationId int, params *param.CreateApprovalDelegationParams) error
+	DeleteApprovalDelegation(id int) error
+	SelectApprovalDelegationById(id int) (m.OvertimeApprovalDelegation, error)
+	SelectApprovalDelegations(organizationId int, userId int) ([]param.ApprovalDelegationRecord, error)
+	SelectActiveDelegations(organizationId int, approverIds []int, date string) ([]m.OvertimeApprovalDelegation, error)
+	InsertOvertimeCap(organizationId int, params *param.CreateOvertimeCapParams) error
+	UpdateOvertimeCap(params *param.EditOvertimeCapParams) error
+	CountOvertimeCapByField(field string, value int) (int, error)
+	SelectOvertimeCapByOrganizationId(organizationId int) (m.OvertimeCap, error)
+	SelectCountedOvertimeRequests(userId int, from time.Time, to time.Time, exceptId int) ([]m.UserOvertimeRequest, error)
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
 )
@@ -59,4 +61,59 @@ type RecruitmentRepository interface {
 		createdBy int,
 		params *param.CreateLogCvStatus,
 		notificationRepo NotificationRepository,)(string, string, error)
+	SelectRecruitmentStages(organizationId int, recruitmentId int) ([]m.RecruitmentStage, error)
+	SaveRecruitmentStages(organizationId int, recruitmentId int, stages []m.RecruitmentStage) error
+	CountCvsInStages(stageIds []int) (int, error)
+	SelectStageDurations(recruitmentId int) ([]param.StageDurationRecords, error)
+	InsertInterview(interview *m.Interview) error
+	SelectInterview(id int) (m.Interview, error)
+	UpdateInterview(interview *m.Interview, columns ...string) error
+	SelectInterviews(organizationId int, params *param.GetInterviewsParams) ([]param.InterviewRecords, error)
+	SelectInterviewConflicts(
+		organizationId int,
+		interviewId int,
+		cvId int,
+		userIds []int,
+		startTime time.Time,
+		endTime time.Time,
+	) ([]param.InterviewConflictRecords, error)
+	SelectScorecardTemplate(recruitmentId int) (m.ScorecardTemplate, error)
+	SaveScorecardTemplate(template *m.ScorecardTemplate) error
+	SelectScorecards(cvId int) ([]m.Scorecard, error)
+	SaveScorecard(scorecard *m.Scorecard) error
+	LinkCvCandidate(organizationId int, cvId int, contact param.CandidateContact) (int, error)
+	SelectCandidate(organizationId int, id int) (m.Candidate, error)
+	SelectCandidates(organizationId int) ([]m.Candidate, error)
+	MergeCandidates(candidate *m.Candidate, mergedCandidates []m.Candidate) error
+	SelectCandidateApplications(candidateId int) ([]param.CandidateApplicationRecords, error)
+	SelectActiveJobs(organizationId int, params *param.GetCareerJobsParams) ([]param.CareerJobRecords, int, error)
+	SelectActiveJob(organizationId int, id int) (param.CareerJobRecords, error)
+	InsertCareerCv(organizationId int, cv *m.Cv, consent *m.CandidateConsent) error
+	SelectAnalyticsCvs(organizationId int, params *param.RecruitmentAnalyticsParams) ([]param.AnalyticsCvRecords, error)
+	SelectLogCvStatesByCvIds(cvIds []int) ([]m.LogCvState, error)
+	SelectOrganizationStages(organizationId int) ([]m.RecruitmentStage, error)
+	SelectAnalyticsInterviews(organizationId int, params *param.RecruitmentAnalyticsParams) ([]m.Interview, error)
+	SelectOfferTemplate(organizationId int) (m.OfferTemplate, error)
+	SaveOfferTemplate(template *m.OfferTemplate) error
+	InsertOffer(offer *m.Offer) error
+	SelectOffer(id int) (m.Offer, error)
+	SelectOfferByToken(token string) (m.Offer, error)
+	SelectOffersByCvId(cvId int) ([]m.Offer, error)
+	UpdateOffer(offer *m.Offer, columns ...string) error
+	AcceptOffer(
+		offer *m.Offer,
+		user *m.User,
+		userProfile *m.UserProfile,
+		contract *m.Contract,
+		onboarding *m.Onboarding,
+	) error
+	SelectOnboardings(organizationId int, params *param.GetOnboardingsParams) ([]m.Onboarding, error)
+	SelectOnboarding(id int) (m.Onboarding, error)
+	UpdateOnboardingChecklist(onboarding *m.Onboarding) error
+	SelectCandidateEmailTemplates(organizationId int) ([]m.CandidateEmailTemplate, error)
+	SelectCandidateEmailTemplate(organizationId int, emailType int, languageId int) (m.CandidateEmailTemplate, error)
+	SaveCandidateEmailTemplate(template *m.CandidateEmailTemplate) error
+	InsertCvCommunication(communication *m.CvCommunication) error
+	SelectCvCommunications(cvId int) ([]m.CvCommunication, error)
+	SelectRejectionCvs(recruitmentId int) ([]m.Cv, error)
 }
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
+	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
+)
+
+type ShiftRepository interface {
+	InsertShift(organizationId int, params *param.CreateShiftParams) error
+	UpdateShift(params *param.EditShiftParams) error
+	DeleteShift(id int) error
+	SelectShiftById(id int) (m.Shift, error)
+	SelectShifts(organizationId int) ([]m.Shift, error)
+	CountUserShiftsByShiftId(shiftId int) (int, error)
+	UpsertUserShifts(organizationId int, createdBy int, shiftId int, usersId []int, shiftDates []string) error
+	DeleteUserShift(id int) error
+	SelectUserShiftById(id int) (m.UserShift, error)
+	SelectRoster(organizationId int, params *param.GetRosterParams) ([]param.RosterRecord, error)
+	SelectUserShiftsByDates(organizationId int, userId int, dateFrom string, dateTo string) ([]param.RosterRecord, error)
+	InsertShiftSwapRequest(
+		organizationId int,
+		requesterId int,
+		targetId int,
+		params *param.CreateShiftSwapRequestParams,
+		notificationRepo NotificationRepository,
+		usersIdNotification []int,
+	) (string, string, error)
+	SelectShiftSwapRequestById(id int) (m.ShiftSwapRequest, error)
+	UpdateStatusShiftSwapRequest(
+		organizationId int,
+		updatedBy int,
+		params *param.UpdateShiftSwapRequestStatusParams,
+		swapRequest m.ShiftSwapRequest,
+		notificationRepo NotificationRepository,
+	) (string, string, error)
+	SelectShiftSwapRequests(
+		organizationId int,
+		userId int,
+		params *param.GetShiftSwapRequestsParams,
+	) ([]param.ShiftSwapRequestRecord, int, error)
+}
@@ -1,17 +1,25 @@
 package repository
 
 import (
+	"time"
+
 	param "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/interfaces/requestparams"
 	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
 )
 
 // TimekeepingRepository : Timekeeping Repository
 type TimekeepingRepository interface {
-	InsertCheckInTime(orgID int, userID int) error
+	InsertCheckInTime(orgID int, userID int, userShiftID int) error
 	InsertCheckOutTime(ID int) error
 	GetTimekeepingWithMaxCheckInTime(orgID int, userID int) (m.UserTimekeeping, error)
-	GetLastTimekeepingToday(orgID int, userID int) (m.UserTimekeeping, error)
-	GetAllTimekeepingUser(orgID int, userID int, seachTimekeepingUserParams *param.SeachTimekeepingUserParams) ([]param.UserTimekeepingResponse, int, error)
-	GetAllTimekeeping(orgID int, seachAllTimekeepingParams *param.SeachAllTimekeepingParams) ([]param.UserTimekeepingResponse, int, error)
-	SelectTimekeepingsByDate(organizationId int, exportCSVParams *param.TkExportExcelParams) ([]param.TkExportExcelRecords, error)
+	GetLastTimekeepingToday(orgID int, userID int, timezone string) (m.UserTimekeeping, error)
+	GetLastTimekeepingFrom(orgID int, userID int, from time.Time) (m.UserTimekeeping, error)
+	GetAllTimekeepingUser(orgID int, userID int, timezone string, seachTimekeepingUserParams *param.SeachTimekeepingUserParams) ([]param.UserTimekeepingResponse, int, error)
+	GetAllTimekeeping(orgID int, timezone string, seachAllTimekeepingParams *param.SeachAllTimekeepingParams) ([]param.UserTimekeepingResponse, int, error)
+	SelectTimekeepingsByDate(organizationId int, timezone string, exportCSVParams *param.TkExportExcelParams) ([]param.TkExportExcelRecords, error)
+	SelectOpenTimekeepings(orgID int, before time.Time) ([]m.UserTimekeeping, error)
+	UpdateAutoCheckOutTime(ID int, checkOutTime time.Time) error
+	InsertTimekeepingSetting(orgID int, params *param.CreateTimekeepingSettingParams) error
+	UpdateTimekeepingSetting(params *param.EditTimekeepingSettingParams) error
+	SelectTimekeepingSettingByOrganizationId(orgID int) (m.TimekeepingSetting, error)
 }
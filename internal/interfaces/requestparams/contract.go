@@ -46,6 +46,7 @@ type ContractByUserRecord struct {
 	InsuranceSalary   string `json:"insurance_salary"`
 	TotalSalary       string `json:"total_salary"`
 	CurrencyUnit      string `json:"currency_unit"`
+	IsDraft           bool   `json:"is_draft"`
 }
 
 type CreateContractParams struct {
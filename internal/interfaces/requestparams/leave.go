@@ -26,6 +26,8 @@ type LeaveRequest struct {
 	Hour                 float64 `json:"hour"`
 	SubtractDayOffTypeID int     `json:"subtract_day_off_type_id"`
 	ExtraTime            float64 `json:"extra_time"`
+	DelegateUserID       int     `json:"delegate_user_id"`
+	HandoverNote         string  `json:"handover_note"`
 }
 
 // CreateLeaveBonusParams : Param for user leave bonus
@@ -45,6 +47,7 @@ type LeaveBonus struct {
 	Hour                 float64 `json:"hour" valid:"required"`
 	ExpireBonusLeaveDate string  `json:"expire_bonus_leave_date"`
 	HourRemaining        float64 `json:"hour_remaining"`
+	OvertimeRequestId    int     `json:"-"`
 }
 
 // LeaveStatusParams : Param get day used, day remaining of user
@@ -194,3 +197,370 @@ type UpdateHourRemainingLeaveParam struct {
 	UserId    int     `json:"user_id" valid:"required"`
 	HourLeave float64 `json:"hour_leave" valid:"required"`
 }
+
+type LeavePolicyTierParams struct {
+	FromMonth int     `json:"from_month"`
+	Hour      float64 `json:"hour"`
+}
+
+type CreateLeavePolicyParams struct {
+	Name             string                  `json:"name" valid:"required"`
+	LeaveBonusTypeId int                     `json:"leave_bonus_type_id"`
+	AccrualHour      float64                 `json:"accrual_hour" valid:"required"`
+	AccrualFrequency int                     `json:"accrual_frequency" valid:"required,range(1|3)"`
+	ProbationMonth   int                     `json:"probation_month"`
+	CarryOverHour    *float64                `json:"carry_over_hour"`
+	ExpiryMonth      int                     `json:"expiry_month" valid:"range(0|12)"`
+	ContractTypeIds  []int                   `json:"contract_type_ids"`
+	Tiers            []LeavePolicyTierParams `json:"tiers"`
+}
+
+type EditLeavePolicyParams struct {
+	Id               int                     `json:"id" valid:"required"`
+	Name             string                  `json:"name" valid:"required"`
+	LeaveBonusTypeId int                     `json:"leave_bonus_type_id"`
+	AccrualHour      float64                 `json:"accrual_hour" valid:"required"`
+	AccrualFrequency int                     `json:"accrual_frequency" valid:"required,range(1|3)"`
+	ProbationMonth   int                     `json:"probation_month"`
+	CarryOverHour    *float64                `json:"carry_over_hour"`
+	ExpiryMonth      int                     `json:"expiry_month" valid:"range(0|12)"`
+	ContractTypeIds  []int                   `json:"contract_type_ids"`
+	Tiers            []LeavePolicyTierParams `json:"tiers"`
+}
+
+type RemoveLeavePolicyParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type RunLeaveAccrualParams struct {
+	Date   string `json:"date"`
+	DryRun bool   `json:"dry_run"`
+}
+
+// LeaveAccrualGrant : Leave bonus granted by policy for a user in a period
+type LeaveAccrualGrant struct {
+	PolicyId             int     `json:"policy_id"`
+	PolicyName           string  `json:"policy_name"`
+	UserId               int     `json:"user_id"`
+	FullName             string  `json:"full_name"`
+	Period               string  `json:"period"`
+	LeaveBonusTypeId     int     `json:"leave_bonus_type_id"`
+	YearBelong           int     `json:"year_belong"`
+	Reason               string  `json:"reason"`
+	Hour                 float64 `json:"hour"`
+	ExpireBonusLeaveDate string  `json:"expire_bonus_leave_date"`
+}
+
+type UserContractTypeRecord struct {
+	UserId         int `json:"user_id"`
+	ContractTypeId int `json:"contract_type_id"`
+}
+
+type LeaveLedgerParams struct {
+	UserId      int    `json:"user_id"`
+	EntryType   int    `json:"entry_type"`
+	DateFrom    string `json:"date_from"`
+	DateTo      string `json:"date_to"`
+	CurrentPage int    `json:"current_page" valid:"required"`
+	RowPerPage  int    `json:"row_per_page" valid:"required"`
+}
+
+type LeaveLedgerRecords struct {
+	Id               int       `json:"id"`
+	UserId           int       `json:"user_id"`
+	FullName         string    `json:"full_name"`
+	EntryType        int       `json:"entry_type"`
+	LeaveBonusTypeId int       `json:"leave_bonus_type_id"`
+	YearBelong       int       `json:"year_belong"`
+	Hour             float64   `json:"hour"`
+	EffectiveDate    time.Time `json:"effective_date"`
+	SourceType       int       `json:"source_type"`
+	SourceId         int       `json:"source_id"`
+	Note             string    `json:"note"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type LeaveBalanceParams struct {
+	UserId int    `json:"user_id" valid:"required"`
+	Date   string `json:"date"`
+}
+
+type RecomputeLeaveBalancesParams struct {
+	UserId int  `json:"user_id"`
+	Apply  bool `json:"apply"`
+}
+
+// LeaveBalanceRecord : Balance of user summed from ledger or from source tables
+type LeaveBalanceRecord struct {
+	UserId     int     `json:"user_id"`
+	YearBelong int     `json:"year_belong"`
+	Hour       float64 `json:"hour"`
+}
+
+type CancelLeaveRequestParams struct {
+	LeaveId int    `json:"leave_id" valid:"required"`
+	Reason  string `json:"reason" valid:"required"`
+}
+
+type AmendLeaveRequestParams struct {
+	LeaveId           int     `json:"leave_id" valid:"required"`
+	DatetimeLeaveFrom string  `json:"datetime_leave_from" valid:"required"`
+	DatetimeLeaveTo   string  `json:"datetime_leave_to" valid:"required"`
+	ExtraTime         float64 `json:"extra_time"`
+	Reason            string  `json:"reason" valid:"required"`
+}
+
+type UpdateLeaveChangeStatusParams struct {
+	Id     int `json:"id" valid:"required"`
+	Status int `json:"status" valid:"required,range(2|3)"`
+}
+
+type GetLeaveChangesParams struct {
+	Status      int `json:"status"`
+	UserId      int `json:"user_id"`
+	CurrentPage int `json:"current_page" valid:"required"`
+	RowPerPage  int `json:"row_per_page" valid:"required"`
+}
+
+type LeaveChangeRecords struct {
+	Id                int       `json:"id"`
+	LeaveRequestId    int       `json:"leave_request_id"`
+	UserId            int       `json:"user_id"`
+	FullName          string    `json:"full_name"`
+	ChangeType        int       `json:"change_type"`
+	LeaveFrom         time.Time `json:"leave_from"`
+	LeaveTo           time.Time `json:"leave_to"`
+	LeaveHour         float64   `json:"leave_hour"`
+	DatetimeLeaveFrom time.Time `json:"datetime_leave_from"`
+	DatetimeLeaveTo   time.Time `json:"datetime_leave_to"`
+	Hour              float64   `json:"hour"`
+	Reason            string    `json:"reason"`
+	Status            int       `json:"status"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type SaveProjectStaffingRuleParams struct {
+	ProjectId      int `json:"project_id" valid:"required"`
+	MinStaff       int `json:"min_staff" valid:"required"`
+	ConflictAction int `json:"conflict_action" valid:"required,range(1|2)"`
+}
+
+type RemoveProjectStaffingRuleParams struct {
+	ProjectId int `json:"project_id" valid:"required"`
+}
+
+type ProjectStaffingRuleRecords struct {
+	ProjectId      int    `json:"project_id"`
+	ProjectName    string `json:"project_name"`
+	MinStaff       int    `json:"min_staff"`
+	ConflictAction int    `json:"conflict_action"`
+}
+
+type TeamLeaveCalendarParams struct {
+	ProjectId int    `json:"project_id"`
+	Branch    int    `json:"branch"`
+	DateFrom  string `json:"date_from" valid:"required"`
+	DateTo    string `json:"date_to" valid:"required"`
+}
+
+type TeamLeaveRecords struct {
+	Id                 int       `json:"id"`
+	UserId             int       `json:"user_id"`
+	FullName           string    `json:"full_name"`
+	LeaveRequestTypeId int       `json:"leave_request_type_id"`
+	DatetimeLeaveFrom  time.Time `json:"datetime_leave_from"`
+	DatetimeLeaveTo    time.Time `json:"datetime_leave_to"`
+	Hour               float64   `json:"hour"`
+}
+
+type CheckLeaveConflictsParams struct {
+	UserId             int    `json:"user_id" valid:"required"`
+	LeaveRequestTypeId int    `json:"leave_request_type_id" valid:"required"`
+	DatetimeLeaveFrom  string `json:"datetime_leave_from" valid:"required"`
+	DatetimeLeaveTo    string `json:"datetime_leave_to" valid:"required"`
+}
+
+type DueTaskRecords struct {
+	Id        int       `json:"id"`
+	Title     string    `json:"title"`
+	DueDate   time.Time `json:"due_date"`
+	ProjectId int       `json:"project_id"`
+}
+
+// LeaveConflict : Day when leave drops project below minimum staffing or task of user is due
+type LeaveConflict struct {
+	Type        int    `json:"type"`
+	TypeName    string `json:"type_name"`
+	ProjectId   int    `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Date        string `json:"date"`
+	Available   int    `json:"available,omitempty"`
+	MinStaff    int    `json:"min_staff,omitempty"`
+	TaskId      int    `json:"task_id,omitempty"`
+	TaskTitle   string `json:"task_title,omitempty"`
+	IsBlocking  bool   `json:"is_blocking"`
+}
+
+type ProposedLeaveParams struct {
+	LeaveRequestTypeId   int     `json:"leave_request_type_id" valid:"required"`
+	DatetimeLeaveFrom    string  `json:"datetime_leave_from" valid:"required"`
+	DatetimeLeaveTo      string  `json:"datetime_leave_to" valid:"required"`
+	SubtractDayOffTypeId int     `json:"subtract_day_off_type_id"`
+	ExtraTime            float64 `json:"extra_time"`
+}
+
+type ForecastLeaveBalanceParams struct {
+	UserId         int                   `json:"user_id"`
+	Months         int                   `json:"months" valid:"range(0|24)"`
+	IncludePending bool                  `json:"include_pending"`
+	Proposed       []ProposedLeaveParams `json:"proposed"`
+}
+
+type LeaveBonusBucketRecords struct {
+	Id                   int     `json:"id"`
+	LeaveBonusTypeId     int     `json:"leave_bonus_type_id"`
+	Hour                 float64 `json:"hour"`
+	HourRemaining        float64 `json:"hour_remaining"`
+	ExpireBonusLeaveDate string  `json:"expire_bonus_leave_date"`
+}
+
+type SaveStatutoryLeaveRuleParams struct {
+	LeaveBonusTypeId   int      `json:"leave_bonus_type_id" valid:"required"`
+	EntitlementDay     float64  `json:"entitlement_day"`
+	IsCalendarDay      bool     `json:"is_calendar_day"`
+	MaxMonth           int      `json:"max_month" valid:"range(0|12)"`
+	RequiredDocuments  []string `json:"required_documents"`
+	CountAgainstAnnual bool     `json:"count_against_annual"`
+}
+
+type RemoveStatutoryLeaveRuleParams struct {
+	LeaveBonusTypeId int `json:"leave_bonus_type_id" valid:"required"`
+}
+
+type StatutoryLeaveDocumentParams struct {
+	DocumentName string `json:"document_name" valid:"required"`
+	FileName     string `json:"file_name" valid:"required"`
+	FileContent  string `json:"file_content" valid:"required"`
+}
+
+type CreateStatutoryLeaveParams struct {
+	UserId            int                            `json:"user_id" valid:"required"`
+	LeaveBonusTypeId  int                            `json:"leave_bonus_type_id" valid:"required"`
+	EventDate         string                         `json:"event_date" valid:"required"`
+	DatetimeLeaveFrom string                         `json:"datetime_leave_from" valid:"required"`
+	DatetimeLeaveTo   string                         `json:"datetime_leave_to" valid:"required"`
+	Reason            string                         `json:"reason" valid:"required"`
+	Documents         []StatutoryLeaveDocumentParams `json:"documents"`
+}
+
+type GetStatutoryLeavesParams struct {
+	UserId           int `json:"user_id"`
+	LeaveBonusTypeId int `json:"leave_bonus_type_id"`
+	Year             int `json:"year"`
+	CurrentPage      int `json:"current_page" valid:"required"`
+	RowPerPage       int `json:"row_per_page" valid:"required"`
+}
+
+type StatutoryLeaveRecords struct {
+	Id                 int       `json:"id"`
+	UserId             int       `json:"user_id"`
+	FullName           string    `json:"full_name"`
+	LeaveBonusTypeId   int       `json:"leave_bonus_type_id"`
+	LeaveRequestId     int       `json:"leave_request_id"`
+	EventDate          time.Time `json:"event_date"`
+	DatetimeLeaveFrom  time.Time `json:"datetime_leave_from"`
+	DatetimeLeaveTo    time.Time `json:"datetime_leave_to"`
+	Day                float64   `json:"day"`
+	Hour               float64   `json:"hour"`
+	CountAgainstAnnual bool      `json:"count_against_annual"`
+	Reason             string    `json:"reason"`
+}
+
+type DownloadStatutoryLeaveDocumentParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type StatutoryLeaveDocumentRecord struct {
+	Id             int    `json:"id"`
+	DocumentName   string `json:"document_name"`
+	FileName       string `json:"file_name"`
+	OrganizationId int    `json:"organization_id"`
+	UserId         int    `json:"user_id"`
+}
+
+type GetLeaveDelegationsParams struct {
+	Date string `json:"date"`
+}
+
+// LeaveDelegateRecords : Delegate who covers user during leave
+type LeaveDelegateRecords struct {
+	LeaveRequestId    int       `json:"leave_request_id"`
+	UserId            int       `json:"user_id"`
+	FullName          string    `json:"full_name"`
+	DelegateUserId    int       `json:"delegate_user_id"`
+	DelegateName      string    `json:"delegate_name"`
+	HandoverNote      string    `json:"handover_note"`
+	DatetimeLeaveFrom time.Time `json:"datetime_leave_from"`
+	DatetimeLeaveTo   time.Time `json:"datetime_leave_to"`
+}
+
+type CreateBulkLeaveParams struct {
+	BranchId             int    `json:"branch_id"`
+	LeaveRequestTypeId   int    `json:"leave_request_type_id" valid:"required,range(1|3)"`
+	DatetimeLeaveFrom    string `json:"datetime_leave_from" valid:"required"`
+	DatetimeLeaveTo      string `json:"datetime_leave_to" valid:"required"`
+	SubtractDayOffTypeId int    `json:"subtract_day_off_type_id" valid:"required"`
+	Reason               string `json:"reason" valid:"required"`
+}
+
+type UndoBulkLeaveParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type GetBulkLeavesParams struct {
+	CurrentPage int `json:"current_page" valid:"required"`
+	RowPerPage  int `json:"row_per_page" valid:"required"`
+}
+
+type LeaveBatchRecords struct {
+	Id                   int       `json:"id"`
+	BranchId             int       `json:"branch_id"`
+	BranchName           string    `json:"branch_name"`
+	LeaveRequestTypeId   int       `json:"leave_request_type_id"`
+	DatetimeLeaveFrom    time.Time `json:"datetime_leave_from"`
+	DatetimeLeaveTo      time.Time `json:"datetime_leave_to"`
+	SubtractDayOffTypeId int       `json:"subtract_day_off_type_id"`
+	Reason               string    `json:"reason"`
+	Hour                 float64   `json:"hour"`
+	UserCount            int       `json:"user_count"`
+	CreatedBy            int       `json:"created_by"`
+	CreatedByName        string    `json:"created_by_name"`
+	UndoneAt             time.Time `json:"undone_at"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+// LeaveSettlementParams : Year to settle with rate and cap of payout, rate and cap are ignored when year was signed off
+type LeaveSettlementParams struct {
+	Year          int      `json:"year" valid:"required"`
+	PayoutRate    float64  `json:"payout_rate"`
+	MaxPayoutHour *float64 `json:"max_payout_hour"`
+}
+
+type LeaveSettlementRecords struct {
+	UserId        int     `json:"user_id"`
+	EmployeeId    string  `json:"employee_id"`
+	FullName      string  `json:"full_name"`
+	UnusedHour    float64 `json:"unused_hour"`
+	CarryOverHour float64 `json:"carry_over_hour"`
+	PayoutHour    float64 `json:"payout_hour"`
+	PayoutAmount  float64 `json:"payout_amount"`
+	ForfeitHour   float64 `json:"forfeit_hour"`
+}
+
+// SettleSeveranceLeaveParams : Date severance of profile is used when it is not given, apply saves settlement
+type SettleSeveranceLeaveParams struct {
+	UserId        int     `json:"user_id" valid:"required"`
+	DateSeverance string  `json:"date_severance"`
+	PayoutRate    float64 `json:"payout_rate"`
+	Apply         bool    `json:"apply"`
+}
@@ -16,6 +16,7 @@ type CreateOvertimeParams struct {
 	SendTo               []string `json:"send_to"`
 	SendCc               []string `json:"send_cc"`
 	UsersIdNotification  []int    `json:"users_id_notification"`
+	ExceedCap            bool     `json:"-"`
 }
 
 type GetOvertimeRequestsParams struct {
@@ -33,6 +34,7 @@ type GetOvertimeRequestsParams struct {
 
 type OvertimeRequestsRecords struct {
 	Id                   int       `json:"id"`
+	UserId               int       `json:"user_id"`
 	EmployeeId           string    `json:"employee_id"`
 	FullName             string    `json:"full_name"`
 	Branch               string    `json:"branch"`
@@ -46,6 +48,8 @@ type OvertimeRequestsRecords struct {
 	MinuteFrom           int       `json:"minute_from"`
 	HourTo               int       `json:"hour_to"`
 	MinuteTo             int       `json:"minute_to"`
+	CurrentStep          int       `json:"current_step"`
+	ExceedCap            bool      `json:"exceed_cap"`
 }
 
 type GetOvertimeRequestParam struct {
@@ -64,17 +68,102 @@ type UpdateOvertimeRequestParams struct {
 	OvertimeType         int      `json:"overtime_type"`
 	SendTo               []string `json:"send_to"`
 	SendCc               []string `json:"send_cc"`
+	ExceedCap            bool     `json:"-"`
 }
 
 type CreateOvertimeWeightParams struct {
-	NormalDayWeight float64 `json:"normal_day_weight" valid:"required"`
-	WeekendWeight   float64 `json:"weekend_weight" valid:"required"`
-	HolidayWeight   float64 `json:"holiday_weight" valid:"required"`
+	NormalDayWeight    float64 `json:"normal_day_weight" valid:"required"`
+	WeekendWeight      float64 `json:"weekend_weight" valid:"required"`
+	HolidayWeight      float64 `json:"holiday_weight" valid:"required"`
+	NightWeight        float64 `json:"night_weight"`
+	WeekendNightWeight float64 `json:"weekend_night_weight"`
+	HolidayNightWeight float64 `json:"holiday_night_weight"`
+	NightStart         string  `json:"night_start"`
+	NightEnd           string  `json:"night_end"`
 }
 
 type EditOvertimeWeightParams struct {
-	Id              int     `json:"id" valid:"required"`
-	NormalDayWeight float64 `json:"normal_day_weight"`
-	WeekendWeight   float64 `json:"weekend_weight"`
-	HolidayWeight   float64 `json:"holiday_weight"`
+	Id                 int     `json:"id" valid:"required"`
+	NormalDayWeight    float64 `json:"normal_day_weight"`
+	WeekendWeight      float64 `json:"weekend_weight"`
+	HolidayWeight      float64 `json:"holiday_weight"`
+	NightWeight        float64 `json:"night_weight"`
+	WeekendNightWeight float64 `json:"weekend_night_weight"`
+	HolidayNightWeight float64 `json:"holiday_night_weight"`
+	NightStart         string  `json:"night_start"`
+	NightEnd           string  `json:"night_end"`
+}
+
+type ApprovalStepParams struct {
+	ApproverType int `json:"approver_type" valid:"required,range(1|3)"`
+	ApproverId   int `json:"approver_id"`
+}
+
+type CreateApprovalChainParams struct {
+	ProjectId int                  `json:"project_id"`
+	Name      string               `json:"name" valid:"required"`
+	Steps     []ApprovalStepParams `json:"steps" valid:"required"`
+}
+
+type EditApprovalChainParams struct {
+	Id    int                  `json:"id" valid:"required"`
+	Name  string               `json:"name"`
+	Steps []ApprovalStepParams `json:"steps" valid:"required"`
+}
+
+type RemoveApprovalChainParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type CreateApprovalDelegationParams struct {
+	ApproverId int    `json:"approver_id"`
+	DelegateId int    `json:"delegate_id" valid:"required"`
+	DateFrom   string `json:"date_from" valid:"required"`
+	DateTo     string `json:"date_to" valid:"required"`
+}
+
+type RemoveApprovalDelegationParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type OvertimeRequestHistoryRecord struct {
+	Step           int       `json:"step"`
+	Action         int       `json:"action"`
+	Status         int       `json:"status"`
+	ActorId        int       `json:"actor_id"`
+	ActorName      string    `json:"actor_name"`
+	OnBehalfOf     int       `json:"on_behalf_of"`
+	OnBehalfOfName string    `json:"on_behalf_of_name"`
+	Note           string    `json:"note"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type ApprovalDelegationRecord struct {
+	Id           int       `json:"id"`
+	ApproverId   int       `json:"approver_id"`
+	ApproverName string    `json:"approver_name"`
+	DelegateId   int       `json:"delegate_id"`
+	DelegateName string    `json:"delegate_name"`
+	DateFrom     time.Time `json:"date_from"`
+	DateTo       time.Time `json:"date_to"`
+}
+
+type CreateOvertimeCapParams struct {
+	DailyHour    float64 `json:"daily_hour"`
+	MonthlyHour  float64 `json:"monthly_hour"`
+	YearlyHour   float64 `json:"yearly_hour"`
+	ExceedAction int     `json:"exceed_action" valid:"required,range(1|2)"`
+}
+
+type EditOvertimeCapParams struct {
+	Id           int     `json:"id" valid:"required"`
+	DailyHour    float64 `json:"daily_hour"`
+	MonthlyHour  float64 `json:"monthly_hour"`
+	YearlyHour   float64 `json:"yearly_hour"`
+	ExceedAction int     `json:"exceed_action" valid:"required,range(1|2)"`
+}
+
+type GetOvertimeAllowanceParams struct {
+	UsersId []int  `json:"users_id"`
+	Date    string `json:"date"`
 }
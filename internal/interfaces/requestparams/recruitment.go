@@ -91,11 +91,13 @@ type GetCVByIdParam struct {
 }
 
 type CvField struct {
-	MediaIdOther     string `json:"media_id_other"`
-	MediaId  int    `json:"media_id"`
-	FileName string `json:"file_name"`
-	Content  string `json:"content"`
-	Status   int    `json:"status"`
+	MediaIdOther string           `json:"media_id_other"`
+	MediaId      int              `json:"media_id"`
+	FileName     string           `json:"file_name"`
+	Content      string           `json:"content"`
+	Status       int              `json:"status"`
+	CvText       string           `json:"-"`
+	Contact      CandidateContact `json:"-"`
 }
 
 type EditJobParam struct {
@@ -141,6 +143,8 @@ type GetCvsParam struct {
 	MediaID       int    `json:"media_id"`
 	DateReceiptCv string `json:"date_receipt_cv"`
 	Status        int    `json:"status"`
+	StageId       int    `json:"stage_id"`
+	Keyword       string `json:"keyword"`
 	CurrentPage   int    `json:"current_page" valid:"required"`
 	RowPerPage    int    `json:"row_per_page" valid:"required"`
 }
@@ -189,6 +193,8 @@ type GetCvRecords struct {
 	MediaID       int       `json:"media_id"`
 	StatusCV      int       `json:"status_cv"`
 	FileName      string    `json:"file_name"`
+	StageId        int       `json:"stage_id"`
+	StageEnteredAt time.Time `json:"stage_entered_at"`
 }
 
 type DetailJobParams struct {
@@ -205,7 +211,9 @@ type RemoveDetailJobFile struct {
 }
 type CreateLogCvStatus struct {
 	CvId      int    `json:"cv_id" valid:"required"`
-	Status    int    `json:"status" valid:"required"`
+	Status    int    `json:"status"`
+	StageId       int       `json:"stage_id"`
+	RejectReason  string    `json:"reject_reason"`
 	RecruitmentId int       `json:"recruitment_id"`
 	Assignees     []int     `json:"assignees"`
 	UpdateDay string `json:"update_day" valid:"required"`
@@ -217,3 +225,409 @@ type InsertCvCommentReturn struct {
 	Body          string
 	Link          string
 }
+
+type RecruitmentStageParams struct {
+	Id            int      `json:"id"`
+	Name          string   `json:"name" valid:"required"`
+	StageType     int      `json:"stage_type" valid:"required"`
+	CvStatus      int      `json:"cv_status"`
+	NextPositions []int    `json:"next_positions"`
+	RejectReasons []string `json:"reject_reasons"`
+}
+
+// SaveRecruitmentStagesParams : Stages in order of pipeline, recruitment id 0 is default pipeline of organization
+type SaveRecruitmentStagesParams struct {
+	RecruitmentId int                      `json:"recruitment_id"`
+	Stages        []RecruitmentStageParams `json:"stages"`
+}
+
+type GetRecruitmentStagesParams struct {
+	RecruitmentId int `json:"recruitment_id"`
+}
+
+type GetCvPipelineParams struct {
+	RecruitmentId int    `json:"recruitment_id" valid:"required"`
+	NameApplicant string `json:"name_applicant"`
+	MediaID       int    `json:"media_id"`
+	DateReceiptCv string `json:"date_receipt_cv"`
+}
+
+type StageDurationRecords struct {
+	StageId    int     `json:"stage_id"`
+	AverageDay float64 `json:"average_day"`
+	CvCount    int     `json:"cv_count"`
+}
+
+// CreateInterviewParams : Start time and end time are in format yyyy-mm-dd hh:mm
+type CreateInterviewParams struct {
+	CvId         int    `json:"cv_id" valid:"required"`
+	Round        int    `json:"round" valid:"required"`
+	Interviewers []int  `json:"interviewers"`
+	StartTime    string `json:"start_time" valid:"required"`
+	EndTime      string `json:"end_time" valid:"required"`
+	Location     string `json:"location"`
+	MeetingLink  string `json:"meeting_link"`
+	Note         string `json:"note"`
+	LanguageId   int    `json:"language_id"`
+}
+
+type RescheduleInterviewParams struct {
+	Id           int    `json:"id" valid:"required"`
+	Interviewers []int  `json:"interviewers"`
+	StartTime    string `json:"start_time" valid:"required"`
+	EndTime      string `json:"end_time" valid:"required"`
+	Location     string `json:"location"`
+	MeetingLink  string `json:"meeting_link"`
+	Note         string `json:"note"`
+	LanguageId   int    `json:"language_id"`
+}
+
+type CancelInterviewParams struct {
+	Id           int    `json:"id" valid:"required"`
+	CancelReason string `json:"cancel_reason" valid:"required"`
+}
+
+type UpdateInterviewOutcomeParams struct {
+	Id          int    `json:"id" valid:"required"`
+	Outcome     int    `json:"outcome" valid:"required"`
+	OutcomeNote string `json:"outcome_note"`
+}
+
+type GetInterviewsParams struct {
+	RecruitmentId int    `json:"recruitment_id"`
+	CvId          int    `json:"cv_id"`
+	Interviewer   int    `json:"interviewer"`
+	Status        int    `json:"status"`
+	FromDate      string `json:"from_date"`
+	ToDate        string `json:"to_date"`
+}
+
+// CheckInterviewAvailabilityParams : Id is interview which is rescheduled, so that it does not conflict with itself
+type CheckInterviewAvailabilityParams struct {
+	Id           int    `json:"id"`
+	CvId         int    `json:"cv_id"`
+	Interviewers []int  `json:"interviewers"`
+	StartTime    string `json:"start_time" valid:"required"`
+	EndTime      string `json:"end_time" valid:"required"`
+}
+
+type InterviewRecords struct {
+	Id            int       `json:"id"`
+	RecruitmentId int       `json:"recruitment_id"`
+	JobName       string    `json:"job_name"`
+	CvId          int       `json:"cv_id"`
+	FullName      string    `json:"full_name"`
+	Email         string    `json:"email"`
+	Round         int       `json:"round"`
+	Interviewers  []int     `json:"interviewers" pg:",array"`
+	StartTime     time.Time `json:"start_time"`
+	EndTime       time.Time `json:"end_time"`
+	Location      string    `json:"location"`
+	MeetingLink   string    `json:"meeting_link"`
+	Note          string    `json:"note"`
+	Status        int       `json:"status"`
+	Outcome       int       `json:"outcome"`
+	OutcomeNote   string    `json:"outcome_note"`
+	CancelReason  string    `json:"cancel_reason"`
+}
+
+// InterviewConflictRecords : Leave request or interview of user which overlaps slot, user id 0 is candidate
+type InterviewConflictRecords struct {
+	UserId      int       `json:"user_id"`
+	Type        string    `json:"type"`
+	ReferenceId int       `json:"reference_id"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+}
+
+type ScorecardCompetencyParams struct {
+	Name         string `json:"name" valid:"required"`
+	Description  string `json:"description"`
+	NoteRequired bool   `json:"note_required"`
+}
+
+type SaveScorecardTemplateParams struct {
+	RecruitmentId int                         `json:"recruitment_id" valid:"required"`
+	Competencies  []ScorecardCompetencyParams `json:"competencies"`
+}
+
+type GetScorecardTemplateParams struct {
+	RecruitmentId int `json:"recruitment_id" valid:"required"`
+}
+
+type ScorecardRatingParams struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+	Note  string `json:"note"`
+}
+
+// SaveScorecardParams : Scorecard is kept as draft until submit is true, submitted scorecard can not be changed
+type SaveScorecardParams struct {
+	InterviewId    int                     `json:"interview_id" valid:"required"`
+	Ratings        []ScorecardRatingParams `json:"ratings"`
+	Recommendation int                     `json:"recommendation"`
+	Note           string                  `json:"note"`
+	Submit         bool                    `json:"submit"`
+}
+
+type GetScorecardsParams struct {
+	CvId int `json:"cv_id" valid:"required"`
+}
+
+// ParseCvParams : File content is base64 as in CreateCvParam
+type ParseCvParams struct {
+	FileName    string `json:"file_name" valid:"required"`
+	FileContent string `json:"file_content" valid:"required"`
+}
+
+// CandidateContact : Contact details which cv is matched with candidate by
+type CandidateContact struct {
+	FullName    string
+	Email       string
+	PhoneNumber string
+}
+
+// MergeCandidatesParams : Cvs of merged candidates are moved to candidate
+type MergeCandidatesParams struct {
+	CandidateId        int   `json:"candidate_id" valid:"required"`
+	MergedCandidateIds []int `json:"merged_candidate_ids"`
+}
+
+// GetCandidateParams : Candidate is found by id or by cv of candidate
+type GetCandidateParams struct {
+	CandidateId int `json:"candidate_id"`
+	CvId        int `json:"cv_id"`
+}
+
+type CandidateApplicationRecords struct {
+	CvId          int       `json:"cv_id"`
+	RecruitmentId int       `json:"recruitment_id"`
+	JobName       string    `json:"job_name"`
+	Assignees     []int     `json:"-" pg:",array"`
+	FullName      string    `json:"full_name"`
+	Email         string    `json:"email"`
+	PhoneNumber   string    `json:"phone_number"`
+	MediaId       int       `json:"media_id"`
+	MediaIdOther  string    `json:"media_id_other"`
+	StatusCv      int       `json:"status_cv"`
+	StageId       int       `json:"stage_id"`
+	DateReceiptCv time.Time `json:"date_receipt_cv"`
+	FileName      string    `json:"file_name"`
+}
+
+type GetCareerJobsParams struct {
+	OrganizationTag string `json:"organization_tag" valid:"required"`
+	Keyword         string `json:"keyword"`
+	CurrentPage     int    `json:"current_page"`
+	RowPerPage      int    `json:"row_per_page"`
+}
+
+type GetCareerJobParams struct {
+	OrganizationTag string `json:"organization_tag" valid:"required"`
+	Id              int    `json:"id" valid:"required"`
+}
+
+// ApplyCareerJobParams : Website is honeypot which is hidden on careers page, so only bots fill it
+type ApplyCareerJobParams struct {
+	OrganizationTag string `json:"organization_tag" valid:"required"`
+	RecruitmentId   int    `json:"recruitment_id" valid:"required"`
+	FullName        string `json:"full_name" valid:"required"`
+	Email           string `json:"email" valid:"required,email"`
+	PhoneNumber     string `json:"phone_number" valid:"required"`
+	FileName        string `json:"file_name" valid:"required"`
+	FileContent     string `json:"file_content" valid:"required"`
+	Consent         bool   `json:"consent"`
+	ConsentText     string `json:"consent_text" valid:"required"`
+	CaptchaToken    string `json:"captcha_token"`
+	Website         string `json:"website"`
+	Source          int    `json:"source"`
+}
+
+// GetJobFeedParams : Source is media id which links of feed are tracked with, careers page when it is empty
+type GetJobFeedParams struct {
+	OrganizationTag string `query:"organization_tag" valid:"required"`
+	Source          int    `query:"source"`
+}
+
+// CareerJobRecords : Active job with detail which is shown publicly, assignees are only used to notify recruiters
+type CareerJobRecords struct {
+	Id                int       `json:"id"`
+	JobName           string    `json:"job_name"`
+	StartDate         time.Time `json:"start_date"`
+	ExpiryDate        time.Time `json:"expiry_date"`
+	Assignees         []int     `json:"-" pg:",array"`
+	Amount            int       `json:"amount"`
+	Address           []string  `json:"address" pg:",array"`
+	Place             []string  `json:"place" pg:",array"`
+	Role              int       `json:"role"`
+	Gender            int       `json:"gender"`
+	TypeOfWork        string    `json:"type_of_work"`
+	Experience        int       `json:"experience"`
+	SalaryType        int       `json:"salary_type"`
+	SalaryFrom        int       `json:"salary_from"`
+	SalaryTo          int       `json:"salary_to"`
+	ProfileRecipients string    `json:"profile_recipients"`
+	Email             string    `json:"email"`
+	PhoneNumber       string    `json:"phone_number"`
+	Description       string    `json:"description"`
+}
+
+// RecruitmentAnalyticsParams : Cvs which were received and interviews which started in period are counted
+type RecruitmentAnalyticsParams struct {
+	BranchId      int    `json:"branch_id"`
+	RecruitmentId int    `json:"recruitment_id"`
+	FromDate      string `json:"from_date"`
+	ToDate        string `json:"to_date"`
+}
+
+type AnalyticsCvRecords struct {
+	Id            int
+	RecruitmentId int
+	MediaId       int
+	StatusCv      int
+	StageId       int
+	DateReceiptCv time.Time
+	CreatedAt     time.Time
+}
+
+type FunnelStageRecords struct {
+	StageType      int     `json:"stage_type"`
+	Name           string  `json:"name"`
+	CvCount        int     `json:"cv_count"`
+	ConversionRate float64 `json:"conversion_rate"`
+	OverallRate    float64 `json:"overall_rate"`
+}
+
+type TimeInStageRecords struct {
+	StageType   int     `json:"stage_type"`
+	Name        string  `json:"name"`
+	AverageDays float64 `json:"average_days"`
+	CvCount     int     `json:"cv_count"`
+}
+
+type TimeToHireRecords struct {
+	HiredCount  int     `json:"hired_count"`
+	AverageDays float64 `json:"average_days"`
+	MedianDays  float64 `json:"median_days"`
+}
+
+type SourceEffectivenessRecords struct {
+	MediaId           int     `json:"media_id"`
+	Name              string  `json:"name"`
+	CvCount           int     `json:"cv_count"`
+	InterviewedCount  int     `json:"interviewed_count"`
+	OfferedCount      int     `json:"offered_count"`
+	HiredCount        int     `json:"hired_count"`
+	HireRate          float64 `json:"hire_rate"`
+	AverageTimeToHire float64 `json:"average_time_to_hire"`
+}
+
+type OfferAcceptanceRecords struct {
+	OfferedCount   int     `json:"offered_count"`
+	AcceptedCount  int     `json:"accepted_count"`
+	DeclinedCount  int     `json:"declined_count"`
+	PendingCount   int     `json:"pending_count"`
+	AcceptanceRate float64 `json:"acceptance_rate"`
+}
+
+type InterviewerLoadRecords struct {
+	UserId         int     `json:"user_id"`
+	FullName       string  `json:"full_name"`
+	ScheduledCount int     `json:"scheduled_count"`
+	CompletedCount int     `json:"completed_count"`
+	CancelledCount int     `json:"cancelled_count"`
+	Hours          float64 `json:"hours"`
+}
+
+type RecruitmentAnalyticsRecords struct {
+	CvCount          int                          `json:"cv_count"`
+	Funnel           []FunnelStageRecords         `json:"funnel"`
+	TimeInStages     []TimeInStageRecords         `json:"time_in_stages"`
+	TimeToHire       TimeToHireRecords            `json:"time_to_hire"`
+	Sources          []SourceEffectivenessRecords `json:"sources"`
+	Offers           OfferAcceptanceRecords       `json:"offers"`
+	InterviewerLoads []InterviewerLoadRecords     `json:"interviewer_loads"`
+}
+
+type SaveOfferTemplateParams struct {
+	Content string `json:"content" valid:"required"`
+}
+
+// CreateOfferParams : Offer is kept as draft until send is true
+type CreateOfferParams struct {
+	CvId           int    `json:"cv_id" valid:"required"`
+	JobTitle       string `json:"job_title" valid:"required"`
+	Salary         int    `json:"salary" valid:"required"`
+	CurrencyUnit   int    `json:"currency_unit"`
+	ContractTypeId int    `json:"contract_type_id" valid:"required"`
+	StartDate      string `json:"start_date" valid:"required"`
+	ExpiredDate    string `json:"expired_date" valid:"required"`
+	Send           bool   `json:"send"`
+	LanguageId     int    `json:"language_id"`
+}
+
+type SendOfferParams struct {
+	Id         int `json:"id" valid:"required"`
+	LanguageId int `json:"language_id"`
+}
+
+type OfferIdParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type GetOffersParams struct {
+	CvId int `json:"cv_id" valid:"required"`
+}
+
+// RespondOfferParams : Response of candidate which recruiter records, as candidate replied by email or phone
+type RespondOfferParams struct {
+	Id            int    `json:"id" valid:"required"`
+	Accept        bool   `json:"accept"`
+	DeclineReason string `json:"decline_reason"`
+}
+
+type GetCareerOfferParams struct {
+	Token string `json:"token" valid:"required"`
+}
+
+type RespondCareerOfferParams struct {
+	Token         string `json:"token" valid:"required"`
+	Accept        bool   `json:"accept"`
+	DeclineReason string `json:"decline_reason"`
+}
+
+type GetOnboardingsParams struct {
+	UserId    int  `json:"user_id"`
+	Completed bool `json:"completed"`
+}
+
+type UpdateOnboardingTaskParams struct {
+	Id    int  `json:"id" valid:"required"`
+	Index int  `json:"index"`
+	Done  bool `json:"done"`
+}
+
+type SaveCandidateEmailTemplateParams struct {
+	Type       int    `json:"type" valid:"required"`
+	LanguageId int    `json:"language_id" valid:"required"`
+	Subject    string `json:"subject" valid:"required"`
+	Body       string `json:"body" valid:"required"`
+}
+
+// SendCandidateEmailParams : Template of type and language is used when subject or body is empty
+type SendCandidateEmailParams struct {
+	CvId       int    `json:"cv_id" valid:"required"`
+	Type       int    `json:"type" valid:"required"`
+	LanguageId int    `json:"language_id"`
+	Subject    string `json:"subject"`
+	Body       string `json:"body"`
+}
+
+type GetCvCommunicationsParams struct {
+	CvId int `json:"cv_id" valid:"required"`
+}
+
+type SendRejectionEmailsParams struct {
+	RecruitmentId int `json:"recruitment_id" valid:"required"`
+	LanguageId    int `json:"language_id"`
+}
@@ -33,6 +33,7 @@ type UpdateRequestStatusParams struct {
 	RequestID int    `json:"request_id" valid:"required"`
 	Email     string `json:"email"`
 	Status    int    `json:"status_request" valid:"required"`
+	Note      string `json:"note"`
 }
 
 type ResendEmailParams struct {
@@ -0,0 +1,89 @@
+package requestparams
+
+import "time"
+
+type CreateShiftParams struct {
+	Name       string `json:"name" valid:"required"`
+	StartTime  string `json:"start_time" valid:"required"`
+	EndTime    string `json:"end_time" valid:"required"`
+	BreakStart string `json:"break_start"`
+	BreakEnd   string `json:"break_end"`
+}
+
+type EditShiftParams struct {
+	Id         int    `json:"id" valid:"required"`
+	Name       string `json:"name"`
+	StartTime  string `json:"start_time"`
+	EndTime    string `json:"end_time"`
+	BreakStart string `json:"break_start"`
+	BreakEnd   string `json:"break_end"`
+}
+
+type RemoveShiftParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type CreateRosterParams struct {
+	UsersId  []int  `json:"users_id" valid:"required"`
+	ShiftId  int    `json:"shift_id" valid:"required"`
+	DateFrom string `json:"date_from" valid:"required"`
+	DateTo   string `json:"date_to" valid:"required"`
+	Weekdays []int  `json:"weekdays"`
+}
+
+type RemoveRosterParams struct {
+	Id int `json:"id" valid:"required"`
+}
+
+type GetRosterParams struct {
+	UsersId  []int  `json:"users_id"`
+	DateFrom string `json:"date_from" valid:"required"`
+	DateTo   string `json:"date_to" valid:"required"`
+}
+
+type RosterRecord struct {
+	Id         int       `json:"id"`
+	UserId     int       `json:"user_id"`
+	FullName   string    `json:"full_name"`
+	ShiftId    int       `json:"shift_id"`
+	ShiftName  string    `json:"shift_name"`
+	ShiftDate  time.Time `json:"shift_date"`
+	StartTime  string    `json:"start_time"`
+	EndTime    string    `json:"end_time"`
+	BreakStart string    `json:"break_start"`
+	BreakEnd   string    `json:"break_end"`
+}
+
+type CreateShiftSwapRequestParams struct {
+	RequesterShiftId int    `json:"requester_shift_id" valid:"required"`
+	TargetShiftId    int    `json:"target_shift_id" valid:"required"`
+	Reason           string `json:"reason"`
+}
+
+type UpdateShiftSwapRequestStatusParams struct {
+	Id     int `json:"id" valid:"required"`
+	Status int `json:"status" valid:"required,range(2|3)"`
+}
+
+type GetShiftSwapRequestsParams struct {
+	Status      int `json:"status"`
+	CurrentPage int `json:"current_page"`
+	RowPerPage  int `json:"row_per_page"`
+}
+
+type ShiftSwapRequestRecord struct {
+	Id                 int       `json:"id"`
+	RequesterId        int       `json:"requester_id"`
+	RequesterName      string    `json:"requester_name"`
+	RequesterShiftId   int       `json:"requester_shift_id"`
+	RequesterShiftName string    `json:"requester_shift_name"`
+	RequesterShiftDate time.Time `json:"requester_shift_date"`
+	TargetId           int       `json:"target_id"`
+	TargetName         string    `json:"target_name"`
+	TargetShiftId      int       `json:"target_shift_id"`
+	TargetShiftName    string    `json:"target_shift_name"`
+	TargetShiftDate    time.Time `json:"target_shift_date"`
+	Status             int       `json:"status"`
+	Reason             string    `json:"reason"`
+	CreatedAt          time.Time `json:"created_at"`
+}
@@ -30,6 +30,7 @@ type UserTimekeepingResponse struct {
 	Branch         int
 	CheckInTime    time.Time
 	CheckOutTime   time.Time
+	AutoClosed     bool
 }
 
 // AllUserTimekeepingResponse : struct for all user timekeeping response
@@ -52,4 +53,18 @@ type TkExportExcelRecords struct {
 	Date         time.Time `json:"date"`
 	CheckInTime  time.Time `json:"check_in_time"`
 	CheckOutTime time.Time `json:"check_out_time"`
+	AutoClosed   bool      `json:"auto_closed"`
+}
+
+type CreateTimekeepingSettingParams struct {
+	Timezone             string `json:"timezone" valid:"required"`
+	AutoCheckoutTime     string `json:"auto_checkout_time" valid:"required"`
+	ReminderBeforeMinute int    `json:"reminder_before_minute"`
+}
+
+type EditTimekeepingSettingParams struct {
+	Id                   int    `json:"id" valid:"required"`
+	Timezone             string `json:"timezone"`
+	AutoCheckoutTime     string `json:"auto_checkout_time"`
+	ReminderBeforeMinute int    `json:"reminder_before_minute"`
 }
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// Candidate : struct for db table candidates, person who is shared by cvs across recruitments
+type Candidate struct {
+	cm.BaseModel
+
+	tableName       struct{} `sql:"alias:cdd"`
+	OrganizationId  int
+	FullName        string
+	Email           string
+	PhoneNumber     string
+	NormalizedEmail string
+	NormalizedPhone string
+	NormalizedName  string
+	MergedIntoId    int
+}
+
+// CandidateConsent : struct for db table candidate_consents, consent to processing of personal data from careers page
+type CandidateConsent struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:ccs"`
+	OrganizationId int
+	CvId           int
+	CandidateId    int
+	ConsentText    string
+	IpAddress      string
+	UserAgent      string
+	ConsentedAt    time.Time
+}
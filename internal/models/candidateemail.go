@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// CandidateEmailTemplate : struct for db table candidate_email_templates, email of organization to candidates per type and language
+type CandidateEmailTemplate struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:cet"`
+	OrganizationId int
+	Type           int
+	LanguageId     int
+	Subject        string
+	Body           string
+}
+
+// CvCommunication : struct for db table cv_communications, email which was sent to candidate of cv
+type CvCommunication struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:cvm"`
+	OrganizationId int
+	CvId           int
+	Type           int
+	LanguageId     int
+	Subject        string
+	Body           string
+	SentTo         string
+	SentBy         int
+	Status         int
+	ErrorMessage   string
+	SentAt         time.Time
+}
@@ -21,6 +21,7 @@ type Contract struct {
 	FileName             string
 	LaborContractNumber  *string
 	ContractCreationDate *time.Time
+	IsDraft              bool
 }
 
 type ContractType struct {
@@ -21,13 +21,20 @@ type Cv struct {
 	ContactLink     string
 	StatusCv        int
 	MediaIdOther	string
+	StageId         int
+	StageEnteredAt  time.Time
+	CvText          string
+	CandidateId     int
 }
 
 type LogCvState struct {
 	cm.BaseModel
 	tableName struct{} `sql:"alias:lcs"`
 
-	CvId      int
-	Status    int
-	UpdateDay time.Time
+	CvId         int
+	Status       int
+	UpdateDay    time.Time
+	StageId      int
+	RejectReason string
+	CreatedBy    int
 }
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// Interview : struct for db table interviews
+type Interview struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:itv"`
+	OrganizationId int
+	RecruitmentId  int
+	CvId           int
+	Round          int
+	Interviewers   []int `pg:",array"`
+	StartTime      time.Time
+	EndTime        time.Time
+	Location       string
+	MeetingLink    string
+	Note           string
+	Status         int
+	Outcome        int
+	OutcomeNote    string
+	CancelReason   string
+	Sequence       int
+	CreatedBy      int
+}
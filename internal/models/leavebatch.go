@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// LeaveBatch : struct for db table leave_batches, leave requests created in bulk for branch or organization
+type LeaveBatch struct {
+	cm.BaseModel
+
+	tableName            struct{} `sql:"alias:lvb"`
+	OrganizationId       int
+	BranchId             int
+	LeaveRequestTypeId   int
+	DatetimeLeaveFrom    time.Time
+	DatetimeLeaveTo      time.Time
+	SubtractDayOffTypeId int
+	Reason               string
+	Hour                 float64
+	UserCount            int
+	CalendarEventId      string
+	CreatedBy            int
+	UndoneBy             int
+	UndoneAt             time.Time
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// LeaveLedgerEntry : struct for db table leave_ledger_entries, entries are appended only
+type LeaveLedgerEntry struct {
+	cm.BaseModel
+
+	tableName        struct{} `sql:"alias:lle"`
+	OrganizationId   int
+	UserId           int
+	EntryType        int
+	LeaveBonusTypeId int
+	YearBelong       int
+	Hour             float64
+	EffectiveDate    time.Time
+	SourceType       int
+	SourceId         int
+	Note             string
+	CreatedBy        int
+}
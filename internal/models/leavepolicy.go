@@ -0,0 +1,40 @@
+package models
+
+import (
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+type LeavePolicy struct {
+	cm.BaseModel
+
+	tableName        struct{} `sql:"alias:lvp"`
+	OrganizationId   int
+	Name             string
+	LeaveBonusTypeId int
+	AccrualHour      float64
+	AccrualFrequency int
+	ProbationMonth   int
+	CarryOverHour    *float64
+	ExpiryMonth      int
+	ContractTypeIds  []int `pg:",array"`
+}
+
+type LeavePolicyTier struct {
+	cm.BaseModel
+
+	tableName struct{} `sql:"alias:lpt"`
+	PolicyId  int
+	FromMonth int
+	Hour      float64
+}
+
+type LeaveAccrualRun struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:lar"`
+	OrganizationId int
+	PolicyId       int
+	UserId         int
+	Period         string
+	Hour           float64
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// LeaveRequestChange : struct for db table leave_request_changes
+type LeaveRequestChange struct {
+	cm.BaseModel
+
+	tableName         struct{} `sql:"alias:lrc"`
+	OrganizationId    int
+	LeaveRequestId    int
+	UserId            int
+	RequestedBy       int
+	ChangeType        int
+	DatetimeLeaveFrom time.Time
+	DatetimeLeaveTo   time.Time
+	Hour              float64
+	Reason            string
+	Status            int
+	ApprovedBy        int
+	ApprovedAt        time.Time
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// LeaveSettlement : struct for db table leave_settlements, year-end settlement signed off by general manager
+type LeaveSettlement struct {
+	cm.BaseModel
+
+	tableName         struct{} `sql:"alias:lse"`
+	OrganizationId    int
+	Year              int
+	PayoutRate        float64
+	MaxPayoutHour     *float64
+	UserCount         int
+	TotalPayoutAmount float64
+	SignedBy          int
+	SignedAt          time.Time
+}
+
+// LeaveSettlementItem : struct for db table leave_settlement_items, unused hours of one user split by settlement
+type LeaveSettlementItem struct {
+	cm.BaseModel
+
+	tableName         struct{} `sql:"alias:lsi"`
+	LeaveSettlementId int
+	UserId            int
+	UnusedHour        float64
+	CarryOverHour     float64
+	PayoutHour        float64
+	PayoutAmount      float64
+	ForfeitHour       float64
+}
+
+// SeveranceLeaveSettlement : struct for db table severance_leave_settlements, final leave balance of user who left the company
+type SeveranceLeaveSettlement struct {
+	cm.BaseModel
+
+	tableName           struct{} `sql:"alias:sls"`
+	OrganizationId      int
+	UserId              int
+	DateSeverance       time.Time
+	BalanceHour         float64
+	UnearnedHour        float64
+	FinalHour           float64
+	PayoutRate          float64
+	PayoutAmount        float64
+	CancelledLeaveCount int
+	SettledBy           int
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// OfferTemplate : struct for db table offer_templates, offer letter of organization
+type OfferTemplate struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:ott"`
+	OrganizationId int
+	Content        string
+}
+
+// Offer : struct for db table offers, offer which is made to cv that passed final
+type Offer struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:ofr"`
+	OrganizationId int
+	RecruitmentId  int
+	CvId           int
+	CandidateId    int
+	JobTitle       string
+	Salary         int
+	CurrencyUnit   int
+	ContractTypeId int
+	StartDate      time.Time
+	ExpiredDate    time.Time
+	Content        string
+	Token          string
+	Status         int
+	SentAt         time.Time
+	RespondedAt    time.Time
+	DeclineReason  string
+	UserId         int
+	ContractId     int
+	CreatedBy      int
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// Onboarding : struct for db table onboardings, checklist of employee who was hired from offer
+type Onboarding struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:onb"`
+	OrganizationId int
+	UserId         int
+	OfferId        int
+	CvId           int
+	ContractId     int
+	CvFileName     string
+	CvFilePath     string
+	Checklist      []OnboardingTask
+	Notes          []OnboardingNote
+	CompletedAt    time.Time
+}
+
+type OnboardingTask struct {
+	Name   string    `json:"name"`
+	Done   bool      `json:"done"`
+	DoneBy int       `json:"done_by"`
+	DoneAt time.Time `json:"done_at"`
+}
+
+// OnboardingNote : Comment of cv which is carried over from recruitment
+type OnboardingNote struct {
+	CreatedBy int       `json:"created_by"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+}
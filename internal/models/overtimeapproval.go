@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// OvertimeApprovalChain : struct for db table overtime_approval_chains
+type OvertimeApprovalChain struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:oac"`
+	OrganizationId int
+	ProjectId      int
+	Name           string
+}
+
+// OvertimeApprovalStep : struct for db table overtime_approval_steps
+type OvertimeApprovalStep struct {
+	cm.BaseModel
+
+	tableName    struct{} `sql:"alias:oas"`
+	ChainId      int
+	StepOrder    int
+	ApproverType int
+	ApproverId   int
+}
+
+// OvertimeApprovalDelegation : struct for db table overtime_approval_delegations
+type OvertimeApprovalDelegation struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:oad"`
+	OrganizationId int
+	ApproverId     int
+	DelegateId     int
+	DateFrom       time.Time
+	DateTo         time.Time
+}
+
+// OvertimeRequestHistory : struct for db table overtime_request_histories
+type OvertimeRequestHistory struct {
+	cm.BaseModel
+
+	tableName         struct{} `sql:"alias:orh"`
+	OvertimeRequestId int
+	Step              int
+	Action            int
+	Status            int
+	ActorId           int
+	OnBehalfOf        int
+	Note              string
+}
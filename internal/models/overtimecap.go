@@ -0,0 +1,16 @@
+package models
+
+import (
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+type OvertimeCap struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:otc"`
+	OrganizationId int
+	DailyHour      float64
+	MonthlyHour    float64
+	YearlyHour     float64
+	ExceedAction   int
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+type OvertimeHourBreakdown struct {
+	cm.BaseModel
+
+	tableName         struct{} `sql:"alias:ohb"`
+	OvertimeRequestId int
+	WorkDate          time.Time
+	Band              int
+	Hour              float64
+	Weight            float64
+}
@@ -7,9 +7,14 @@ import (
 type OvertimeWeight struct {
 	cm.BaseModel
 
-	tableName       struct{} `sql:"alias:ow"`
-	OrganizationId  int
-	NormalDayWeight float64
-	WeekendWeight   float64
-	HolidayWeight   float64
+	tableName          struct{} `sql:"alias:ow"`
+	OrganizationId     int
+	NormalDayWeight    float64
+	WeekendWeight      float64
+	HolidayWeight      float64
+	NightWeight        float64
+	WeekendNightWeight float64
+	HolidayNightWeight float64
+	NightStart         string
+	NightEnd           string
 }
@@ -0,0 +1,16 @@
+package models
+
+import (
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// ProjectStaffingRule : struct for db table project_staffing_rules
+type ProjectStaffingRule struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:psr"`
+	OrganizationId int
+	ProjectId      int
+	MinStaff       int
+	ConflictAction int
+}
@@ -0,0 +1,20 @@
+package models
+
+import (
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// RecruitmentStage : struct for db table recruitment_stages, ordered pipeline of organization or recruitment
+type RecruitmentStage struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:rst"`
+	OrganizationId int
+	RecruitmentId  int
+	Name           string
+	StageType      int
+	Position       int
+	CvStatus       int
+	NextPositions  []int    `pg:",array"`
+	RejectReasons  []string `pg:",array"`
+}
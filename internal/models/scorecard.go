@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// ScorecardTemplate : struct for db table scorecard_templates
+type ScorecardTemplate struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:sct"`
+	OrganizationId int
+	RecruitmentId  int
+	Competencies   []ScorecardCompetency
+}
+
+type ScorecardCompetency struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	NoteRequired bool   `json:"note_required"`
+}
+
+// Scorecard : struct for db table scorecards, one per interviewer per round of cv
+type Scorecard struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:scd"`
+	OrganizationId int
+	RecruitmentId  int
+	CvId           int
+	InterviewId    int
+	Round          int
+	InterviewerId  int
+	Ratings        []ScorecardRating
+	Recommendation int
+	Note           string
+	Status         int
+	SubmittedAt    time.Time
+}
+
+type ScorecardRating struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+	Note  string `json:"note"`
+}
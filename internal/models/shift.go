@@ -0,0 +1,18 @@
+package models
+
+import (
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// Shift : struct for db table shifts
+type Shift struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:sft"`
+	OrganizationId int
+	Name           string
+	StartTime      string
+	EndTime        string
+	BreakStart     string
+	BreakEnd       string
+}
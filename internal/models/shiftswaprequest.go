@@ -0,0 +1,20 @@
+package models
+
+import (
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// ShiftSwapRequest : struct for db table shift_swap_requests
+type ShiftSwapRequest struct {
+	cm.BaseModel
+
+	tableName        struct{} `sql:"alias:ssr"`
+	OrganizationId   int
+	RequesterId      int
+	RequesterShiftId int
+	TargetId         int
+	TargetShiftId    int
+	Status           int
+	Reason           string
+	UpdatedBy        int
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// StatutoryLeaveRule : struct for db table statutory_leave_rules
+type StatutoryLeaveRule struct {
+	cm.BaseModel
+
+	tableName          struct{} `sql:"alias:slr"`
+	OrganizationId     int
+	LeaveBonusTypeId   int
+	EntitlementDay     float64
+	IsCalendarDay      bool
+	MaxMonth           int
+	RequiredDocuments  []string `pg:",array"`
+	CountAgainstAnnual bool
+}
+
+// StatutoryLeave : struct for db table statutory_leaves
+type StatutoryLeave struct {
+	cm.BaseModel
+
+	tableName          struct{} `sql:"alias:stl"`
+	OrganizationId     int
+	UserId             int
+	LeaveBonusTypeId   int
+	LeaveRequestId     int
+	EventDate          time.Time
+	DatetimeLeaveFrom  time.Time
+	DatetimeLeaveTo    time.Time
+	Day                float64
+	Hour               float64
+	CountAgainstAnnual bool
+	Reason             string
+	CreatedBy          int
+}
+
+// StatutoryLeaveDocument : struct for db table statutory_leave_documents
+type StatutoryLeaveDocument struct {
+	cm.BaseModel
+
+	tableName        struct{} `sql:"alias:sld"`
+	StatutoryLeaveId int
+	DocumentName     string
+	FileName         string
+	CreatedBy        int
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// TimekeepingSetting : struct for db table timekeeping_settings
+type TimekeepingSetting struct {
+	cm.BaseModel
+
+	tableName            struct{} `sql:"alias:tks"`
+	OrganizationId       int
+	Timezone             string
+	AutoCheckoutTime     string
+	ReminderBeforeMinute int
+}
@@ -24,6 +24,10 @@ type UserLeaveRequest struct {
 	Reason               string
 	Hour                 float64
 	CalendarEventId      string
+	DelegateUserId       int
+	HandoverNote         string
+	DelegateNotifiedAt   time.Time
+	LeaveBatchId         int
 }
 
 type UserLeaveRequestExt struct {
@@ -22,4 +22,7 @@ type UserOvertimeRequest struct {
 	WorkAtNoon           int
 	SendTo               []string `pg:",array"`
 	SendCc               []string `pg:",array"`
+	ApprovalChainId      int
+	CurrentStep          int
+	ExceedCap            bool
 }
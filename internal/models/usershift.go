@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	cm "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/common"
+)
+
+// UserShift : struct for db table user_shifts
+type UserShift struct {
+	cm.BaseModel
+
+	tableName      struct{} `sql:"alias:usft"`
+	OrganizationId int
+	UserId         int
+	ShiftId        int
+	ShiftDate      time.Time
+	CreatedBy      int
+}
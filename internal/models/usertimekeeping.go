@@ -15,4 +15,6 @@ type UserTimekeeping struct {
 	OrganizationID int
 	CheckInTime    time.Time
 	CheckOutTime   time.Time
+	UserShiftId    int
+	AutoClosed     bool
 }
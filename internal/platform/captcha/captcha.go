@@ -0,0 +1,68 @@
+package captcha
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const recaptchaVerifyUrl = "https://www.google.com/recaptcha/api/siteverify"
+
+// Verifier : Check captcha token which public form sends
+type Verifier interface {
+	Verify(token string, remoteIp string) (bool, error)
+}
+
+// NewVerifier : reCAPTCHA verifier when RECAPTCHA_SECRET_KEY is set, otherwise captcha is not checked and it is warned at startup
+func NewVerifier(logger echo.Logger) Verifier {
+	secret := os.Getenv("RECAPTCHA_SECRET_KEY")
+	if secret == "" {
+		logger.Warn("RECAPTCHA_SECRET_KEY is not set, captcha of public careers forms is not checked")
+		return noVerifier{}
+	}
+
+	return &recaptchaVerifier{
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type noVerifier struct{}
+
+func (noVerifier) Verify(token string, remoteIp string) (bool, error) {
+	return true, nil
+}
+
+type recaptchaVerifier struct {
+	secret string
+	client *http.Client
+}
+
+func (v *recaptchaVerifier) Verify(token string, remoteIp string) (bool, error) {
+	if token == "" {
+		return false, nil
+	}
+
+	resp, err := v.client.PostForm(recaptchaVerifyUrl, url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+		"remoteip": {remoteIp},
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, err
+	}
+
+	return result.Success, nil
+}
@@ -0,0 +1,2 @@
+alter table shifts drop constraint if exists shifts_organization_id;
+drop table if exists shifts;
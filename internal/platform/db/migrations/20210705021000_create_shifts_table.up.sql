@@ -0,0 +1,27 @@
+create table if not exists shifts(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    name varchar(100) not null,
+    start_time varchar(5) not null,
+    end_time varchar(5) not null,
+    break_start varchar(5),
+    break_end varchar(5)
+);
+
+create index index_shifts_organization_id on shifts (organization_id);
+
+alter table shifts add constraint shifts_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column shifts.id is 'shifts id';
+comment on column shifts.created_at is 'Save timestamp when create';
+comment on column shifts.updated_at is 'Save timestamp when update';
+comment on column shifts.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column shifts.organization_id is 'organization id';
+comment on column shifts.name is 'Name of shift template';
+comment on column shifts.start_time is 'Start time of shift (HH:MM, local time)';
+comment on column shifts.end_time is 'End time of shift (HH:MM, local time). Less than or equal start_time means the shift ends the next day';
+comment on column shifts.break_start is 'Start time of break in shift (HH:MM)';
+comment on column shifts.break_end is 'End time of break in shift (HH:MM)';
@@ -0,0 +1,4 @@
+alter table user_shifts drop constraint if exists user_shifts_shift_id;
+alter table user_shifts drop constraint if exists user_shifts_user_id;
+alter table user_shifts drop constraint if exists user_shifts_organization_id;
+drop table if exists user_shifts;
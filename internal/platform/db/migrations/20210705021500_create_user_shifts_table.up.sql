@@ -0,0 +1,28 @@
+create table if not exists user_shifts(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    user_id integer not null,
+    shift_id integer not null,
+    shift_date date not null,
+    created_by integer not null
+);
+
+create index index_user_shifts_organization_id on user_shifts (organization_id);
+create index index_user_shifts_user_id_shift_date on user_shifts (user_id, shift_date);
+
+alter table user_shifts add constraint user_shifts_organization_id foreign key (organization_id) references organizations (id);
+alter table user_shifts add constraint user_shifts_user_id foreign key (user_id) references users (id);
+alter table user_shifts add constraint user_shifts_shift_id foreign key (shift_id) references shifts (id);
+
+comment on column user_shifts.id is 'user_shifts id';
+comment on column user_shifts.created_at is 'Save timestamp when create';
+comment on column user_shifts.updated_at is 'Save timestamp when update';
+comment on column user_shifts.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column user_shifts.organization_id is 'organization id';
+comment on column user_shifts.user_id is 'user id assigned to the shift';
+comment on column user_shifts.shift_id is 'shift template id';
+comment on column user_shifts.shift_date is 'Date the shift starts on';
+comment on column user_shifts.created_by is 'user id who assigned the shift';
@@ -0,0 +1,4 @@
+alter table shift_swap_requests drop constraint if exists shift_swap_requests_target_shift_id;
+alter table shift_swap_requests drop constraint if exists shift_swap_requests_requester_shift_id;
+alter table shift_swap_requests drop constraint if exists shift_swap_requests_organization_id;
+drop table if exists shift_swap_requests;
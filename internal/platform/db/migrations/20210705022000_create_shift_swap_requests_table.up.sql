@@ -0,0 +1,35 @@
+create table if not exists shift_swap_requests(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    requester_id integer not null,
+    requester_shift_id integer not null,
+    target_id integer not null,
+    target_shift_id integer not null,
+    status integer not null,
+    reason text,
+    updated_by integer
+);
+
+create index index_shift_swap_requests_organization_id on shift_swap_requests (organization_id);
+create index index_shift_swap_requests_requester_id on shift_swap_requests (requester_id);
+create index index_shift_swap_requests_target_id on shift_swap_requests (target_id);
+
+alter table shift_swap_requests add constraint shift_swap_requests_organization_id foreign key (organization_id) references organizations (id);
+alter table shift_swap_requests add constraint shift_swap_requests_requester_shift_id foreign key (requester_shift_id) references user_shifts (id);
+alter table shift_swap_requests add constraint shift_swap_requests_target_shift_id foreign key (target_shift_id) references user_shifts (id);
+
+comment on column shift_swap_requests.id is 'shift_swap_requests id';
+comment on column shift_swap_requests.created_at is 'Save timestamp when create';
+comment on column shift_swap_requests.updated_at is 'Save timestamp when update';
+comment on column shift_swap_requests.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column shift_swap_requests.organization_id is 'organization id';
+comment on column shift_swap_requests.requester_id is 'user id who asks to swap';
+comment on column shift_swap_requests.requester_shift_id is 'user_shifts id of requester';
+comment on column shift_swap_requests.target_id is 'user id who is asked to swap';
+comment on column shift_swap_requests.target_shift_id is 'user_shifts id of target user';
+comment on column shift_swap_requests.status is 'Status of request: 1 pending, 2 deny, 3 accept';
+comment on column shift_swap_requests.reason is 'Reason of swap';
+comment on column shift_swap_requests.updated_by is 'user id who accepted or denied the request';
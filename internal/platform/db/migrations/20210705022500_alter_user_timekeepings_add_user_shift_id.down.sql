@@ -0,0 +1 @@
+alter table user_timekeepings drop column if exists user_shift_id;
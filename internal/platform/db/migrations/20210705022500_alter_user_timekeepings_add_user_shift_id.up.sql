@@ -0,0 +1,3 @@
+alter table user_timekeepings add column if not exists user_shift_id integer default 0 not null;
+
+comment on column user_timekeepings.user_shift_id is 'user_shifts id the record belongs to, 0 when user has no shift';
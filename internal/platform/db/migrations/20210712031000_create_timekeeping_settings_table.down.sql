@@ -0,0 +1,2 @@
+alter table timekeeping_settings drop constraint if exists timekeeping_settings_organization_id;
+drop table if exists timekeeping_settings;
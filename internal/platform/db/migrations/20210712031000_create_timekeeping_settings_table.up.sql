@@ -0,0 +1,21 @@
+create table if not exists timekeeping_settings(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    timezone varchar(64) not null default 'Asia/Ho_Chi_Minh',
+    auto_checkout_time varchar(5) not null,
+    reminder_before_minute integer not null default 0
+);
+
+alter table timekeeping_settings add constraint timekeeping_settings_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column timekeeping_settings.id is 'timekeeping_settings id';
+comment on column timekeeping_settings.created_at is 'Save timestamp when create';
+comment on column timekeeping_settings.updated_at is 'Save timestamp when update';
+comment on column timekeeping_settings.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column timekeeping_settings.organization_id is 'organization id';
+comment on column timekeeping_settings.timezone is 'IANA timezone of organization, used for daily timekeeping and cron';
+comment on column timekeeping_settings.auto_checkout_time is 'Time (HH:MM) in organization timezone to close open timekeeping records';
+comment on column timekeeping_settings.reminder_before_minute is 'Minutes before auto checkout to remind employee to check out, 0 is no reminder';
@@ -0,0 +1 @@
+alter table user_timekeepings drop column if exists auto_closed;
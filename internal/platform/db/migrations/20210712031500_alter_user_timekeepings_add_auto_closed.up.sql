@@ -0,0 +1,3 @@
+alter table user_timekeepings add column if not exists auto_closed boolean default false not null;
+
+comment on column user_timekeepings.auto_closed is 'true when check out time is set by auto checkout job (missing punch)';
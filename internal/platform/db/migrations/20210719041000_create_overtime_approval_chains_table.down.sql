@@ -0,0 +1,4 @@
+alter table overtime_approval_steps drop constraint if exists overtime_approval_steps_chain_id;
+drop table if exists overtime_approval_steps;
+alter table overtime_approval_chains drop constraint if exists overtime_approval_chains_organization_id;
+drop table if exists overtime_approval_chains;
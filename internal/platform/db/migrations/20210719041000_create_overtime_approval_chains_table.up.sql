@@ -0,0 +1,45 @@
+create table if not exists overtime_approval_chains(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    project_id integer default 0 not null,
+    name varchar(100) not null
+);
+
+create index index_overtime_approval_chains_organization_id on overtime_approval_chains (organization_id, project_id);
+
+alter table overtime_approval_chains add constraint overtime_approval_chains_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column overtime_approval_chains.id is 'overtime_approval_chains id';
+comment on column overtime_approval_chains.created_at is 'Save timestamp when create';
+comment on column overtime_approval_chains.updated_at is 'Save timestamp when update';
+comment on column overtime_approval_chains.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column overtime_approval_chains.organization_id is 'organization id';
+comment on column overtime_approval_chains.project_id is 'project id, 0 is default chain of organization';
+comment on column overtime_approval_chains.name is 'Name of approval chain';
+
+create table if not exists overtime_approval_steps(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    chain_id integer not null,
+    step_order integer not null,
+    approver_type integer not null,
+    approver_id integer default 0 not null
+);
+
+create index index_overtime_approval_steps_chain_id on overtime_approval_steps (chain_id);
+
+alter table overtime_approval_steps add constraint overtime_approval_steps_chain_id foreign key (chain_id) references overtime_approval_chains (id);
+
+comment on column overtime_approval_steps.id is 'overtime_approval_steps id';
+comment on column overtime_approval_steps.created_at is 'Save timestamp when create';
+comment on column overtime_approval_steps.updated_at is 'Save timestamp when update';
+comment on column overtime_approval_steps.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column overtime_approval_steps.chain_id is 'overtime_approval_chains id';
+comment on column overtime_approval_steps.step_order is 'Order of step in chain, start from 1';
+comment on column overtime_approval_steps.approver_type is 'Approver of step: 1 project manager, 2 general manager, 3 specific user';
+comment on column overtime_approval_steps.approver_id is 'user id of approver when approver_type is specific user';
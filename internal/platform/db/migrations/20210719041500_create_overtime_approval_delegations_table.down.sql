@@ -0,0 +1,4 @@
+alter table overtime_approval_delegations drop constraint if exists overtime_approval_delegations_delegate_id;
+alter table overtime_approval_delegations drop constraint if exists overtime_approval_delegations_approver_id;
+alter table overtime_approval_delegations drop constraint if exists overtime_approval_delegations_organization_id;
+drop table if exists overtime_approval_delegations;
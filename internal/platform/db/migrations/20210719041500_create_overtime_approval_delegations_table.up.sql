@@ -0,0 +1,27 @@
+create table if not exists overtime_approval_delegations(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    approver_id integer not null,
+    delegate_id integer not null,
+    date_from date not null,
+    date_to date not null
+);
+
+create index index_overtime_approval_delegations_approver_id on overtime_approval_delegations (organization_id, approver_id);
+
+alter table overtime_approval_delegations add constraint overtime_approval_delegations_organization_id foreign key (organization_id) references organizations (id);
+alter table overtime_approval_delegations add constraint overtime_approval_delegations_approver_id foreign key (approver_id) references users (id);
+alter table overtime_approval_delegations add constraint overtime_approval_delegations_delegate_id foreign key (delegate_id) references users (id);
+
+comment on column overtime_approval_delegations.id is 'overtime_approval_delegations id';
+comment on column overtime_approval_delegations.created_at is 'Save timestamp when create';
+comment on column overtime_approval_delegations.updated_at is 'Save timestamp when update';
+comment on column overtime_approval_delegations.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column overtime_approval_delegations.organization_id is 'organization id';
+comment on column overtime_approval_delegations.approver_id is 'user id of approver who is away';
+comment on column overtime_approval_delegations.delegate_id is 'user id who approves instead of approver';
+comment on column overtime_approval_delegations.date_from is 'First date of delegation';
+comment on column overtime_approval_delegations.date_to is 'Last date of delegation';
@@ -0,0 +1,4 @@
+alter table user_overtime_requests drop column if exists current_step;
+alter table user_overtime_requests drop column if exists approval_chain_id;
+alter table overtime_request_histories drop constraint if exists overtime_request_histories_overtime_request_id;
+drop table if exists overtime_request_histories;
@@ -0,0 +1,35 @@
+create table if not exists overtime_request_histories(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    overtime_request_id integer not null,
+    step integer not null,
+    action integer not null,
+    status integer not null,
+    actor_id integer not null,
+    on_behalf_of integer default 0 not null,
+    note text
+);
+
+create index index_overtime_request_histories_overtime_request_id on overtime_request_histories (overtime_request_id);
+
+alter table overtime_request_histories add constraint overtime_request_histories_overtime_request_id foreign key (overtime_request_id) references user_overtime_requests (id);
+
+comment on column overtime_request_histories.id is 'overtime_request_histories id';
+comment on column overtime_request_histories.created_at is 'Save timestamp when create';
+comment on column overtime_request_histories.updated_at is 'Save timestamp when update';
+comment on column overtime_request_histories.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column overtime_request_histories.overtime_request_id is 'user_overtime_requests id';
+comment on column overtime_request_histories.step is 'Step of approval chain when action happened';
+comment on column overtime_request_histories.action is 'Action: 1 submit, 2 approve step, 3 deny';
+comment on column overtime_request_histories.status is 'Status of request after action';
+comment on column overtime_request_histories.actor_id is 'user id who did action';
+comment on column overtime_request_histories.on_behalf_of is 'user id of approver when actor is delegate, 0 otherwise';
+comment on column overtime_request_histories.note is 'Note of action';
+
+alter table user_overtime_requests add column if not exists approval_chain_id integer default 0 not null;
+alter table user_overtime_requests add column if not exists current_step integer default 1 not null;
+
+comment on column user_overtime_requests.approval_chain_id is 'overtime_approval_chains id, 0 is single approval by general manager';
+comment on column user_overtime_requests.current_step is 'Step of approval chain waiting for approval';
@@ -0,0 +1,3 @@
+alter table user_overtime_requests drop column if exists exceed_cap;
+alter table overtime_caps drop constraint if exists overtime_caps_organization_id;
+drop table if exists overtime_caps;
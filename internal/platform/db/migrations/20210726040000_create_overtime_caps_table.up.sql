@@ -0,0 +1,27 @@
+create table if not exists overtime_caps(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    daily_hour real,
+    monthly_hour real,
+    yearly_hour real,
+    exceed_action integer default 1 not null
+);
+
+alter table overtime_caps add constraint overtime_caps_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column overtime_caps.id is 'overtime_caps id';
+comment on column overtime_caps.created_at is 'Save timestamp when create';
+comment on column overtime_caps.updated_at is 'Save timestamp when update';
+comment on column overtime_caps.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column overtime_caps.organization_id is 'organization id';
+comment on column overtime_caps.daily_hour is 'Max overtime hours per day, 0 is unlimited';
+comment on column overtime_caps.monthly_hour is 'Max overtime hours per month, 0 is unlimited';
+comment on column overtime_caps.yearly_hour is 'Max overtime hours per year, 0 is unlimited';
+comment on column overtime_caps.exceed_action is 'Action when request exceeds cap: 1 block, 2 flag for general manager override';
+
+alter table user_overtime_requests add column if not exists exceed_cap boolean default false not null;
+
+comment on column user_overtime_requests.exceed_cap is 'Request exceeds overtime cap and needs general manager override';
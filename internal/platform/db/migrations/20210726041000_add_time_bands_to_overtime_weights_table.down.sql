@@ -0,0 +1,7 @@
+alter table overtime_hour_breakdowns drop constraint if exists overtime_hour_breakdowns_overtime_request_id;
+drop table if exists overtime_hour_breakdowns;
+alter table overtime_weights drop column if exists night_end;
+alter table overtime_weights drop column if exists night_start;
+alter table overtime_weights drop column if exists holiday_night_weight;
+alter table overtime_weights drop column if exists weekend_night_weight;
+alter table overtime_weights drop column if exists night_weight;
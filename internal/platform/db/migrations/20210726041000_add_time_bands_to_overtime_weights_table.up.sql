@@ -0,0 +1,37 @@
+alter table overtime_weights add column if not exists night_weight real default 0;
+alter table overtime_weights add column if not exists weekend_night_weight real default 0;
+alter table overtime_weights add column if not exists holiday_night_weight real default 0;
+alter table overtime_weights add column if not exists night_start varchar(5) default '22:00' not null;
+alter table overtime_weights add column if not exists night_end varchar(5) default '06:00' not null;
+
+comment on column overtime_weights.night_weight is 'Weight of night on normal day, 0 is same as normal day weight';
+comment on column overtime_weights.weekend_night_weight is 'Weight of night on weekend, 0 is same as weekend weight';
+comment on column overtime_weights.holiday_night_weight is 'Weight of night on holiday, 0 is same as holiday weight';
+comment on column overtime_weights.night_start is 'Start time of night band (HH:MM)';
+comment on column overtime_weights.night_end is 'End time of night band (HH:MM)';
+
+create table if not exists overtime_hour_breakdowns(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    overtime_request_id integer not null,
+    work_date date not null,
+    band integer not null,
+    hour real not null,
+    weight real not null
+);
+
+create index index_overtime_hour_breakdowns_overtime_request_id on overtime_hour_breakdowns (overtime_request_id);
+
+alter table overtime_hour_breakdowns add constraint overtime_hour_breakdowns_overtime_request_id foreign key (overtime_request_id) references user_overtime_requests (id);
+
+comment on column overtime_hour_breakdowns.id is 'overtime_hour_breakdowns id';
+comment on column overtime_hour_breakdowns.created_at is 'Save timestamp when create';
+comment on column overtime_hour_breakdowns.updated_at is 'Save timestamp when update';
+comment on column overtime_hour_breakdowns.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column overtime_hour_breakdowns.overtime_request_id is 'user_overtime_requests id';
+comment on column overtime_hour_breakdowns.work_date is 'Date which hours belong to, overtime across midnight is split by date';
+comment on column overtime_hour_breakdowns.band is 'Band: 1 normal day, 2 normal night, 3 weekend day, 4 weekend night, 5 holiday day, 6 holiday night';
+comment on column overtime_hour_breakdowns.hour is 'Working hours in band';
+comment on column overtime_hour_breakdowns.weight is 'Weight applied to band';
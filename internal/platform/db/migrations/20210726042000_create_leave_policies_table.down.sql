@@ -0,0 +1,6 @@
+alter table leave_accrual_runs drop constraint if exists leave_accrual_runs_organization_id;
+drop table if exists leave_accrual_runs;
+alter table leave_policy_tiers drop constraint if exists leave_policy_tiers_policy_id;
+drop table if exists leave_policy_tiers;
+alter table leave_policies drop constraint if exists leave_policies_organization_id;
+drop table if exists leave_policies;
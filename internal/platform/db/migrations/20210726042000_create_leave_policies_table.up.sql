@@ -0,0 +1,77 @@
+create table if not exists leave_policies(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    name varchar(255) not null,
+    leave_bonus_type_id integer default 1 not null,
+    accrual_hour real not null,
+    accrual_frequency integer default 1 not null,
+    probation_month integer default 0 not null,
+    carry_over_hour real,
+    expiry_month integer default 0 not null,
+    contract_type_ids integer[]
+);
+
+alter table leave_policies add constraint leave_policies_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column leave_policies.id is 'leave_policies id';
+comment on column leave_policies.created_at is 'Save timestamp when create';
+comment on column leave_policies.updated_at is 'Save timestamp when update';
+comment on column leave_policies.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_policies.organization_id is 'organization id';
+comment on column leave_policies.name is 'Name of policy';
+comment on column leave_policies.leave_bonus_type_id is 'Leave bonus type granted by policy';
+comment on column leave_policies.accrual_hour is 'Hours granted per year';
+comment on column leave_policies.accrual_frequency is 'Frequency: 1 yearly, 2 monthly, 3 yearly pro-rata by join date';
+comment on column leave_policies.probation_month is 'Months after join date when user is not granted';
+comment on column leave_policies.carry_over_hour is 'Max hours carried over to next year, null is unlimited';
+comment on column leave_policies.expiry_month is 'Month of next year when granted hours expire, 0 is organization setting';
+comment on column leave_policies.contract_type_ids is 'Eligible contract types, empty is all';
+
+create table if not exists leave_policy_tiers(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    policy_id integer not null,
+    from_month integer not null,
+    hour real not null
+);
+
+alter table leave_policy_tiers add constraint leave_policy_tiers_policy_id foreign key (policy_id) references leave_policies (id);
+
+comment on column leave_policy_tiers.id is 'leave_policy_tiers id';
+comment on column leave_policy_tiers.created_at is 'Save timestamp when create';
+comment on column leave_policy_tiers.updated_at is 'Save timestamp when update';
+comment on column leave_policy_tiers.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_policy_tiers.policy_id is 'leave_policies id';
+comment on column leave_policy_tiers.from_month is 'Months of seniority from which tier applies';
+comment on column leave_policy_tiers.hour is 'Seniority hours granted per year';
+
+create table if not exists leave_accrual_runs(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    policy_id integer not null,
+    user_id integer not null,
+    period varchar(10) not null,
+    hour real not null
+);
+
+create unique index unique_leave_accrual_runs on leave_accrual_runs (organization_id, policy_id, user_id, period);
+
+alter table leave_accrual_runs add constraint leave_accrual_runs_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column leave_accrual_runs.id is 'leave_accrual_runs id';
+comment on column leave_accrual_runs.created_at is 'Save timestamp when create';
+comment on column leave_accrual_runs.updated_at is 'Save timestamp when update';
+comment on column leave_accrual_runs.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_accrual_runs.organization_id is 'organization id';
+comment on column leave_accrual_runs.policy_id is 'leave_policies id, 0 is default policy';
+comment on column leave_accrual_runs.user_id is 'user id';
+comment on column leave_accrual_runs.period is 'Granted period: YYYY, YYYY-MM or YYYY-carry';
+comment on column leave_accrual_runs.hour is 'Total hours granted in period';
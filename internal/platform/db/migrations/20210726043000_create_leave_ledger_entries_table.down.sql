@@ -0,0 +1,3 @@
+alter table leave_ledger_entries drop constraint if exists leave_ledger_entries_user_id;
+alter table leave_ledger_entries drop constraint if exists leave_ledger_entries_organization_id;
+drop table if exists leave_ledger_entries;
@@ -0,0 +1,53 @@
+create table if not exists leave_ledger_entries(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    user_id integer not null,
+    entry_type integer not null,
+    leave_bonus_type_id integer,
+    year_belong integer not null,
+    hour real not null,
+    effective_date date not null,
+    source_type integer not null,
+    source_id integer,
+    note text,
+    created_by integer
+);
+
+create index leave_ledger_entries_user_id on leave_ledger_entries (organization_id, user_id, effective_date);
+
+alter table leave_ledger_entries add constraint leave_ledger_entries_organization_id foreign key (organization_id) references organizations (id);
+alter table leave_ledger_entries add constraint leave_ledger_entries_user_id foreign key (user_id) references users (id);
+
+comment on column leave_ledger_entries.id is 'leave_ledger_entries id';
+comment on column leave_ledger_entries.created_at is 'Save timestamp when create';
+comment on column leave_ledger_entries.updated_at is 'Save timestamp when update';
+comment on column leave_ledger_entries.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_ledger_entries.organization_id is 'organization id';
+comment on column leave_ledger_entries.user_id is 'user id';
+comment on column leave_ledger_entries.entry_type is 'Type: 1 grant, 2 usage, 3 expiry, 4 adjustment, 5 overtime conversion, 6 reversal';
+comment on column leave_ledger_entries.leave_bonus_type_id is 'Leave bonus type of grant, null for usage';
+comment on column leave_ledger_entries.year_belong is 'Year which hours belong to';
+comment on column leave_ledger_entries.hour is 'Signed hours, positive adds to balance and negative subtracts';
+comment on column leave_ledger_entries.effective_date is 'Date from which entry counts in balance';
+comment on column leave_ledger_entries.source_type is 'Source: 1 leave bonus, 2 leave request, 3 overtime request, 4 accrual run, 5 recompute';
+comment on column leave_ledger_entries.source_id is 'Id of source record';
+comment on column leave_ledger_entries.note is 'Note of entry';
+comment on column leave_ledger_entries.created_by is 'user id who creates entry';
+
+insert into leave_ledger_entries (created_at, updated_at, organization_id, user_id, entry_type, leave_bonus_type_id,
+    year_belong, hour, effective_date, source_type, source_id, note, created_by)
+select ulb.created_at, ulb.created_at, ulb.organization_id, ulb.user_id,
+    case when ulb.leave_bonus_type_id = 7 then 3 when ulb.leave_bonus_type_id = 8 then 5 else 1 end,
+    ulb.leave_bonus_type_id, ulb.year_belong, ulb.hour, date(ulb.created_at), 1, ulb.id, ulb.reason, ulb.created_by
+from user_leave_bonus as ulb
+where ulb.deleted_at is null;
+
+insert into leave_ledger_entries (created_at, updated_at, organization_id, user_id, entry_type,
+    year_belong, hour, effective_date, source_type, source_id, note, created_by)
+select ulr.created_at, ulr.created_at, ulr.organization_id, ulr.user_id, 2,
+    date_part('year', ulr.datetime_leave_from), -ulr.hour, date(ulr.datetime_leave_from), 2, ulr.id, ulr.reason, ulr.created_by
+from user_leave_requests as ulr
+where ulr.deleted_at is null and ulr.hour is not null;
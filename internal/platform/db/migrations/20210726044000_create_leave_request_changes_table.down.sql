@@ -0,0 +1,3 @@
+alter table leave_request_changes drop constraint if exists leave_request_changes_leave_request_id;
+alter table leave_request_changes drop constraint if exists leave_request_changes_organization_id;
+drop table if exists leave_request_changes;
@@ -0,0 +1,38 @@
+create table if not exists leave_request_changes(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    leave_request_id integer not null,
+    user_id integer not null,
+    requested_by integer not null,
+    change_type integer not null,
+    datetime_leave_from timestamp,
+    datetime_leave_to timestamp,
+    hour real,
+    reason text,
+    status integer default 1 not null,
+    approved_by integer,
+    approved_at timestamp
+);
+
+alter table leave_request_changes add constraint leave_request_changes_organization_id foreign key (organization_id) references organizations (id);
+alter table leave_request_changes add constraint leave_request_changes_leave_request_id foreign key (leave_request_id) references user_leave_requests (id);
+
+comment on column leave_request_changes.id is 'leave_request_changes id';
+comment on column leave_request_changes.created_at is 'Save timestamp when create';
+comment on column leave_request_changes.updated_at is 'Save timestamp when update';
+comment on column leave_request_changes.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_request_changes.organization_id is 'organization id';
+comment on column leave_request_changes.leave_request_id is 'user_leave_requests id';
+comment on column leave_request_changes.user_id is 'user id who owns leave request';
+comment on column leave_request_changes.requested_by is 'user id who requests change';
+comment on column leave_request_changes.change_type is 'Type: 1 cancel, 2 amend';
+comment on column leave_request_changes.datetime_leave_from is 'New leave from of amendment';
+comment on column leave_request_changes.datetime_leave_to is 'New leave to of amendment';
+comment on column leave_request_changes.hour is 'New leave hour of amendment';
+comment on column leave_request_changes.reason is 'Reason of change';
+comment on column leave_request_changes.status is 'Status: 1 pending, 2 deny, 3 accept';
+comment on column leave_request_changes.approved_by is 'user id who approves or denies change';
+comment on column leave_request_changes.approved_at is 'Timestamp when change is approved or denied';
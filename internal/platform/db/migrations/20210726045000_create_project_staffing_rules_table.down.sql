@@ -0,0 +1,3 @@
+alter table project_staffing_rules drop constraint if exists project_staffing_rules_project_id;
+alter table project_staffing_rules drop constraint if exists project_staffing_rules_organization_id;
+drop table if exists project_staffing_rules;
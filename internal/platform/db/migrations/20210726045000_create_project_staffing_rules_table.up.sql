@@ -0,0 +1,24 @@
+create table if not exists project_staffing_rules(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    project_id integer not null,
+    min_staff integer not null,
+    conflict_action integer default 1 not null
+);
+
+create unique index unique_project_staffing_rules_project_id on project_staffing_rules (project_id);
+
+alter table project_staffing_rules add constraint project_staffing_rules_organization_id foreign key (organization_id) references organizations (id);
+alter table project_staffing_rules add constraint project_staffing_rules_project_id foreign key (project_id) references projects (id);
+
+comment on column project_staffing_rules.id is 'project_staffing_rules id';
+comment on column project_staffing_rules.created_at is 'Save timestamp when create';
+comment on column project_staffing_rules.updated_at is 'Save timestamp when update';
+comment on column project_staffing_rules.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column project_staffing_rules.organization_id is 'organization id';
+comment on column project_staffing_rules.project_id is 'projects id';
+comment on column project_staffing_rules.min_staff is 'Minimum members of project who are not off on a working day';
+comment on column project_staffing_rules.conflict_action is 'Action when leave conflicts with rule or task deadline: 1 warn, 2 block';
@@ -0,0 +1,2 @@
+alter table statutory_leave_rules drop constraint if exists statutory_leave_rules_organization_id;
+drop table if exists statutory_leave_rules;
@@ -0,0 +1,29 @@
+create table if not exists statutory_leave_rules(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    leave_bonus_type_id integer not null,
+    entitlement_day real default 0 not null,
+    is_calendar_day boolean default false not null,
+    max_month integer default 0 not null,
+    required_documents text[],
+    count_against_annual boolean default false not null
+);
+
+create unique index unique_statutory_leave_rules_organization_id_leave_bonus_type_id on statutory_leave_rules (organization_id, leave_bonus_type_id);
+
+alter table statutory_leave_rules add constraint statutory_leave_rules_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column statutory_leave_rules.id is 'statutory_leave_rules id';
+comment on column statutory_leave_rules.created_at is 'Save timestamp when create';
+comment on column statutory_leave_rules.updated_at is 'Save timestamp when update';
+comment on column statutory_leave_rules.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column statutory_leave_rules.organization_id is 'organization id';
+comment on column statutory_leave_rules.leave_bonus_type_id is 'Leave bonus type: 3 sick, 4 marry, 5 maternity, 6 bereavement';
+comment on column statutory_leave_rules.entitlement_day is 'Days entitled per event, 0 is unlimited within max_month';
+comment on column statutory_leave_rules.is_calendar_day is 'Count calendar days instead of working days';
+comment on column statutory_leave_rules.max_month is 'Max months which one leave can span, 0 is not limited';
+comment on column statutory_leave_rules.required_documents is 'Names of supporting documents which must be uploaded';
+comment on column statutory_leave_rules.count_against_annual is 'Leave is subtracted from annual leave balance';
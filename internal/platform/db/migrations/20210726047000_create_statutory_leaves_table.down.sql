@@ -0,0 +1,3 @@
+alter table statutory_leaves drop constraint if exists statutory_leaves_leave_request_id;
+alter table statutory_leaves drop constraint if exists statutory_leaves_organization_id;
+drop table if exists statutory_leaves;
@@ -0,0 +1,38 @@
+create table if not exists statutory_leaves(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    user_id integer not null,
+    leave_bonus_type_id integer not null,
+    leave_request_id integer not null,
+    event_date date not null,
+    datetime_leave_from timestamp not null,
+    datetime_leave_to timestamp not null,
+    day real not null,
+    hour real,
+    count_against_annual boolean default false not null,
+    reason text,
+    created_by integer not null
+);
+
+alter table statutory_leaves add constraint statutory_leaves_organization_id foreign key (organization_id) references organizations (id);
+alter table statutory_leaves add constraint statutory_leaves_leave_request_id foreign key (leave_request_id) references user_leave_requests (id);
+
+comment on column statutory_leaves.id is 'statutory_leaves id';
+comment on column statutory_leaves.created_at is 'Save timestamp when create';
+comment on column statutory_leaves.updated_at is 'Save timestamp when update';
+comment on column statutory_leaves.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column statutory_leaves.organization_id is 'organization id';
+comment on column statutory_leaves.user_id is 'user id who takes leave';
+comment on column statutory_leaves.leave_bonus_type_id is 'Leave bonus type of statutory leave rule';
+comment on column statutory_leaves.leave_request_id is 'user_leave_requests id created for this leave';
+comment on column statutory_leaves.event_date is 'Date of event which leave is entitled for, as wedding or birth date';
+comment on column statutory_leaves.datetime_leave_from is 'Leave start';
+comment on column statutory_leaves.datetime_leave_to is 'Leave end';
+comment on column statutory_leaves.day is 'Days counted by rule';
+comment on column statutory_leaves.hour is 'Working hours within leave';
+comment on column statutory_leaves.count_against_annual is 'Leave was subtracted from annual leave balance';
+comment on column statutory_leaves.reason is 'Reason of leave';
+comment on column statutory_leaves.created_by is 'user id who created leave';
@@ -0,0 +1,2 @@
+alter table statutory_leave_documents drop constraint if exists statutory_leave_documents_statutory_leave_id;
+drop table if exists statutory_leave_documents;
@@ -0,0 +1,21 @@
+create table if not exists statutory_leave_documents(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    statutory_leave_id integer not null,
+    document_name varchar(255) not null,
+    file_name varchar(255) not null,
+    created_by integer not null
+);
+
+alter table statutory_leave_documents add constraint statutory_leave_documents_statutory_leave_id foreign key (statutory_leave_id) references statutory_leaves (id);
+
+comment on column statutory_leave_documents.id is 'statutory_leave_documents id';
+comment on column statutory_leave_documents.created_at is 'Save timestamp when create';
+comment on column statutory_leave_documents.updated_at is 'Save timestamp when update';
+comment on column statutory_leave_documents.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column statutory_leave_documents.statutory_leave_id is 'statutory_leaves id';
+comment on column statutory_leave_documents.document_name is 'Name of required document, as marriage certificate';
+comment on column statutory_leave_documents.file_name is 'File name in cloud storage';
+comment on column statutory_leave_documents.created_by is 'user id who uploaded document';
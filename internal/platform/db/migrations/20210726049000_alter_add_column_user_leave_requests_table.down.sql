@@ -0,0 +1,5 @@
+drop index if exists index_user_leave_requests_delegate_user_id;
+alter table user_leave_requests drop constraint if exists user_leave_requests_delegate_user_id;
+alter table user_leave_requests drop column delegate_notified_at;
+alter table user_leave_requests drop column handover_note;
+alter table user_leave_requests drop column delegate_user_id;
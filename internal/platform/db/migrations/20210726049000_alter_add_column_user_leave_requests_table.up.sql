@@ -0,0 +1,9 @@
+alter table user_leave_requests add column delegate_user_id integer;
+alter table user_leave_requests add column handover_note text;
+alter table user_leave_requests add column delegate_notified_at timestamp;
+alter table user_leave_requests add constraint user_leave_requests_delegate_user_id foreign key (delegate_user_id) references users (id);
+create index index_user_leave_requests_delegate_user_id on user_leave_requests (delegate_user_id);
+
+comment on column user_leave_requests.delegate_user_id is 'user id who covers tasks and approvals during leave';
+comment on column user_leave_requests.handover_note is 'Hand-over note for delegate';
+comment on column user_leave_requests.delegate_notified_at is 'Timestamp when delegate was notified that leave started';
@@ -0,0 +1,2 @@
+alter table leave_batches drop constraint if exists leave_batches_organization_id;
+drop table if exists leave_batches;
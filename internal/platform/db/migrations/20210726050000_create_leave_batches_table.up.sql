@@ -0,0 +1,39 @@
+create table if not exists leave_batches(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    branch_id integer,
+    leave_request_type_id integer not null,
+    datetime_leave_from timestamp not null,
+    datetime_leave_to timestamp not null,
+    subtract_day_off_type_id integer not null,
+    reason text not null,
+    hour real,
+    user_count integer default 0 not null,
+    calendar_event_id varchar(255),
+    created_by integer not null,
+    undone_by integer,
+    undone_at timestamp
+);
+
+alter table leave_batches add constraint leave_batches_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column leave_batches.id is 'leave_batches id';
+comment on column leave_batches.created_at is 'Save timestamp when create';
+comment on column leave_batches.updated_at is 'Save timestamp when update';
+comment on column leave_batches.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_batches.organization_id is 'organization id';
+comment on column leave_batches.branch_id is 'Branch whose members take leave, null is whole organization';
+comment on column leave_batches.leave_request_type_id is 'Leave type: 1 full day, 2 morning, 3 afternoon';
+comment on column leave_batches.datetime_leave_from is 'Leave start';
+comment on column leave_batches.datetime_leave_to is 'Leave end';
+comment on column leave_batches.subtract_day_off_type_id is 'Subtract day off type, leave is deducted from annual leave only when 1';
+comment on column leave_batches.reason is 'Reason of leave, as company trip or office closure';
+comment on column leave_batches.hour is 'Hours subtracted from each user';
+comment on column leave_batches.user_count is 'Number of users whose leave request was created';
+comment on column leave_batches.calendar_event_id is 'Google calendar event of batch';
+comment on column leave_batches.created_by is 'user id who created batch';
+comment on column leave_batches.undone_by is 'user id who undid batch';
+comment on column leave_batches.undone_at is 'Timestamp when batch was undone';
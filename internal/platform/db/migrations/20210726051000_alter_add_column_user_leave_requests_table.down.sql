@@ -0,0 +1,3 @@
+drop index if exists index_user_leave_requests_leave_batch_id;
+alter table user_leave_requests drop constraint if exists user_leave_requests_leave_batch_id;
+alter table user_leave_requests drop column leave_batch_id;
@@ -0,0 +1,5 @@
+alter table user_leave_requests add column leave_batch_id integer;
+alter table user_leave_requests add constraint user_leave_requests_leave_batch_id foreign key (leave_batch_id) references leave_batches (id);
+create index index_user_leave_requests_leave_batch_id on user_leave_requests (leave_batch_id);
+
+comment on column user_leave_requests.leave_batch_id is 'leave_batches id when leave was created in bulk';
@@ -0,0 +1,2 @@
+alter table leave_settlements drop constraint if exists leave_settlements_organization_id;
+drop table if exists leave_settlements;
@@ -0,0 +1,31 @@
+create table if not exists leave_settlements(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    year integer not null,
+    payout_rate real default 0 not null,
+    max_payout_hour real,
+    user_count integer default 0 not null,
+    total_payout_amount real default 0 not null,
+    signed_by integer not null,
+    signed_at timestamp not null
+);
+
+create unique index unique_leave_settlements_organization_id_year on leave_settlements (organization_id, year);
+
+alter table leave_settlements add constraint leave_settlements_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column leave_settlements.id is 'leave_settlements id';
+comment on column leave_settlements.created_at is 'Save timestamp when create';
+comment on column leave_settlements.updated_at is 'Save timestamp when update';
+comment on column leave_settlements.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_settlements.organization_id is 'organization id';
+comment on column leave_settlements.year is 'Year of leave which is settled';
+comment on column leave_settlements.payout_rate is 'Amount paid for one unused hour';
+comment on column leave_settlements.max_payout_hour is 'Max hours paid out for each user, null is not limited';
+comment on column leave_settlements.user_count is 'Number of users who had unused hours';
+comment on column leave_settlements.total_payout_amount is 'Sum of payout amount of all users';
+comment on column leave_settlements.signed_by is 'General manager who signed off settlement';
+comment on column leave_settlements.signed_at is 'Timestamp when settlement was signed off';
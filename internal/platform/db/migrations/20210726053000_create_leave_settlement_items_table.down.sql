@@ -0,0 +1,2 @@
+alter table leave_settlement_items drop constraint if exists leave_settlement_items_leave_settlement_id;
+drop table if exists leave_settlement_items;
@@ -0,0 +1,27 @@
+create table if not exists leave_settlement_items(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    leave_settlement_id integer not null,
+    user_id integer not null,
+    unused_hour real default 0 not null,
+    carry_over_hour real default 0 not null,
+    payout_hour real default 0 not null,
+    payout_amount real default 0 not null,
+    forfeit_hour real default 0 not null
+);
+
+alter table leave_settlement_items add constraint leave_settlement_items_leave_settlement_id foreign key (leave_settlement_id) references leave_settlements (id);
+
+comment on column leave_settlement_items.id is 'leave_settlement_items id';
+comment on column leave_settlement_items.created_at is 'Save timestamp when create';
+comment on column leave_settlement_items.updated_at is 'Save timestamp when update';
+comment on column leave_settlement_items.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column leave_settlement_items.leave_settlement_id is 'leave_settlements id';
+comment on column leave_settlement_items.user_id is 'user id';
+comment on column leave_settlement_items.unused_hour is 'Hours of year which were not used';
+comment on column leave_settlement_items.carry_over_hour is 'Hours moved to next year within carry-over cap of leave policy';
+comment on column leave_settlement_items.payout_hour is 'Hours paid out';
+comment on column leave_settlement_items.payout_amount is 'payout_hour multiplied by payout rate';
+comment on column leave_settlement_items.forfeit_hour is 'Hours which are neither carried over nor paid out';
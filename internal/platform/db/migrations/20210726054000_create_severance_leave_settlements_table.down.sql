@@ -0,0 +1,2 @@
+alter table severance_leave_settlements drop constraint if exists severance_leave_settlements_organization_id;
+drop table if exists severance_leave_settlements;
@@ -0,0 +1,35 @@
+create table if not exists severance_leave_settlements(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    user_id integer not null,
+    date_severance date not null,
+    balance_hour real default 0 not null,
+    unearned_hour real default 0 not null,
+    final_hour real default 0 not null,
+    payout_rate real default 0 not null,
+    payout_amount real default 0 not null,
+    cancelled_leave_count integer default 0 not null,
+    settled_by integer not null
+);
+
+create unique index unique_severance_leave_settlements_user_id on severance_leave_settlements (user_id);
+
+alter table severance_leave_settlements add constraint severance_leave_settlements_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column severance_leave_settlements.id is 'severance_leave_settlements id';
+comment on column severance_leave_settlements.created_at is 'Save timestamp when create';
+comment on column severance_leave_settlements.updated_at is 'Save timestamp when update';
+comment on column severance_leave_settlements.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column severance_leave_settlements.organization_id is 'organization id';
+comment on column severance_leave_settlements.user_id is 'User who left the company';
+comment on column severance_leave_settlements.date_severance is 'Last working date, leave after this date is cancelled';
+comment on column severance_leave_settlements.balance_hour is 'Leave balance after future leave was cancelled';
+comment on column severance_leave_settlements.unearned_hour is 'Part of yearly grant for months after severance which is taken back';
+comment on column severance_leave_settlements.final_hour is 'Pro-rata balance which is paid out, negative is deducted from salary';
+comment on column severance_leave_settlements.payout_rate is 'Amount paid for one hour';
+comment on column severance_leave_settlements.payout_amount is 'final_hour multiplied by payout rate';
+comment on column severance_leave_settlements.cancelled_leave_count is 'Number of future leave requests which were cancelled';
+comment on column severance_leave_settlements.settled_by is 'user id who settled leave';
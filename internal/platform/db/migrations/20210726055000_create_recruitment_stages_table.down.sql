@@ -0,0 +1,2 @@
+alter table recruitment_stages drop constraint if exists recruitment_stages_organization_id;
+drop table if exists recruitment_stages;
@@ -0,0 +1,31 @@
+create table if not exists recruitment_stages(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    recruitment_id integer,
+    name varchar(255) not null,
+    stage_type smallint not null,
+    position integer not null,
+    cv_status smallint not null,
+    next_positions integer[],
+    reject_reasons text[]
+);
+
+create index index_recruitment_stages_organization_id_recruitment_id on recruitment_stages (organization_id, recruitment_id);
+
+alter table recruitment_stages add constraint recruitment_stages_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column recruitment_stages.id is 'recruitment_stages id';
+comment on column recruitment_stages.created_at is 'Save timestamp when create';
+comment on column recruitment_stages.updated_at is 'Save timestamp when update';
+comment on column recruitment_stages.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column recruitment_stages.organization_id is 'organization id';
+comment on column recruitment_stages.recruitment_id is 'Recruitment which uses stage, null is default pipeline of organization';
+comment on column recruitment_stages.name is 'Name of stage, as coding test or round 2';
+comment on column recruitment_stages.stage_type is 'Stage type: 1 screening, 2 test, 3 interview, 4 offer, 5 hired, 6 rejected';
+comment on column recruitment_stages.position is 'Order of stage in pipeline, starting from 1';
+comment on column recruitment_stages.cv_status is 'Status of cv which is set when cv enters stage';
+comment on column recruitment_stages.next_positions is 'Positions of stages which cv can move to, empty is any stage';
+comment on column recruitment_stages.reject_reasons is 'Reasons which can be chosen when cv is rejected in stage';
@@ -0,0 +1,2 @@
+alter table cvs drop column if exists stage_id;
+alter table cvs drop column if exists stage_entered_at;
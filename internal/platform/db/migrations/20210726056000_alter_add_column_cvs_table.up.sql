@@ -0,0 +1,5 @@
+alter table cvs add column stage_id integer;
+alter table cvs add column stage_entered_at timestamp;
+
+comment on column cvs.stage_id is 'Current pipeline stage of cv, null is first stage';
+comment on column cvs.stage_entered_at is 'Timestamp when cv entered current stage';
@@ -0,0 +1,3 @@
+alter table log_cv_states drop column if exists stage_id;
+alter table log_cv_states drop column if exists reject_reason;
+alter table log_cv_states drop column if exists created_by;
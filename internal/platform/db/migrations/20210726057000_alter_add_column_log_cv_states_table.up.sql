@@ -0,0 +1,7 @@
+alter table log_cv_states add column stage_id integer;
+alter table log_cv_states add column reject_reason text;
+alter table log_cv_states add column created_by integer;
+
+comment on column log_cv_states.stage_id is 'Pipeline stage which cv moved to';
+comment on column log_cv_states.reject_reason is 'Reason when cv moved to rejected stage';
+comment on column log_cv_states.created_by is 'user id who moved cv';
@@ -0,0 +1,3 @@
+alter table interviews drop constraint if exists interviews_cv_id;
+alter table interviews drop constraint if exists interviews_organization_id;
+drop table if exists interviews;
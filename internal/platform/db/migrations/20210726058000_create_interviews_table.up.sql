@@ -0,0 +1,49 @@
+create table if not exists interviews(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    recruitment_id integer not null,
+    cv_id integer not null,
+    round integer not null,
+    interviewers integer[] not null,
+    start_time timestamp not null,
+    end_time timestamp not null,
+    location varchar(255),
+    meeting_link text,
+    note text,
+    status smallint not null,
+    outcome smallint,
+    outcome_note text,
+    cancel_reason text,
+    sequence integer not null default 0,
+    created_by integer not null
+);
+
+create index index_interviews_cv_id on interviews (cv_id);
+create index index_interviews_organization_id_start_time on interviews (organization_id, start_time);
+
+alter table interviews add constraint interviews_organization_id foreign key (organization_id) references organizations (id);
+alter table interviews add constraint interviews_cv_id foreign key (cv_id) references cvs (id);
+
+comment on column interviews.id is 'interviews id';
+comment on column interviews.created_at is 'Save timestamp when create';
+comment on column interviews.updated_at is 'Save timestamp when update';
+comment on column interviews.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column interviews.organization_id is 'organization id';
+comment on column interviews.recruitment_id is 'recruitment id';
+comment on column interviews.cv_id is 'Cv of candidate who is interviewed';
+comment on column interviews.round is 'Interview round, starting from 1';
+comment on column interviews.interviewers is 'User ids of interview panel';
+comment on column interviews.start_time is 'Start time of interview slot';
+comment on column interviews.end_time is 'End time of interview slot';
+comment on column interviews.location is 'Place of interview';
+comment on column interviews.meeting_link is 'Link of online meeting';
+comment on column interviews.note is 'Note which is sent to candidate and panel';
+comment on column interviews.status is 'Interview status: 1 scheduled, 2 completed, 3 cancelled';
+comment on column interviews.outcome is 'Interview outcome: 1 pass, 2 fail, 3 no show';
+comment on column interviews.outcome_note is 'Feedback of panel about outcome';
+comment on column interviews.cancel_reason is 'Reason when interview is cancelled';
+comment on column interviews.sequence is 'Sequence of icalendar invite, increased when interview is rescheduled or cancelled';
+comment on column interviews.created_by is 'User who scheduled interview';
@@ -0,0 +1,2 @@
+alter table scorecard_templates drop constraint if exists scorecard_templates_organization_id;
+drop table if exists scorecard_templates;
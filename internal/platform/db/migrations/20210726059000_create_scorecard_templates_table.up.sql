@@ -0,0 +1,21 @@
+create table if not exists scorecard_templates(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    recruitment_id integer not null,
+    competencies jsonb
+);
+
+create unique index unique_scorecard_templates_recruitment_id on scorecard_templates (recruitment_id) where deleted_at is null;
+
+alter table scorecard_templates add constraint scorecard_templates_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column scorecard_templates.id is 'scorecard_templates id';
+comment on column scorecard_templates.created_at is 'Save timestamp when create';
+comment on column scorecard_templates.updated_at is 'Save timestamp when update';
+comment on column scorecard_templates.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column scorecard_templates.organization_id is 'organization id';
+comment on column scorecard_templates.recruitment_id is 'Recruitment which uses template';
+comment on column scorecard_templates.competencies is 'Competencies which are rated from 1 to 5: name, description, note_required';
@@ -0,0 +1,3 @@
+alter table scorecards drop constraint if exists scorecards_interview_id;
+alter table scorecards drop constraint if exists scorecards_cv_id;
+drop table if exists scorecards;
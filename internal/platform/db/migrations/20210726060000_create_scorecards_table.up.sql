@@ -0,0 +1,38 @@
+create table if not exists scorecards(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    recruitment_id integer not null,
+    cv_id integer not null,
+    interview_id integer not null,
+    round integer not null,
+    interviewer_id integer not null,
+    ratings jsonb,
+    recommendation smallint,
+    note text,
+    status smallint not null,
+    submitted_at timestamp
+);
+
+create unique index unique_scorecards_cv_id_round_interviewer_id on scorecards (cv_id, round, interviewer_id) where deleted_at is null;
+
+alter table scorecards add constraint scorecards_cv_id foreign key (cv_id) references cvs (id);
+alter table scorecards add constraint scorecards_interview_id foreign key (interview_id) references interviews (id);
+
+comment on column scorecards.id is 'scorecards id';
+comment on column scorecards.created_at is 'Save timestamp when create';
+comment on column scorecards.updated_at is 'Save timestamp when update';
+comment on column scorecards.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column scorecards.organization_id is 'organization id';
+comment on column scorecards.recruitment_id is 'recruitment id';
+comment on column scorecards.cv_id is 'Cv of candidate who is rated';
+comment on column scorecards.interview_id is 'Interview which scorecard is submitted for';
+comment on column scorecards.round is 'Interview round';
+comment on column scorecards.interviewer_id is 'User who rates candidate';
+comment on column scorecards.ratings is 'Score from 1 to 5 and note of each competency: name, score, note';
+comment on column scorecards.recommendation is 'Recommendation: 1 strong no, 2 no, 3 yes, 4 strong yes';
+comment on column scorecards.note is 'Overall note of interviewer';
+comment on column scorecards.status is 'Scorecard status: 1 draft, 2 submitted';
+comment on column scorecards.submitted_at is 'Time when scorecard is submitted, other scorecards of round are visible after it';
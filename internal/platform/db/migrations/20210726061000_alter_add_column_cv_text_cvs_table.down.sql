@@ -0,0 +1,2 @@
+drop index if exists index_cvs_cv_text;
+alter table cvs drop column if exists cv_text;
@@ -0,0 +1,5 @@
+alter table cvs add column cv_text text;
+
+create index index_cvs_cv_text on cvs using gin (to_tsvector('simple', coalesce(cv_text, '')));
+
+comment on column cvs.cv_text is 'Text which is extracted from pdf or docx file of cv, used for search';
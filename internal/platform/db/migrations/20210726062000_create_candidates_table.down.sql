@@ -0,0 +1,2 @@
+alter table candidates drop constraint if exists candidates_organization_id;
+drop table if exists candidates;
@@ -0,0 +1,32 @@
+create table if not exists candidates(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    full_name varchar(255),
+    email varchar(255),
+    phone_number varchar(50),
+    normalized_email varchar(255),
+    normalized_phone varchar(50),
+    normalized_name varchar(255),
+    merged_into_id integer
+);
+
+create index index_candidates_organization_id_normalized_email on candidates (organization_id, normalized_email);
+create index index_candidates_organization_id_normalized_phone on candidates (organization_id, normalized_phone);
+
+alter table candidates add constraint candidates_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column candidates.id is 'candidates id';
+comment on column candidates.created_at is 'Save timestamp when create';
+comment on column candidates.updated_at is 'Save timestamp when update';
+comment on column candidates.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column candidates.organization_id is 'organization id';
+comment on column candidates.full_name is 'Full name of candidate';
+comment on column candidates.email is 'Email of candidate';
+comment on column candidates.phone_number is 'Phone number of candidate';
+comment on column candidates.normalized_email is 'Lower case email without +tag and gmail dots, used for matching';
+comment on column candidates.normalized_phone is 'Phone number in 10 digit domestic format, used for matching';
+comment on column candidates.normalized_name is 'Unaccented lower case name with sorted words, used for fuzzy matching';
+comment on column candidates.merged_into_id is 'Candidate which this candidate was merged into, this record is deleted logic when merged';
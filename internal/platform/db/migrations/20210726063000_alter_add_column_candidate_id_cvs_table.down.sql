@@ -0,0 +1,3 @@
+alter table cvs drop constraint if exists cvs_candidate_id;
+drop index if exists index_cvs_candidate_id;
+alter table cvs drop column if exists candidate_id;
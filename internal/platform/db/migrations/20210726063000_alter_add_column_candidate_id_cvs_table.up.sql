@@ -0,0 +1,7 @@
+alter table cvs add column candidate_id integer;
+
+create index index_cvs_candidate_id on cvs (candidate_id);
+
+alter table cvs add constraint cvs_candidate_id foreign key (candidate_id) references candidates (id);
+
+comment on column cvs.candidate_id is 'Candidate who is shared by cvs of the same person across recruitments';
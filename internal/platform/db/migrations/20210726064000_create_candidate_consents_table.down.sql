@@ -0,0 +1,3 @@
+alter table candidate_consents drop constraint if exists candidate_consents_cv_id;
+alter table candidate_consents drop constraint if exists candidate_consents_organization_id;
+drop table if exists candidate_consents;
@@ -0,0 +1,30 @@
+create table if not exists candidate_consents(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    cv_id integer not null,
+    candidate_id integer,
+    consent_text text not null,
+    ip_address varchar(45),
+    user_agent text,
+    consented_at timestamp not null
+);
+
+create index index_candidate_consents_cv_id on candidate_consents (cv_id);
+
+alter table candidate_consents add constraint candidate_consents_organization_id foreign key (organization_id) references organizations (id);
+alter table candidate_consents add constraint candidate_consents_cv_id foreign key (cv_id) references cvs (id);
+
+comment on column candidate_consents.id is 'candidate_consents id';
+comment on column candidate_consents.created_at is 'Save timestamp when create';
+comment on column candidate_consents.updated_at is 'Save timestamp when update';
+comment on column candidate_consents.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column candidate_consents.organization_id is 'organization id';
+comment on column candidate_consents.cv_id is 'Cv which candidate applied with';
+comment on column candidate_consents.candidate_id is 'Candidate who gave consent';
+comment on column candidate_consents.consent_text is 'Consent statement which candidate agreed to on careers page';
+comment on column candidate_consents.ip_address is 'Ip address of candidate when consent was given';
+comment on column candidate_consents.user_agent is 'Browser of candidate when consent was given';
+comment on column candidate_consents.consented_at is 'Time when consent was given';
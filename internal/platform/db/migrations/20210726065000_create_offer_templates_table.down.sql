@@ -0,0 +1,2 @@
+alter table offer_templates drop constraint if exists offer_templates_organization_id;
+drop table if exists offer_templates;
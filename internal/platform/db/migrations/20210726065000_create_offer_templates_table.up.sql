@@ -0,0 +1,19 @@
+create table if not exists offer_templates(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    content text not null
+);
+
+create unique index unique_offer_templates_organization_id on offer_templates (organization_id) where deleted_at is null;
+
+alter table offer_templates add constraint offer_templates_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column offer_templates.id is 'offer_templates id';
+comment on column offer_templates.created_at is 'Save timestamp when create';
+comment on column offer_templates.updated_at is 'Save timestamp when update';
+comment on column offer_templates.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column offer_templates.organization_id is 'organization id';
+comment on column offer_templates.content is 'Html of offer letter with placeholders as {{candidate_name}} which are replaced by fields of candidate and job';
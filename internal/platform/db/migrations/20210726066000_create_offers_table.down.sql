@@ -0,0 +1,5 @@
+alter table offers drop constraint if exists offers_organization_id;
+alter table offers drop constraint if exists offers_recruitment_id;
+alter table offers drop constraint if exists offers_cv_id;
+alter table offers drop constraint if exists offers_contract_type_id;
+drop table if exists offers;
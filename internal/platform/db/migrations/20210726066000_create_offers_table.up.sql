@@ -0,0 +1,57 @@
+create table if not exists offers(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    recruitment_id integer not null,
+    cv_id integer not null,
+    candidate_id integer,
+    job_title varchar(255) not null,
+    salary bigint not null,
+    currency_unit integer,
+    contract_type_id integer not null,
+    start_date date not null,
+    expired_date date not null,
+    content text not null,
+    token varchar(255),
+    status smallint not null,
+    sent_at timestamp,
+    responded_at timestamp,
+    decline_reason text,
+    user_id integer,
+    contract_id integer,
+    created_by integer not null
+);
+
+create index index_offers_cv_id on offers (cv_id);
+create unique index unique_offers_token on offers (token);
+
+alter table offers add constraint offers_organization_id foreign key (organization_id) references organizations (id);
+alter table offers add constraint offers_recruitment_id foreign key (recruitment_id) references recruitments (id);
+alter table offers add constraint offers_cv_id foreign key (cv_id) references cvs (id);
+alter table offers add constraint offers_contract_type_id foreign key (contract_type_id) references contract_types (id);
+
+comment on column offers.id is 'offers id';
+comment on column offers.created_at is 'Save timestamp when create';
+comment on column offers.updated_at is 'Save timestamp when update';
+comment on column offers.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column offers.organization_id is 'organization id';
+comment on column offers.recruitment_id is 'Recruitment of cv';
+comment on column offers.cv_id is 'Cv which passed final and is offered';
+comment on column offers.candidate_id is 'Candidate of cv';
+comment on column offers.job_title is 'Job title which is offered';
+comment on column offers.salary is 'Salary which is offered, it is used as insurance salary of draft contract';
+comment on column offers.currency_unit is 'Currency unit of salary';
+comment on column offers.contract_type_id is 'Type of contract which is drafted when offer is accepted';
+comment on column offers.start_date is 'Date when candidate starts working';
+comment on column offers.expired_date is 'Last date when candidate can respond';
+comment on column offers.content is 'Offer letter which was generated from offer template';
+comment on column offers.token is 'Hash of token of link which candidate responds to offer with';
+comment on column offers.status is '1: draft, 2: sent, 3: accepted, 4: declined, 5: withdrawn';
+comment on column offers.sent_at is 'Time when offer was sent to candidate';
+comment on column offers.responded_at is 'Time when offer was accepted or declined';
+comment on column offers.decline_reason is 'Reason which candidate declined with';
+comment on column offers.user_id is 'User who was created from candidate when offer was accepted';
+comment on column offers.contract_id is 'Draft contract which was created when offer was accepted';
+comment on column offers.created_by is 'User who created offer';
@@ -0,0 +1,4 @@
+alter table onboardings drop constraint if exists onboardings_organization_id;
+alter table onboardings drop constraint if exists onboardings_user_id;
+alter table onboardings drop constraint if exists onboardings_offer_id;
+drop table if exists onboardings;
@@ -0,0 +1,38 @@
+create table if not exists onboardings(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    user_id integer not null,
+    offer_id integer not null,
+    cv_id integer not null,
+    contract_id integer,
+    cv_file_name varchar(255),
+    cv_file_path varchar(255),
+    checklist jsonb,
+    notes jsonb,
+    completed_at timestamp
+);
+
+create index index_onboardings_organization_id on onboardings (organization_id);
+create unique index unique_onboardings_user_id on onboardings (user_id) where deleted_at is null;
+
+alter table onboardings add constraint onboardings_organization_id foreign key (organization_id) references organizations (id);
+alter table onboardings add constraint onboardings_user_id foreign key (user_id) references users (id);
+alter table onboardings add constraint onboardings_offer_id foreign key (offer_id) references offers (id);
+
+comment on column onboardings.id is 'onboardings id';
+comment on column onboardings.created_at is 'Save timestamp when create';
+comment on column onboardings.updated_at is 'Save timestamp when update';
+comment on column onboardings.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column onboardings.organization_id is 'organization id';
+comment on column onboardings.user_id is 'New employee';
+comment on column onboardings.offer_id is 'Offer which was accepted';
+comment on column onboardings.cv_id is 'Cv which employee was hired from';
+comment on column onboardings.contract_id is 'Draft contract of employee';
+comment on column onboardings.cv_file_name is 'File name of cv which is carried over from recruitment';
+comment on column onboardings.cv_file_path is 'Folder of cv file on cloud storage';
+comment on column onboardings.checklist is 'Onboarding tasks: name, done, done_by, done_at';
+comment on column onboardings.notes is 'Comments of cv which are carried over from recruitment: created_by, comment, created_at';
+comment on column onboardings.completed_at is 'Time when all tasks of checklist were done';
@@ -0,0 +1,3 @@
+update contracts set file_name = '' where file_name is null;
+alter table contracts alter column file_name set not null;
+alter table contracts drop column if exists is_draft;
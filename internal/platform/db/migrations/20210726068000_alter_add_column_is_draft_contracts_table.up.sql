@@ -0,0 +1,4 @@
+alter table contracts add column if not exists is_draft boolean default false not null;
+alter table contracts alter column file_name drop not null;
+
+comment on column contracts.is_draft is 'true when contract was drafted from accepted offer and has not been signed, draft has no file';
@@ -0,0 +1,2 @@
+alter table candidate_email_templates drop constraint if exists candidate_email_templates_organization_id;
+drop table if exists candidate_email_templates;
@@ -0,0 +1,25 @@
+create table if not exists candidate_email_templates(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    type smallint not null,
+    language_id integer not null,
+    subject varchar(255) not null,
+    body text not null
+);
+
+create unique index unique_candidate_email_templates_type_language_id on candidate_email_templates (organization_id, type, language_id) where deleted_at is null;
+
+alter table candidate_email_templates add constraint candidate_email_templates_organization_id foreign key (organization_id) references organizations (id);
+
+comment on column candidate_email_templates.id is 'candidate_email_templates id';
+comment on column candidate_email_templates.created_at is 'Save timestamp when create';
+comment on column candidate_email_templates.updated_at is 'Save timestamp when update';
+comment on column candidate_email_templates.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column candidate_email_templates.organization_id is 'organization id';
+comment on column candidate_email_templates.type is '1: interview invite, 2: rejection, 3: offer';
+comment on column candidate_email_templates.language_id is '1: English, 2: Japanese, 3: Vietnamese';
+comment on column candidate_email_templates.subject is 'Subject with merge fields as {{candidate_name}}';
+comment on column candidate_email_templates.body is 'Plain text body with merge fields as {{candidate_name}}';
@@ -0,0 +1,3 @@
+alter table cv_communications drop constraint if exists cv_communications_organization_id;
+alter table cv_communications drop constraint if exists cv_communications_cv_id;
+drop table if exists cv_communications;
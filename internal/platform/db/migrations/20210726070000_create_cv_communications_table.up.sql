@@ -0,0 +1,38 @@
+create table if not exists cv_communications(
+    id serial primary key not null,
+    created_at timestamp not null,
+    updated_at timestamp not null,
+    deleted_at timestamp,
+    organization_id integer not null,
+    cv_id integer not null,
+    type smallint not null,
+    language_id integer,
+    subject varchar(255) not null,
+    body text,
+    sent_to varchar(100) not null,
+    sent_by integer,
+    status smallint not null,
+    error_message text,
+    sent_at timestamp not null
+);
+
+create index index_cv_communications_cv_id on cv_communications (cv_id);
+
+alter table cv_communications add constraint cv_communications_organization_id foreign key (organization_id) references organizations (id);
+alter table cv_communications add constraint cv_communications_cv_id foreign key (cv_id) references cvs (id);
+
+comment on column cv_communications.id is 'cv_communications id';
+comment on column cv_communications.created_at is 'Save timestamp when create';
+comment on column cv_communications.updated_at is 'Save timestamp when update';
+comment on column cv_communications.deleted_at is 'Timestamp delete logic this record. When delete save current time';
+comment on column cv_communications.organization_id is 'organization id';
+comment on column cv_communications.cv_id is 'Cv of candidate who was emailed';
+comment on column cv_communications.type is '1: interview invite, 2: rejection, 3: offer, 4: other';
+comment on column cv_communications.language_id is 'Language of template which email was generated from';
+comment on column cv_communications.subject is 'Subject which was sent';
+comment on column cv_communications.body is 'Body which was sent, merge fields were replaced';
+comment on column cv_communications.sent_to is 'Email address of candidate';
+comment on column cv_communications.sent_by is 'User who sent email, null when it was sent by system';
+comment on column cv_communications.status is '1: sent, 2: failed';
+comment on column cv_communications.error_message is 'Error of smtp server when sending failed';
+comment on column cv_communications.sent_at is 'Time when email was sent';
@@ -0,0 +1,243 @@
+package document
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CvInfo : Contact details, skills and experience which are guessed from text of cv, recruiter confirms them
+type CvInfo struct {
+	FullName          string   `json:"full_name"`
+	Email             string   `json:"email"`
+	PhoneNumber       string   `json:"phone_number"`
+	Skills            []string `json:"skills"`
+	YearsOfExperience float64  `json:"years_of_experience"`
+}
+
+var (
+	emailRegexp     = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phoneRegexp     = regexp.MustCompile(`(?:\+|\b)\d[\d .\-()]{7,16}\d\b`)
+	nameLabelRegexp = regexp.MustCompile(`(?i)^(?:full\s*name|name|họ\s*và\s*tên|họ\s*tên|氏名|名前)\s*[:：]\s*(.+)$`)
+	statedExpRegexp = regexp.MustCompile(
+		`(?i)(\d{1,2}(?:[.,]\d)?)\s*\+?\s*(?:years?|yrs?|năm)\s*(?:of\s+)?(?:working\s+)?(?:experience|exp\b|kinh\s*nghiệm)`,
+	)
+	dateRangeRegexp = regexp.MustCompile(
+		`(?i)(?:(0?[1-9]|1[0-2])\s*[/.\-]\s*)?((?:19|20)\d{2})\s*(?:-|–|—|~|to|đến)\s*` +
+			`(?:(?:(0?[1-9]|1[0-2])\s*[/.\-]\s*)?((?:19|20)\d{2})|(present|now|current|nay|hiện\s*tại))`,
+	)
+	educationRegexp  = regexp.MustCompile(`(?i)education|university|college|academy|school|học\s*vấn|đại\s*học|cao\s*đẳng|trường`)
+	experienceRegexp = regexp.MustCompile(`(?i)experience|employment|work\s*history|kinh\s*nghiệm|projects?|dự\s*án|skills?|kỹ\s*năng`)
+	headerWords      = []string{"curriculum vitae", "resume", "résumé", "cv", "profile", "sơ yếu lý lịch", "履歴書"}
+)
+
+// ParseCv : Guess contact details of candidate, skills which are in technologies of organization and years of experience
+func ParseCv(text string, technologies []string) CvInfo {
+	lines := strings.Split(text, "\n")
+	info := CvInfo{
+		Email:             emailRegexp.FindString(text),
+		PhoneNumber:       findPhoneNumber(text),
+		FullName:          findFullName(lines),
+		Skills:            findSkills(text, technologies),
+		YearsOfExperience: findYearsOfExperience(lines, time.Now()),
+	}
+
+	return info
+}
+
+func findPhoneNumber(text string) string {
+	for _, candidate := range phoneRegexp.FindAllString(text, -1) {
+		var digits strings.Builder
+		if strings.HasPrefix(candidate, "+") {
+			digits.WriteString("+")
+		}
+
+		for _, r := range candidate {
+			if r >= '0' && r <= '9' {
+				digits.WriteRune(r)
+			}
+		}
+
+		// Year ranges as 2017 - 2019 have the same shape as phone number
+		count := len(strings.TrimPrefix(digits.String(), "+"))
+		if count >= 9 && count <= 13 && !dateRangeRegexp.MatchString(candidate) {
+			return digits.String()
+		}
+	}
+
+	return ""
+}
+
+func findFullName(lines []string) string {
+	for _, line := range lines {
+		if match := nameLabelRegexp.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			return titleName(match[1])
+		}
+	}
+
+	checked := 0
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		checked++
+		if checked > 10 {
+			break
+		}
+
+		if isHeaderLine(line) {
+			continue
+		}
+
+		words := strings.Fields(line)
+		if len(words) < 2 || len(words) > 6 {
+			continue
+		}
+
+		isName := true
+		for _, r := range line {
+			if !unicode.IsLetter(r) && r != ' ' && r != '.' && r != '-' && r != '\'' {
+				isName = false
+				break
+			}
+		}
+
+		if isName {
+			return titleName(line)
+		}
+	}
+
+	return ""
+}
+
+func isHeaderLine(line string) bool {
+	lower := strings.ToLower(line)
+	for _, word := range headerWords {
+		if lower == word || strings.HasPrefix(lower, word+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// titleName : Name which is written in upper case is changed to title case
+func titleName(name string) string {
+	name = strings.Join(strings.Fields(name), " ")
+	if name != strings.ToUpper(name) {
+		return name
+	}
+
+	words := strings.Fields(strings.ToLower(name))
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+
+	return strings.Join(words, " ")
+}
+
+func findSkills(text string, technologies []string) []string {
+	var skills []string
+	for _, technology := range technologies {
+		name := strings.TrimSpace(technology)
+		if name == "" {
+			continue
+		}
+
+		// Letters around name mean other word, so that Java does not match JavaScript
+		pattern := `(?i)(?:^|[^\p{L}\p{N}+#])` + regexp.QuoteMeta(name) + `(?:$|[^\p{L}\p{N}+#])`
+		matcher, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+
+		if matcher.MatchString(text) {
+			skills = append(skills, name)
+		}
+	}
+
+	return skills
+}
+
+// findYearsOfExperience : Years which cv states, else months of work date ranges which are merged, ranges of education are skipped
+func findYearsOfExperience(lines []string, now time.Time) float64 {
+	stated := 0.0
+	for _, match := range statedExpRegexp.FindAllStringSubmatch(strings.Join(lines, "\n"), -1) {
+		years, err := strconv.ParseFloat(strings.Replace(match[1], ",", ".", 1), 64)
+		if err == nil && years > stated && years <= 50 {
+			stated = years
+		}
+	}
+
+	if stated > 0 {
+		return stated
+	}
+
+	type period struct{ from, to int }
+	var periods []period
+	inEducation := false
+	currentMonth := now.Year()*12 + int(now.Month()) - 1
+	for _, line := range lines {
+		isHeading := len([]rune(line)) <= 40
+		if isHeading && educationRegexp.MatchString(line) && !dateRangeRegexp.MatchString(line) {
+			inEducation = true
+			continue
+		}
+
+		if isHeading && experienceRegexp.MatchString(line) {
+			inEducation = false
+		}
+
+		if inEducation || educationRegexp.MatchString(line) {
+			continue
+		}
+
+		for _, match := range dateRangeRegexp.FindAllStringSubmatch(line, -1) {
+			from := monthIndex(match[2], match[1], 1)
+			to := currentMonth
+			if match[5] == "" {
+				to = monthIndex(match[4], match[3], 12)
+			}
+
+			if from <= to && to <= currentMonth {
+				periods = append(periods, period{from, to})
+			}
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].from < periods[j].from
+	})
+
+	months := 0
+	end := -1
+	for _, p := range periods {
+		if p.from > end {
+			months += p.to - p.from + 1
+			end = p.to
+		} else if p.to > end {
+			months += p.to - end
+			end = p.to
+		}
+	}
+
+	return math.Round(float64(months)/12*10) / 10
+}
+
+func monthIndex(year string, month string, defaultMonth int) int {
+	y, _ := strconv.Atoi(year)
+	m, err := strconv.Atoi(month)
+	if err != nil || m == 0 {
+		m = defaultMonth
+	}
+
+	return y*12 + m - 1
+}
@@ -0,0 +1,62 @@
+package document
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxDecompressedSize : Bytes which are decompressed from one file at most, so that zip bomb can not exhaust memory
+	maxDecompressedSize = 16 << 20
+	// maxTextSize : Bytes of text which are extracted at most, text of cv is far shorter
+	maxTextSize = 1 << 20
+)
+
+var ErrUnsupportedFile = errors.New("unsupported file type, only pdf and docx can be read")
+
+var (
+	spacesRegexp     = regexp.MustCompile(`[ \t\x{00a0}]+`)
+	emptyLinesRegexp = regexp.MustCompile(`\n{3,}`)
+)
+
+// ExtractText : Plain text of pdf or docx file, type is detected by extension and then by content
+func ExtractText(fileName string, content []byte) (string, error) {
+	var (
+		text string
+		err  error
+	)
+
+	switch {
+	case strings.EqualFold(filepath.Ext(fileName), ".pdf") || bytes.HasPrefix(content, []byte("%PDF")):
+		text, err = extractPdfText(content)
+	case strings.EqualFold(filepath.Ext(fileName), ".docx") || bytes.HasPrefix(content, []byte("PK")):
+		text, err = extractDocxText(content)
+	default:
+		return "", ErrUnsupportedFile
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if len(text) > maxTextSize {
+		text = strings.ToValidUTF8(text[:maxTextSize], "")
+	}
+
+	return normalizeText(text), nil
+}
+
+func normalizeText(text string) string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text = strings.Replace(text, "\r", "\n", -1)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacesRegexp.ReplaceAllString(line, " "))
+	}
+
+	text = emptyLinesRegexp.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
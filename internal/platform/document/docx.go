@@ -0,0 +1,80 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// extractDocxText : Text of runs in word/document.xml, paragraph and break are new lines
+func extractDocxText(content []byte) (string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return "", err
+	}
+
+	var document *zip.File
+	for _, file := range reader.File {
+		if file.Name == "word/document.xml" {
+			document = file
+		}
+	}
+
+	if document == nil {
+		return "", errors.New("word/document.xml does not exist in docx")
+	}
+
+	rc, err := document.Open()
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	var (
+		builder strings.Builder
+		inText  bool
+	)
+	// Xml is cut at decompressed size limit, text which is read before it is kept
+	limited := &io.LimitedReader{R: rc, N: maxDecompressedSize}
+	decoder := xml.NewDecoder(limited)
+	for builder.Len() < maxTextSize {
+		token, err := decoder.Token()
+		if err == io.EOF || (err != nil && limited.N <= 0) {
+			break
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				builder.WriteString("\t")
+			case "br", "cr":
+				builder.WriteString("\n")
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				builder.WriteString("\n")
+			case "tc":
+				builder.WriteString("\t")
+			}
+		case xml.CharData:
+			if inText {
+				builder.Write(t)
+			}
+		}
+	}
+
+	return builder.String(), nil
+}
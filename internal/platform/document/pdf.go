@@ -0,0 +1,714 @@
+package document
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+type (
+	pdfName    string
+	pdfKeyword string
+	pdfString  []byte
+	pdfDict    map[pdfName]interface{}
+	pdfRef     struct{ num int }
+	pdfStream  struct {
+		dict pdfDict
+		data []byte
+	}
+)
+
+// pdfFont : Font of content stream, codes of string are mapped to text by ToUnicode cmap
+type pdfFont struct {
+	codeBytes int
+	cmap      map[int]string
+}
+
+type pdfDocument struct {
+	objects map[int]interface{}
+	fonts   map[pdfRef]*pdfFont
+	// budget : Bytes which can still be decompressed from streams of document
+	budget int64
+}
+
+var pdfObjectRegexp = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+
+const maxPdfDepth = 32
+
+// extractPdfText : Text shown by text operators of pages in order, which covers pdf exported by office and browsers.
+// Scanned pdf has no text and gives empty string.
+func extractPdfText(content []byte) (string, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(content, " \r\n\t"), []byte("%PDF")) {
+		return "", errors.New("invalid pdf file")
+	}
+
+	doc := &pdfDocument{
+		objects: make(map[int]interface{}),
+		fonts:   make(map[pdfRef]*pdfFont),
+		budget:  maxDecompressedSize,
+	}
+	doc.readObjects(content)
+	doc.readObjectStreams()
+
+	var builder strings.Builder
+	for _, catalog := range doc.objects {
+		dict, ok := catalog.(pdfDict)
+		if !ok || dict["Type"] != pdfName("Catalog") {
+			continue
+		}
+
+		pages, _ := doc.resolve(dict["Pages"]).(pdfDict)
+		doc.walkPages(pages, nil, 0, func(page pdfDict, resources pdfDict) {
+			if builder.Len() >= maxTextSize {
+				return
+			}
+
+			fonts := doc.pageFonts(resources)
+			for _, stream := range doc.pageContents(page) {
+				builder.WriteString(contentText(doc.decode(stream), fonts))
+				builder.WriteString("\n")
+			}
+		})
+		break
+	}
+
+	return builder.String(), nil
+}
+
+func (doc *pdfDocument) readObjects(content []byte) {
+	parsedUntil := 0
+	for _, loc := range pdfObjectRegexp.FindAllSubmatchIndex(content, -1) {
+		// "n g obj" which is found inside data of stream is not object
+		if loc[0] < parsedUntil {
+			continue
+		}
+
+		num, err := strconv.Atoi(string(content[loc[2]:loc[3]]))
+		if err != nil {
+			continue
+		}
+
+		lexer := &pdfLexer{data: content, pos: loc[1]}
+		object := lexer.parseObject(0)
+		dict, isDict := object.(pdfDict)
+		mark := lexer.pos
+		if token := lexer.next(); isDict && token == pdfKeyword("stream") {
+			start := lexer.pos
+			if start < len(content) && content[start] == '\r' {
+				start++
+			}
+
+			if start < len(content) && content[start] == '\n' {
+				start++
+			}
+
+			end := bytes.Index(content[start:], []byte("endstream"))
+			if end < 0 {
+				continue
+			}
+
+			data := bytes.TrimRight(content[start:start+end], "\r\n")
+			object = &pdfStream{dict: dict, data: data}
+			parsedUntil = start + end
+		} else {
+			lexer.pos = mark
+		}
+
+		doc.objects[num] = object
+	}
+}
+
+// readObjectStreams : Objects which are compressed in object streams of pdf 1.5
+func (doc *pdfDocument) readObjectStreams() {
+	var streams []*pdfStream
+	for _, object := range doc.objects {
+		if stream, ok := object.(*pdfStream); ok && stream.dict["Type"] == pdfName("ObjStm") {
+			streams = append(streams, stream)
+		}
+	}
+
+	for _, stream := range streams {
+		data := doc.decode(stream)
+		count, _ := doc.resolve(stream.dict["N"]).(float64)
+		first, _ := doc.resolve(stream.dict["First"]).(float64)
+		header := &pdfLexer{data: data}
+		for i := 0; i < int(count); i++ {
+			num, ok1 := header.next().(float64)
+			offset, ok2 := header.next().(float64)
+			if !ok1 || !ok2 {
+				break
+			}
+
+			if _, exists := doc.objects[int(num)]; exists || int(first+offset) >= len(data) {
+				continue
+			}
+
+			lexer := &pdfLexer{data: data, pos: int(first + offset)}
+			doc.objects[int(num)] = lexer.parseObject(0)
+		}
+	}
+}
+
+func (doc *pdfDocument) resolve(object interface{}) interface{} {
+	for i := 0; i < maxPdfDepth; i++ {
+		ref, ok := object.(pdfRef)
+		if !ok {
+			return object
+		}
+
+		object = doc.objects[ref.num]
+	}
+
+	return nil
+}
+
+// decode : Data of stream which is decompressed when filter is FlateDecode, stream with other filter gives nil.
+// Decompressed data of all streams is limited by budget of document
+func (doc *pdfDocument) decode(stream *pdfStream) []byte {
+	var filters []interface{}
+	switch filter := doc.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []interface{}{filter}
+	case []interface{}:
+		filters = filter
+	}
+
+	data := stream.data
+	for _, filter := range filters {
+		if doc.resolve(filter) != pdfName("FlateDecode") {
+			return nil
+		}
+
+		reader, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+
+		// Stream which is cut short still gives the text which is read before error
+		data, _ = ioutil.ReadAll(io.LimitReader(reader, doc.budget))
+		doc.budget -= int64(len(data))
+	}
+
+	return data
+}
+
+func (doc *pdfDocument) walkPages(node pdfDict, resources pdfDict, depth int, visit func(page pdfDict, resources pdfDict)) {
+	if node == nil || depth > maxPdfDepth {
+		return
+	}
+
+	if nodeResources, ok := doc.resolve(node["Resources"]).(pdfDict); ok {
+		resources = nodeResources
+	}
+
+	kids, hasKids := doc.resolve(node["Kids"]).([]interface{})
+	if node["Type"] == pdfName("Pages") || hasKids {
+		for _, kid := range kids {
+			kidNode, _ := doc.resolve(kid).(pdfDict)
+			doc.walkPages(kidNode, resources, depth+1, visit)
+		}
+		return
+	}
+
+	visit(node, resources)
+}
+
+func (doc *pdfDocument) pageContents(page pdfDict) []*pdfStream {
+	var streams []*pdfStream
+	switch contents := doc.resolve(page["Contents"]).(type) {
+	case *pdfStream:
+		streams = append(streams, contents)
+	case []interface{}:
+		for _, content := range contents {
+			if stream, ok := doc.resolve(content).(*pdfStream); ok {
+				streams = append(streams, stream)
+			}
+		}
+	}
+
+	return streams
+}
+
+func (doc *pdfDocument) pageFonts(resources pdfDict) map[pdfName]*pdfFont {
+	fonts := make(map[pdfName]*pdfFont)
+	fontDict, _ := doc.resolve(resources["Font"]).(pdfDict)
+	for name, object := range fontDict {
+		ref, isRef := object.(pdfRef)
+		if font, ok := doc.fonts[ref]; isRef && ok {
+			fonts[name] = font
+			continue
+		}
+
+		font := &pdfFont{codeBytes: 1}
+		if dict, ok := doc.resolve(object).(pdfDict); ok {
+			if dict["Subtype"] == pdfName("Type0") {
+				font.codeBytes = 2
+			}
+
+			if stream, ok := doc.resolve(dict["ToUnicode"]).(*pdfStream); ok {
+				font.cmap = parseCMap(doc.decode(stream), font)
+			}
+		}
+
+		if isRef {
+			doc.fonts[ref] = font
+		}
+		fonts[name] = font
+	}
+
+	return fonts
+}
+
+// parseCMap : Codes of bfchar and bfrange of ToUnicode cmap, code length is taken from codespacerange
+func parseCMap(data []byte, font *pdfFont) map[int]string {
+	cmap := make(map[int]string)
+	lexer := &pdfLexer{data: data}
+	for {
+		token := lexer.next()
+		if token == nil {
+			break
+		}
+
+		switch token {
+		case pdfKeyword("begincodespacerange"):
+			if low, ok := lexer.next().(pdfString); ok && len(low) > 0 {
+				font.codeBytes = len(low)
+			}
+		case pdfKeyword("beginbfchar"):
+			for {
+				src, ok := lexer.parseObject(0).(pdfString)
+				if !ok {
+					break
+				}
+
+				if dst, ok := lexer.parseObject(0).(pdfString); ok {
+					cmap[bytesToCode(src)] = utf16BytesToString(dst)
+				}
+			}
+		case pdfKeyword("beginbfrange"):
+			for {
+				low, ok := lexer.parseObject(0).(pdfString)
+				if !ok {
+					break
+				}
+
+				high, _ := lexer.parseObject(0).(pdfString)
+				from, to := bytesToCode(low), bytesToCode(high)
+				if to-from > 0xffff {
+					continue
+				}
+
+				switch dst := lexer.parseObject(0).(type) {
+				case pdfString:
+					runes := []rune(utf16BytesToString(dst))
+					for code := from; code <= to && len(runes) > 0; code++ {
+						cmap[code] = string(runes)
+						runes[len(runes)-1]++
+					}
+				case []interface{}:
+					for i, item := range dst {
+						if s, ok := item.(pdfString); ok && from+i <= to {
+							cmap[from+i] = utf16BytesToString(s)
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return cmap
+}
+
+func bytesToCode(b []byte) int {
+	code := 0
+	for _, c := range b {
+		code = code<<8 | int(c)
+	}
+
+	return code
+}
+
+func utf16BytesToString(b []byte) string {
+	if len(b)%2 != 0 {
+		return string(b)
+	}
+
+	units := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+
+	return string(utf16.Decode(units))
+}
+
+func (font *pdfFont) decodeString(s pdfString) string {
+	if font == nil {
+		return latinString(s)
+	}
+
+	if font.cmap == nil {
+		if font.codeBytes > 1 {
+			return ""
+		}
+
+		return latinString(s)
+	}
+
+	var builder strings.Builder
+	for i := 0; i+font.codeBytes <= len(s); i += font.codeBytes {
+		code := bytesToCode(s[i : i+font.codeBytes])
+		if text, ok := font.cmap[code]; ok {
+			builder.WriteString(text)
+		} else if font.codeBytes == 1 {
+			builder.WriteByte(byte(code))
+		}
+	}
+
+	return builder.String()
+}
+
+// latinString : Bytes of simple font without cmap are read as WinAnsi, which is the same as latin-1 for letters
+func latinString(s pdfString) string {
+	runes := make([]rune, 0, len(s))
+	for _, b := range s {
+		if b >= 0x20 && b != 0x7f {
+			runes = append(runes, rune(b))
+		}
+	}
+
+	return string(runes)
+}
+
+// contentText : Text of text operators in content stream, moving to other line gives new line
+func contentText(data []byte, fonts map[pdfName]*pdfFont) string {
+	var (
+		builder  strings.Builder
+		operands []interface{}
+		font     *pdfFont
+		lastY    float64
+	)
+
+	newLine := func() {
+		if builder.Len() > 0 {
+			builder.WriteString("\n")
+		}
+	}
+
+	lexer := &pdfLexer{data: data}
+	for {
+		object := lexer.parseObject(0)
+		if object == nil {
+			break
+		}
+
+		operator, ok := object.(pdfKeyword)
+		if !ok {
+			operands = append(operands, object)
+			continue
+		}
+
+		switch operator {
+		case "Tf":
+			if len(operands) >= 2 {
+				name, _ := operands[len(operands)-2].(pdfName)
+				font = fonts[name]
+			}
+		case "Tj":
+			if len(operands) >= 1 {
+				if s, ok := operands[len(operands)-1].(pdfString); ok {
+					builder.WriteString(font.decodeString(s))
+				}
+			}
+		case "'", "\"":
+			newLine()
+			if len(operands) >= 1 {
+				if s, ok := operands[len(operands)-1].(pdfString); ok {
+					builder.WriteString(font.decodeString(s))
+				}
+			}
+		case "TJ":
+			if len(operands) >= 1 {
+				items, _ := operands[len(operands)-1].([]interface{})
+				for _, item := range items {
+					switch v := item.(type) {
+					case pdfString:
+						builder.WriteString(font.decodeString(v))
+					case float64:
+						if v < -200 {
+							builder.WriteString(" ")
+						}
+					}
+				}
+			}
+		case "Td", "TD":
+			if len(operands) >= 2 {
+				if ty, _ := operands[len(operands)-1].(float64); ty != 0 {
+					newLine()
+				} else {
+					builder.WriteString(" ")
+				}
+			}
+		case "Tm":
+			if len(operands) >= 6 {
+				y, _ := operands[len(operands)-1].(float64)
+				if y != lastY {
+					newLine()
+				} else {
+					builder.WriteString(" ")
+				}
+				lastY = y
+			}
+		case "T*":
+			newLine()
+		case "ET":
+			builder.WriteString(" ")
+		case "BI":
+			lexer.skipInlineImage()
+		}
+
+		operands = operands[:0]
+	}
+
+	return builder.String()
+}
+
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isPdfSpace(b byte) bool {
+	return b == 0 || b == '\t' || b == '\n' || b == '\f' || b == '\r' || b == ' '
+}
+
+func isPdfDelimiter(b byte) bool {
+	return strings.IndexByte("()<>[]{}/%", b) >= 0
+}
+
+func (lexer *pdfLexer) skipSpace() {
+	for lexer.pos < len(lexer.data) {
+		b := lexer.data[lexer.pos]
+		if b == '%' {
+			for lexer.pos < len(lexer.data) && lexer.data[lexer.pos] != '\n' && lexer.data[lexer.pos] != '\r' {
+				lexer.pos++
+			}
+		} else if !isPdfSpace(b) {
+			return
+		}
+
+		lexer.pos++
+	}
+}
+
+// next : Next token, which is number, name, string, keyword or delimiter of array and dictionary, nil at end of data
+func (lexer *pdfLexer) next() interface{} {
+	lexer.skipSpace()
+	if lexer.pos >= len(lexer.data) {
+		return nil
+	}
+
+	b := lexer.data[lexer.pos]
+	switch {
+	case b == '(':
+		return lexer.literalString()
+	case b == '<' && lexer.pos+1 < len(lexer.data) && lexer.data[lexer.pos+1] == '<':
+		lexer.pos += 2
+		return pdfKeyword("<<")
+	case b == '>' && lexer.pos+1 < len(lexer.data) && lexer.data[lexer.pos+1] == '>':
+		lexer.pos += 2
+		return pdfKeyword(">>")
+	case b == '<':
+		return lexer.hexString()
+	case b == '[' || b == ']' || b == '{' || b == '}':
+		lexer.pos++
+		return pdfKeyword(string(b))
+	case b == '/':
+		lexer.pos++
+		return pdfName(lexer.word())
+	}
+
+	word := lexer.word()
+	if word == "" {
+		// Stray delimiter as ) or > is skipped
+		lexer.pos++
+		return lexer.next()
+	}
+
+	if (word[0] >= '0' && word[0] <= '9') || word[0] == '-' || word[0] == '+' || word[0] == '.' {
+		if number, err := strconv.ParseFloat(word, 64); err == nil {
+			return number
+		}
+	}
+
+	return pdfKeyword(word)
+}
+
+func (lexer *pdfLexer) word() string {
+	start := lexer.pos
+	for lexer.pos < len(lexer.data) && !isPdfSpace(lexer.data[lexer.pos]) && !isPdfDelimiter(lexer.data[lexer.pos]) {
+		lexer.pos++
+	}
+
+	return string(lexer.data[start:lexer.pos])
+}
+
+func (lexer *pdfLexer) literalString() pdfString {
+	var s []byte
+	depth := 0
+	lexer.pos++
+	for lexer.pos < len(lexer.data) {
+		b := lexer.data[lexer.pos]
+		lexer.pos++
+		switch b {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return s
+			}
+			depth--
+		case '\\':
+			if lexer.pos >= len(lexer.data) {
+				return s
+			}
+
+			b = lexer.data[lexer.pos]
+			lexer.pos++
+			switch b {
+			case 'n':
+				b = '\n'
+			case 'r':
+				b = '\r'
+			case 't':
+				b = '\t'
+			case 'b':
+				b = '\b'
+			case 'f':
+				b = '\f'
+			case '\r', '\n':
+				if b == '\r' && lexer.pos < len(lexer.data) && lexer.data[lexer.pos] == '\n' {
+					lexer.pos++
+				}
+				continue
+			default:
+				if b >= '0' && b <= '7' {
+					octal := int(b - '0')
+					for i := 0; i < 2 && lexer.pos < len(lexer.data); i++ {
+						next := lexer.data[lexer.pos]
+						if next < '0' || next > '7' {
+							break
+						}
+						octal = octal*8 + int(next-'0')
+						lexer.pos++
+					}
+					b = byte(octal)
+				}
+			}
+		}
+
+		s = append(s, b)
+	}
+
+	return s
+}
+
+func (lexer *pdfLexer) hexString() pdfString {
+	var digits []byte
+	lexer.pos++
+	for lexer.pos < len(lexer.data) && lexer.data[lexer.pos] != '>' {
+		b := lexer.data[lexer.pos]
+		if (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F') {
+			digits = append(digits, b)
+		}
+		lexer.pos++
+	}
+	lexer.pos++
+
+	if len(digits)%2 != 0 {
+		digits = append(digits, '0')
+	}
+
+	s := make(pdfString, len(digits)/2)
+	for i := range s {
+		value, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		s[i] = byte(value)
+	}
+
+	return s
+}
+
+// parseObject : Next object, where array is []interface{}, dictionary is pdfDict and "n g R" is pdfRef
+func (lexer *pdfLexer) parseObject(depth int) interface{} {
+	token := lexer.next()
+	if depth > maxPdfDepth {
+		return token
+	}
+
+	switch token {
+	case pdfKeyword("["):
+		array := []interface{}{}
+		for {
+			mark := lexer.pos
+			if lexer.next() == pdfKeyword("]") {
+				return array
+			}
+			lexer.pos = mark
+
+			item := lexer.parseObject(depth + 1)
+			if item == nil {
+				return array
+			}
+			array = append(array, item)
+		}
+	case pdfKeyword("<<"):
+		dict := make(pdfDict)
+		for {
+			key := lexer.next()
+			if key == nil || key == pdfKeyword(">>") {
+				return dict
+			}
+
+			name, ok := key.(pdfName)
+			if !ok {
+				continue
+			}
+			dict[name] = lexer.parseObject(depth + 1)
+		}
+	}
+
+	if number, ok := token.(float64); ok && number >= 0 && number == float64(int(number)) {
+		mark := lexer.pos
+		if _, ok := lexer.next().(float64); ok && lexer.next() == pdfKeyword("R") {
+			return pdfRef{num: int(number)}
+		}
+		lexer.pos = mark
+	}
+
+	return token
+}
+
+// skipInlineImage : Move to end of inline image, whose binary data can not be read as tokens
+func (lexer *pdfLexer) skipInlineImage() {
+	end := bytes.Index(lexer.data[lexer.pos:], []byte("EI"))
+	for end >= 0 {
+		at := lexer.pos + end
+		if (at == 0 || isPdfSpace(lexer.data[at-1])) && (at+2 >= len(lexer.data) || isPdfSpace(lexer.data[at+2])) {
+			lexer.pos = at + 2
+			return
+		}
+
+		next := bytes.Index(lexer.data[at+2:], []byte("EI"))
+		if next < 0 {
+			break
+		}
+		end = at + 2 + next - lexer.pos
+	}
+
+	lexer.pos = len(lexer.data)
+}
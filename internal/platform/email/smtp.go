@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"gopkg.in/gomail.v2"
 	"html/template"
+	"io"
 	"os"
 	"strconv"
 
@@ -29,11 +30,31 @@ func (smtp *SMTPGoMail) InitSmtp(email string, password string) {
 }
 
 func (smtp *SMTPGoMail) SendMail(subject string, sampleData *param.SampleData, templateFile string) error {
+	return smtp.SendMailWithAttachment(subject, sampleData, templateFile, nil)
+}
+
+// Attachment : file which is attached to mail
+type Attachment struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+// SendMailWithAttachment : send mail with files attached, as icalendar invite
+func (smtp *SMTPGoMail) SendMailWithAttachment(
+	subject string,
+	sampleData *param.SampleData,
+	templateFile string,
+	attachments []Attachment,
+) error {
 
 	dryrun, _ := strconv.Atoi(os.Getenv("DRY_RUN"))
 	if dryrun == 0 {
 		fmt.Println("subject : " + subject)
 		fmt.Println("templateFile : " + templateFile)
+		for _, attachment := range attachments {
+			fmt.Println("attachment : " + attachment.FileName)
+		}
 		utils.PrintVars(os.Stdout, true, sampleData)
 		return nil
 	}
@@ -59,6 +80,18 @@ func (smtp *SMTPGoMail) SendMail(subject string, sampleData *param.SampleData, t
 	m.SetHeader("Subject", subject)
 	m.SetBody("text/html", body)
 
+	for _, attachment := range attachments {
+		content := attachment.Content
+		m.Attach(
+			attachment.FileName,
+			gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}),
+		)
+	}
+
 	d := gomail.NewDialer(smtp.smtpAdrr, smtp.smtpPort, smtp.hostMail, smtp.hostMailPassword)
 
 	// Send the email
@@ -0,0 +1,158 @@
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
+)
+
+// maxKeys : Counters which are kept at most, new keys are limited when it is reached so that memory is bounded
+const maxKeys = 100000
+
+// trustedProxies : Networks of reverse proxies from TRUSTED_PROXIES, comma separated ips or cidrs,
+// X-Forwarded-For is only read when request comes from them
+var trustedProxies = parseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+
+// Limiter : Fixed window counter of requests per key, counters are kept in memory of process
+type Limiter struct {
+	mu       sync.Mutex
+	limit    int
+	window   time.Duration
+	counters map[string]*counter
+	cleanAt  time.Time
+}
+
+type counter struct {
+	count   int
+	resetAt time.Time
+}
+
+func NewLimiter(limit int, window time.Duration) *Limiter {
+	return &Limiter{
+		limit:    limit,
+		window:   window,
+		counters: make(map[string]*counter),
+	}
+}
+
+// Allow : Count request of key, false when key reached limit in current window
+func (l *Limiter) Allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if now.After(l.cleanAt) {
+		for k, c := range l.counters {
+			if now.After(c.resetAt) {
+				delete(l.counters, k)
+			}
+		}
+		l.cleanAt = now.Add(l.window)
+	}
+
+	c, ok := l.counters[key]
+	if !ok && len(l.counters) >= maxKeys {
+		return false
+	}
+
+	if !ok || now.After(c.resetAt) {
+		c = &counter{resetAt: now.Add(l.window)}
+		l.counters[key] = c
+	}
+
+	if c.count >= l.limit {
+		return false
+	}
+
+	c.count++
+	return true
+}
+
+// Middleware : Limit requests by ip of client
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !l.Allow(ClientIP(c)) {
+				return c.JSON(http.StatusTooManyRequests, cf.JsonResponse{
+					Status:  cf.FailResponseCode,
+					Message: "Too many requests, please try again later",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// ClientIP : Ip of connection, X-Forwarded-For which client sends is not trusted unless connection comes from trusted proxy.
+// Proxy appends ip of its client, so right most address which is not a trusted proxy is taken
+func ClientIP(c echo.Context) string {
+	ip, _, err := net.SplitHostPort(c.Request().RemoteAddr)
+	if err != nil {
+		ip = c.Request().RemoteAddr
+	}
+
+	if !isTrustedProxy(ip) {
+		return ip
+	}
+
+	forwarded := strings.Split(c.Request().Header.Get(echo.HeaderXForwardedFor), ",")
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		address := strings.TrimSpace(forwarded[i])
+		if address == "" {
+			continue
+		}
+
+		if !isTrustedProxy(address) {
+			return address
+		}
+		ip = address
+	}
+
+	return ip
+}
+
+func isTrustedProxy(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+
+	for _, network := range trustedProxies {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseTrustedProxies(value string) []*net.IPNet {
+	var networks []*net.IPNet
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			if strings.Contains(item, ":") {
+				item += "/128"
+			} else {
+				item += "/32"
+			}
+		}
+
+		if _, network, err := net.ParseCIDR(item); err == nil {
+			networks = append(networks, network)
+		}
+	}
+
+	return networks
+}
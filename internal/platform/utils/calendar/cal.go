@@ -320,20 +320,14 @@ func CalculateHourBonusOvertime(
 	to time.Time,
 	overtimeWeight m.OvertimeWeight,
 	workAtNoon int,
-) (float64, float64, float64) {
-	lunchBreakStart := ParseTime(cf.FormatDateNoSec, to.Format(cf.FormatDateDatabase)+" "+cf.BreakLunchStart)
-	lunchBreakEnd := ParseTime(cf.FormatDateNoSec, to.Format(cf.FormatDateDatabase)+" "+cf.BreakLunchEnd)
-
-	hour := calculateHourGoOutSide(lunchBreakStart, lunchBreakEnd, from, to, workAtNoon)
-	if c.IsHoliday(to) {
-		return overtimeWeight.HolidayWeight * hour, hour, overtimeWeight.HolidayWeight
-	}
-
-	if IsWeekend(to) {
-		return overtimeWeight.WeekendWeight * hour, hour, overtimeWeight.WeekendWeight
+) (float64, float64, []m.OvertimeHourBreakdown) {
+	var lunchBreakStart, lunchBreakEnd time.Time
+	if workAtNoon != cf.WorkAtNoon {
+		lunchBreakStart = ParseTime(cf.FormatDateNoSec, to.Format(cf.FormatDateDatabase)+" "+cf.BreakLunchStart)
+		lunchBreakEnd = ParseTime(cf.FormatDateNoSec, to.Format(cf.FormatDateDatabase)+" "+cf.BreakLunchEnd)
 	}
 
-	return overtimeWeight.NormalDayWeight * hour, hour, overtimeWeight.NormalDayWeight
+	return CalculateOvertimeBands(c, from, to, overtimeWeight, lunchBreakStart, lunchBreakEnd)
 }
 
 func calculateHourGoOutSide(lunchBreakStart time.Time, lunchBreakEnd time.Time, from time.Time, to time.Time, workAtNoon int) float64 {
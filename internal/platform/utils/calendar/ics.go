@@ -0,0 +1,116 @@
+package calendar
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	IcsRequest = "REQUEST"
+	IcsCancel  = "CANCEL"
+
+	icsTimeFormat = "20060102T150405Z"
+)
+
+// IcsEvent : event of icalendar invite
+type IcsEvent struct {
+	Uid            string
+	Sequence       int
+	Method         string
+	Summary        string
+	Description    string
+	Location       string
+	Url            string
+	Start          time.Time
+	End            time.Time
+	OrganizerName  string
+	OrganizerEmail string
+	Attendees      []string
+}
+
+// BuildIcs : build icalendar (RFC 5545) content of event, start and end are converted to UTC
+func BuildIcs(event IcsEvent) []byte {
+	status := "CONFIRMED"
+	if event.Method == IcsCancel {
+		status = "CANCELLED"
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Micro ERP//Recruitment//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:" + event.Method,
+		"BEGIN:VEVENT",
+		"UID:" + event.Uid,
+		"SEQUENCE:" + strconv.Itoa(event.Sequence),
+		"DTSTAMP:" + time.Now().UTC().Format(icsTimeFormat),
+		"DTSTART:" + event.Start.UTC().Format(icsTimeFormat),
+		"DTEND:" + event.End.UTC().Format(icsTimeFormat),
+		"SUMMARY:" + escapeIcsText(event.Summary),
+		"STATUS:" + status,
+	}
+
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeIcsText(event.Description))
+	}
+
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeIcsText(event.Location))
+	}
+
+	if event.Url != "" {
+		lines = append(lines, "URL:"+event.Url)
+	}
+
+	if event.OrganizerEmail != "" {
+		lines = append(lines, "ORGANIZER;CN="+escapeIcsText(event.OrganizerName)+":mailto:"+event.OrganizerEmail)
+	}
+
+	for _, attendee := range event.Attendees {
+		lines = append(lines, "ATTENDEE;ROLE=REQ-PARTICIPANT;PARTSTAT=NEEDS-ACTION;RSVP=TRUE:mailto:"+attendee)
+	}
+
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(foldIcsLine(line))
+		builder.WriteString("\r\n")
+	}
+
+	return []byte(builder.String())
+}
+
+func escapeIcsText(text string) string {
+	return strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+	).Replace(text)
+}
+
+// foldIcsLine : split line longer than 75 octets into continuation lines without breaking utf-8 characters
+func foldIcsLine(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+
+	var builder strings.Builder
+	size := 0
+	for _, r := range line {
+		runeSize := len(string(r))
+		if size+runeSize > 75 {
+			builder.WriteString("\r\n ")
+			size = 1
+		}
+
+		builder.WriteRune(r)
+		size += runeSize
+	}
+
+	return builder.String()
+}
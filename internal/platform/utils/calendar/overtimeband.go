@@ -0,0 +1,151 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+
+	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
+	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
+)
+
+// CalculateOvertimeBands : Split overtime into time bands by date and day/night, break is not counted
+// Params                 : calendar, from, to, overtimeWeight, breakFrom, breakTo (zero when no break)
+// Returns                : weighted hour, hour, breakdown by date and band
+func CalculateOvertimeBands(
+	c *Calendar,
+	from time.Time,
+	to time.Time,
+	overtimeWeight m.OvertimeWeight,
+	breakFrom time.Time,
+	breakTo time.Time,
+) (float64, float64, []m.OvertimeHourBreakdown) {
+	var breakdowns []m.OvertimeHourBreakdown
+	if !to.After(from) {
+		return 0, 0, breakdowns
+	}
+
+	nightStart := overtimeWeight.NightStart
+	if nightStart == "" {
+		nightStart = cf.DefaultOvertimeNightStart
+	}
+
+	nightEnd := overtimeWeight.NightEnd
+	if nightEnd == "" {
+		nightEnd = cf.DefaultOvertimeNightEnd
+	}
+
+	// Cut points: midnights and night boundaries between from and to
+	points := []time.Time{from, to}
+	for day := dateOf(from); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, point := range []time.Time{day, shiftClock(day, nightStart, day.Location()), shiftClock(day, nightEnd, day.Location())} {
+			if point.After(from) && point.Before(to) {
+				points = append(points, point)
+			}
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Before(points[j]) })
+
+	var weightedHour, hour float64
+	for i := 0; i+1 < len(points); i++ {
+		segmentFrom, segmentTo := points[i], points[i+1]
+		segmentHour := segmentTo.Sub(segmentFrom).Hours() - overlapHour(segmentFrom, segmentTo, breakFrom, breakTo)
+		if segmentHour <= 0 {
+			continue
+		}
+
+		workDate := dateOf(segmentFrom)
+		band := overtimeBand(c, workDate, isNightClock(segmentFrom, nightStart, nightEnd))
+		weight := overtimeWeightByBand(band, overtimeWeight)
+		weightedHour += weight * segmentHour
+		hour += segmentHour
+
+		last := len(breakdowns) - 1
+		if last >= 0 && breakdowns[last].Band == band && breakdowns[last].WorkDate.Equal(workDate) {
+			breakdowns[last].Hour += segmentHour
+			continue
+		}
+
+		breakdowns = append(breakdowns, m.OvertimeHourBreakdown{
+			WorkDate: workDate,
+			Band:     band,
+			Hour:     segmentHour,
+			Weight:   weight,
+		})
+	}
+
+	return weightedHour, hour, breakdowns
+}
+
+func overtimeBand(c *Calendar, date time.Time, isNight bool) int {
+	switch {
+	case c.IsHoliday(date) && isNight:
+		return cf.HolidayNightOvertimeBand
+	case c.IsHoliday(date):
+		return cf.HolidayDayOvertimeBand
+	case IsWeekend(date) && isNight:
+		return cf.WeekendNightOvertimeBand
+	case IsWeekend(date):
+		return cf.WeekendDayOvertimeBand
+	case isNight:
+		return cf.NormalNightOvertimeBand
+	default:
+		return cf.NormalDayOvertimeBand
+	}
+}
+
+// overtimeWeightByBand : Night weight which has not been set falls back to day weight of the same day type
+func overtimeWeightByBand(band int, overtimeWeight m.OvertimeWeight) float64 {
+	switch band {
+	case cf.HolidayNightOvertimeBand:
+		if overtimeWeight.HolidayNightWeight > 0 {
+			return overtimeWeight.HolidayNightWeight
+		}
+		return overtimeWeight.HolidayWeight
+	case cf.HolidayDayOvertimeBand:
+		return overtimeWeight.HolidayWeight
+	case cf.WeekendNightOvertimeBand:
+		if overtimeWeight.WeekendNightWeight > 0 {
+			return overtimeWeight.WeekendNightWeight
+		}
+		return overtimeWeight.WeekendWeight
+	case cf.WeekendDayOvertimeBand:
+		return overtimeWeight.WeekendWeight
+	case cf.NormalNightOvertimeBand:
+		if overtimeWeight.NightWeight > 0 {
+			return overtimeWeight.NightWeight
+		}
+		return overtimeWeight.NormalDayWeight
+	default:
+		return overtimeWeight.NormalDayWeight
+	}
+}
+
+func isNightClock(t time.Time, nightStart string, nightEnd string) bool {
+	clock := t.Format(cf.FormatShiftTime)
+	if nightStart <= nightEnd {
+		return clock >= nightStart && clock < nightEnd
+	}
+
+	return clock >= nightStart || clock < nightEnd
+}
+
+func overlapHour(from time.Time, to time.Time, breakFrom time.Time, breakTo time.Time) float64 {
+	start, end := from, to
+	if breakFrom.After(start) {
+		start = breakFrom
+	}
+
+	if breakTo.Before(end) {
+		end = breakTo
+	}
+
+	if !end.After(start) {
+		return 0
+	}
+
+	return end.Sub(start).Hours()
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,49 @@
+package calendar
+
+import (
+	"time"
+
+	cf "gitlab.vietnamlab.vn/micro_erp/frontend-api/configs"
+	m "gitlab.vietnamlab.vn/micro_erp/frontend-api/internal/models"
+)
+
+// ShiftPeriod : Get start and end time of a shift on the given date
+// Params      : shiftDate, startTime, endTime (HH:MM), location
+// Returns     : start, end. End is moved to next day for overnight shift
+func ShiftPeriod(shiftDate time.Time, startTime string, endTime string, loc *time.Location) (time.Time, time.Time) {
+	start := shiftClock(shiftDate, startTime, loc)
+	end := shiftClock(shiftDate, endTime, loc)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+
+	return start, end
+}
+
+// CalculateHourBonusOvertimeInShift : Same as CalculateHourBonusOvertime but subtract break of assigned shift
+// instead of fixed lunch break. Shift without break is calculated as working through.
+func CalculateHourBonusOvertimeInShift(
+	c *Calendar,
+	from time.Time,
+	to time.Time,
+	overtimeWeight m.OvertimeWeight,
+	workAtNoon int,
+	breakStart string,
+	breakEnd string,
+) (float64, float64, []m.OvertimeHourBreakdown) {
+	var breakFrom, breakTo time.Time
+	if workAtNoon != cf.WorkAtNoon && breakStart != "" && breakEnd != "" {
+		breakFrom, breakTo = ShiftPeriod(from, breakStart, breakEnd, from.Location())
+		if !breakTo.After(from) {
+			breakFrom = breakFrom.AddDate(0, 0, 1)
+			breakTo = breakTo.AddDate(0, 0, 1)
+		}
+	}
+
+	return CalculateOvertimeBands(c, from, to, overtimeWeight, breakFrom, breakTo)
+}
+
+func shiftClock(date time.Time, clock string, loc *time.Location) time.Time {
+	t := ParseTime(cf.FormatShiftTime, clock)
+	return time.Date(date.Year(), date.Month(), date.Day(), t.Hour(), t.Minute(), 0, 0, loc)
+}
@@ -0,0 +1,225 @@
+package jobfeed
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FullTime   = "FULL_TIME"
+	PartTime   = "PART_TIME"
+	Contractor = "CONTRACTOR"
+	Intern     = "INTERN"
+	Other      = "OTHER"
+
+	indeedDateFormat = "Mon, 02 Jan 2006 15:04:05 GMT"
+	jsonLdDateFormat = "2006-01-02"
+)
+
+// indeedJobTypes : job type of Indeed feed for schema.org employment type
+var indeedJobTypes = map[string]string{
+	FullTime:   "fulltime",
+	PartTime:   "parttime",
+	Contractor: "contract",
+	Intern:     "internship",
+}
+
+// Job : job posting which is published to job boards
+type Job struct {
+	ReferenceNumber string
+	Title           string
+	Company         string
+	CompanyUrl      string
+	Url             string
+	Description     string
+	Addresses       []string
+	City            string
+	Country         string
+	TypeOfWork      string
+	SalaryFrom      int
+	SalaryTo        int
+	Currency        string
+	ExperienceYears int
+	Openings        int
+	DatePosted      time.Time
+	ValidThrough    time.Time
+}
+
+type indeedSource struct {
+	XMLName       xml.Name    `xml:"source"`
+	Publisher     string      `xml:"publisher"`
+	PublisherUrl  string      `xml:"publisherurl"`
+	LastBuildDate string      `xml:"lastBuildDate"`
+	Jobs          []indeedJob `xml:"job"`
+}
+
+type indeedJob struct {
+	Title           cdata `xml:"title"`
+	Date            cdata `xml:"date"`
+	ReferenceNumber cdata `xml:"referencenumber"`
+	Url             cdata `xml:"url"`
+	Company         cdata `xml:"company"`
+	City            cdata `xml:"city"`
+	Country         cdata `xml:"country"`
+	StreetAddress   cdata `xml:"streetaddress"`
+	Description     cdata `xml:"description"`
+	Salary          cdata `xml:"salary"`
+	JobType         cdata `xml:"jobtype"`
+	Experience      cdata `xml:"experience"`
+	ExpirationDate  cdata `xml:"expirationdate"`
+}
+
+type cdata struct {
+	Value string `xml:",cdata"`
+}
+
+// EmploymentType : schema.org employment type which is guessed from type of work that recruiter typed
+func EmploymentType(typeOfWork string) string {
+	lower := strings.ToLower(typeOfWork)
+	switch {
+	case strings.Contains(lower, "intern") || strings.Contains(lower, "thực tập"):
+		return Intern
+	case strings.Contains(lower, "part") || strings.Contains(lower, "bán thời gian"):
+		return PartTime
+	case strings.Contains(lower, "ctv") || strings.Contains(lower, "contract") || strings.Contains(lower, "freelance") ||
+		strings.Contains(lower, "cộng tác"):
+		return Contractor
+	case strings.Contains(lower, "full") || strings.Contains(lower, "toàn thời gian"):
+		return FullTime
+	}
+
+	return Other
+}
+
+// BuildIndeedXml : Indeed XML feed of jobs
+func BuildIndeedXml(publisher string, publisherUrl string, jobs []Job, buildDate time.Time) ([]byte, error) {
+	source := indeedSource{
+		Publisher:     publisher,
+		PublisherUrl:  publisherUrl,
+		LastBuildDate: buildDate.UTC().Format(indeedDateFormat),
+	}
+
+	for _, job := range jobs {
+		source.Jobs = append(source.Jobs, indeedJob{
+			Title:           cdata{job.Title},
+			Date:            cdata{job.DatePosted.UTC().Format(indeedDateFormat)},
+			ReferenceNumber: cdata{job.ReferenceNumber},
+			Url:             cdata{job.Url},
+			Company:         cdata{job.Company},
+			City:            cdata{job.City},
+			Country:         cdata{job.Country},
+			StreetAddress:   cdata{strings.Join(job.Addresses, "; ")},
+			Description:     cdata{job.Description},
+			Salary:          cdata{salaryText(job)},
+			JobType:         cdata{indeedJobTypes[EmploymentType(job.TypeOfWork)]},
+			Experience:      cdata{experienceText(job.ExperienceYears)},
+			ExpirationDate:  cdata{job.ValidThrough.UTC().Format(indeedDateFormat)},
+		})
+	}
+
+	content, err := xml.MarshalIndent(source, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
+
+// BuildJobPosting : schema.org JobPosting of job, it is marshalled to JSON-LD
+func BuildJobPosting(job Job) map[string]interface{} {
+	posting := map[string]interface{}{
+		"@context":       "https://schema.org/",
+		"@type":          "JobPosting",
+		"title":          job.Title,
+		"description":    job.Description,
+		"datePosted":     job.DatePosted.Format(jsonLdDateFormat),
+		"validThrough":   job.ValidThrough.Format(jsonLdDateFormat),
+		"employmentType": EmploymentType(job.TypeOfWork),
+		"url":            job.Url,
+		"directApply":    true,
+		"identifier": map[string]interface{}{
+			"@type": "PropertyValue",
+			"name":  job.Company,
+			"value": job.ReferenceNumber,
+		},
+		"hiringOrganization": map[string]interface{}{
+			"@type":  "Organization",
+			"name":   job.Company,
+			"sameAs": job.CompanyUrl,
+		},
+	}
+
+	var locations []map[string]interface{}
+	for _, address := range job.Addresses {
+		locations = append(locations, map[string]interface{}{
+			"@type": "Place",
+			"address": map[string]interface{}{
+				"@type":           "PostalAddress",
+				"streetAddress":   address,
+				"addressLocality": job.City,
+				"addressCountry":  job.Country,
+			},
+		})
+	}
+
+	if len(locations) > 0 {
+		posting["jobLocation"] = locations
+	}
+
+	if job.SalaryFrom > 0 || job.SalaryTo > 0 {
+		value := map[string]interface{}{
+			"@type":    "QuantitativeValue",
+			"unitText": "MONTH",
+		}
+
+		if job.SalaryFrom > 0 {
+			value["minValue"] = job.SalaryFrom
+		}
+
+		if job.SalaryTo > 0 {
+			value["maxValue"] = job.SalaryTo
+		}
+
+		posting["baseSalary"] = map[string]interface{}{
+			"@type":    "MonetaryAmount",
+			"currency": job.Currency,
+			"value":    value,
+		}
+	}
+
+	if job.ExperienceYears > 0 {
+		posting["experienceRequirements"] = map[string]interface{}{
+			"@type":              "OccupationalExperienceRequirements",
+			"monthsOfExperience": job.ExperienceYears * 12,
+		}
+	}
+
+	if job.Openings > 0 {
+		posting["totalJobOpenings"] = job.Openings
+	}
+
+	return posting
+}
+
+func salaryText(job Job) string {
+	switch {
+	case job.SalaryFrom > 0 && job.SalaryTo > 0:
+		return strconv.Itoa(job.SalaryFrom) + " - " + strconv.Itoa(job.SalaryTo) + " " + job.Currency + " per month"
+	case job.SalaryFrom > 0:
+		return "From " + strconv.Itoa(job.SalaryFrom) + " " + job.Currency + " per month"
+	case job.SalaryTo > 0:
+		return "Up to " + strconv.Itoa(job.SalaryTo) + " " + job.Currency + " per month"
+	}
+
+	return ""
+}
+
+func experienceText(years int) string {
+	if years <= 0 {
+		return ""
+	}
+
+	return strconv.Itoa(years) + "+ years"
+}
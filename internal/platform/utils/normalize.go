@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+var vietnameseReplacer = func() *strings.Replacer {
+	groups := map[string]string{
+		"a": "àáạảãâầấậẩẫăằắặẳẵ",
+		"e": "èéẹẻẽêềếệểễ",
+		"i": "ìíịỉĩ",
+		"o": "òóọỏõôồốộổỗơờớợởỡ",
+		"u": "ùúụủũưừứựửữ",
+		"y": "ỳýỵỷỹ",
+		"d": "đ",
+	}
+
+	var pairs []string
+	for base, letters := range groups {
+		for _, letter := range letters {
+			pairs = append(pairs, string(letter), base)
+		}
+	}
+
+	return strings.NewReplacer(pairs...)
+}()
+
+// oldMobilePrefixes : 11 digit mobile prefixes which were changed to 10 digit ones in 2018
+var oldMobilePrefixes = map[string]string{
+	"0162": "032", "0163": "033", "0164": "034", "0165": "035", "0166": "036", "0167": "037", "0168": "038", "0169": "039",
+	"0120": "070", "0121": "079", "0122": "077", "0126": "076", "0128": "078",
+	"0123": "083", "0124": "084", "0125": "085", "0127": "081", "0129": "082",
+	"0186": "056", "0188": "058", "0199": "059",
+}
+
+// NormalizeEmail : Lower case email without +tag, dots of gmail address are removed as gmail ignores them
+func NormalizeEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	at := strings.LastIndex(email, "@")
+	if at <= 0 {
+		return email
+	}
+
+	local, domain := email[:at], email[at+1:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.Replace(local, ".", "", -1)
+		domain = "gmail.com"
+	}
+
+	return local + "@" + domain
+}
+
+// NormalizeVietnamesePhone : Phone number in 10 digit domestic format, as +84 912 345 678 and 0912.345.678 are the same
+func NormalizeVietnamesePhone(phone string) string {
+	var builder strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			builder.WriteRune(r)
+		}
+	}
+
+	digits := builder.String()
+	if strings.HasPrefix(digits, "84") && (len(digits) == 11 || len(digits) == 12) {
+		digits = "0" + digits[2:]
+	}
+
+	if len(digits) == 9 && !strings.HasPrefix(digits, "0") {
+		digits = "0" + digits
+	}
+
+	if len(digits) == 11 {
+		if prefix, ok := oldMobilePrefixes[digits[:4]]; ok {
+			digits = prefix + digits[4:]
+		}
+	}
+
+	return digits
+}
+
+// NormalizeName : Lower case name without Vietnamese accents, words are sorted so that order of family name does not matter
+func NormalizeName(name string) string {
+	name = vietnameseReplacer.Replace(strings.ToLower(name))
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	sort.Strings(words)
+
+	return strings.Join(words, " ")
+}
+
+// NameSimilarity : Similarity from 0 to 1 of normalized names, based on edit distance
+func NameSimilarity(a string, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+
+	maxLen := len(ra)
+	if len(rb) > maxLen {
+		maxLen = len(rb)
+	}
+
+	return 1 - float64(previous[len(rb)])/float64(maxLen)
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}